@@ -0,0 +1,108 @@
+// Command apikey manages API keys of service:
+//
+//	apikey create -name ci -scopes memberships:read,history:export
+//	apikey list
+//	apikey revoke -id 3
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"os"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"github.com/pollykon/avito_test_task/cmd"
+	"github.com/pollykon/avito_test_task/internal/actor"
+	apiKeyRepository "github.com/pollykon/avito_test_task/internal/repository/api_key"
+	serviceAuth "github.com/pollykon/avito_test_task/internal/service/auth"
+)
+
+const usage = `usage:
+  apikey create -name NAME -scopes SCOPE[,SCOPE...]
+  apikey list
+  apikey revoke -id ID
+  apikey config print
+
+scopes: `
+
+func main() {
+	if len(os.Args) < 2 {
+		exit(usage + strings.Join(actor.Scopes, ", "))
+	}
+
+	// config of apikey is set by env vars and CONFIG_FILE, its flags belong to subcommands
+	var configArgs []string
+	if os.Args[1] == "config" {
+		configArgs = os.Args[1:]
+	}
+	config := cmd.LoadOrExit(configArgs)
+
+	db, err := cmd.NewDatabase(config)
+	if err != nil {
+		exit(fmt.Sprintf("fail to connect to database: %s", err))
+	}
+
+	defer func() { _ = db.Close() }()
+
+	authService := serviceAuth.New(apiKeyRepository.New(cmd.NewStorage(config, nil, db, nil)), nil)
+	ctx := context.Background()
+
+	switch os.Args[1] {
+	case "create":
+		flags := flag.NewFlagSet("create", flag.ExitOnError)
+		name := flags.String("name", "", "name of key owner")
+		scopes := flags.String("scopes", "", "comma separated scopes")
+		_ = flags.Parse(os.Args[2:])
+
+		if *name == "" || *scopes == "" {
+			exit("name and scopes shouldn't be empty")
+		}
+
+		createdKey, err := authService.CreateKey(ctx, *name, strings.Split(*scopes, ","))
+		if err != nil {
+			exit(fmt.Sprintf("error while creating key: %s", err))
+		}
+
+		fmt.Printf("id: %d\nkey: %s\n", createdKey.ID, createdKey.Key)
+		fmt.Println("key is shown only once, store it securely")
+	case "list":
+		keys, err := authService.GetKeys(ctx)
+		if err != nil {
+			exit(fmt.Sprintf("error while getting keys: %s", err))
+		}
+
+		w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+		_, _ = fmt.Fprintln(w, "ID\tNAME\tPREFIX\tSCOPES\tCREATED\tREVOKED")
+		for _, key := range keys {
+			revoked := "-"
+			if key.RevokeTime != nil {
+				revoked = key.RevokeTime.Format(time.RFC3339)
+			}
+			_, _ = fmt.Fprintf(w, "%d\t%s\t%s\t%s\t%s\t%s\n",
+				key.ID, key.Name, key.Prefix, strings.Join(key.Scopes, ","), key.InsertTime.Format(time.RFC3339), revoked,
+			)
+		}
+		_ = w.Flush()
+	case "revoke":
+		flags := flag.NewFlagSet("revoke", flag.ExitOnError)
+		id := flags.Int64("id", 0, "id of key")
+		_ = flags.Parse(os.Args[2:])
+
+		err := authService.RevokeKey(ctx, *id)
+		if err != nil {
+			exit(fmt.Sprintf("error while revoking key: %s", err))
+		}
+
+		fmt.Printf("key %d revoked\n", *id)
+	default:
+		exit(usage + strings.Join(actor.Scopes, ", "))
+	}
+}
+
+func exit(message string) {
+	_, _ = fmt.Fprintln(os.Stderr, message)
+	os.Exit(1)
+}
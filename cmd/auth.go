@@ -0,0 +1,42 @@
+package cmd
+
+import (
+	"context"
+	"net/http"
+	"time"
+
+	"github.com/pollykon/avito_test_task/internal/jwks"
+	serviceAuth "github.com/pollykon/avito_test_task/internal/service/auth"
+)
+
+const jwksRequestTimeout = 10 * time.Second
+
+// NewTokenVerifier creates verifier of JWTs with keys from JWT_JWKS_FILE or JWT_JWKS_URL,
+// nil means that authentication by JWTs is disabled
+func NewTokenVerifier(ctx context.Context, config *Config) (*serviceAuth.JWTVerifier, error) {
+	jwtConfig := config.Auth.JWT
+
+	var keySet *jwks.KeySet
+	var err error
+	switch {
+	case jwtConfig.JWKSFile != "":
+		keySet, err = jwks.NewFromFile(jwtConfig.JWKSFile)
+	case jwtConfig.JWKSURL != "":
+		keySet, err = jwks.NewRemote(
+			ctx, jwtConfig.JWKSURL, &http.Client{Timeout: jwksRequestTimeout}, jwtConfig.JWKSRefreshInterval,
+		)
+	default:
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return serviceAuth.NewJWTVerifier(keySet, serviceAuth.TokenConfig{
+		Issuer:      jwtConfig.Issuer,
+		Audience:    jwtConfig.Audience,
+		RolesClaim:  jwtConfig.RolesClaim,
+		RoleMapping: jwtConfig.RoleMapping,
+		Leeway:      jwtConfig.Leeway,
+	}), nil
+}
@@ -0,0 +1,45 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/pollykon/avito_test_task/internal/repository/blob"
+	"github.com/pollykon/avito_test_task/internal/repository/blob/local"
+	"github.com/pollykon/avito_test_task/internal/repository/blob/s3"
+)
+
+const (
+	BlobStorageBackendLocal = "local"
+	BlobStorageBackendS3    = "s3"
+)
+
+const s3RequestTimeout = 30 * time.Second
+
+type BlobStorage interface {
+	Save(ctx context.Context, name string, content []byte) error
+	Open(ctx context.Context, name string) (blob.Object, error)
+	Delete(ctx context.Context, name string) error
+}
+
+// NewBlobStorage creates storage for export files selected by BLOB_STORAGE_BACKEND
+func NewBlobStorage(config *Config) (BlobStorage, error) {
+	switch config.BlobStorage.Backend {
+	case BlobStorageBackendLocal:
+		return local.New(config.CSV.LogCSVDirectory), nil
+	case BlobStorageBackendS3:
+		s3Config := config.BlobStorage.S3
+		return s3.New(s3.Config{
+			Endpoint:        s3Config.Endpoint,
+			Region:          s3Config.Region,
+			Bucket:          s3Config.Bucket,
+			AccessKeyID:     s3Config.AccessKeyID,
+			SecretAccessKey: s3Config.SecretAccessKey,
+			UsePathStyle:    s3Config.UsePathStyle,
+		}, &http.Client{Timeout: s3RequestTimeout})
+	default:
+		return nil, fmt.Errorf("unknown blob storage backend: %q", config.BlobStorage.Backend)
+	}
+}
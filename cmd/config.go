@@ -1,52 +1,219 @@
 package cmd
 
 import (
-	"github.com/caarlos0/env/v7"
 	"time"
 )
 
+// Config is loaded from defaults, config file, env vars and flags, each next source overrides previous ones.
+// Fields with secret tag are masked when config is printed
 type Config struct {
 	Database         DatabaseConfig
 	Microservice     MicroserviceConfig
 	CronTimeInterval CronTimeIntervalConfig
 	BatchSize        DeleteBatchSizeConfig
 	CSV              CSVConfig
+	BlobStorage      BlobStorageConfig
+	LogRetention     LogRetentionConfig
+	LogPartitions    LogPartitionsConfig
+	Outbox           OutboxConfig
+	Webhook          WebhookConfig
+	Stream           StreamConfig
+	Auth             AuthConfig
+	RateLimit        RateLimitConfig
+	Metrics          MetricsConfig
+	Tracing          TracingConfig
 }
 
 type DatabaseConfig struct {
-	Host     string `env:"PG_HOST,required"`
-	Port     string `env:"PG_PORT,required"`
-	User     string `env:"PG_USER,required"`
-	Password string `env:"PG_PASSWORD,required"`
-	Name     string `env:"PG_DATABASE_NAME,required"`
+	Host string `env:"PG_HOST,required"`
+	Port string `env:"PG_PORT,required"`
+	User string `env:"PG_USER,required"`
+	// Password is read from PasswordFile when it is set, e.g. from docker or kubernetes secret
+	Password     string `env:"PG_PASSWORD" secret:"true"`
+	PasswordFile string `env:"PG_PASSWORD_FILE"`
+	Name         string `env:"PG_DATABASE_NAME,required"`
+	// SSLMode is disable, require, verify-ca or verify-full, certificates are paths to PEM files
+	SSLMode     string `env:"PG_SSL_MODE" envDefault:"disable"`
+	SSLRootCert string `env:"PG_SSL_ROOT_CERT"`
+	SSLCert     string `env:"PG_SSL_CERT"`
+	SSLKey      string `env:"PG_SSL_KEY"`
+	// MaxOpenConns limits connections of each process, 0 means no limit
+	MaxOpenConns    int           `env:"PG_MAX_OPEN_CONNS" envDefault:"25"`
+	MaxIdleConns    int           `env:"PG_MAX_IDLE_CONNS" envDefault:"10"`
+	ConnMaxLifetime time.Duration `env:"PG_CONN_MAX_LIFETIME" envDefault:"30m"`
+	ConnMaxIdleTime time.Duration `env:"PG_CONN_MAX_IDLE_TIME" envDefault:"5m"`
+	// StatementTimeout makes postgres cancel longer statements, 0 disables it
+	StatementTimeout time.Duration `env:"PG_STATEMENT_TIMEOUT" envDefault:"0"`
+	Replica          DatabaseReplicaConfig
+	Transaction      DatabaseTransactionConfig
+}
+
+// DatabaseTransactionConfig sets default isolation level of transactions and isolation level of transactions
+// of segments: read_committed, repeatable_read or serializable, and retries of transactions failed because
+// of serialization failure or deadlock
+type DatabaseTransactionConfig struct {
+	Isolation        string        `env:"PG_TX_ISOLATION" envDefault:"read_committed"`
+	SegmentIsolation string        `env:"PG_SEGMENT_TX_ISOLATION" envDefault:"serializable"`
+	MaxRetries       int           `env:"PG_TX_MAX_RETRIES" envDefault:"3"`
+	RetryMinBackoff  time.Duration `env:"PG_TX_RETRY_MIN_BACKOFF" envDefault:"10ms"`
+	RetryMaxBackoff  time.Duration `env:"PG_TX_RETRY_MAX_BACKOFF" envDefault:"500ms"`
+}
+
+// DatabaseReplicaConfig configures replica for read-only queries, it is disabled when host isn't set. Replica is
+// connected with credentials, TLS and pool settings of primary
+type DatabaseReplicaConfig struct {
+	Host string `env:"PG_REPLICA_HOST"`
+	// Port is PG_PORT by default
+	Port          string        `env:"PG_REPLICA_PORT"`
+	MaxLag        time.Duration `env:"PG_REPLICA_MAX_LAG" envDefault:"5s"`
+	CheckInterval time.Duration `env:"PG_REPLICA_CHECK_INTERVAL" envDefault:"1s"`
 }
 
 type MicroserviceConfig struct {
 	Port string `env:"MICROSERVICE_PORT,required"`
+	GRPC GRPCConfig
+	// ShutdownDrainDelay is a time between failing readiness and stopping server, orchestrator must notice it
+	ShutdownDrainDelay time.Duration `env:"SHUTDOWN_DRAIN_DELAY" envDefault:"5s"`
+}
+
+type GRPCConfig struct {
+	Port string `env:"GRPC_PORT" envDefault:"1012"`
+	// DownloadHost is a host of HTTP server in urls of generated files, localhost with MICROSERVICE_PORT by default
+	DownloadHost string `env:"GRPC_DOWNLOAD_HOST"`
 }
 
 type CSVConfig struct {
-	LogCSVDirectory string `env:"LOGS_CSV_DIRECTORY,required"`
+	LogCSVDirectory   string        `env:"LOGS_CSV_DIRECTORY,required"`
+	DownloadURLSecret string        `env:"DOWNLOAD_URL_SECRET,required" secret:"true"`
+	DownloadURLTTL    time.Duration `env:"DOWNLOAD_URL_TTL,required"`
+	ExportFilesMaxAge time.Duration `env:"EXPORT_FILES_MAX_AGE,required"`
+}
+
+type BlobStorageConfig struct {
+	Backend string `env:"BLOB_STORAGE_BACKEND" envDefault:"local"`
+	S3      S3Config
+}
+
+type S3Config struct {
+	Endpoint        string `env:"S3_ENDPOINT"`
+	Region          string `env:"S3_REGION" envDefault:"us-east-1"`
+	Bucket          string `env:"S3_BUCKET"`
+	AccessKeyID     string `env:"S3_ACCESS_KEY_ID" secret:"true"`
+	SecretAccessKey string `env:"S3_SECRET_ACCESS_KEY" secret:"true"`
+	UsePathStyle    bool   `env:"S3_USE_PATH_STYLE" envDefault:"true"`
+	// ProxyDownloads makes service stream files itself instead of returning presigned urls
+	ProxyDownloads bool `env:"S3_PROXY_DOWNLOADS" envDefault:"false"`
+}
+
+// LogRetentionConfig sets how long logs are stored before archiving, zero retention of operation means default one
+type LogRetentionConfig struct {
+	Default time.Duration `env:"LOG_RETENTION" envDefault:"2160h"`
+	Add     time.Duration `env:"LOG_RETENTION_ADD" envDefault:"0"`
+	Delete  time.Duration `env:"LOG_RETENTION_DELETE" envDefault:"0"`
+}
+
+type LogPartitionsConfig struct {
+	// MonthsAhead is number of months after current one which must always have partitions
+	MonthsAhead int `env:"LOG_PARTITIONS_MONTHS_AHEAD" envDefault:"3"`
+}
+
+type OutboxConfig struct {
+	Sink            string        `env:"OUTBOX_SINK" envDefault:"file"`
+	SinkFilePath    string        `env:"OUTBOX_SINK_FILE_PATH" envDefault:"./outbox_events.ndjson"`
+	SinkHTTPURL     string        `env:"OUTBOX_SINK_HTTP_URL"`
+	SinkHTTPTimeout time.Duration `env:"OUTBOX_SINK_HTTP_TIMEOUT" envDefault:"10s"`
+	RelayInterval   time.Duration `env:"OUTBOX_RELAY_INTERVAL" envDefault:"1s"`
+	BatchSize       int64         `env:"OUTBOX_BATCH_SIZE" envDefault:"100"`
+	RetryMinBackoff time.Duration `env:"OUTBOX_RETRY_MIN_BACKOFF" envDefault:"1s"`
+	RetryMaxBackoff time.Duration `env:"OUTBOX_RETRY_MAX_BACKOFF" envDefault:"10m"`
+	// SentRetention is how long sent events are kept in outbox
+	SentRetention time.Duration `env:"OUTBOX_SENT_RETENTION" envDefault:"168h"`
+	// CleanupInterval is how often events older than SentRetention are deleted
+	CleanupInterval time.Duration `env:"OUTBOX_CLEANUP_INTERVAL" envDefault:"1h"`
+}
+
+type WebhookConfig struct {
+	DispatchInterval time.Duration `env:"WEBHOOK_DISPATCH_INTERVAL" envDefault:"1s"`
+	DeliverInterval  time.Duration `env:"WEBHOOK_DELIVER_INTERVAL" envDefault:"1s"`
+	BatchSize        int64         `env:"WEBHOOK_BATCH_SIZE" envDefault:"100"`
+	Timeout          time.Duration `env:"WEBHOOK_TIMEOUT" envDefault:"10s"`
+	// MaxAttempts is number of failed attempts after which delivery is marked as dead
+	MaxAttempts     int64         `env:"WEBHOOK_MAX_ATTEMPTS" envDefault:"10"`
+	RetryMinBackoff time.Duration `env:"WEBHOOK_RETRY_MIN_BACKOFF" envDefault:"10s"`
+	RetryMaxBackoff time.Duration `env:"WEBHOOK_RETRY_MAX_BACKOFF" envDefault:"1h"`
+	// AllowHTTP allows subscriptions with http urls, e.g. of receivers in local network during development
+	AllowHTTP bool `env:"WEBHOOK_ALLOW_HTTP" envDefault:"false"`
+	// AllowPrivateAddresses allows sending webhooks to loopback, link-local and private addresses
+	AllowPrivateAddresses bool `env:"WEBHOOK_ALLOW_PRIVATE_ADDRESSES" envDefault:"false"`
+}
+
+type StreamConfig struct {
+	HeartbeatInterval time.Duration `env:"STREAM_HEARTBEAT_INTERVAL" envDefault:"15s"`
+	// BufferSize is a number of events waiting for slow client, client is disconnected when it is exceeded
+	BufferSize int `env:"STREAM_BUFFER_SIZE" envDefault:"100"`
+	// MaxBacklog is a number of missed events which are sent after reconnect, stream is reset if there are more
+	MaxBacklog int `env:"STREAM_MAX_BACKLOG" envDefault:"10000"`
+}
+
+type AuthConfig struct {
+	// Enabled makes all endpoints except downloads of files and gRPC health checks require API key or JWT
+	Enabled bool `env:"AUTH_ENABLED" envDefault:"true"`
+	JWT     JWTConfig
+}
+
+// JWTConfig configures authentication of admin UI users by JWTs, it is disabled when neither JWKS url nor file is set
+type JWTConfig struct {
+	JWKSURL string `env:"JWT_JWKS_URL"`
+	// JWKSFile is a local file with keys, it is used instead of JWKSURL in tests and for static keys
+	JWKSFile            string            `env:"JWT_JWKS_FILE"`
+	JWKSRefreshInterval time.Duration     `env:"JWT_JWKS_REFRESH_INTERVAL" envDefault:"1h"`
+	Issuer              string            `env:"JWT_ISSUER"`
+	Audience            string            `env:"JWT_AUDIENCE"`
+	RolesClaim          string            `env:"JWT_ROLES_CLAIM" envDefault:"roles"`
+	RoleMapping         map[string]string `env:"JWT_ROLE_MAPPING"`
+	Leeway              time.Duration     `env:"JWT_LEEWAY" envDefault:"30s"`
+}
+
+// RateLimitConfig sets limits of requests of each client to each endpoint in "rate/burst" format,
+// e.g. 10/20 is 10 requests per second with bursts up to 20, 0/0 disables limit
+type RateLimitConfig struct {
+	Enabled bool `env:"RATE_LIMIT_ENABLED" envDefault:"true"`
+	// Backend is memory for limits of each replica or postgres for limits shared by replicas
+	Backend string `env:"RATE_LIMIT_BACKEND" envDefault:"memory"`
+	Default string `env:"RATE_LIMIT_DEFAULT" envDefault:"10/20"`
+	// Endpoints contains limits by endpoint, e.g. get_user_active_segments_v1:5/10. Endpoints of gRPC are
+	// method names, e.g. GetUserActiveSegments
+	Endpoints map[string]string `env:"RATE_LIMIT_ENDPOINTS" envDefault:"get_user_active_segments_v1:5/10"`
+	// Address limits all requests of ip address, it is checked before authentication
+	Address string `env:"RATE_LIMIT_ADDRESS" envDefault:"50/100"`
+}
+
+// MetricsConfig configures metrics listener of crons, service exposes metrics on MICROSERVICE_PORT
+type MetricsConfig struct {
+	CronPort string `env:"METRICS_CRON_PORT" envDefault:"9100"`
+}
+
+// TracingConfig selects exporter of spans: none, otlp or stdout. Endpoint and headers of otlp exporter
+// are set by standard OTEL_EXPORTER_OTLP_* variables
+type TracingConfig struct {
+	Exporter    string  `env:"TRACING_EXPORTER" envDefault:"none"`
+	ServiceName string  `env:"TRACING_SERVICE_NAME" envDefault:"segment-service"`
+	SampleRatio float64 `env:"TRACING_SAMPLE_RATIO" envDefault:"1"`
 }
 
 type CronTimeIntervalConfig struct {
-	DeleteSegments    time.Duration `env:"TIME_INTERVAL_DELETE_SEGMENTS,required"`
-	DeleteTTLSegments time.Duration `env:"TIME_INTERVAL_DELETE_TTL_SEGMENTS,required"`
-	DeleteLogs        time.Duration `env:"TIME_INTERVAL_DELETE_LOGS,required"`
+	DeleteSegments      time.Duration `env:"TIME_INTERVAL_DELETE_SEGMENTS,required"`
+	DeleteTTLSegments   time.Duration `env:"TIME_INTERVAL_DELETE_TTL_SEGMENTS,required"`
+	DeleteLogs          time.Duration `env:"TIME_INTERVAL_DELETE_LOGS,required"`
+	DeleteExportFiles   time.Duration `env:"TIME_INTERVAL_DELETE_EXPORT_FILES,required"`
+	CreateLogPartitions time.Duration `env:"TIME_INTERVAL_CREATE_LOG_PARTITIONS" envDefault:"24h"`
+	// DeleteRateLimitBuckets is used only with postgres backend of rate limits
+	DeleteRateLimitBuckets time.Duration `env:"TIME_INTERVAL_DELETE_RATE_LIMIT_BUCKETS" envDefault:"1m"`
 }
 
 type DeleteBatchSizeConfig struct {
 	Segments    int64 `env:"BATCH_SIZE_SEGMENTS,required"`
 	TTLSegments int64 `env:"BATCH_SIZE_TTL_SEGMENTS,required"`
 	Logs        int64 `env:"BATCH_SIZE_LOGS,required"`
-}
-
-func Load() (*Config, error) {
-	cfg := Config{}
-
-	err := env.Parse(&cfg)
-	if err != nil {
-		return nil, err
-	}
-	return &cfg, nil
+	ExportFiles int64 `env:"BATCH_SIZE_EXPORT_FILES,required"`
 }
@@ -0,0 +1,266 @@
+package cmd
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"reflect"
+	"sort"
+	"strings"
+
+	"github.com/BurntSushi/toml"
+	"github.com/caarlos0/env/v7"
+	"github.com/joho/godotenv"
+	"gopkg.in/yaml.v3"
+)
+
+const (
+	// configFileEnv is env var with path of config file, it is used when -config flag is not set
+	configFileEnv = "CONFIG_FILE"
+	configFlag    = "config"
+	dotEnvFile    = ".env"
+)
+
+// Load loads config from sources in order of increasing priority: defaults, .env file if it exists, config file,
+// env vars and flags. Config file is YAML or TOML file selected by extension, its keys are names of env vars.
+// Flags are names of env vars in lower case with dashes, e.g. -pg-host. Loaded config is validated and all found
+// problems are returned together
+func Load(args []string) (*Config, error) {
+	dotEnv, err := godotenv.Read(dotEnvFile)
+	if err != nil && !errors.Is(err, fs.ErrNotExist) {
+		return nil, fmt.Errorf("error while reading %s: %w", dotEnvFile, err)
+	}
+
+	keys := configKeys()
+
+	known := make(map[string]bool, len(keys))
+	for _, key := range keys {
+		known[key] = true
+	}
+	// other variables of .env, e.g. CONFIG_FILE and OTEL_*, are read by their users from environment,
+	// so they are set there unless environment has them
+	for key, value := range dotEnv {
+		if _, ok := os.LookupEnv(key); known[key] || ok {
+			continue
+		}
+		err = os.Setenv(key, value)
+		if err != nil {
+			return nil, fmt.Errorf("error while setting %s from %s: %w", key, dotEnvFile, err)
+		}
+	}
+
+	flags := flag.NewFlagSet(filepath.Base(os.Args[0]), flag.ContinueOnError)
+	configFile := flags.String(configFlag, os.Getenv(configFileEnv), "path to YAML or TOML config file")
+	flagValues := make(map[string]string)
+	for _, key := range keys {
+		key := key
+		flags.Func(flagName(key), "overrides "+key, func(value string) error {
+			flagValues[key] = value
+			return nil
+		})
+	}
+
+	err = flags.Parse(args)
+	if err != nil {
+		return nil, err
+	}
+	if flags.NArg() != 0 {
+		return nil, fmt.Errorf("unexpected arguments: %s", strings.Join(flags.Args(), " "))
+	}
+
+	environment := make(map[string]string)
+	for key, value := range dotEnv {
+		if known[key] {
+			environment[key] = value
+		}
+	}
+	if *configFile != "" {
+		fileValues, err := readConfigFile(*configFile, keys)
+		if err != nil {
+			return nil, err
+		}
+		for key, value := range fileValues {
+			environment[key] = value
+		}
+	}
+	for _, variable := range os.Environ() {
+		key, value, _ := strings.Cut(variable, "=")
+		environment[key] = value
+	}
+	for key, value := range flagValues {
+		environment[key] = value
+	}
+
+	cfg := Config{}
+	err = env.Parse(&cfg, env.Options{Environment: environment})
+	if err != nil {
+		var aggregateErr env.AggregateError
+		if errors.As(err, &aggregateErr) {
+			return nil, errors.Join(aggregateErr.Errors...)
+		}
+		return nil, err
+	}
+
+	err = errors.Join(cfg.Database.readPasswordFile(), cfg.Validate())
+	if err != nil {
+		return nil, err
+	}
+
+	return &cfg, nil
+}
+
+// LoadOrExit loads config in main of binary. Arguments "config print" make it print effective config with masked
+// secrets and exit. Invalid config is reported to stderr and binary exits with code 2
+func LoadOrExit(args []string) *Config {
+	printConfig := len(args) > 0 && args[0] == "config"
+	if printConfig {
+		if len(args) < 2 || args[1] != "print" {
+			exitWithError(errors.New("unknown config command, only \"config print\" is supported"))
+		}
+		args = args[2:]
+	}
+
+	config, err := Load(args)
+	if errors.Is(err, flag.ErrHelp) {
+		os.Exit(0)
+	}
+	if err != nil {
+		exitWithError(err)
+	}
+
+	if printConfig {
+		PrintConfig(os.Stdout, config)
+		os.Exit(0)
+	}
+
+	return config
+}
+
+func exitWithError(err error) {
+	_, _ = fmt.Fprintln(os.Stderr, "invalid config:")
+	for _, line := range strings.Split(err.Error(), "\n") {
+		_, _ = fmt.Fprintln(os.Stderr, "  -", line)
+	}
+	os.Exit(2)
+}
+
+// readConfigFile reads values of config file by names of env vars, unknown keys are reported as errors
+// to catch typos
+func readConfigFile(path string, keys []string) (map[string]string, error) {
+	content, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("error while reading config file: %w", err)
+	}
+
+	raw := make(map[string]interface{})
+	switch ext := strings.ToLower(filepath.Ext(path)); ext {
+	case ".yaml", ".yml":
+		err = yaml.Unmarshal(content, &raw)
+	case ".toml":
+		err = toml.Unmarshal(content, &raw)
+	default:
+		return nil, fmt.Errorf("unknown format of config file %s: %q, expected .yaml, .yml or .toml", path, ext)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("error while parsing config file %s: %w", path, err)
+	}
+
+	known := make(map[string]bool, len(keys))
+	for _, key := range keys {
+		known[key] = true
+	}
+
+	values := make(map[string]string, len(raw))
+	var errs []error
+	for key, value := range raw {
+		key = strings.ToUpper(key)
+		if !known[key] {
+			errs = append(errs, fmt.Errorf("unknown key %s in config file %s", key, path))
+			continue
+		}
+		values[key] = formatFileValue(value)
+	}
+	if len(errs) != 0 {
+		sort.Slice(errs, func(i, j int) bool { return errs[i].Error() < errs[j].Error() })
+		return nil, errors.Join(errs...)
+	}
+
+	return values, nil
+}
+
+// formatFileValue converts value of config file to format of env var, mappings become "key:value,key2:value2"
+// and lists become "value,value2"
+func formatFileValue(value interface{}) string {
+	switch v := value.(type) {
+	case nil:
+		return ""
+	case map[string]interface{}:
+		pairs := make([]string, 0, len(v))
+		for key, item := range v {
+			pairs = append(pairs, key+":"+formatFileValue(item))
+		}
+		sort.Strings(pairs)
+		return strings.Join(pairs, ",")
+	case []interface{}:
+		items := make([]string, 0, len(v))
+		for _, item := range v {
+			items = append(items, formatFileValue(item))
+		}
+		return strings.Join(items, ",")
+	default:
+		return fmt.Sprint(v)
+	}
+}
+
+// configField is a field of config with name of its env var
+type configField struct {
+	key    string
+	secret bool
+	value  reflect.Value
+}
+
+// configFields returns fields of config in order of declaration
+func configFields(cfg *Config) []configField {
+	var fields []configField
+
+	var walk func(value reflect.Value)
+	walk = func(value reflect.Value) {
+		for i := 0; i < value.NumField(); i++ {
+			field := value.Type().Field(i)
+			tag, ok := field.Tag.Lookup("env")
+			if !ok {
+				if field.Type.Kind() == reflect.Struct {
+					walk(value.Field(i))
+				}
+				continue
+			}
+			key, _, _ := strings.Cut(tag, ",")
+			fields = append(fields, configField{
+				key:    key,
+				secret: field.Tag.Get("secret") == "true",
+				value:  value.Field(i),
+			})
+		}
+	}
+	walk(reflect.ValueOf(cfg).Elem())
+
+	return fields
+}
+
+// configKeys returns names of env vars of all config fields
+func configKeys() []string {
+	fields := configFields(&Config{})
+	keys := make([]string, 0, len(fields))
+	for _, field := range fields {
+		keys = append(keys, field.key)
+	}
+	return keys
+}
+
+// flagName converts name of env var to flag, e.g. PG_HOST to pg-host
+func flagName(key string) string {
+	return strings.ReplaceAll(strings.ToLower(key), "_", "-")
+}
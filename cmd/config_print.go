@@ -0,0 +1,39 @@
+package cmd
+
+import (
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+	"time"
+)
+
+// maskedValue replaces values of secrets in printed config
+const maskedValue = "******"
+
+// PrintConfig writes effective config as KEY=value lines in format of .env file, secrets are masked
+func PrintConfig(w io.Writer, config *Config) {
+	for _, field := range configFields(config) {
+		value := formatConfigValue(field.value.Interface())
+		if field.secret && value != "" {
+			value = maskedValue
+		}
+		_, _ = fmt.Fprintf(w, "%s=%s\n", field.key, value)
+	}
+}
+
+func formatConfigValue(value interface{}) string {
+	switch v := value.(type) {
+	case time.Duration:
+		return v.String()
+	case map[string]string:
+		pairs := make([]string, 0, len(v))
+		for key, item := range v {
+			pairs = append(pairs, key+":"+item)
+		}
+		sort.Strings(pairs)
+		return strings.Join(pairs, ",")
+	default:
+		return fmt.Sprint(v)
+	}
+}
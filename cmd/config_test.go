@@ -0,0 +1,268 @@
+package cmd
+
+import (
+	"bytes"
+	"fmt"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func writeConfigFile(t *testing.T, name string, content string) string {
+	t.Helper()
+
+	path := filepath.Join(t.TempDir(), name)
+	require.NoError(t, os.WriteFile(path, []byte(content), 0o600))
+	return path
+}
+
+func requiredYAML(directory string) string {
+	return fmt.Sprintf(`PG_HOST: file-host
+PG_PORT: "5432"
+PG_USER: postgres
+PG_PASSWORD: file-password
+PG_DATABASE_NAME: postgres
+MICROSERVICE_PORT: "1011"
+LOGS_CSV_DIRECTORY: %s
+DOWNLOAD_URL_SECRET: secret
+DOWNLOAD_URL_TTL: 1h
+EXPORT_FILES_MAX_AGE: 24h
+TIME_INTERVAL_DELETE_SEGMENTS: 30s
+TIME_INTERVAL_DELETE_TTL_SEGMENTS: 30s
+TIME_INTERVAL_DELETE_LOGS: 30s
+TIME_INTERVAL_DELETE_EXPORT_FILES: 1h
+BATCH_SIZE_SEGMENTS: 100
+BATCH_SIZE_TTL_SEGMENTS: 100
+BATCH_SIZE_LOGS: 10000
+BATCH_SIZE_EXPORT_FILES: 100
+`, directory)
+}
+
+func TestLoad_Precedence(t *testing.T) {
+	path := writeConfigFile(t, "config.yaml", requiredYAML(t.TempDir())+`
+STREAM_BUFFER_SIZE: 50
+JWT_ROLE_MAPPING:
+  admin: admin
+  viewer: read
+`)
+
+	t.Setenv("PG_HOST", "env-host")
+	t.Setenv("BATCH_SIZE_LOGS", "500")
+
+	config, err := Load([]string{"-config", path, "-batch-size-logs", "7"})
+	require.NoError(t, err)
+
+	// default
+	require.Equal(t, "1012", config.Microservice.GRPC.Port)
+	// file
+	require.Equal(t, "file-password", config.Database.Password)
+	require.Equal(t, 50, config.Stream.BufferSize)
+	require.Equal(t, map[string]string{"admin": "admin", "viewer": "read"}, config.Auth.JWT.RoleMapping)
+	require.Equal(t, time.Hour, config.CSV.DownloadURLTTL)
+	// env overrides file
+	require.Equal(t, "env-host", config.Database.Host)
+	// flag overrides env
+	require.Equal(t, int64(7), config.BatchSize.Logs)
+}
+
+func TestLoad_DotEnv(t *testing.T) {
+	path := writeConfigFile(t, "config.yaml", requiredYAML(t.TempDir()))
+
+	directory := t.TempDir()
+	require.NoError(t, os.WriteFile(filepath.Join(directory, dotEnvFile), []byte(`PG_HOST = "dotenv-host"
+STREAM_BUFFER_SIZE = 70
+BATCH_SIZE_LOGS = 70
+OTEL_SERVICE_NAME = "dotenv-service"
+`), 0o600))
+
+	workingDirectory, err := os.Getwd()
+	require.NoError(t, err)
+	require.NoError(t, os.Chdir(directory))
+	t.Cleanup(func() { _ = os.Chdir(workingDirectory) })
+
+	t.Setenv("BATCH_SIZE_LOGS", "500")
+	// variable is restored after test, it is set by Load
+	t.Setenv("OTEL_SERVICE_NAME", "")
+	require.NoError(t, os.Unsetenv("OTEL_SERVICE_NAME"))
+
+	config, err := Load([]string{"-config", path})
+	require.NoError(t, err)
+
+	// .env overrides default
+	require.Equal(t, 70, config.Stream.BufferSize)
+	// file overrides .env
+	require.Equal(t, "file-host", config.Database.Host)
+	// env overrides .env
+	require.Equal(t, int64(500), config.BatchSize.Logs)
+	// variables which aren't part of config are passed to environment
+	require.Equal(t, "dotenv-service", os.Getenv("OTEL_SERVICE_NAME"))
+}
+
+func TestLoad_TOML(t *testing.T) {
+	path := writeConfigFile(t, "config.toml", fmt.Sprintf(`PG_HOST = "toml-host"
+PG_PORT = "5432"
+PG_USER = "postgres"
+PG_PASSWORD = "postgres"
+PG_DATABASE_NAME = "postgres"
+MICROSERVICE_PORT = "1011"
+LOGS_CSV_DIRECTORY = %q
+DOWNLOAD_URL_SECRET = "secret"
+DOWNLOAD_URL_TTL = "1h"
+EXPORT_FILES_MAX_AGE = "24h"
+TIME_INTERVAL_DELETE_SEGMENTS = "30s"
+TIME_INTERVAL_DELETE_TTL_SEGMENTS = "30s"
+TIME_INTERVAL_DELETE_LOGS = "30s"
+TIME_INTERVAL_DELETE_EXPORT_FILES = "1h"
+BATCH_SIZE_SEGMENTS = 100
+BATCH_SIZE_TTL_SEGMENTS = 100
+BATCH_SIZE_LOGS = 10000
+BATCH_SIZE_EXPORT_FILES = 100
+TRACING_SAMPLE_RATIO = 0.5
+`, t.TempDir()))
+
+	t.Setenv(configFileEnv, path)
+
+	config, err := Load(nil)
+	require.NoError(t, err)
+	require.Equal(t, "toml-host", config.Database.Host)
+	require.Equal(t, 0.5, config.Tracing.SampleRatio)
+}
+
+func TestLoad_Errors(t *testing.T) {
+	tests := []struct {
+		name string
+
+		config string
+		args   []string
+
+		expectedErrors []string
+	}{
+		{
+			name: "unknown key in file",
+
+			config: "PG_HOTS: localhost\n",
+
+			expectedErrors: []string{"unknown key PG_HOTS in config file"},
+		},
+		{
+			name: "missing required",
+
+			config: "PG_HOST: localhost\n",
+
+			expectedErrors: []string{`required environment variable "PG_PORT" is not set`},
+		},
+		{
+			name: "invalid values",
+
+			config: requiredYAML("/not/existing/directory") + `
+OUTBOX_RETRY_MIN_BACKOFF: 1m
+OUTBOX_RETRY_MAX_BACKOFF: 1s
+`,
+			args: []string{
+				"-batch-size-segments", "0", "-time-interval-delete-logs", "0s", "-tracing-sample-ratio", "2",
+			},
+
+			expectedErrors: []string{
+				"BATCH_SIZE_SEGMENTS must be positive, got 0",
+				"TIME_INTERVAL_DELETE_LOGS must be positive duration, got 0s",
+				"OUTBOX_RETRY_MAX_BACKOFF must not be less than OUTBOX_RETRY_MIN_BACKOFF, got 1s < 1m0s",
+				"TRACING_SAMPLE_RATIO must be between 0 and 1, got 2",
+				"LOGS_CSV_DIRECTORY must be existing directory",
+			},
+		},
+		{
+			name: "invalid database settings",
+
+			config: requiredYAML(t.TempDir()) + `
+PG_SSL_MODE: prefer
+PG_SSL_CERT: /certs/client.pem
+PG_MAX_OPEN_CONNS: 5
+PG_MAX_IDLE_CONNS: 10
+PG_TX_ISOLATION: snapshot
+PG_SEGMENT_TX_ISOLATION: read_uncommitted
+PG_TX_MAX_RETRIES: -1
+`,
+
+			expectedErrors: []string{
+				`PG_SSL_MODE must be one of disable, require, verify-ca, verify-full, got "prefer"`,
+				"PG_SSL_CERT and PG_SSL_KEY must be set together",
+				"PG_SSL_CERT must be existing file",
+				"PG_MAX_IDLE_CONNS must not be greater than PG_MAX_OPEN_CONNS, got 10 > 5",
+				`PG_TX_ISOLATION must be one of read_committed, repeatable_read, serializable, got "snapshot"`,
+				`PG_SEGMENT_TX_ISOLATION must be one of read_committed, repeatable_read, serializable, got "read_uncommitted"`,
+				"PG_TX_MAX_RETRIES must not be negative, got -1",
+			},
+		},
+		{
+			name: "invalid enums and limits",
+
+			config: requiredYAML(t.TempDir()) + `
+BLOB_STORAGE_BACKEND: gcs
+OUTBOX_SINK: kafka
+TRACING_EXPORTER: jaeger
+RATE_LIMIT_BACKEND: redis
+RATE_LIMIT_DEFAULT: "10"
+RATE_LIMIT_ENDPOINTS:
+  v2/users: fast
+`,
+
+			expectedErrors: []string{
+				`BLOB_STORAGE_BACKEND must be one of local, s3, got "gcs"`,
+				`OUTBOX_SINK must be one of file, http, got "kafka"`,
+				`TRACING_EXPORTER must be one of none, otlp, stdout, got "jaeger"`,
+				`RATE_LIMIT_BACKEND must be one of memory, postgres, got "redis"`,
+				`RATE_LIMIT_DEFAULT must be in rate/burst format, got "10"`,
+				`RATE_LIMIT_ENDPOINTS of v2/users must be in rate/burst format, got "fast"`,
+			},
+		},
+		{
+			name: "unexpected argument",
+
+			config: requiredYAML(t.TempDir()),
+			args:   []string{"print"},
+
+			expectedErrors: []string{"unexpected arguments: print"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			path := writeConfigFile(t, "config.yml", tt.config)
+
+			_, err := Load(append([]string{"-config", path}, tt.args...))
+			require.Error(t, err)
+			for _, expected := range tt.expectedErrors {
+				require.ErrorContains(t, err, expected)
+			}
+		})
+	}
+}
+
+func TestPrintConfig(t *testing.T) {
+	config := &Config{
+		Database: DatabaseConfig{Host: "localhost", Password: "postgres"},
+		BlobStorage: BlobStorageConfig{
+			S3: S3Config{AccessKeyID: "key"},
+		},
+		CSV: CSVConfig{DownloadURLTTL: time.Hour},
+		Auth: AuthConfig{
+			JWT: JWTConfig{RoleMapping: map[string]string{"viewer": "read", "admin": "admin"}},
+		},
+	}
+
+	buffer := &bytes.Buffer{}
+	PrintConfig(buffer, config)
+
+	printed := buffer.String()
+	require.Contains(t, printed, "PG_HOST=localhost\n")
+	require.Contains(t, printed, "PG_PASSWORD=******\n")
+	require.Contains(t, printed, "S3_ACCESS_KEY_ID=******\n")
+	// empty secrets are printed as is to show that they are not set
+	require.Contains(t, printed, "S3_SECRET_ACCESS_KEY=\n")
+	require.Contains(t, printed, "DOWNLOAD_URL_TTL=1h0m0s\n")
+	require.Contains(t, printed, "JWT_ROLE_MAPPING=admin:admin,viewer:read\n")
+	require.NotContains(t, printed, "postgres")
+}
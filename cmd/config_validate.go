@@ -0,0 +1,187 @@
+package cmd
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	serviceRateLimit "github.com/pollykon/avito_test_task/internal/service/rate_limit"
+)
+
+// Validate checks values which can be parsed but can't be used, all found problems are returned together
+func (c *Config) Validate() error {
+	var errs []error
+
+	positive := func(key string, value int64) {
+		if value <= 0 {
+			errs = append(errs, fmt.Errorf("%s must be positive, got %d", key, value))
+		}
+	}
+	positiveDuration := func(key string, value time.Duration) {
+		if value <= 0 {
+			errs = append(errs, fmt.Errorf("%s must be positive duration, got %s", key, value))
+		}
+	}
+	notNegativeDuration := func(key string, value time.Duration) {
+		if value < 0 {
+			errs = append(errs, fmt.Errorf("%s must not be negative, got %s", key, value))
+		}
+	}
+	backoff := func(minKey string, minValue time.Duration, maxKey string, maxValue time.Duration) {
+		positiveDuration(minKey, minValue)
+		if maxValue < minValue {
+			errs = append(errs, fmt.Errorf("%s must not be less than %s, got %s < %s", maxKey, minKey, maxValue, minValue))
+		}
+	}
+	oneOf := func(key string, value string, allowed ...string) {
+		for _, item := range allowed {
+			if value == item {
+				return
+			}
+		}
+		errs = append(errs, fmt.Errorf("%s must be one of %s, got %q", key, strings.Join(allowed, ", "), value))
+	}
+	limit := func(key string, value string) {
+		_, err := serviceRateLimit.ParseLimit(value)
+		if err != nil {
+			errs = append(errs, fmt.Errorf("%s must be in rate/burst format, got %q", key, value))
+		}
+	}
+	directory := func(key string, path string) error {
+		info, err := os.Stat(path)
+		if err != nil {
+			return fmt.Errorf("%s must be existing directory: %w", key, err)
+		}
+		if !info.IsDir() {
+			return fmt.Errorf("%s must be existing directory, %s is a file", key, path)
+		}
+		return nil
+	}
+
+	positive("BATCH_SIZE_SEGMENTS", c.BatchSize.Segments)
+	positive("BATCH_SIZE_TTL_SEGMENTS", c.BatchSize.TTLSegments)
+	positive("BATCH_SIZE_LOGS", c.BatchSize.Logs)
+	positive("BATCH_SIZE_EXPORT_FILES", c.BatchSize.ExportFiles)
+	positive("OUTBOX_BATCH_SIZE", c.Outbox.BatchSize)
+	positive("WEBHOOK_BATCH_SIZE", c.Webhook.BatchSize)
+	positive("WEBHOOK_MAX_ATTEMPTS", c.Webhook.MaxAttempts)
+	positive("STREAM_BUFFER_SIZE", int64(c.Stream.BufferSize))
+	positive("STREAM_MAX_BACKLOG", int64(c.Stream.MaxBacklog))
+
+	positiveDuration("TIME_INTERVAL_DELETE_SEGMENTS", c.CronTimeInterval.DeleteSegments)
+	positiveDuration("TIME_INTERVAL_DELETE_TTL_SEGMENTS", c.CronTimeInterval.DeleteTTLSegments)
+	positiveDuration("TIME_INTERVAL_DELETE_LOGS", c.CronTimeInterval.DeleteLogs)
+	positiveDuration("TIME_INTERVAL_DELETE_EXPORT_FILES", c.CronTimeInterval.DeleteExportFiles)
+	positiveDuration("TIME_INTERVAL_CREATE_LOG_PARTITIONS", c.CronTimeInterval.CreateLogPartitions)
+	positiveDuration("TIME_INTERVAL_DELETE_RATE_LIMIT_BUCKETS", c.CronTimeInterval.DeleteRateLimitBuckets)
+	positiveDuration("OUTBOX_RELAY_INTERVAL", c.Outbox.RelayInterval)
+	positiveDuration("OUTBOX_SINK_HTTP_TIMEOUT", c.Outbox.SinkHTTPTimeout)
+	positiveDuration("OUTBOX_CLEANUP_INTERVAL", c.Outbox.CleanupInterval)
+	positiveDuration("WEBHOOK_DISPATCH_INTERVAL", c.Webhook.DispatchInterval)
+	positiveDuration("WEBHOOK_DELIVER_INTERVAL", c.Webhook.DeliverInterval)
+	positiveDuration("WEBHOOK_TIMEOUT", c.Webhook.Timeout)
+	positiveDuration("STREAM_HEARTBEAT_INTERVAL", c.Stream.HeartbeatInterval)
+	positiveDuration("JWT_JWKS_REFRESH_INTERVAL", c.Auth.JWT.JWKSRefreshInterval)
+	positiveDuration("DOWNLOAD_URL_TTL", c.CSV.DownloadURLTTL)
+	positiveDuration("EXPORT_FILES_MAX_AGE", c.CSV.ExportFilesMaxAge)
+	positiveDuration("LOG_RETENTION", c.LogRetention.Default)
+
+	notNegativeDuration("PG_CONN_MAX_LIFETIME", c.Database.ConnMaxLifetime)
+	notNegativeDuration("PG_CONN_MAX_IDLE_TIME", c.Database.ConnMaxIdleTime)
+	notNegativeDuration("PG_STATEMENT_TIMEOUT", c.Database.StatementTimeout)
+	backoff(
+		"PG_TX_RETRY_MIN_BACKOFF", c.Database.Transaction.RetryMinBackoff,
+		"PG_TX_RETRY_MAX_BACKOFF", c.Database.Transaction.RetryMaxBackoff,
+	)
+	if c.Database.Transaction.MaxRetries < 0 {
+		errs = append(errs, fmt.Errorf("PG_TX_MAX_RETRIES must not be negative, got %d", c.Database.Transaction.MaxRetries))
+	}
+	isolation := func(key string, value string) {
+		if _, ok := isolationLevels[value]; !ok {
+			errs = append(errs, fmt.Errorf(
+				"%s must be one of read_committed, repeatable_read, serializable, got %q", key, value,
+			))
+		}
+	}
+	isolation("PG_TX_ISOLATION", c.Database.Transaction.Isolation)
+	isolation("PG_SEGMENT_TX_ISOLATION", c.Database.Transaction.SegmentIsolation)
+	if c.Database.Replica.Host != "" {
+		notNegativeDuration("PG_REPLICA_MAX_LAG", c.Database.Replica.MaxLag)
+		positiveDuration("PG_REPLICA_CHECK_INTERVAL", c.Database.Replica.CheckInterval)
+	}
+	notNegativeDuration("LOG_RETENTION_ADD", c.LogRetention.Add)
+	notNegativeDuration("LOG_RETENTION_DELETE", c.LogRetention.Delete)
+	notNegativeDuration("SHUTDOWN_DRAIN_DELAY", c.Microservice.ShutdownDrainDelay)
+	notNegativeDuration("OUTBOX_SENT_RETENTION", c.Outbox.SentRetention)
+	notNegativeDuration("JWT_LEEWAY", c.Auth.JWT.Leeway)
+
+	backoff("OUTBOX_RETRY_MIN_BACKOFF", c.Outbox.RetryMinBackoff, "OUTBOX_RETRY_MAX_BACKOFF", c.Outbox.RetryMaxBackoff)
+	backoff("WEBHOOK_RETRY_MIN_BACKOFF", c.Webhook.RetryMinBackoff, "WEBHOOK_RETRY_MAX_BACKOFF", c.Webhook.RetryMaxBackoff)
+
+	if c.Database.MaxOpenConns < 0 {
+		errs = append(errs, fmt.Errorf("PG_MAX_OPEN_CONNS must not be negative, got %d", c.Database.MaxOpenConns))
+	}
+	if c.Database.MaxIdleConns < 0 {
+		errs = append(errs, fmt.Errorf("PG_MAX_IDLE_CONNS must not be negative, got %d", c.Database.MaxIdleConns))
+	}
+	if c.Database.MaxOpenConns > 0 && c.Database.MaxIdleConns > c.Database.MaxOpenConns {
+		errs = append(errs, fmt.Errorf(
+			"PG_MAX_IDLE_CONNS must not be greater than PG_MAX_OPEN_CONNS, got %d > %d",
+			c.Database.MaxIdleConns, c.Database.MaxOpenConns,
+		))
+	}
+	oneOf("PG_SSL_MODE", c.Database.SSLMode, SSLModeDisable, SSLModeRequire, SSLModeVerifyCA, SSLModeVerifyFull)
+	if (c.Database.SSLCert == "") != (c.Database.SSLKey == "") {
+		errs = append(errs, errors.New("PG_SSL_CERT and PG_SSL_KEY must be set together"))
+	}
+
+	if c.LogPartitions.MonthsAhead < 0 {
+		errs = append(errs, fmt.Errorf("LOG_PARTITIONS_MONTHS_AHEAD must not be negative, got %d", c.LogPartitions.MonthsAhead))
+	}
+	if c.Tracing.SampleRatio < 0 || c.Tracing.SampleRatio > 1 {
+		errs = append(errs, fmt.Errorf("TRACING_SAMPLE_RATIO must be between 0 and 1, got %v", c.Tracing.SampleRatio))
+	}
+
+	oneOf("BLOB_STORAGE_BACKEND", c.BlobStorage.Backend, BlobStorageBackendLocal, BlobStorageBackendS3)
+	oneOf("OUTBOX_SINK", c.Outbox.Sink, OutboxSinkFile, OutboxSinkHTTP)
+	oneOf("TRACING_EXPORTER", c.Tracing.Exporter, TracingExporterNone, TracingExporterOTLP, TracingExporterStdout)
+	oneOf("RATE_LIMIT_BACKEND", c.RateLimit.Backend, RateLimitBackendMemory, RateLimitBackendPostgres)
+
+	limit("RATE_LIMIT_DEFAULT", c.RateLimit.Default)
+	limit("RATE_LIMIT_ADDRESS", c.RateLimit.Address)
+	endpoints := make([]string, 0, len(c.RateLimit.Endpoints))
+	for endpoint := range c.RateLimit.Endpoints {
+		endpoints = append(endpoints, endpoint)
+	}
+	sort.Strings(endpoints)
+	for _, endpoint := range endpoints {
+		limit("RATE_LIMIT_ENDPOINTS of "+endpoint, c.RateLimit.Endpoints[endpoint])
+	}
+
+	if c.BlobStorage.Backend == BlobStorageBackendLocal {
+		errs = append(errs, directory("LOGS_CSV_DIRECTORY", c.CSV.LogCSVDirectory))
+	}
+	if c.Outbox.Sink == OutboxSinkFile {
+		errs = append(errs, directory("directory of OUTBOX_SINK_FILE_PATH", filepath.Dir(c.Outbox.SinkFilePath)))
+	}
+	file := func(key string, path string) {
+		if path == "" {
+			return
+		}
+		_, err := os.Stat(path)
+		if err != nil {
+			errs = append(errs, fmt.Errorf("%s must be existing file: %w", key, err))
+		}
+	}
+	file("JWT_JWKS_FILE", c.Auth.JWT.JWKSFile)
+	file("PG_SSL_ROOT_CERT", c.Database.SSLRootCert)
+	file("PG_SSL_CERT", c.Database.SSLCert)
+	file("PG_SSL_KEY", c.Database.SSLKey)
+
+	// errors.Join skips nil errors of checks which passed
+	return errors.Join(errs...)
+}
@@ -2,46 +2,27 @@ package main
 
 import (
 	"context"
-	"database/sql"
-	"fmt"
 	"log/slog"
 	"os"
 	"time"
 
 	"github.com/go-co-op/gocron"
-	"github.com/joho/godotenv"
-	_ "github.com/lib/pq"
 
 	"github.com/pollykon/avito_test_task/cmd"
+	exportFileRepository "github.com/pollykon/avito_test_task/internal/repository/export_file"
 	logRepository "github.com/pollykon/avito_test_task/internal/repository/log"
+	outboxRepository "github.com/pollykon/avito_test_task/internal/repository/outbox"
+	"github.com/pollykon/avito_test_task/internal/repository/rate_limit/postgres"
 	segmentRepository "github.com/pollykon/avito_test_task/internal/repository/segment"
 	deletersService "github.com/pollykon/avito_test_task/internal/service/deleters"
-	"github.com/pollykon/avito_test_task/internal/storage"
 )
 
 func main() {
 	logger := slog.New(slog.NewJSONHandler(os.Stdout, nil))
 
-	err := godotenv.Load()
-	if err != nil {
-		panic(err)
-	}
-
-	config, err := cmd.Load()
-	if err != nil {
-		panic(err)
-	}
-
-	dsn := fmt.Sprintf(
-		"host=%s port=%s user=%s password=%s dbname=%s sslmode=disable",
-		config.Database.Host,
-		config.Database.Port,
-		config.Database.User,
-		config.Database.Password,
-		config.Database.Name,
-	)
+	config := cmd.LoadOrExit(os.Args[1:])
 
-	db, err := sql.Open("postgres", dsn)
+	db, err := cmd.NewDatabase(config)
 	if err != nil {
 		logger.ErrorContext(context.Background(), "fail to connect to database", "error", err)
 		return
@@ -49,16 +30,41 @@ func main() {
 
 	defer func() { _ = db.Close() }()
 
-	database := storage.New(db)
+	database := cmd.NewStorage(config, logger, db, nil)
 
 	logRepo := logRepository.New(database)
 
-	segmentRepo := segmentRepository.New(database)
+	segmentRepo := segmentRepository.New(database, cmd.SegmentIsolation(config))
 
-	cron := deletersService.New(segmentRepo, logRepo)
+	exportFileRepo := exportFileRepository.New(database)
+
+	outboxRepo := outboxRepository.New(database)
+
+	blobStorage, err := cmd.NewBlobStorage(config)
+	if err != nil {
+		logger.ErrorContext(context.Background(), "fail to create blob storage", "error", err)
+		return
+	}
+
+	// buckets of rate limits are kept in database only with postgres backend
+	var bucketRepo deletersService.RateLimitBucketRepository
+	if config.RateLimit.Backend == cmd.RateLimitBackendPostgres {
+		limits, err := cmd.RateLimits(config)
+		if err != nil {
+			logger.ErrorContext(context.Background(), "fail to parse rate limits", "error", err)
+			return
+		}
+		bucketRepo = postgres.New(database, limits.RefillTime())
+	}
+
+	cron := deletersService.New(segmentRepo, logRepo, exportFileRepo, blobStorage, outboxRepo, bucketRepo)
 	ctx := context.Background()
 
+	cmd.ServeCronMetrics(config, logger)
+
 	s := gocron.NewScheduler(time.UTC)
+	// next run of job mustn't start until previous one finishes, otherwise two runs archive and drop the same partition
+	s.SingletonModeAll()
 
 	//cron which deletes segments with flag 'deleted' = true
 	_, err = s.Every(config.CronTimeInterval.DeleteSegments).Do(func() {
@@ -87,19 +93,72 @@ func main() {
 		logger.ErrorContext(ctx, "error while running cron which deletes segments from user segments", "error", err)
 		return
 	}
-	// cron which deletes old logs (3 month)
+	logRetention := deletersService.LogRetention{
+		Default: config.LogRetention.Default,
+		ByOperation: map[string]time.Duration{
+			logRepository.OperationTypeAdd:    config.LogRetention.Add,
+			logRepository.OperationTypeDelete: config.LogRetention.Delete,
+		},
+	}
+
+	// cron which creates partitions of log table for upcoming months
+	_, err = s.Every(config.CronTimeInterval.CreateLogPartitions).Do(func() {
+		logger.InfoContext(ctx, "starting to create log partitions")
+		err := cron.CreateLogPartitions(ctx, config.LogPartitions.MonthsAhead)
+		if err != nil {
+			logger.ErrorContext(ctx, "error while creating log partitions", "error", err)
+			return
+		}
+	})
+	if err != nil {
+		logger.ErrorContext(ctx, "error while running cron which creates log partitions", "error", err)
+		return
+	}
+
+	// cron which archives and deletes old logs
 	_, err = s.Every(config.CronTimeInterval.DeleteLogs).Do(func() {
 		logger.InfoContext(ctx, "starting to delete logs")
-		err = cron.DeleteLogs(ctx, config.BatchSize.Logs)
+		deletedLogs, err := cron.DeleteLogs(ctx, logRetention, config.BatchSize.Logs)
 		if err != nil {
-			logger.ErrorContext(ctx, "error while deleting logs", "error", err)
+			logger.ErrorContext(ctx, "error while deleting logs", "error", err, "deleted_logs", deletedLogs)
 			return
 		}
+		logger.InfoContext(ctx, "logs deleted", "deleted_logs", deletedLogs)
 	})
 	if err != nil {
 		logger.ErrorContext(ctx, "error while running cron which deletes logs", "error", err)
 		return
 	}
 
+	// cron which deletes old export files
+	_, err = s.Every(config.CronTimeInterval.DeleteExportFiles).Do(func() {
+		logger.InfoContext(ctx, "starting to delete export files")
+		reclaimedBytes, err := cron.DeleteExportFiles(ctx, config.CSV.ExportFilesMaxAge, config.BatchSize.ExportFiles)
+		if err != nil {
+			logger.ErrorContext(ctx, "error while deleting export files", "error", err, "reclaimed_bytes", reclaimedBytes)
+			return
+		}
+		logger.InfoContext(ctx, "export files deleted", "reclaimed_bytes", reclaimedBytes)
+	})
+	if err != nil {
+		logger.ErrorContext(ctx, "error while running cron which deletes export files", "error", err)
+		return
+	}
+
+	// cron which deletes full buckets of rate limits
+	if bucketRepo != nil {
+		_, err = s.Every(config.CronTimeInterval.DeleteRateLimitBuckets).Do(func() {
+			err := cron.DeleteRateLimitBuckets(ctx)
+			if err != nil {
+				logger.ErrorContext(ctx, "error while deleting rate limit buckets", "error", err)
+				return
+			}
+		})
+		if err != nil {
+			logger.ErrorContext(ctx, "error while running cron which deletes rate limit buckets", "error", err)
+			return
+		}
+	}
+
 	s.StartBlocking()
 }
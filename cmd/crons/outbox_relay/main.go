@@ -0,0 +1,130 @@
+package main
+
+import (
+	"context"
+	"log/slog"
+	"os"
+	"time"
+
+	"github.com/go-co-op/gocron"
+
+	"github.com/pollykon/avito_test_task/cmd"
+	"github.com/pollykon/avito_test_task/internal/publisher/webhook_sink"
+	outboxRepository "github.com/pollykon/avito_test_task/internal/repository/outbox"
+	webhookRepository "github.com/pollykon/avito_test_task/internal/repository/webhook"
+	relayService "github.com/pollykon/avito_test_task/internal/service/relay"
+	webhookService "github.com/pollykon/avito_test_task/internal/service/webhook"
+)
+
+func main() {
+	logger := slog.New(slog.NewJSONHandler(os.Stdout, nil))
+
+	config := cmd.LoadOrExit(os.Args[1:])
+
+	db, err := cmd.NewDatabase(config)
+	if err != nil {
+		logger.ErrorContext(context.Background(), "fail to connect to database", "error", err)
+		return
+	}
+
+	defer func() { _ = db.Close() }()
+
+	database := cmd.NewStorage(config, logger, db, nil)
+
+	outboxRepo := outboxRepository.New(database)
+	webhookRepo := webhookRepository.New(database)
+
+	publisher, err := cmd.NewPublisher(config)
+	if err != nil {
+		logger.ErrorContext(context.Background(), "fail to create publisher", "error", err)
+		return
+	}
+
+	relay := relayService.New(
+		outboxRepo,
+		publisher,
+		config.Outbox.SinkHTTPTimeout,
+		config.Outbox.RetryMinBackoff,
+		config.Outbox.RetryMaxBackoff,
+	)
+	webhookWorker := webhookService.NewWorker(
+		webhookRepo,
+		outboxRepo,
+		webhook_sink.New(webhook_sink.NewClient(config.Webhook.Timeout, config.Webhook.AllowPrivateAddresses)),
+		config.Webhook.Timeout,
+		config.Webhook.MaxAttempts,
+		config.Webhook.RetryMinBackoff,
+		config.Webhook.RetryMaxBackoff,
+	)
+	ctx := context.Background()
+
+	cmd.ServeCronMetrics(config, logger)
+
+	s := gocron.NewScheduler(time.UTC)
+	// next run of job mustn't start until previous one finishes
+	s.SingletonModeAll()
+
+	// cron which sends events from outbox
+	_, err = s.Every(config.Outbox.RelayInterval).Do(func() {
+		sent, err := relay.Relay(ctx, config.Outbox.BatchSize)
+		if err != nil {
+			logger.ErrorContext(ctx, "error while relaying events", "error", err)
+			return
+		}
+		if sent > 0 {
+			logger.InfoContext(ctx, "events sent", "sent_events", sent)
+		}
+	})
+	if err != nil {
+		logger.ErrorContext(ctx, "error while running cron which relays events", "error", err)
+		return
+	}
+
+	// cron which creates webhook deliveries for new events
+	_, err = s.Every(config.Webhook.DispatchInterval).Do(func() {
+		dispatched, err := webhookWorker.Dispatch(ctx, config.Webhook.BatchSize)
+		if err != nil {
+			logger.ErrorContext(ctx, "error while dispatching events to webhooks", "error", err)
+			return
+		}
+		if dispatched > 0 {
+			logger.InfoContext(ctx, "events dispatched to webhooks", "dispatched_events", dispatched)
+		}
+	})
+	if err != nil {
+		logger.ErrorContext(ctx, "error while running cron which dispatches events to webhooks", "error", err)
+		return
+	}
+
+	// cron which sends webhooks
+	_, err = s.Every(config.Webhook.DeliverInterval).Do(func() {
+		delivered, err := webhookWorker.Deliver(ctx, config.Webhook.BatchSize)
+		if err != nil {
+			logger.ErrorContext(ctx, "error while delivering webhooks", "error", err)
+			return
+		}
+		if delivered > 0 {
+			logger.InfoContext(ctx, "webhooks delivered", "delivered_webhooks", delivered)
+		}
+	})
+	if err != nil {
+		logger.ErrorContext(ctx, "error while running cron which delivers webhooks", "error", err)
+		return
+	}
+
+	// cron which deletes sent events
+	_, err = s.Every(config.Outbox.CleanupInterval).Do(func() {
+		logger.InfoContext(ctx, "starting to delete sent events")
+		err := relay.DeleteSent(ctx, config.Outbox.SentRetention, config.Outbox.BatchSize)
+		if err != nil {
+			logger.ErrorContext(ctx, "error while deleting sent events", "error", err)
+			return
+		}
+	})
+	if err != nil {
+		logger.ErrorContext(ctx, "error while running cron which deletes sent events", "error", err)
+		return
+	}
+
+	s.StartBlocking()
+}
@@ -0,0 +1,152 @@
+package cmd
+
+import (
+	"database/sql"
+	"fmt"
+	"log/slog"
+	"os"
+	"strconv"
+	"strings"
+
+	_ "github.com/lib/pq"
+
+	"github.com/pollykon/avito_test_task/internal/storage"
+)
+
+const (
+	SSLModeDisable    = "disable"
+	SSLModeRequire    = "require"
+	SSLModeVerifyCA   = "verify-ca"
+	SSLModeVerifyFull = "verify-full"
+)
+
+// DSN builds connection string of lib/pq from database config, it is also used by listener of notifications
+func DSN(config *Config) string {
+	return dsn(config.Database)
+}
+
+func dsn(database DatabaseConfig) string {
+	params := [][2]string{
+		{"host", database.Host},
+		{"port", database.Port},
+		{"user", database.User},
+		{"password", database.Password},
+		{"dbname", database.Name},
+		{"sslmode", database.SSLMode},
+		{"sslrootcert", database.SSLRootCert},
+		{"sslcert", database.SSLCert},
+		{"sslkey", database.SSLKey},
+	}
+	if database.StatementTimeout > 0 {
+		// unknown keys are sent to postgres as run-time parameters of connection
+		params = append(params, [2]string{
+			"statement_timeout", strconv.FormatInt(database.StatementTimeout.Milliseconds(), 10),
+		})
+	}
+
+	parts := make([]string, 0, len(params))
+	for _, param := range params {
+		if param[1] == "" {
+			continue
+		}
+		parts = append(parts, param[0]+"="+quoteDSNValue(param[1]))
+	}
+
+	return strings.Join(parts, " ")
+}
+
+// NewDatabase opens pool of connections to postgres with limits from config. Connections are established lazily,
+// so it doesn't fail when database is unavailable
+func NewDatabase(config *Config) (*sql.DB, error) {
+	return openDatabase(config.Database)
+}
+
+// NewReplicaDatabase opens pool of connections to replica, it returns nil when replica isn't configured
+func NewReplicaDatabase(config *Config) (*sql.DB, error) {
+	if config.Database.Replica.Host == "" {
+		return nil, nil
+	}
+
+	return openDatabase(replicaConfig(config.Database))
+}
+
+// isolationLevels are values of PG_TX_ISOLATION and PG_SEGMENT_TX_ISOLATION
+var isolationLevels = map[string]sql.IsolationLevel{
+	"read_committed":  sql.LevelReadCommitted,
+	"repeatable_read": sql.LevelRepeatableRead,
+	"serializable":    sql.LevelSerializable,
+}
+
+// NewStorage creates storage of primary database which routes read-only queries to replica when it is set.
+// Retried transactions are logged by logger
+func NewStorage(config *Config, logger *slog.Logger, db *sql.DB, replicaDB *sql.DB) storage.Database {
+	database := storage.New(db)
+	if replicaDB != nil {
+		database = storage.NewWithReplica(db, replicaDB, storage.ReplicaConfig{
+			MaxLag:        config.Database.Replica.MaxLag,
+			CheckInterval: config.Database.Replica.CheckInterval,
+		})
+	}
+
+	transaction := config.Database.Transaction
+	return database.WithTransactionConfig(storage.TransactionConfig{
+		// level is checked by validation of config
+		Isolation:       isolationLevels[transaction.Isolation],
+		MaxRetries:      transaction.MaxRetries,
+		RetryMinBackoff: transaction.RetryMinBackoff,
+		RetryMaxBackoff: transaction.RetryMaxBackoff,
+		Logger:          logger,
+	})
+}
+
+// SegmentIsolation returns isolation level of transactions of segments
+func SegmentIsolation(config *Config) sql.IsolationLevel {
+	// level is checked by validation of config
+	return isolationLevels[config.Database.Transaction.SegmentIsolation]
+}
+
+func openDatabase(database DatabaseConfig) (*sql.DB, error) {
+	db, err := sql.Open("postgres", dsn(database))
+	if err != nil {
+		return nil, fmt.Errorf("error while opening database: %w", err)
+	}
+
+	db.SetMaxOpenConns(database.MaxOpenConns)
+	db.SetMaxIdleConns(database.MaxIdleConns)
+	db.SetConnMaxLifetime(database.ConnMaxLifetime)
+	db.SetConnMaxIdleTime(database.ConnMaxIdleTime)
+
+	return db, nil
+}
+
+// replicaConfig returns config of primary with host and port of replica
+func replicaConfig(database DatabaseConfig) DatabaseConfig {
+	database.Host = database.Replica.Host
+	if database.Replica.Port != "" {
+		database.Port = database.Replica.Port
+	}
+	return database
+}
+
+// quoteDSNValue quotes value of key=value connection string, so values with spaces and quotes are passed as is
+func quoteDSNValue(value string) string {
+	return "'" + strings.NewReplacer(`\`, `\\`, `'`, `\'`).Replace(value) + "'"
+}
+
+// readPasswordFile replaces password with content of PG_PASSWORD_FILE, trailing newline of the file is ignored
+func (c *DatabaseConfig) readPasswordFile() error {
+	if c.PasswordFile == "" {
+		return nil
+	}
+	if c.Password != "" {
+		return fmt.Errorf("only one of PG_PASSWORD and PG_PASSWORD_FILE should be set")
+	}
+
+	content, err := os.ReadFile(c.PasswordFile)
+	if err != nil {
+		return fmt.Errorf("PG_PASSWORD_FILE must be readable file: %w", err)
+	}
+	c.Password = strings.TrimRight(string(content), "\r\n")
+
+	return nil
+}
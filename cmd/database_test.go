@@ -0,0 +1,89 @@
+package cmd
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestDSN(t *testing.T) {
+	tests := []struct {
+		name string
+
+		config DatabaseConfig
+
+		expectedDSN string
+	}{
+		{
+			name: "without ssl",
+
+			config: DatabaseConfig{
+				Host: "localhost", Port: "5432", User: "postgres", Password: "postgres", Name: "segments",
+				SSLMode: SSLModeDisable,
+			},
+
+			expectedDSN: "host='localhost' port='5432' user='postgres' password='postgres' dbname='segments' " +
+				"sslmode='disable'",
+		},
+		{
+			name: "client certificates and statement timeout",
+
+			config: DatabaseConfig{
+				Host: "db", Port: "5432", User: "service", Name: "segments",
+				SSLMode:          SSLModeVerifyFull,
+				SSLRootCert:      "/certs/ca.pem",
+				SSLCert:          "/certs/client.pem",
+				SSLKey:           "/certs/client.key",
+				StatementTimeout: 5 * time.Second,
+			},
+
+			expectedDSN: "host='db' port='5432' user='service' dbname='segments' sslmode='verify-full' " +
+				"sslrootcert='/certs/ca.pem' sslcert='/certs/client.pem' sslkey='/certs/client.key' " +
+				"statement_timeout='5000'",
+		},
+		{
+			name: "password with spaces and quotes",
+
+			config: DatabaseConfig{Host: "db", Password: `pa ss'wo\rd`, SSLMode: SSLModeRequire},
+
+			expectedDSN: `host='db' password='pa ss\'wo\\rd' sslmode='require'`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			require.Equal(t, tt.expectedDSN, DSN(&Config{Database: tt.config}))
+		})
+	}
+}
+
+func TestDatabaseConfig_ReadPasswordFile(t *testing.T) {
+	path := writeConfigFile(t, "password", "from-file\n")
+
+	config := DatabaseConfig{PasswordFile: path}
+	require.NoError(t, config.readPasswordFile())
+	require.Equal(t, "from-file", config.Password)
+
+	config = DatabaseConfig{PasswordFile: path, Password: "postgres"}
+	require.ErrorContains(t, config.readPasswordFile(), "only one of PG_PASSWORD and PG_PASSWORD_FILE")
+
+	config = DatabaseConfig{PasswordFile: path + ".missing"}
+	require.ErrorContains(t, config.readPasswordFile(), "PG_PASSWORD_FILE must be readable file")
+}
+
+func TestReplicaConfig(t *testing.T) {
+	primary := DatabaseConfig{
+		Host: "primary", Port: "5432", User: "service", SSLMode: SSLModeRequire,
+		Replica: DatabaseReplicaConfig{Host: "replica"},
+	}
+
+	replica := replicaConfig(primary)
+	require.Equal(t, "replica", replica.Host)
+	require.Equal(t, "5432", replica.Port)
+	require.Equal(t, "service", replica.User)
+	require.Equal(t, SSLModeRequire, replica.SSLMode)
+
+	primary.Replica.Port = "5433"
+	require.Equal(t, "5433", replicaConfig(primary).Port)
+}
@@ -0,0 +1,39 @@
+package cmd
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"time"
+
+	"github.com/lib/pq"
+
+	outboxRepository "github.com/pollykon/avito_test_task/internal/repository/outbox"
+	serviceStream "github.com/pollykon/avito_test_task/internal/service/stream"
+)
+
+// listenRetryInterval is a pause between attempts to listen to outbox notifications
+const listenRetryInterval = 5 * time.Second
+
+// ListenOutbox subscribes listener to outbox notifications in background and then distributes them by stream service
+// until ctx is done. Like database, listening is retried until it succeeds and service isn't ready until then
+func ListenOutbox(ctx context.Context, logger *slog.Logger, listener *pq.Listener, streamService *serviceStream.Service) {
+	go func() {
+		for {
+			err := listener.Listen(outboxRepository.NotifyChannel)
+			if err == nil || errors.Is(err, pq.ErrChannelAlreadyOpen) {
+				break
+			}
+
+			logger.WarnContext(ctx, "fail to listen outbox notifications", "error", err)
+
+			select {
+			case <-ctx.Done():
+				return
+			case <-time.After(listenRetryInterval):
+			}
+		}
+
+		streamService.Run(ctx, listener.NotificationChannel())
+	}()
+}
@@ -0,0 +1,28 @@
+package cmd
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"net/http"
+
+	handlerMetrics "github.com/pollykon/avito_test_task/internal/handlers/metrics"
+)
+
+// ServeCronMetrics exposes metrics of cron on /metrics of METRICS_CRON_PORT, crons don't have other http server
+func ServeCronMetrics(config *Config, logger *slog.Logger) {
+	mux := http.NewServeMux()
+	mux.Handle("/metrics", handlerMetrics.Handler())
+
+	server := http.Server{
+		Addr:    ":" + config.Metrics.CronPort,
+		Handler: mux,
+	}
+
+	go func() {
+		logger.InfoContext(context.Background(), "metrics listener started", "port", config.Metrics.CronPort)
+		if err := server.ListenAndServe(); err != nil && !errors.Is(err, http.ErrServerClosed) {
+			logger.ErrorContext(context.Background(), "error while starting metrics listener", "error", err)
+		}
+	}()
+}
@@ -0,0 +1,35 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+
+	"github.com/pollykon/avito_test_task/internal/publisher"
+	"github.com/pollykon/avito_test_task/internal/publisher/file_sink"
+	"github.com/pollykon/avito_test_task/internal/publisher/http_sink"
+)
+
+const (
+	OutboxSinkFile = "file"
+	OutboxSinkHTTP = "http"
+)
+
+type Publisher interface {
+	Publish(ctx context.Context, message publisher.Message) error
+}
+
+// NewPublisher creates sink for outbox events selected by OUTBOX_SINK
+func NewPublisher(config *Config) (Publisher, error) {
+	switch config.Outbox.Sink {
+	case OutboxSinkFile:
+		return file_sink.New(config.Outbox.SinkFilePath)
+	case OutboxSinkHTTP:
+		if config.Outbox.SinkHTTPURL == "" {
+			return nil, fmt.Errorf("OUTBOX_SINK_HTTP_URL is required for http sink")
+		}
+		return http_sink.New(config.Outbox.SinkHTTPURL, &http.Client{Timeout: config.Outbox.SinkHTTPTimeout}), nil
+	default:
+		return nil, fmt.Errorf("unknown outbox sink: %q", config.Outbox.Sink)
+	}
+}
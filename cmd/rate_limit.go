@@ -0,0 +1,63 @@
+package cmd
+
+import (
+	"fmt"
+
+	rateLimitRepository "github.com/pollykon/avito_test_task/internal/repository/rate_limit"
+	"github.com/pollykon/avito_test_task/internal/repository/rate_limit/memory"
+	"github.com/pollykon/avito_test_task/internal/repository/rate_limit/postgres"
+	serviceRateLimit "github.com/pollykon/avito_test_task/internal/service/rate_limit"
+	"github.com/pollykon/avito_test_task/internal/storage"
+)
+
+const (
+	RateLimitBackendMemory   = "memory"
+	RateLimitBackendPostgres = "postgres"
+)
+
+// RateLimits parses limits of rate limiter from config
+func RateLimits(config *Config) (serviceRateLimit.Limits, error) {
+	defaultLimit, err := serviceRateLimit.ParseLimit(config.RateLimit.Default)
+	if err != nil {
+		return serviceRateLimit.Limits{}, fmt.Errorf("invalid RATE_LIMIT_DEFAULT: %w", err)
+	}
+
+	endpointLimits := make(map[string]rateLimitRepository.Limit, len(config.RateLimit.Endpoints))
+	for endpoint, value := range config.RateLimit.Endpoints {
+		endpointLimits[endpoint], err = serviceRateLimit.ParseLimit(value)
+		if err != nil {
+			return serviceRateLimit.Limits{}, fmt.Errorf("invalid RATE_LIMIT_ENDPOINTS of %s: %w", endpoint, err)
+		}
+	}
+
+	addressLimit, err := serviceRateLimit.ParseLimit(config.RateLimit.Address)
+	if err != nil {
+		return serviceRateLimit.Limits{}, fmt.Errorf("invalid RATE_LIMIT_ADDRESS: %w", err)
+	}
+
+	return serviceRateLimit.Limits{
+		Default:   defaultLimit,
+		Endpoints: endpointLimits,
+		Address:   addressLimit,
+	}, nil
+}
+
+// NewRateLimiter creates rate limiter with limits from config and buckets selected by RATE_LIMIT_BACKEND
+func NewRateLimiter(config *Config, db storage.Database) (serviceRateLimit.Service, error) {
+	limits, err := RateLimits(config)
+	if err != nil {
+		return serviceRateLimit.Service{}, err
+	}
+
+	var bucketRepo serviceRateLimit.BucketRepository
+	switch config.RateLimit.Backend {
+	case RateLimitBackendMemory:
+		bucketRepo = memory.New()
+	case RateLimitBackendPostgres:
+		bucketRepo = postgres.New(db, limits.RefillTime())
+	default:
+		return serviceRateLimit.Service{}, fmt.Errorf("unknown rate limit backend: %q", config.RateLimit.Backend)
+	}
+
+	return serviceRateLimit.New(bucketRepo, limits), nil
+}
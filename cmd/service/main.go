@@ -2,32 +2,67 @@ package main
 
 import (
 	"context"
-	"database/sql"
 	"errors"
-	"fmt"
 	"github.com/pollykon/avito_test_task/cmd"
 	"log/slog"
+	"net"
 	"net/http"
 	"os"
 	"os/signal"
+	"strings"
 	"syscall"
 	"time"
 
-	"github.com/joho/godotenv"
-	_ "github.com/lib/pq"
+	"github.com/lib/pq"
+	"go.opentelemetry.io/contrib/instrumentation/net/http/otelhttp"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/health"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+	"google.golang.org/grpc/reflection"
 
+	"github.com/pollykon/avito_test_task/internal/actor"
+	segmentv1 "github.com/pollykon/avito_test_task/internal/api/segment/v1"
+	"github.com/pollykon/avito_test_task/internal/handlers"
+	handlerAccessLog "github.com/pollykon/avito_test_task/internal/handlers/access_log"
+	handlerAddAPIKey "github.com/pollykon/avito_test_task/internal/handlers/add_api_key"
 	handlerAddSegment "github.com/pollykon/avito_test_task/internal/handlers/add_segment"
 	handlerAddUserToSegment "github.com/pollykon/avito_test_task/internal/handlers/add_user_to_segments"
+	handlerAddWebhook "github.com/pollykon/avito_test_task/internal/handlers/add_webhook"
+	handlerAuth "github.com/pollykon/avito_test_task/internal/handlers/auth"
+	handlerDeleteAPIKey "github.com/pollykon/avito_test_task/internal/handlers/delete_api_key"
 	handlerDeleteSegment "github.com/pollykon/avito_test_task/internal/handlers/delete_segment"
 	handlerDeleteUserFromSegment "github.com/pollykon/avito_test_task/internal/handlers/delete_user_from_segment"
+	handlerDeleteWebhook "github.com/pollykon/avito_test_task/internal/handlers/delete_webhook"
+	handlerDownloadLogs "github.com/pollykon/avito_test_task/internal/handlers/download_logs"
+	handlerGetAPIKeys "github.com/pollykon/avito_test_task/internal/handlers/get_api_keys"
 	handlerGetLogs "github.com/pollykon/avito_test_task/internal/handlers/get_logs"
 	handlerGetUserActiveSegment "github.com/pollykon/avito_test_task/internal/handlers/get_user_active_segments"
-	csvRepository "github.com/pollykon/avito_test_task/internal/repository/csv"
+	handlerGetWebhookDeliveries "github.com/pollykon/avito_test_task/internal/handlers/get_webhook_deliveries"
+	handlerGetWebhooks "github.com/pollykon/avito_test_task/internal/handlers/get_webhooks"
+	handlerGRPCServer "github.com/pollykon/avito_test_task/internal/handlers/grpc_server"
+	handlerLiveness "github.com/pollykon/avito_test_task/internal/handlers/liveness"
+	handlerMetrics "github.com/pollykon/avito_test_task/internal/handlers/metrics"
+	handlerRateLimit "github.com/pollykon/avito_test_task/internal/handlers/rate_limit"
+	handlerReadiness "github.com/pollykon/avito_test_task/internal/handlers/readiness"
+	handlerSegmentsV2 "github.com/pollykon/avito_test_task/internal/handlers/segments_v2"
+	handlerStreamUserSegments "github.com/pollykon/avito_test_task/internal/handlers/stream_user_segments"
+	handlerUsersV2 "github.com/pollykon/avito_test_task/internal/handlers/users_v2"
+	internalLogger "github.com/pollykon/avito_test_task/internal/logger"
+	apiKeyRepository "github.com/pollykon/avito_test_task/internal/repository/api_key"
+	exportFileRepository "github.com/pollykon/avito_test_task/internal/repository/export_file"
 	logRepository "github.com/pollykon/avito_test_task/internal/repository/log"
+	outboxRepository "github.com/pollykon/avito_test_task/internal/repository/outbox"
+	schemaRepository "github.com/pollykon/avito_test_task/internal/repository/schema"
 	segmentRepository "github.com/pollykon/avito_test_task/internal/repository/segment"
+	webhookRepository "github.com/pollykon/avito_test_task/internal/repository/webhook"
+	serviceAuth "github.com/pollykon/avito_test_task/internal/service/auth"
+	serviceHealth "github.com/pollykon/avito_test_task/internal/service/health"
+	serviceLink "github.com/pollykon/avito_test_task/internal/service/link"
 	serviceLog "github.com/pollykon/avito_test_task/internal/service/log"
 	serviceSegment "github.com/pollykon/avito_test_task/internal/service/segment"
-	"github.com/pollykon/avito_test_task/internal/storage"
+	serviceStream "github.com/pollykon/avito_test_task/internal/service/stream"
+	serviceWebhook "github.com/pollykon/avito_test_task/internal/service/webhook"
+	"github.com/pollykon/avito_test_task/internal/signer"
 )
 
 const (
@@ -35,45 +70,97 @@ const (
 )
 
 func main() {
-	logger := slog.New(slog.NewJSONHandler(os.Stdout, nil))
+	logger := slog.New(internalLogger.NewContextHandler(slog.NewJSONHandler(os.Stdout, nil)))
 
-	err := godotenv.Load()
+	config := cmd.LoadOrExit(os.Args[1:])
+
+	shutdownTracing, err := cmd.SetupTracing(context.Background(), config)
 	if err != nil {
-		panic(err)
+		logger.ErrorContext(context.Background(), "fail to set up tracing", "error", err)
+		return
 	}
 
-	config, err := cmd.Load()
+	db, err := cmd.NewDatabase(config)
 	if err != nil {
-		panic(err)
+		logger.ErrorContext(context.Background(), "fail to connect to database", "error", err)
+		return
 	}
 
-	logCSVDirectory := config.CSV.LogCSVDirectory
-
-	dsn := fmt.Sprintf(
-		"host=%s port=%s user=%s password=%s dbname=%s sslmode=disable",
-		config.Database.Host,
-		config.Database.Port,
-		config.Database.User,
-		config.Database.Password,
-		config.Database.Name,
-	)
+	defer func() { _ = db.Close() }()
 
-	db, err := sql.Open("postgres", dsn)
+	replicaDB, err := cmd.NewReplicaDatabase(config)
 	if err != nil {
-		logger.ErrorContext(context.Background(), "fail to connect to database", "error", err)
+		logger.ErrorContext(context.Background(), "fail to connect to database replica", "error", err)
 		return
 	}
 
-	defer func() { _ = db.Close() }()
+	if replicaDB != nil {
+		defer func() { _ = replicaDB.Close() }()
+	}
+
+	database := cmd.NewStorage(config, logger, db, replicaDB)
 
-	database := storage.New(db)
+	// service starts without database, it isn't ready until database is available
+	err = database.PingContext(context.Background())
+	if err != nil {
+		logger.WarnContext(context.Background(), "database is unavailable", "error", err)
+	}
 
-	segmentRepo := segmentRepository.New(database)
+	segmentRepo := segmentRepository.New(database, cmd.SegmentIsolation(config))
 	logRepo := logRepository.New(database)
-	csvRepo := csvRepository.New(logCSVDirectory)
+	exportFileRepo := exportFileRepository.New(database)
+	outboxRepo := outboxRepository.New(database)
+	webhookRepo := webhookRepository.New(database)
+	apiKeyRepo := apiKeyRepository.New(database)
+	schemaRepo := schemaRepository.New(database)
+
+	blobStorage, err := cmd.NewBlobStorage(config)
+	if err != nil {
+		logger.ErrorContext(context.Background(), "fail to create blob storage", "error", err)
+		return
+	}
 
-	segmentService := serviceSegment.New(logRepo, segmentRepo)
-	logService := serviceLog.New(logRepo, csvRepo)
+	segmentService := serviceSegment.New(logRepo, segmentRepo, outboxRepo)
+	logService := serviceLog.New(logRepo, blobStorage, exportFileRepo)
+	webhookService := serviceWebhook.New(webhookRepo, config.Webhook.AllowHTTP)
+	streamService := serviceStream.New(outboxRepo, config.Stream.BufferSize, config.Stream.MaxBacklog)
+
+	// writability is checked only for export files stored on local disk
+	exportDirectory, _ := blobStorage.(serviceHealth.ExportDirectory)
+	healthService := serviceHealth.New(schemaRepo, exportDirectory, streamService)
+
+	tokenVerifier, err := cmd.NewTokenVerifier(context.Background(), config)
+	if err != nil {
+		logger.ErrorContext(context.Background(), "fail to create token verifier", "error", err)
+		return
+	}
+
+	authService := serviceAuth.New(apiKeyRepo, tokenVerifier)
+
+	rateLimiter, err := cmd.NewRateLimiter(config, database)
+	if err != nil {
+		logger.ErrorContext(context.Background(), "fail to create rate limiter", "error", err)
+		return
+	}
+
+	listener := pq.NewListener(cmd.DSN(config), time.Second, time.Minute, func(event pq.ListenerEventType, err error) {
+		if err != nil {
+			logger.ErrorContext(context.Background(), "error in outbox listener", "error", err, "event", event)
+		}
+	})
+	defer func() { _ = listener.Close() }()
+
+	streamCtx, stopStreams := context.WithCancel(context.Background())
+	defer stopStreams()
+	cmd.ListenOutbox(streamCtx, logger, listener, streamService)
+
+	urlSigner := signer.New(config.CSV.DownloadURLSecret, config.CSV.DownloadURLTTL)
+
+	// files are streamed through this service unless storage can give direct links
+	var urlGenerator handlerGetLogs.URLGenerator = serviceLink.NewProxy(urlSigner, staticURIPrefix)
+	if presigner, ok := blobStorage.(serviceLink.Presigner); ok && !config.BlobStorage.S3.ProxyDownloads {
+		urlGenerator = serviceLink.NewPresign(presigner, config.CSV.DownloadURLTTL)
+	}
 
 	segmentAddHandler := handlerAddSegment.New(segmentService, logger)
 
@@ -85,25 +172,120 @@ func main() {
 
 	segmentGetUserActiveSegments := handlerGetUserActiveSegment.New(segmentService, logger)
 
-	logGetLogsHandler := handlerGetLogs.New(logService, staticURIPrefix, logger)
+	logGetLogsHandler := handlerGetLogs.New(logService, urlGenerator, logger)
+
+	logDownloadLogsHandler := handlerDownloadLogs.New(logService, urlSigner, staticURIPrefix, logger)
+
+	webhookAddHandler := handlerAddWebhook.New(webhookService, logger)
+
+	webhookDeleteHandler := handlerDeleteWebhook.New(webhookService, logger)
+
+	webhookGetWebhooksHandler := handlerGetWebhooks.New(webhookService, logger)
+
+	webhookGetDeliveriesHandler := handlerGetWebhookDeliveries.New(webhookService, logger)
+
+	streamUserSegmentsHandler := handlerStreamUserSegments.New(streamService, config.Stream.HeartbeatInterval, logger)
+
+	segmentsV2Handler := handlerSegmentsV2.New(segmentService, logger)
+
+	usersV2Handler := handlerUsersV2.New(segmentService, logService, logger)
+
+	apiKeyAddHandler := handlerAddAPIKey.New(authService, logger)
+
+	apiKeyDeleteHandler := handlerDeleteAPIKey.New(authService, logger)
+
+	apiKeyGetKeysHandler := handlerGetAPIKeys.New(authService, logger)
+
+	auth := handlerAuth.New(authService, config.Auth.Enabled, logger)
+	rateLimit := handlerRateLimit.New(rateLimiter, config.RateLimit.Enabled, logger)
+	scope := handlerAuth.Static
 
 	mux := http.NewServeMux()
 
-	mux.Handle("/add_segment_v1", segmentAddHandler)
-	mux.Handle("/delete_segment_v1", segmentDeleteHandler)
-	mux.Handle("/add_user_to_segments_v1", segmentAddUserToSegment)
-	mux.Handle("/delete_user_from_segments_v1", segmentDeleteUserFromSegment)
-	mux.Handle("/get_user_active_segments_v1", segmentGetUserActiveSegments)
-	mux.Handle("/get_user_logs_v1", logGetLogsHandler)
+	// handle registers handler which requires scope. Requests are limited by ip address before authentication and
+	// then by client and endpoint. All requests including rejected ones are counted in metrics
+	handle := func(pattern string, scope handlerAuth.ScopeFunc, handler http.Handler) {
+		endpoint := strings.Trim(pattern, "/")
+		mux.Handle(pattern, handlerMetrics.Instrument(
+			endpoint,
+			rateLimit.LimitAddress(auth.Require(scope, rateLimit.Limit(endpoint, handler))),
+		))
+	}
+
+	handle("/add_segment_v1", scope(actor.ScopeSegmentsManage), segmentAddHandler)
+	handle("/delete_segment_v1", scope(actor.ScopeSegmentsManage), segmentDeleteHandler)
+	handle("/add_user_to_segments_v1", scope(actor.ScopeMembershipsWrite), segmentAddUserToSegment)
+	handle("/delete_user_from_segments_v1", scope(actor.ScopeMembershipsWrite), segmentDeleteUserFromSegment)
+	handle("/get_user_active_segments_v1", scope(actor.ScopeMembershipsRead), segmentGetUserActiveSegments)
+	handle("/get_user_logs_v1", scope(actor.ScopeHistoryExport), logGetLogsHandler)
+	handle("/add_webhook_v1", scope(actor.ScopeWebhooksManage), webhookAddHandler)
+	handle("/delete_webhook_v1", scope(actor.ScopeWebhooksManage), webhookDeleteHandler)
+	handle("/get_webhooks_v1", scope(actor.ScopeWebhooksManage), webhookGetWebhooksHandler)
+	handle("/get_webhook_deliveries_v1", scope(actor.ScopeWebhooksManage), webhookGetDeliveriesHandler)
+	handle("/stream_user_segments_v1", scope(actor.ScopeMembershipsRead), streamUserSegmentsHandler)
+	handle("/add_api_key_v1", scope(actor.ScopeKeysManage), apiKeyAddHandler)
+	handle("/delete_api_key_v1", scope(actor.ScopeKeysManage), apiKeyDeleteHandler)
+	handle("/get_api_keys_v1", scope(actor.ScopeKeysManage), apiKeyGetKeysHandler)
+
+	segmentsV2Scope := handlerAuth.ByMethod(actor.ScopeMembershipsRead, actor.ScopeSegmentsManage)
+	handle(handlerSegmentsV2.URIPrefix, segmentsV2Scope, segmentsV2Handler)
+	handle(handlerSegmentsV2.URIPrefix+"/", segmentsV2Scope, segmentsV2Handler)
+	handle(handlerUsersV2.URIPrefix, handlerUsersV2.RequiredScope, usersV2Handler)
 
-	staticHandler := http.StripPrefix(staticURIPrefix, http.FileServer(http.Dir(logCSVDirectory)))
-	mux.Handle(staticURIPrefix+"/", staticHandler)
+	// links to files are signed, so downloads don't require API key
+	mux.Handle(staticURIPrefix+"/", handlerMetrics.Instrument(strings.Trim(staticURIPrefix, "/"), logDownloadLogsHandler))
+	// metrics and probes are used by prometheus and orchestrator from internal network
+	mux.Handle("/metrics", handlerMetrics.Handler())
+	mux.Handle("/healthz", handlerLiveness.New())
+	mux.Handle("/readyz", handlerReadiness.New(healthService, logger))
 
+	// spans of requests are named by route, paths of v2 contain ids
+	spanName := otelhttp.WithSpanNameFormatter(func(_ string, r *http.Request) string {
+		_, pattern := mux.Handler(r)
+		return r.Method + " " + pattern
+	})
 	server := http.Server{
 		Addr:    ":" + config.Microservice.Port,
-		Handler: mux,
+		Handler: otelhttp.NewHandler(handlerAccessLog.New(logger).Log(handlers.LanguageMiddleware(mux)), "", spanName),
+	}
+	// streams never become idle, so they are closed before shutdown waits for connections
+	server.RegisterOnShutdown(stopStreams)
+
+	downloadHost := config.Microservice.GRPC.DownloadHost
+	if downloadHost == "" {
+		downloadHost = "localhost:" + config.Microservice.Port
 	}
 
+	grpcServer := grpc.NewServer(grpc.ChainUnaryInterceptor(
+		handlerGRPCServer.AccessLogInterceptor(logger),
+		handlerGRPCServer.LanguageInterceptor,
+		handlerGRPCServer.AddressRateLimitInterceptor(rateLimiter, config.RateLimit.Enabled, logger),
+		handlerGRPCServer.AuthInterceptor(authService, config.Auth.Enabled, logger),
+		handlerGRPCServer.RateLimitInterceptor(rateLimiter, config.RateLimit.Enabled, logger),
+	))
+	segmentv1.RegisterSegmentServiceServer(
+		grpcServer,
+		handlerGRPCServer.New(segmentService, logService, urlGenerator, downloadHost, logger),
+	)
+
+	healthServer := health.NewServer()
+	healthServer.SetServingStatus(segmentv1.SegmentService_ServiceDesc.ServiceName, healthpb.HealthCheckResponse_SERVING)
+	healthpb.RegisterHealthServer(grpcServer, healthServer)
+	reflection.Register(grpcServer)
+
+	grpcListener, err := net.Listen("tcp", ":"+config.Microservice.GRPC.Port)
+	if err != nil {
+		logger.ErrorContext(context.Background(), "fail to listen grpc port", "error", err)
+		return
+	}
+
+	go func() {
+		logger.InfoContext(context.Background(), "grpc service started", "port", config.Microservice.GRPC.Port)
+		if err := grpcServer.Serve(grpcListener); err != nil {
+			logger.ErrorContext(context.Background(), "error while starting grpc server", "error", err)
+		}
+	}()
+
 	go func() {
 		logger.InfoContext(context.Background(), "service started", "port", config.Microservice.Port)
 		if err = server.ListenAndServe(); err != nil && !errors.Is(err, http.ErrServerClosed) {
@@ -117,6 +299,11 @@ func main() {
 	<-quit
 	logger.InfoContext(context.Background(), "shutting down server...")
 
+	// readiness fails first, so that orchestrator stops sending new requests before server stops accepting them
+	healthService.Drain()
+	healthServer.Shutdown()
+	time.Sleep(config.Microservice.ShutdownDrainDelay)
+
 	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
 	defer cancel()
 
@@ -124,4 +311,11 @@ func main() {
 	if err != nil {
 		logger.ErrorContext(context.Background(), "error while shutting down", "error", err)
 	}
+
+	grpcServer.GracefulStop()
+
+	err = shutdownTracing(ctx)
+	if err != nil {
+		logger.ErrorContext(context.Background(), "error while flushing spans", "error", err)
+	}
 }
@@ -0,0 +1,68 @@
+package actor
+
+import (
+	"context"
+	"slices"
+)
+
+// Scopes of actors' permissions
+const (
+	ScopeMembershipsRead  = "memberships:read"
+	ScopeMembershipsWrite = "memberships:write"
+	ScopeSegmentsManage   = "segments:manage"
+	ScopeHistoryExport    = "history:export"
+	ScopeWebhooksManage   = "webhooks:manage"
+	ScopeKeysManage       = "keys:manage"
+)
+
+var Scopes = []string{
+	ScopeMembershipsRead,
+	ScopeMembershipsWrite,
+	ScopeSegmentsManage,
+	ScopeHistoryExport,
+	ScopeWebhooksManage,
+	ScopeKeysManage,
+}
+
+// Roles of admin UI users, each role includes scopes of previous one
+const (
+	RoleViewer = "viewer"
+	RoleEditor = "editor"
+	RoleAdmin  = "admin"
+)
+
+// RoleScopes contains scopes granted by roles
+var RoleScopes = map[string][]string{
+	RoleViewer: {ScopeMembershipsRead, ScopeHistoryExport},
+	RoleEditor: {ScopeMembershipsRead, ScopeHistoryExport, ScopeMembershipsWrite, ScopeSegmentsManage},
+	RoleAdmin:  Scopes,
+}
+
+// Actor is an authenticated client which makes request
+type Actor struct {
+	// ID identifies actor in logs, e.g. "api_key:12"
+	ID     string
+	Name   string
+	Scopes []string
+}
+
+func (a Actor) HasScope(scope string) bool {
+	return slices.Contains(a.Scopes, scope)
+}
+
+type actorKey struct{}
+
+func WithActor(ctx context.Context, a Actor) context.Context {
+	return context.WithValue(ctx, actorKey{}, a)
+}
+
+// FromContext returns actor of request, false if request isn't authenticated (e.g. made by crons)
+func FromContext(ctx context.Context) (Actor, bool) {
+	a, ok := ctx.Value(actorKey{}).(Actor)
+	return a, ok
+}
+
+// IsScope checks if scope is known
+func IsScope(scope string) bool {
+	return slices.Contains(Scopes, scope)
+}
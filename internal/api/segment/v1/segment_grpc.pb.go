@@ -0,0 +1,315 @@
+// Code generated by protoc-gen-go-grpc. DO NOT EDIT.
+// versions:
+// - protoc-gen-go-grpc v1.5.1
+// - protoc             (unknown)
+// source: segment/v1/segment.proto
+
+package segmentv1
+
+import (
+	context "context"
+	grpc "google.golang.org/grpc"
+	codes "google.golang.org/grpc/codes"
+	status "google.golang.org/grpc/status"
+)
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+// Requires gRPC-Go v1.64.0 or later.
+const _ = grpc.SupportPackageIsVersion9
+
+const (
+	SegmentService_AddSegment_FullMethodName             = "/segment.v1.SegmentService/AddSegment"
+	SegmentService_DeleteSegment_FullMethodName          = "/segment.v1.SegmentService/DeleteSegment"
+	SegmentService_AddUserToSegments_FullMethodName      = "/segment.v1.SegmentService/AddUserToSegments"
+	SegmentService_DeleteUserFromSegments_FullMethodName = "/segment.v1.SegmentService/DeleteUserFromSegments"
+	SegmentService_GetUserActiveSegments_FullMethodName  = "/segment.v1.SegmentService/GetUserActiveSegments"
+	SegmentService_GetUserLogs_FullMethodName            = "/segment.v1.SegmentService/GetUserLogs"
+)
+
+// SegmentServiceClient is the client API for SegmentService service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+//
+// SegmentService mirrors the JSON API: segments, user memberships and history exports.
+type SegmentServiceClient interface {
+	AddSegment(ctx context.Context, in *AddSegmentRequest, opts ...grpc.CallOption) (*AddSegmentResponse, error)
+	DeleteSegment(ctx context.Context, in *DeleteSegmentRequest, opts ...grpc.CallOption) (*DeleteSegmentResponse, error)
+	AddUserToSegments(ctx context.Context, in *AddUserToSegmentsRequest, opts ...grpc.CallOption) (*AddUserToSegmentsResponse, error)
+	DeleteUserFromSegments(ctx context.Context, in *DeleteUserFromSegmentsRequest, opts ...grpc.CallOption) (*DeleteUserFromSegmentsResponse, error)
+	GetUserActiveSegments(ctx context.Context, in *GetUserActiveSegmentsRequest, opts ...grpc.CallOption) (*GetUserActiveSegmentsResponse, error)
+	GetUserLogs(ctx context.Context, in *GetUserLogsRequest, opts ...grpc.CallOption) (*GetUserLogsResponse, error)
+}
+
+type segmentServiceClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewSegmentServiceClient(cc grpc.ClientConnInterface) SegmentServiceClient {
+	return &segmentServiceClient{cc}
+}
+
+func (c *segmentServiceClient) AddSegment(ctx context.Context, in *AddSegmentRequest, opts ...grpc.CallOption) (*AddSegmentResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(AddSegmentResponse)
+	err := c.cc.Invoke(ctx, SegmentService_AddSegment_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *segmentServiceClient) DeleteSegment(ctx context.Context, in *DeleteSegmentRequest, opts ...grpc.CallOption) (*DeleteSegmentResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(DeleteSegmentResponse)
+	err := c.cc.Invoke(ctx, SegmentService_DeleteSegment_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *segmentServiceClient) AddUserToSegments(ctx context.Context, in *AddUserToSegmentsRequest, opts ...grpc.CallOption) (*AddUserToSegmentsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(AddUserToSegmentsResponse)
+	err := c.cc.Invoke(ctx, SegmentService_AddUserToSegments_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *segmentServiceClient) DeleteUserFromSegments(ctx context.Context, in *DeleteUserFromSegmentsRequest, opts ...grpc.CallOption) (*DeleteUserFromSegmentsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(DeleteUserFromSegmentsResponse)
+	err := c.cc.Invoke(ctx, SegmentService_DeleteUserFromSegments_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *segmentServiceClient) GetUserActiveSegments(ctx context.Context, in *GetUserActiveSegmentsRequest, opts ...grpc.CallOption) (*GetUserActiveSegmentsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(GetUserActiveSegmentsResponse)
+	err := c.cc.Invoke(ctx, SegmentService_GetUserActiveSegments_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *segmentServiceClient) GetUserLogs(ctx context.Context, in *GetUserLogsRequest, opts ...grpc.CallOption) (*GetUserLogsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(GetUserLogsResponse)
+	err := c.cc.Invoke(ctx, SegmentService_GetUserLogs_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// SegmentServiceServer is the server API for SegmentService service.
+// All implementations must embed UnimplementedSegmentServiceServer
+// for forward compatibility.
+//
+// SegmentService mirrors the JSON API: segments, user memberships and history exports.
+type SegmentServiceServer interface {
+	AddSegment(context.Context, *AddSegmentRequest) (*AddSegmentResponse, error)
+	DeleteSegment(context.Context, *DeleteSegmentRequest) (*DeleteSegmentResponse, error)
+	AddUserToSegments(context.Context, *AddUserToSegmentsRequest) (*AddUserToSegmentsResponse, error)
+	DeleteUserFromSegments(context.Context, *DeleteUserFromSegmentsRequest) (*DeleteUserFromSegmentsResponse, error)
+	GetUserActiveSegments(context.Context, *GetUserActiveSegmentsRequest) (*GetUserActiveSegmentsResponse, error)
+	GetUserLogs(context.Context, *GetUserLogsRequest) (*GetUserLogsResponse, error)
+	mustEmbedUnimplementedSegmentServiceServer()
+}
+
+// UnimplementedSegmentServiceServer must be embedded to have
+// forward compatible implementations.
+//
+// NOTE: this should be embedded by value instead of pointer to avoid a nil
+// pointer dereference when methods are called.
+type UnimplementedSegmentServiceServer struct{}
+
+func (UnimplementedSegmentServiceServer) AddSegment(context.Context, *AddSegmentRequest) (*AddSegmentResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method AddSegment not implemented")
+}
+func (UnimplementedSegmentServiceServer) DeleteSegment(context.Context, *DeleteSegmentRequest) (*DeleteSegmentResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method DeleteSegment not implemented")
+}
+func (UnimplementedSegmentServiceServer) AddUserToSegments(context.Context, *AddUserToSegmentsRequest) (*AddUserToSegmentsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method AddUserToSegments not implemented")
+}
+func (UnimplementedSegmentServiceServer) DeleteUserFromSegments(context.Context, *DeleteUserFromSegmentsRequest) (*DeleteUserFromSegmentsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method DeleteUserFromSegments not implemented")
+}
+func (UnimplementedSegmentServiceServer) GetUserActiveSegments(context.Context, *GetUserActiveSegmentsRequest) (*GetUserActiveSegmentsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetUserActiveSegments not implemented")
+}
+func (UnimplementedSegmentServiceServer) GetUserLogs(context.Context, *GetUserLogsRequest) (*GetUserLogsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetUserLogs not implemented")
+}
+func (UnimplementedSegmentServiceServer) mustEmbedUnimplementedSegmentServiceServer() {}
+func (UnimplementedSegmentServiceServer) testEmbeddedByValue()                        {}
+
+// UnsafeSegmentServiceServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to SegmentServiceServer will
+// result in compilation errors.
+type UnsafeSegmentServiceServer interface {
+	mustEmbedUnimplementedSegmentServiceServer()
+}
+
+func RegisterSegmentServiceServer(s grpc.ServiceRegistrar, srv SegmentServiceServer) {
+	// If the following call pancis, it indicates UnimplementedSegmentServiceServer was
+	// embedded by pointer and is nil.  This will cause panics if an
+	// unimplemented method is ever invoked, so we test this at initialization
+	// time to prevent it from happening at runtime later due to I/O.
+	if t, ok := srv.(interface{ testEmbeddedByValue() }); ok {
+		t.testEmbeddedByValue()
+	}
+	s.RegisterService(&SegmentService_ServiceDesc, srv)
+}
+
+func _SegmentService_AddSegment_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(AddSegmentRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(SegmentServiceServer).AddSegment(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: SegmentService_AddSegment_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SegmentServiceServer).AddSegment(ctx, req.(*AddSegmentRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _SegmentService_DeleteSegment_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DeleteSegmentRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(SegmentServiceServer).DeleteSegment(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: SegmentService_DeleteSegment_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SegmentServiceServer).DeleteSegment(ctx, req.(*DeleteSegmentRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _SegmentService_AddUserToSegments_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(AddUserToSegmentsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(SegmentServiceServer).AddUserToSegments(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: SegmentService_AddUserToSegments_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SegmentServiceServer).AddUserToSegments(ctx, req.(*AddUserToSegmentsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _SegmentService_DeleteUserFromSegments_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DeleteUserFromSegmentsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(SegmentServiceServer).DeleteUserFromSegments(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: SegmentService_DeleteUserFromSegments_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SegmentServiceServer).DeleteUserFromSegments(ctx, req.(*DeleteUserFromSegmentsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _SegmentService_GetUserActiveSegments_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetUserActiveSegmentsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(SegmentServiceServer).GetUserActiveSegments(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: SegmentService_GetUserActiveSegments_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SegmentServiceServer).GetUserActiveSegments(ctx, req.(*GetUserActiveSegmentsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _SegmentService_GetUserLogs_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetUserLogsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(SegmentServiceServer).GetUserLogs(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: SegmentService_GetUserLogs_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SegmentServiceServer).GetUserLogs(ctx, req.(*GetUserLogsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+// SegmentService_ServiceDesc is the grpc.ServiceDesc for SegmentService service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var SegmentService_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "segment.v1.SegmentService",
+	HandlerType: (*SegmentServiceServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "AddSegment",
+			Handler:    _SegmentService_AddSegment_Handler,
+		},
+		{
+			MethodName: "DeleteSegment",
+			Handler:    _SegmentService_DeleteSegment_Handler,
+		},
+		{
+			MethodName: "AddUserToSegments",
+			Handler:    _SegmentService_AddUserToSegments_Handler,
+		},
+		{
+			MethodName: "DeleteUserFromSegments",
+			Handler:    _SegmentService_DeleteUserFromSegments_Handler,
+		},
+		{
+			MethodName: "GetUserActiveSegments",
+			Handler:    _SegmentService_GetUserActiveSegments_Handler,
+		},
+		{
+			MethodName: "GetUserLogs",
+			Handler:    _SegmentService_GetUserLogs_Handler,
+		},
+	},
+	Streams:  []grpc.StreamDesc{},
+	Metadata: "segment/v1/segment.proto",
+}
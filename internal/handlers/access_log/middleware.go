@@ -0,0 +1,87 @@
+package access_log
+
+import (
+	"log/slog"
+	"net"
+	"net/http"
+	"time"
+
+	"github.com/pollykon/avito_test_task/internal/request_id"
+)
+
+const HeaderRequestID = "X-Request-ID"
+
+// Middleware assigns id to each request and writes one log line per request after it is served
+type Middleware struct {
+	logger *slog.Logger
+}
+
+func New(logger *slog.Logger) Middleware {
+	return Middleware{logger: logger}
+}
+
+// Log takes request id from X-Request-ID header or generates new one, puts it to request context
+// and returns it in response header. It must wrap all other middlewares, so that their logs contain request id
+func (m Middleware) Log(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		start := time.Now()
+
+		requestID := r.Header.Get(HeaderRequestID)
+		if !request_id.Valid(requestID) {
+			requestID = request_id.New()
+		}
+		w.Header().Set(HeaderRequestID, requestID)
+		ctx := request_id.WithRequestID(r.Context(), requestID)
+
+		sw := &statusWriter{ResponseWriter: w, status: http.StatusOK}
+		next.ServeHTTP(sw, r.WithContext(ctx))
+
+		m.logger.InfoContext(ctx, "request served",
+			"method", r.Method,
+			"path", r.URL.Path,
+			"status", sw.status,
+			"latency_ms", float64(time.Since(start).Microseconds())/1000,
+			"client", clientIP(r.RemoteAddr),
+		)
+	})
+}
+
+func clientIP(remoteAddr string) string {
+	host, _, err := net.SplitHostPort(remoteAddr)
+	if err != nil {
+		return remoteAddr
+	}
+	return host
+}
+
+// statusWriter remembers status of response. It implements http.Flusher for streams
+type statusWriter struct {
+	http.ResponseWriter
+	status      int
+	wroteHeader bool
+}
+
+func (w *statusWriter) WriteHeader(status int) {
+	if !w.wroteHeader {
+		w.status = status
+		w.wroteHeader = true
+	}
+	w.ResponseWriter.WriteHeader(status)
+}
+
+func (w *statusWriter) Write(b []byte) (int, error) {
+	w.wroteHeader = true
+	return w.ResponseWriter.Write(b)
+}
+
+func (w *statusWriter) Flush() {
+	if flusher, ok := w.ResponseWriter.(http.Flusher); ok {
+		w.wroteHeader = true
+		flusher.Flush()
+	}
+}
+
+// Unwrap lets http.ResponseController reach underlying writer
+func (w *statusWriter) Unwrap() http.ResponseWriter {
+	return w.ResponseWriter
+}
@@ -0,0 +1,109 @@
+package access_log
+
+import (
+	"bytes"
+	"encoding/json"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/pollykon/avito_test_task/internal/logger"
+	"github.com/pollykon/avito_test_task/internal/request_id"
+)
+
+func TestMiddleware_Log(t *testing.T) {
+	tt := []struct {
+		name string
+
+		requestID string
+		status    int
+
+		expectGeneratedID bool
+		expectedRequestID string
+		expectedStatus    int
+	}{
+		{
+			name: "request_id_from_client",
+
+			requestID: "abc-123",
+			status:    http.StatusBadRequest,
+
+			expectedRequestID: "abc-123",
+			expectedStatus:    http.StatusBadRequest,
+		},
+		{
+			name: "without_request_id",
+
+			requestID: "",
+			status:    0,
+
+			expectGeneratedID: true,
+			expectedStatus:    http.StatusOK,
+		},
+		{
+			name: "invalid_request_id",
+
+			requestID: "abc 123",
+			status:    http.StatusNoContent,
+
+			expectGeneratedID: true,
+			expectedStatus:    http.StatusNoContent,
+		},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			var buf bytes.Buffer
+			log := slog.New(logger.NewContextHandler(slog.NewJSONHandler(&buf, nil)))
+
+			var requestIDInHandler string
+			next := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				requestIDInHandler, _ = request_id.FromContext(r.Context())
+				if tc.status != 0 {
+					w.WriteHeader(tc.status)
+				}
+				_, _ = w.Write([]byte("{}"))
+			})
+
+			r := httptest.NewRequest(http.MethodPost, "/add_segment_v1", nil)
+			if tc.requestID != "" {
+				r.Header.Set(HeaderRequestID, tc.requestID)
+			}
+			w := httptest.NewRecorder()
+
+			New(log).Log(next).ServeHTTP(w, r)
+
+			requestID := w.Header().Get(HeaderRequestID)
+			if tc.expectGeneratedID {
+				assert.Len(t, requestID, 32)
+				assert.NotEqual(t, tc.requestID, requestID)
+			} else {
+				assert.Equal(t, tc.expectedRequestID, requestID)
+			}
+			assert.Equal(t, requestID, requestIDInHandler)
+
+			var record map[string]any
+			require.NoError(t, json.Unmarshal(buf.Bytes(), &record))
+			assert.Equal(t, requestID, record["request_id"])
+			assert.Equal(t, http.MethodPost, record["method"])
+			assert.Equal(t, "/add_segment_v1", record["path"])
+			assert.Equal(t, float64(tc.expectedStatus), record["status"])
+			assert.Equal(t, "192.0.2.1", record["client"])
+			assert.Contains(t, record, "latency_ms")
+		})
+	}
+}
+
+func TestMiddleware_Log_Flusher(t *testing.T) {
+	next := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, ok := w.(http.Flusher)
+		assert.True(t, ok)
+	})
+
+	New(slog.New(logger.NewNoopHandler())).Log(next).
+		ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, "/stream_user_segments_v1", nil))
+}
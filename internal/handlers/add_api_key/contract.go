@@ -0,0 +1,12 @@
+//go:generate mockery --all --output ./mocks --case underscore --with-expecter
+package add_api_key
+
+import (
+	"context"
+
+	serviceAuth "github.com/pollykon/avito_test_task/internal/service/auth"
+)
+
+type AuthService interface {
+	CreateKey(ctx context.Context, name string, scopes []string) (serviceAuth.CreatedKey, error)
+}
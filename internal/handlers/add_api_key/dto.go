@@ -0,0 +1,16 @@
+package add_api_key
+
+import "github.com/pollykon/avito_test_task/internal/handlers"
+
+type HandlerRequest struct {
+	Name   string   `json:"name"`
+	Scopes []string `json:"scopes"`
+}
+
+type HandlerResponse struct {
+	Status int             `json:"status"`
+	Error  *handlers.Error `json:"error,omitempty"`
+	ID     int64           `json:"id,omitempty"`
+	// Key is returned only once, service stores only its hash
+	Key string `json:"key,omitempty"`
+}
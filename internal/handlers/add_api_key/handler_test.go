@@ -0,0 +1,158 @@
+package add_api_key
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/pollykon/avito_test_task/internal/handlers"
+	"github.com/pollykon/avito_test_task/internal/handlers/add_api_key/mocks"
+	"github.com/pollykon/avito_test_task/internal/logger"
+	serviceAuth "github.com/pollykon/avito_test_task/internal/service/auth"
+)
+
+func TestAPIKeyHandler_AddAPIKey(t *testing.T) {
+	tt := []struct {
+		name string
+
+		requestMethod string
+		sentBody      string
+
+		buildAuthServiceMock func(service *mocks.AuthService)
+
+		expectedStatusCode int
+		expectedResponse   *HandlerResponse
+	}{
+		{
+			name: "success",
+
+			requestMethod: http.MethodPost,
+			sentBody:      `{"name": "ci", "scopes": ["memberships:read"]}`,
+
+			buildAuthServiceMock: func(service *mocks.AuthService) {
+				service.EXPECT().CreateKey(context.Background(), "ci", []string{"memberships:read"}).
+					Return(serviceAuth.CreatedKey{ID: 3, Key: "sk_key"}, nil)
+			},
+
+			expectedStatusCode: http.StatusOK,
+			expectedResponse:   &HandlerResponse{Status: http.StatusOK, ID: 3, Key: "sk_key"},
+		},
+		{
+			name: "wrong_method",
+
+			requestMethod: http.MethodGet,
+			sentBody:      `{}`,
+
+			buildAuthServiceMock: nil,
+
+			expectedStatusCode: http.StatusMethodNotAllowed,
+			expectedResponse:   nil,
+		},
+		{
+			name: "decode_error",
+
+			requestMethod: http.MethodPost,
+			sentBody:      `[`,
+
+			buildAuthServiceMock: nil,
+
+			expectedStatusCode: http.StatusBadRequest,
+			expectedResponse:   nil,
+		},
+		{
+			name: "empty_name",
+
+			requestMethod: http.MethodPost,
+			sentBody:      `{"scopes": ["memberships:read"]}`,
+
+			buildAuthServiceMock: nil,
+
+			expectedStatusCode: http.StatusBadRequest,
+			expectedResponse: &HandlerResponse{
+				Status: http.StatusBadRequest,
+				Error: &handlers.Error{
+					Code:    "VALIDATION_FAILED",
+					Message: "name shouldn't be empty",
+					Details: []handlers.FieldError{{Field: "name", Message: "name shouldn't be empty"}},
+				},
+			},
+		},
+		{
+			name: "service_error_unknown_scope",
+
+			requestMethod: http.MethodPost,
+			sentBody:      `{"name": "ci", "scopes": ["everything"]}`,
+
+			buildAuthServiceMock: func(service *mocks.AuthService) {
+				service.EXPECT().CreateKey(context.Background(), "ci", []string{"everything"}).
+					Return(serviceAuth.CreatedKey{}, fmt.Errorf("%w: everything", serviceAuth.ErrUnknownScope))
+			},
+
+			expectedStatusCode: http.StatusBadRequest,
+			expectedResponse: &HandlerResponse{
+				Status: http.StatusBadRequest,
+				Error: &handlers.Error{
+					Code:    "VALIDATION_FAILED",
+					Message: "scopes contains unknown scope",
+					Details: []handlers.FieldError{{Field: "scopes", Message: "scopes contains unknown scope"}},
+				},
+			},
+		},
+		{
+			name: "service_error_unexpected_error",
+
+			requestMethod: http.MethodPost,
+			sentBody:      `{"name": "ci", "scopes": ["memberships:read"]}`,
+
+			buildAuthServiceMock: func(service *mocks.AuthService) {
+				service.EXPECT().CreateKey(context.Background(), "ci", []string{"memberships:read"}).
+					Return(serviceAuth.CreatedKey{}, fmt.Errorf("error from service"))
+			},
+
+			expectedStatusCode: http.StatusInternalServerError,
+			expectedResponse: &HandlerResponse{
+				Status: http.StatusInternalServerError,
+				Error:  &handlers.Error{Code: "INTERNAL", Message: "unexpected error"},
+			},
+		},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			request, err := http.NewRequest(tc.requestMethod, "", strings.NewReader(tc.sentBody))
+			if err != nil {
+				t.Fatalf("error while sending request: %s", err)
+			}
+
+			w := httptest.NewRecorder()
+			authServiceMock := mocks.NewAuthService(t)
+
+			if tc.buildAuthServiceMock != nil {
+				tc.buildAuthServiceMock(authServiceMock)
+			}
+
+			handler := New(authServiceMock, slog.New(logger.NewNoopHandler()))
+			handler.ServeHTTP(w, request)
+
+			responseResult := w.Result()
+
+			assert.Equal(t, handlers.ContentTypeJSON, responseResult.Header.Get("Content-Type"))
+			assert.Equal(t, tc.expectedStatusCode, responseResult.StatusCode)
+
+			if tc.expectedResponse != nil {
+				var response HandlerResponse
+				err = json.NewDecoder(responseResult.Body).Decode(&response)
+				assert.NoError(t, err)
+
+				assert.Equal(t, *tc.expectedResponse, response)
+			}
+		})
+	}
+}
@@ -0,0 +1,92 @@
+// Code generated by mockery v2.33.0. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+
+	auth "github.com/pollykon/avito_test_task/internal/service/auth"
+
+	mock "github.com/stretchr/testify/mock"
+)
+
+// AuthService is an autogenerated mock type for the AuthService type
+type AuthService struct {
+	mock.Mock
+}
+
+type AuthService_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *AuthService) EXPECT() *AuthService_Expecter {
+	return &AuthService_Expecter{mock: &_m.Mock}
+}
+
+// CreateKey provides a mock function with given fields: ctx, name, scopes
+func (_m *AuthService) CreateKey(ctx context.Context, name string, scopes []string) (auth.CreatedKey, error) {
+	ret := _m.Called(ctx, name, scopes)
+
+	var r0 auth.CreatedKey
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, []string) (auth.CreatedKey, error)); ok {
+		return rf(ctx, name, scopes)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, []string) auth.CreatedKey); ok {
+		r0 = rf(ctx, name, scopes)
+	} else {
+		r0 = ret.Get(0).(auth.CreatedKey)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, []string) error); ok {
+		r1 = rf(ctx, name, scopes)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// AuthService_CreateKey_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'CreateKey'
+type AuthService_CreateKey_Call struct {
+	*mock.Call
+}
+
+// CreateKey is a helper method to define mock.On call
+//   - ctx context.Context
+//   - name string
+//   - scopes []string
+func (_e *AuthService_Expecter) CreateKey(ctx interface{}, name interface{}, scopes interface{}) *AuthService_CreateKey_Call {
+	return &AuthService_CreateKey_Call{Call: _e.mock.On("CreateKey", ctx, name, scopes)}
+}
+
+func (_c *AuthService_CreateKey_Call) Run(run func(ctx context.Context, name string, scopes []string)) *AuthService_CreateKey_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string), args[2].([]string))
+	})
+	return _c
+}
+
+func (_c *AuthService_CreateKey_Call) Return(_a0 auth.CreatedKey, _a1 error) *AuthService_CreateKey_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *AuthService_CreateKey_Call) RunAndReturn(run func(context.Context, string, []string) (auth.CreatedKey, error)) *AuthService_CreateKey_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// NewAuthService creates a new instance of AuthService. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewAuthService(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *AuthService {
+	mock := &AuthService{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
@@ -1,15 +1,13 @@
 package add_segment
 
+import "github.com/pollykon/avito_test_task/internal/handlers"
+
 type HandlerRequest struct {
 	SegmentSlug    string `json:"slug"`
 	SegmentPercent *int64 `json:"percent"`
 }
 
 type HandlerResponse struct {
-	Status int                   `json:"status"`
-	Error  *HandlerResponseError `json:"error,omitempty"`
-}
-
-type HandlerResponseError struct {
-	Message string `json:"message"`
+	Status int             `json:"status"`
+	Error  *handlers.Error `json:"error,omitempty"`
 }
@@ -3,9 +3,7 @@ package add_segment
 import (
 	"context"
 	"encoding/json"
-	"errors"
 	"github.com/pollykon/avito_test_task/internal/handlers"
-	segmentService "github.com/pollykon/avito_test_task/internal/service/segment"
 	"log/slog"
 	"net/http"
 )
@@ -27,9 +25,7 @@ func (h Handler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
 		w.WriteHeader(http.StatusMethodNotAllowed)
 		_ = json.NewEncoder(w).Encode(HandlerResponse{
 			Status: http.StatusMethodNotAllowed,
-			Error: &HandlerResponseError{
-				Message: handlers.ErrMsgMethodNotAllowed,
-			},
+			Error:  handlers.ErrMethodNotAllowed(r.Context()),
 		})
 		return
 	}
@@ -40,9 +36,7 @@ func (h Handler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
 		w.WriteHeader(http.StatusBadRequest)
 		_ = json.NewEncoder(w).Encode(HandlerResponse{
 			Status: http.StatusBadRequest,
-			Error: &HandlerResponseError{
-				Message: handlers.ErrMsgBadRequest,
-			},
+			Error:  handlers.ErrMalformedRequest(r.Context()),
 		})
 		return
 	}
@@ -57,37 +51,29 @@ func (h Handler) handle(ctx context.Context, request HandlerRequest) HandlerResp
 	if request.SegmentSlug == "" {
 		return HandlerResponse{
 			Status: http.StatusBadRequest,
-			Error: &HandlerResponseError{
-				Message: "slug shouldn't be empty",
-			},
+			Error:  handlers.NewValidationError(ctx, "slug", handlers.MsgRequired),
 		}
 	}
 	if request.SegmentPercent != nil && (*request.SegmentPercent < 0 || *request.SegmentPercent > 100) {
 		return HandlerResponse{
 			Status: http.StatusBadRequest,
-			Error: &HandlerResponseError{
-				Message: "percent should be more than 0 and less than 100",
-			},
+			Error:  handlers.NewValidationError(ctx, "percent", handlers.MsgBetween, 0, 100),
 		}
 	}
 
 	err := h.segmentService.AddSegment(ctx, request.SegmentSlug, request.SegmentPercent)
 	if err != nil {
-		if errors.Is(err, segmentService.ErrSegmentAlreadyExists) {
+		if responseErr := handlers.FromServiceError(ctx, err); responseErr != nil {
 			return HandlerResponse{
 				Status: http.StatusBadRequest,
-				Error: &HandlerResponseError{
-					Message: "segment already exists",
-				},
+				Error:  responseErr,
 			}
 		}
 
 		h.logger.ErrorContext(ctx, "error while adding segment", "error", err, "request", request)
 		return HandlerResponse{
 			Status: http.StatusInternalServerError,
-			Error: &HandlerResponseError{
-				Message: handlers.ErrMsgInternal,
-			},
+			Error:  handlers.ErrInternal(ctx),
 		}
 	}
 
@@ -100,8 +100,10 @@ func TestSegmentHandler_AddSegment_Error(t *testing.T) {
 			expectedStatusCode: http.StatusBadRequest,
 			expectedResponse: &HandlerResponse{
 				Status: http.StatusBadRequest,
-				Error: &HandlerResponseError{
+				Error: &handlers.Error{
+					Code:    "VALIDATION_FAILED",
 					Message: "slug shouldn't be empty",
+					Details: []handlers.FieldError{{Field: "slug", Message: "slug shouldn't be empty"}},
 				},
 			},
 		},
@@ -120,9 +122,7 @@ func TestSegmentHandler_AddSegment_Error(t *testing.T) {
 			expectedStatusCode: http.StatusBadRequest,
 			expectedResponse: &HandlerResponse{
 				Status: http.StatusBadRequest,
-				Error: &HandlerResponseError{
-					Message: "segment already exists",
-				},
+				Error:  &handlers.Error{Code: "SEGMENT_ALREADY_EXISTS", Message: "segment already exists"},
 			},
 		},
 		{
@@ -140,9 +140,7 @@ func TestSegmentHandler_AddSegment_Error(t *testing.T) {
 			expectedStatusCode: http.StatusInternalServerError,
 			expectedResponse: &HandlerResponse{
 				Status: http.StatusInternalServerError,
-				Error: &HandlerResponseError{
-					Message: handlers.ErrMsgInternal,
-				},
+				Error:  &handlers.Error{Code: "INTERNAL", Message: "unexpected error"},
 			},
 		},
 	}
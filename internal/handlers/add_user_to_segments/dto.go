@@ -1,5 +1,7 @@
 package add_user_to_segments
 
+import "github.com/pollykon/avito_test_task/internal/handlers"
+
 type HandlerRequest struct {
 	UserID       int64    `json:"userId"`
 	SegmentSlugs []string `json:"slugs"`
@@ -7,10 +9,6 @@ type HandlerRequest struct {
 }
 
 type HandlerResponse struct {
-	Status int                   `json:"status"`
-	Error  *HandlerResponseError `json:"error,omitempty"`
-}
-
-type HandlerResponseError struct {
-	Message string `json:"message"`
+	Status int             `json:"status"`
+	Error  *handlers.Error `json:"error,omitempty"`
 }
@@ -3,13 +3,11 @@ package add_user_to_segments
 import (
 	"context"
 	"encoding/json"
-	"errors"
 	"log/slog"
 	"net/http"
 	"time"
 
 	"github.com/pollykon/avito_test_task/internal/handlers"
-	segmentService "github.com/pollykon/avito_test_task/internal/service/segment"
 )
 
 type Handler struct {
@@ -28,9 +26,7 @@ func (h Handler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
 		w.WriteHeader(http.StatusMethodNotAllowed)
 		_ = json.NewEncoder(w).Encode(HandlerResponse{
 			Status: http.StatusMethodNotAllowed,
-			Error: &HandlerResponseError{
-				Message: handlers.ErrMsgMethodNotAllowed,
-			},
+			Error:  handlers.ErrMethodNotAllowed(r.Context()),
 		})
 		return
 	}
@@ -44,9 +40,7 @@ func (h Handler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
 		w.WriteHeader(http.StatusBadRequest)
 		_ = json.NewEncoder(w).Encode(HandlerResponse{
 			Status: http.StatusBadRequest,
-			Error: &HandlerResponseError{
-				Message: handlers.ErrMsgBadRequest,
-			},
+			Error:  handlers.ErrMalformedRequest(r.Context()),
 		})
 		return
 	}
@@ -60,27 +54,21 @@ func (h Handler) handle(ctx context.Context, request HandlerRequest) HandlerResp
 	if request.UserID <= 0 {
 		return HandlerResponse{
 			Status: http.StatusBadRequest,
-			Error: &HandlerResponseError{
-				Message: "userId should be more than 0",
-			},
+			Error:  handlers.NewValidationError(ctx, "userId", handlers.MsgPositive),
 		}
 	}
 
 	if request.SegmentSlugs == nil {
 		return HandlerResponse{
 			Status: http.StatusBadRequest,
-			Error: &HandlerResponseError{
-				Message: "slugs shouldn't be empty",
-			},
+			Error:  handlers.NewValidationError(ctx, "slugs", handlers.MsgRequired),
 		}
 	}
 
 	if request.TTLHours != nil && *request.TTLHours <= 0 {
 		return HandlerResponse{
 			Status: http.StatusBadRequest,
-			Error: &HandlerResponseError{
-				Message: "ttl should be positive",
-			},
+			Error:  handlers.NewValidationError(ctx, "ttl", handlers.MsgPositive),
 		}
 	}
 
@@ -89,22 +77,18 @@ func (h Handler) handle(ctx context.Context, request HandlerRequest) HandlerResp
 		ttl := time.Duration(*request.TTLHours) * time.Hour
 		ttlDuration = &ttl
 	}
-	err := h.segmentService.AddUserToSegment(context.Background(), request.UserID, request.SegmentSlugs, ttlDuration)
+	err := h.segmentService.AddUserToSegment(ctx, request.UserID, request.SegmentSlugs, ttlDuration)
 	if err != nil {
-		if errors.Is(err, segmentService.ErrUserAlreadyInSegment) {
+		if responseErr := handlers.FromServiceError(ctx, err); responseErr != nil {
 			return HandlerResponse{
 				Status: http.StatusBadRequest,
-				Error: &HandlerResponseError{
-					Message: handlers.ErrMsgBadRequest,
-				},
+				Error:  responseErr,
 			}
 		}
 		h.logger.ErrorContext(ctx, "error while adding user to segment", "error", err, "request", request)
 		return HandlerResponse{
 			Status: http.StatusInternalServerError,
-			Error: &HandlerResponseError{
-				Message: handlers.ErrMsgInternal,
-			},
+			Error:  handlers.ErrInternal(ctx),
 		}
 	}
 
@@ -111,8 +111,10 @@ func TestSegmentHandler_AddUserToSegment_Error(t *testing.T) {
 			expectedStatusCode: http.StatusBadRequest,
 			expectedResponse: &HandlerResponse{
 				Status: http.StatusBadRequest,
-				Error: &HandlerResponseError{
+				Error: &handlers.Error{
+					Code:    "VALIDATION_FAILED",
 					Message: "userId should be more than 0",
+					Details: []handlers.FieldError{{Field: "userId", Message: "userId should be more than 0"}},
 				},
 			},
 		},
@@ -129,8 +131,10 @@ func TestSegmentHandler_AddUserToSegment_Error(t *testing.T) {
 			expectedStatusCode: http.StatusBadRequest,
 			expectedResponse: &HandlerResponse{
 				Status: http.StatusBadRequest,
-				Error: &HandlerResponseError{
+				Error: &handlers.Error{
+					Code:    "VALIDATION_FAILED",
 					Message: "slugs shouldn't be empty",
+					Details: []handlers.FieldError{{Field: "slugs", Message: "slugs shouldn't be empty"}},
 				},
 			},
 		},
@@ -147,8 +151,10 @@ func TestSegmentHandler_AddUserToSegment_Error(t *testing.T) {
 			expectedStatusCode: http.StatusBadRequest,
 			expectedResponse: &HandlerResponse{
 				Status: http.StatusBadRequest,
-				Error: &HandlerResponseError{
-					Message: "ttl should be positive",
+				Error: &handlers.Error{
+					Code:    "VALIDATION_FAILED",
+					Message: "ttl should be more than 0",
+					Details: []handlers.FieldError{{Field: "ttl", Message: "ttl should be more than 0"}},
 				},
 			},
 		},
@@ -170,9 +176,28 @@ func TestSegmentHandler_AddUserToSegment_Error(t *testing.T) {
 			expectedStatusCode: http.StatusBadRequest,
 			expectedResponse: &HandlerResponse{
 				Status: http.StatusBadRequest,
-				Error: &HandlerResponseError{
-					Message: handlers.ErrMsgBadRequest,
-				},
+				Error:  &handlers.Error{Code: "USER_ALREADY_IN_SEGMENT", Message: "user already in segment"},
+			},
+		},
+		{
+			name: "error_segment_not_exist",
+
+			requestMethod: http.MethodPost,
+			sentSlugs:     []string{"AVITO_TEST1"},
+			sentUserID:    2,
+			sentTTL:       &positiveTTL,
+
+			buildSegmentServiceMock: func(service *mocks.SegmentService) {
+				service.EXPECT().AddUserToSegment(
+					context.Background(), int64(2), []string{"AVITO_TEST1"}, &positiveTTLDuration,
+				).
+					Return(segmentService.ErrSegmentNotExist)
+			},
+
+			expectedStatusCode: http.StatusBadRequest,
+			expectedResponse: &HandlerResponse{
+				Status: http.StatusBadRequest,
+				Error:  &handlers.Error{Code: "SEGMENT_NOT_FOUND", Message: "segment doesn't exist"},
 			},
 		},
 		{
@@ -193,9 +218,7 @@ func TestSegmentHandler_AddUserToSegment_Error(t *testing.T) {
 			expectedStatusCode: http.StatusInternalServerError,
 			expectedResponse: &HandlerResponse{
 				Status: http.StatusInternalServerError,
-				Error: &HandlerResponseError{
-					Message: handlers.ErrMsgInternal,
-				},
+				Error:  &handlers.Error{Code: "INTERNAL", Message: "unexpected error"},
 			},
 		},
 	}
@@ -0,0 +1,12 @@
+//go:generate mockery --all --output ./mocks --case underscore --with-expecter
+package add_webhook
+
+import (
+	"context"
+
+	serviceWebhook "github.com/pollykon/avito_test_task/internal/service/webhook"
+)
+
+type WebhookService interface {
+	AddSubscription(ctx context.Context, subscription serviceWebhook.Subscription) (int64, error)
+}
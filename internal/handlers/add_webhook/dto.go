@@ -0,0 +1,16 @@
+package add_webhook
+
+import "github.com/pollykon/avito_test_task/internal/handlers"
+
+type HandlerRequest struct {
+	URL        string   `json:"url"`
+	Secret     string   `json:"secret"`
+	EventTypes []string `json:"eventTypes"`
+	SegmentIDs []string `json:"segmentIds"`
+}
+
+type HandlerResponse struct {
+	Status int             `json:"status"`
+	Error  *handlers.Error `json:"error,omitempty"`
+	ID     int64           `json:"id,omitempty"`
+}
@@ -0,0 +1,87 @@
+package add_webhook
+
+import (
+	"context"
+	"encoding/json"
+	"log/slog"
+	"net/http"
+
+	"github.com/pollykon/avito_test_task/internal/handlers"
+	serviceWebhook "github.com/pollykon/avito_test_task/internal/service/webhook"
+)
+
+type Handler struct {
+	webhookService WebhookService
+	logger         *slog.Logger
+}
+
+func New(s WebhookService, l *slog.Logger) Handler {
+	return Handler{webhookService: s, logger: l}
+}
+
+func (h Handler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", handlers.ContentTypeJSON)
+
+	if r.Method != http.MethodPost {
+		w.WriteHeader(http.StatusMethodNotAllowed)
+		_ = json.NewEncoder(w).Encode(HandlerResponse{
+			Status: http.StatusMethodNotAllowed,
+			Error:  handlers.ErrMethodNotAllowed(r.Context()),
+		})
+		return
+	}
+
+	var request HandlerRequest
+	err := json.NewDecoder(r.Body).Decode(&request)
+	if err != nil {
+		w.WriteHeader(http.StatusBadRequest)
+		_ = json.NewEncoder(w).Encode(HandlerResponse{
+			Status: http.StatusBadRequest,
+			Error:  handlers.ErrMalformedRequest(r.Context()),
+		})
+		return
+	}
+
+	response := h.handle(r.Context(), request)
+	w.WriteHeader(response.Status)
+	_ = json.NewEncoder(w).Encode(response)
+}
+
+func (h Handler) handle(ctx context.Context, request HandlerRequest) HandlerResponse {
+	if request.URL == "" {
+		return HandlerResponse{
+			Status: http.StatusBadRequest,
+			Error:  handlers.NewValidationError(ctx, "url", handlers.MsgRequired),
+		}
+	}
+
+	if request.Secret == "" {
+		return HandlerResponse{
+			Status: http.StatusBadRequest,
+			Error:  handlers.NewValidationError(ctx, "secret", handlers.MsgRequired),
+		}
+	}
+
+	id, err := h.webhookService.AddSubscription(ctx, serviceWebhook.Subscription{
+		URL:        request.URL,
+		Secret:     request.Secret,
+		EventTypes: request.EventTypes,
+		SegmentIDs: request.SegmentIDs,
+	})
+	if err != nil {
+		if responseErr := handlers.FromServiceError(ctx, err); responseErr != nil {
+			return HandlerResponse{
+				Status: http.StatusBadRequest,
+				Error:  responseErr,
+			}
+		}
+
+		h.logger.ErrorContext(ctx, "error while adding webhook", "error", err, "url", request.URL)
+		return HandlerResponse{
+			Status: http.StatusInternalServerError,
+			Error:  handlers.ErrInternal(ctx),
+		}
+	}
+
+	return HandlerResponse{Status: http.StatusOK, ID: id}
+}
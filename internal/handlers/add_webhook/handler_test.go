@@ -0,0 +1,236 @@
+package add_webhook
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/pollykon/avito_test_task/internal/handlers"
+	"github.com/pollykon/avito_test_task/internal/handlers/add_webhook/mocks"
+	"github.com/pollykon/avito_test_task/internal/logger"
+	serviceWebhook "github.com/pollykon/avito_test_task/internal/service/webhook"
+)
+
+func TestWebhookHandler_AddWebhook_Success(t *testing.T) {
+	jsonBodyRequest, _ := json.Marshal(map[string]interface{}{
+		"url":        "https://example.com/hook",
+		"secret":     "secret",
+		"eventTypes": []string{"user_segment.added"},
+		"segmentIds": []string{"AVITO"},
+	})
+	request, err := http.NewRequest(http.MethodPost, "", strings.NewReader(string(jsonBodyRequest)))
+	if err != nil {
+		t.Fatalf("error while sending request: %s", err)
+	}
+
+	w := httptest.NewRecorder()
+	webhookServiceMock := mocks.NewWebhookService(t)
+
+	webhookServiceMock.EXPECT().AddSubscription(context.Background(), serviceWebhook.Subscription{
+		URL:        "https://example.com/hook",
+		Secret:     "secret",
+		EventTypes: []string{"user_segment.added"},
+		SegmentIDs: []string{"AVITO"},
+	}).Return(7, nil)
+
+	handler := New(webhookServiceMock, slog.New(logger.NewNoopHandler()))
+	handler.ServeHTTP(w, request)
+
+	responseResult := w.Result()
+
+	assert.Equal(t, handlers.ContentTypeJSON, responseResult.Header.Get("Content-Type"))
+	assert.Equal(t, http.StatusOK, responseResult.StatusCode)
+
+	var response HandlerResponse
+	err = json.NewDecoder(responseResult.Body).Decode(&response)
+	assert.NoError(t, err)
+
+	assert.Equal(t, HandlerResponse{Status: http.StatusOK, ID: 7}, response)
+}
+
+func TestWebhookHandler_AddWebhook_Error(t *testing.T) {
+	tt := []struct {
+		name string
+
+		requestMethod string
+		sentURL       interface{}
+		sentSecret    interface{}
+
+		buildWebhookServiceMock func(service *mocks.WebhookService)
+
+		expectedStatusCode int
+		expectedResponse   *HandlerResponse
+	}{
+		{
+			name: "wrong_method",
+
+			requestMethod: http.MethodGet,
+			sentURL:       "https://example.com/hook",
+			sentSecret:    "secret",
+
+			buildWebhookServiceMock: nil,
+
+			expectedStatusCode: http.StatusMethodNotAllowed,
+			expectedResponse:   nil,
+		},
+		{
+			name: "decode_error",
+
+			requestMethod: http.MethodPost,
+			sentURL:       0,
+			sentSecret:    "secret",
+
+			buildWebhookServiceMock: nil,
+
+			expectedStatusCode: http.StatusBadRequest,
+			expectedResponse:   nil,
+		},
+		{
+			name: "empty_url",
+
+			requestMethod: http.MethodPost,
+			sentURL:       "",
+			sentSecret:    "secret",
+
+			buildWebhookServiceMock: nil,
+
+			expectedStatusCode: http.StatusBadRequest,
+			expectedResponse: &HandlerResponse{
+				Status: http.StatusBadRequest,
+				Error: &handlers.Error{
+					Code:    "VALIDATION_FAILED",
+					Message: "url shouldn't be empty",
+					Details: []handlers.FieldError{{Field: "url", Message: "url shouldn't be empty"}},
+				},
+			},
+		},
+		{
+			name: "empty_secret",
+
+			requestMethod: http.MethodPost,
+			sentURL:       "https://example.com/hook",
+			sentSecret:    "",
+
+			buildWebhookServiceMock: nil,
+
+			expectedStatusCode: http.StatusBadRequest,
+			expectedResponse: &HandlerResponse{
+				Status: http.StatusBadRequest,
+				Error: &handlers.Error{
+					Code:    "VALIDATION_FAILED",
+					Message: "secret shouldn't be empty",
+					Details: []handlers.FieldError{{Field: "secret", Message: "secret shouldn't be empty"}},
+				},
+			},
+		},
+		{
+			name: "service_error_invalid_url",
+
+			requestMethod: http.MethodPost,
+			sentURL:       "example.com",
+			sentSecret:    "secret",
+
+			buildWebhookServiceMock: func(service *mocks.WebhookService) {
+				service.EXPECT().AddSubscription(context.Background(), serviceWebhook.Subscription{
+					URL: "example.com", Secret: "secret",
+				}).Return(0, serviceWebhook.ErrInvalidURL)
+			},
+
+			expectedStatusCode: http.StatusBadRequest,
+			expectedResponse: &HandlerResponse{
+				Status: http.StatusBadRequest,
+				Error: &handlers.Error{
+					Code:    "VALIDATION_FAILED",
+					Message: "url should be absolute https url",
+					Details: []handlers.FieldError{{Field: "url", Message: "url should be absolute https url"}},
+				},
+			},
+		},
+		{
+			name: "service_error_unknown_event_type",
+
+			requestMethod: http.MethodPost,
+			sentURL:       "https://example.com/hook",
+			sentSecret:    "secret",
+
+			buildWebhookServiceMock: func(service *mocks.WebhookService) {
+				service.EXPECT().AddSubscription(context.Background(), serviceWebhook.Subscription{
+					URL: "https://example.com/hook", Secret: "secret",
+				}).Return(0, fmt.Errorf("%w: user.created", serviceWebhook.ErrUnknownEventType))
+			},
+
+			expectedStatusCode: http.StatusBadRequest,
+			expectedResponse: &HandlerResponse{
+				Status: http.StatusBadRequest,
+				Error: &handlers.Error{
+					Code:    "VALIDATION_FAILED",
+					Message: "eventTypes contains unknown event type",
+					Details: []handlers.FieldError{
+						{Field: "eventTypes", Message: "eventTypes contains unknown event type"},
+					},
+				},
+			},
+		},
+		{
+			name: "service_error_unexpected_error",
+
+			requestMethod: http.MethodPost,
+			sentURL:       "https://example.com/hook",
+			sentSecret:    "secret",
+
+			buildWebhookServiceMock: func(service *mocks.WebhookService) {
+				service.EXPECT().AddSubscription(context.Background(), serviceWebhook.Subscription{
+					URL: "https://example.com/hook", Secret: "secret",
+				}).Return(0, fmt.Errorf("error from service"))
+			},
+
+			expectedStatusCode: http.StatusInternalServerError,
+			expectedResponse: &HandlerResponse{
+				Status: http.StatusInternalServerError,
+				Error:  &handlers.Error{Code: "INTERNAL", Message: "unexpected error"},
+			},
+		},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			jsonBodyRequest, _ := json.Marshal(
+				map[string]interface{}{"url": tc.sentURL, "secret": tc.sentSecret},
+			)
+			request, err := http.NewRequest(tc.requestMethod, "", strings.NewReader(string(jsonBodyRequest)))
+			if err != nil {
+				t.Fatalf("error while sending request: %s", err)
+			}
+
+			w := httptest.NewRecorder()
+			webhookServiceMock := mocks.NewWebhookService(t)
+
+			if tc.buildWebhookServiceMock != nil {
+				tc.buildWebhookServiceMock(webhookServiceMock)
+			}
+
+			handler := New(webhookServiceMock, slog.New(logger.NewNoopHandler()))
+			handler.ServeHTTP(w, request)
+
+			responseResult := w.Result()
+
+			assert.Equal(t, handlers.ContentTypeJSON, responseResult.Header.Get("Content-Type"))
+			assert.Equal(t, tc.expectedStatusCode, responseResult.StatusCode)
+
+			if tc.expectedResponse != nil {
+				var response HandlerResponse
+				err = json.NewDecoder(responseResult.Body).Decode(&response)
+				assert.NoError(t, err)
+
+				assert.Equal(t, *tc.expectedResponse, response)
+			}
+		})
+	}
+}
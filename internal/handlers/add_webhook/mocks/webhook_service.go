@@ -0,0 +1,90 @@
+// Code generated by mockery v2.33.0. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+
+	webhook "github.com/pollykon/avito_test_task/internal/service/webhook"
+	mock "github.com/stretchr/testify/mock"
+)
+
+// WebhookService is an autogenerated mock type for the WebhookService type
+type WebhookService struct {
+	mock.Mock
+}
+
+type WebhookService_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *WebhookService) EXPECT() *WebhookService_Expecter {
+	return &WebhookService_Expecter{mock: &_m.Mock}
+}
+
+// AddSubscription provides a mock function with given fields: ctx, subscription
+func (_m *WebhookService) AddSubscription(ctx context.Context, subscription webhook.Subscription) (int64, error) {
+	ret := _m.Called(ctx, subscription)
+
+	var r0 int64
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, webhook.Subscription) (int64, error)); ok {
+		return rf(ctx, subscription)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, webhook.Subscription) int64); ok {
+		r0 = rf(ctx, subscription)
+	} else {
+		r0 = ret.Get(0).(int64)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, webhook.Subscription) error); ok {
+		r1 = rf(ctx, subscription)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// WebhookService_AddSubscription_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'AddSubscription'
+type WebhookService_AddSubscription_Call struct {
+	*mock.Call
+}
+
+// AddSubscription is a helper method to define mock.On call
+//   - ctx context.Context
+//   - subscription webhook.Subscription
+func (_e *WebhookService_Expecter) AddSubscription(ctx interface{}, subscription interface{}) *WebhookService_AddSubscription_Call {
+	return &WebhookService_AddSubscription_Call{Call: _e.mock.On("AddSubscription", ctx, subscription)}
+}
+
+func (_c *WebhookService_AddSubscription_Call) Run(run func(ctx context.Context, subscription webhook.Subscription)) *WebhookService_AddSubscription_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(webhook.Subscription))
+	})
+	return _c
+}
+
+func (_c *WebhookService_AddSubscription_Call) Return(_a0 int64, _a1 error) *WebhookService_AddSubscription_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *WebhookService_AddSubscription_Call) RunAndReturn(run func(context.Context, webhook.Subscription) (int64, error)) *WebhookService_AddSubscription_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// NewWebhookService creates a new instance of WebhookService. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewWebhookService(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *WebhookService {
+	mock := &WebhookService{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
@@ -0,0 +1,13 @@
+//go:generate mockery --all --output ./mocks --case underscore --with-expecter
+package auth
+
+import (
+	"context"
+
+	"github.com/pollykon/avito_test_task/internal/actor"
+)
+
+type Authenticator interface {
+	Authenticate(ctx context.Context, key string) (actor.Actor, error)
+	AuthenticateToken(ctx context.Context, token string) (actor.Actor, error)
+}
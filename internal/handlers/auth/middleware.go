@@ -0,0 +1,123 @@
+package auth
+
+import (
+	"encoding/json"
+	"errors"
+	"log/slog"
+	"net/http"
+	"strings"
+
+	"github.com/pollykon/avito_test_task/internal/actor"
+	"github.com/pollykon/avito_test_task/internal/handlers"
+	authService "github.com/pollykon/avito_test_task/internal/service/auth"
+)
+
+const (
+	// HeaderAPIKey is a header with API key of service
+	HeaderAPIKey = "X-API-Key"
+	// HeaderAuthorization is a header with bearer token of admin UI user
+	HeaderAuthorization = "Authorization"
+
+	bearerPrefix = "bearer "
+)
+
+// ScopeFunc returns scope required for request
+type ScopeFunc func(r *http.Request) string
+
+// Static requires the same scope for all requests
+func Static(scope string) ScopeFunc {
+	return func(*http.Request) string {
+		return scope
+	}
+}
+
+// ByMethod requires read scope for GET requests and write scope for others
+func ByMethod(read string, write string) ScopeFunc {
+	return func(r *http.Request) string {
+		if r.Method == http.MethodGet {
+			return read
+		}
+		return write
+	}
+}
+
+// Middleware authenticates requests by JWT or API key and puts actor to request context
+type Middleware struct {
+	authenticator Authenticator
+	// enabled is false when service runs without authentication, e.g. locally
+	enabled bool
+	logger  *slog.Logger
+}
+
+func New(authenticator Authenticator, enabled bool, logger *slog.Logger) Middleware {
+	return Middleware{
+		authenticator: authenticator,
+		enabled:       enabled,
+		logger:        logger,
+	}
+}
+
+// Require passes request to next only if its key has scope
+func (m Middleware) Require(scope ScopeFunc, next http.Handler) http.Handler {
+	if !m.enabled {
+		return next
+	}
+
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		ctx := r.Context()
+
+		a, err := m.authenticate(r)
+		if err != nil {
+			if errors.Is(err, authService.ErrUnauthenticated) {
+				m.logger.InfoContext(ctx, "request isn't authenticated", "error", err)
+				writeError(w, handlers.NewError(ctx, handlers.CodeUnauthenticated))
+				return
+			}
+
+			m.logger.ErrorContext(ctx, "error while authenticating request", "error", err)
+			writeError(w, handlers.ErrInternal(ctx))
+			return
+		}
+
+		requiredScope := scope(r)
+		if !a.HasScope(requiredScope) {
+			m.logger.InfoContext(ctx, "request without required scope", "actor", a.ID, "scope", requiredScope)
+			writeError(w, handlers.NewError(ctx, handlers.CodeForbidden))
+			return
+		}
+
+		next.ServeHTTP(w, r.WithContext(actor.WithActor(ctx, a)))
+	})
+}
+
+// authenticate checks bearer token if it is passed and API key otherwise
+func (m Middleware) authenticate(r *http.Request) (actor.Actor, error) {
+	if token, ok := BearerToken(r.Header.Get(HeaderAuthorization)); ok {
+		return m.authenticator.AuthenticateToken(r.Context(), token)
+	}
+
+	return m.authenticator.Authenticate(r.Context(), r.Header.Get(HeaderAPIKey))
+}
+
+// BearerToken returns token from value of Authorization header, false if it isn't bearer token
+func BearerToken(authorization string) (string, bool) {
+	if len(authorization) <= len(bearerPrefix) || !strings.EqualFold(authorization[:len(bearerPrefix)], bearerPrefix) {
+		return "", false
+	}
+
+	return strings.TrimSpace(authorization[len(bearerPrefix):]), true
+}
+
+func writeError(w http.ResponseWriter, responseErr *handlers.Error) {
+	status := responseErr.HTTPStatus()
+	w.Header().Set("Content-Type", handlers.ContentTypeJSON)
+	if status == http.StatusUnauthorized {
+		w.Header().Add("WWW-Authenticate", "Bearer")
+		w.Header().Add("WWW-Authenticate", HeaderAPIKey)
+	}
+	w.WriteHeader(status)
+	_ = json.NewEncoder(w).Encode(HandlerResponse{
+		Status: status,
+		Error:  responseErr,
+	})
+}
@@ -0,0 +1,205 @@
+package auth
+
+import (
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+
+	"github.com/pollykon/avito_test_task/internal/actor"
+	"github.com/pollykon/avito_test_task/internal/handlers"
+	"github.com/pollykon/avito_test_task/internal/handlers/auth/mocks"
+	"github.com/pollykon/avito_test_task/internal/logger"
+	authService "github.com/pollykon/avito_test_task/internal/service/auth"
+)
+
+func TestMiddleware_Require(t *testing.T) {
+	reader := actor.Actor{ID: "api_key:1", Scopes: []string{actor.ScopeMembershipsRead}}
+
+	tt := []struct {
+		name string
+
+		requestMethod string
+		apiKey        string
+		authorization string
+
+		buildAuthenticatorMock func(authenticator *mocks.Authenticator)
+
+		expectedStatusCode int
+		expectedResponse   *HandlerResponse
+		expectedActor      *actor.Actor
+	}{
+		{
+			name: "success",
+
+			requestMethod: http.MethodGet,
+			apiKey:        "sk_reader",
+
+			buildAuthenticatorMock: func(authenticator *mocks.Authenticator) {
+				authenticator.EXPECT().Authenticate(mock.Anything, "sk_reader").Return(reader, nil)
+			},
+
+			expectedStatusCode: http.StatusOK,
+			expectedActor:      &reader,
+		},
+		{
+			name: "bearer_token",
+
+			requestMethod: http.MethodGet,
+			apiKey:        "sk_ignored",
+			authorization: "Bearer token",
+
+			buildAuthenticatorMock: func(authenticator *mocks.Authenticator) {
+				authenticator.EXPECT().AuthenticateToken(mock.Anything, "token").Return(reader, nil)
+			},
+
+			expectedStatusCode: http.StatusOK,
+			expectedActor:      &reader,
+		},
+		{
+			name: "invalid_bearer_token",
+
+			requestMethod: http.MethodGet,
+			authorization: "bearer expired",
+
+			buildAuthenticatorMock: func(authenticator *mocks.Authenticator) {
+				authenticator.EXPECT().AuthenticateToken(mock.Anything, "expired").
+					Return(actor.Actor{}, authService.ErrUnauthenticated)
+			},
+
+			expectedStatusCode: http.StatusUnauthorized,
+			expectedResponse: &HandlerResponse{
+				Status: http.StatusUnauthorized,
+				Error:  &handlers.Error{Code: "UNAUTHENTICATED", Message: "valid api key is required"},
+			},
+		},
+		{
+			name: "no_key",
+
+			requestMethod: http.MethodGet,
+			apiKey:        "",
+
+			buildAuthenticatorMock: func(authenticator *mocks.Authenticator) {
+				authenticator.EXPECT().Authenticate(mock.Anything, "").Return(actor.Actor{}, authService.ErrUnauthenticated)
+			},
+
+			expectedStatusCode: http.StatusUnauthorized,
+			expectedResponse: &HandlerResponse{
+				Status: http.StatusUnauthorized,
+				Error:  &handlers.Error{Code: "UNAUTHENTICATED", Message: "valid api key is required"},
+			},
+		},
+		{
+			name: "no_scope",
+
+			requestMethod: http.MethodPost,
+			apiKey:        "sk_reader",
+
+			buildAuthenticatorMock: func(authenticator *mocks.Authenticator) {
+				authenticator.EXPECT().Authenticate(mock.Anything, "sk_reader").Return(reader, nil)
+			},
+
+			expectedStatusCode: http.StatusForbidden,
+			expectedResponse: &HandlerResponse{
+				Status: http.StatusForbidden,
+				Error:  &handlers.Error{Code: "FORBIDDEN", Message: "api key doesn't have required scope"},
+			},
+		},
+		{
+			name: "authenticator_error",
+
+			requestMethod: http.MethodGet,
+			apiKey:        "sk_reader",
+
+			buildAuthenticatorMock: func(authenticator *mocks.Authenticator) {
+				authenticator.EXPECT().Authenticate(mock.Anything, "sk_reader").Return(actor.Actor{}, fmt.Errorf("db is down"))
+			},
+
+			expectedStatusCode: http.StatusInternalServerError,
+			expectedResponse: &HandlerResponse{
+				Status: http.StatusInternalServerError,
+				Error:  &handlers.Error{Code: "INTERNAL", Message: "unexpected error"},
+			},
+		},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			authenticatorMock := mocks.NewAuthenticator(t)
+			if tc.buildAuthenticatorMock != nil {
+				tc.buildAuthenticatorMock(authenticatorMock)
+			}
+
+			var actorInHandler *actor.Actor
+			next := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				a, ok := actor.FromContext(r.Context())
+				if ok {
+					actorInHandler = &a
+				}
+			})
+
+			request := httptest.NewRequest(tc.requestMethod, "/", nil)
+			request.Header.Set(HeaderAPIKey, tc.apiKey)
+			if tc.authorization != "" {
+				request.Header.Set(HeaderAuthorization, tc.authorization)
+			}
+			w := httptest.NewRecorder()
+
+			New(authenticatorMock, true, slog.New(logger.NewNoopHandler())).
+				Require(ByMethod(actor.ScopeMembershipsRead, actor.ScopeMembershipsWrite), next).
+				ServeHTTP(w, request)
+
+			responseResult := w.Result()
+
+			assert.Equal(t, tc.expectedStatusCode, responseResult.StatusCode)
+			assert.Equal(t, tc.expectedActor, actorInHandler)
+
+			if tc.expectedResponse != nil {
+				var response HandlerResponse
+				err := json.NewDecoder(responseResult.Body).Decode(&response)
+				assert.NoError(t, err)
+
+				assert.Equal(t, *tc.expectedResponse, response)
+			}
+		})
+	}
+}
+
+func TestMiddleware_Require_Disabled(t *testing.T) {
+	called := false
+	next := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) { called = true })
+
+	New(mocks.NewAuthenticator(t), false, slog.New(logger.NewNoopHandler())).
+		Require(Static(actor.ScopeKeysManage), next).
+		ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, "/", nil))
+
+	assert.True(t, called)
+}
+
+func TestBearerToken(t *testing.T) {
+	tt := []struct {
+		authorization string
+		expectedToken string
+		expectedOK    bool
+	}{
+		{authorization: "Bearer abc.def.ghi", expectedToken: "abc.def.ghi", expectedOK: true},
+		{authorization: "BEARER abc", expectedToken: "abc", expectedOK: true},
+		{authorization: "Basic dXNlcjpwYXNz", expectedOK: false},
+		{authorization: "Bearer ", expectedOK: false},
+		{authorization: "", expectedOK: false},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.authorization, func(t *testing.T) {
+			token, ok := BearerToken(tc.authorization)
+
+			assert.Equal(t, tc.expectedOK, ok)
+			assert.Equal(t, tc.expectedToken, token)
+		})
+	}
+}
@@ -0,0 +1,144 @@
+// Code generated by mockery v2.33.0. DO NOT EDIT.
+
+package mocks
+
+import (
+	actor "github.com/pollykon/avito_test_task/internal/actor"
+
+	context "context"
+
+	mock "github.com/stretchr/testify/mock"
+)
+
+// Authenticator is an autogenerated mock type for the Authenticator type
+type Authenticator struct {
+	mock.Mock
+}
+
+type Authenticator_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *Authenticator) EXPECT() *Authenticator_Expecter {
+	return &Authenticator_Expecter{mock: &_m.Mock}
+}
+
+// Authenticate provides a mock function with given fields: ctx, key
+func (_m *Authenticator) Authenticate(ctx context.Context, key string) (actor.Actor, error) {
+	ret := _m.Called(ctx, key)
+
+	var r0 actor.Actor
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) (actor.Actor, error)); ok {
+		return rf(ctx, key)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) actor.Actor); ok {
+		r0 = rf(ctx, key)
+	} else {
+		r0 = ret.Get(0).(actor.Actor)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, key)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Authenticator_Authenticate_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Authenticate'
+type Authenticator_Authenticate_Call struct {
+	*mock.Call
+}
+
+// Authenticate is a helper method to define mock.On call
+//   - ctx context.Context
+//   - key string
+func (_e *Authenticator_Expecter) Authenticate(ctx interface{}, key interface{}) *Authenticator_Authenticate_Call {
+	return &Authenticator_Authenticate_Call{Call: _e.mock.On("Authenticate", ctx, key)}
+}
+
+func (_c *Authenticator_Authenticate_Call) Run(run func(ctx context.Context, key string)) *Authenticator_Authenticate_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string))
+	})
+	return _c
+}
+
+func (_c *Authenticator_Authenticate_Call) Return(_a0 actor.Actor, _a1 error) *Authenticator_Authenticate_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *Authenticator_Authenticate_Call) RunAndReturn(run func(context.Context, string) (actor.Actor, error)) *Authenticator_Authenticate_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// AuthenticateToken provides a mock function with given fields: ctx, token
+func (_m *Authenticator) AuthenticateToken(ctx context.Context, token string) (actor.Actor, error) {
+	ret := _m.Called(ctx, token)
+
+	var r0 actor.Actor
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) (actor.Actor, error)); ok {
+		return rf(ctx, token)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) actor.Actor); ok {
+		r0 = rf(ctx, token)
+	} else {
+		r0 = ret.Get(0).(actor.Actor)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, token)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Authenticator_AuthenticateToken_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'AuthenticateToken'
+type Authenticator_AuthenticateToken_Call struct {
+	*mock.Call
+}
+
+// AuthenticateToken is a helper method to define mock.On call
+//   - ctx context.Context
+//   - token string
+func (_e *Authenticator_Expecter) AuthenticateToken(ctx interface{}, token interface{}) *Authenticator_AuthenticateToken_Call {
+	return &Authenticator_AuthenticateToken_Call{Call: _e.mock.On("AuthenticateToken", ctx, token)}
+}
+
+func (_c *Authenticator_AuthenticateToken_Call) Run(run func(ctx context.Context, token string)) *Authenticator_AuthenticateToken_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string))
+	})
+	return _c
+}
+
+func (_c *Authenticator_AuthenticateToken_Call) Return(_a0 actor.Actor, _a1 error) *Authenticator_AuthenticateToken_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *Authenticator_AuthenticateToken_Call) RunAndReturn(run func(context.Context, string) (actor.Actor, error)) *Authenticator_AuthenticateToken_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// NewAuthenticator creates a new instance of Authenticator. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewAuthenticator(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *Authenticator {
+	mock := &Authenticator{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
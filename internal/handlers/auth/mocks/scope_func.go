@@ -0,0 +1,78 @@
+// Code generated by mockery v2.33.0. DO NOT EDIT.
+
+package mocks
+
+import (
+	http "net/http"
+
+	mock "github.com/stretchr/testify/mock"
+)
+
+// ScopeFunc is an autogenerated mock type for the ScopeFunc type
+type ScopeFunc struct {
+	mock.Mock
+}
+
+type ScopeFunc_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *ScopeFunc) EXPECT() *ScopeFunc_Expecter {
+	return &ScopeFunc_Expecter{mock: &_m.Mock}
+}
+
+// Execute provides a mock function with given fields: r
+func (_m *ScopeFunc) Execute(r *http.Request) string {
+	ret := _m.Called(r)
+
+	var r0 string
+	if rf, ok := ret.Get(0).(func(*http.Request) string); ok {
+		r0 = rf(r)
+	} else {
+		r0 = ret.Get(0).(string)
+	}
+
+	return r0
+}
+
+// ScopeFunc_Execute_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Execute'
+type ScopeFunc_Execute_Call struct {
+	*mock.Call
+}
+
+// Execute is a helper method to define mock.On call
+//   - r *http.Request
+func (_e *ScopeFunc_Expecter) Execute(r interface{}) *ScopeFunc_Execute_Call {
+	return &ScopeFunc_Execute_Call{Call: _e.mock.On("Execute", r)}
+}
+
+func (_c *ScopeFunc_Execute_Call) Run(run func(r *http.Request)) *ScopeFunc_Execute_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(*http.Request))
+	})
+	return _c
+}
+
+func (_c *ScopeFunc_Execute_Call) Return(_a0 string) *ScopeFunc_Execute_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *ScopeFunc_Execute_Call) RunAndReturn(run func(*http.Request) string) *ScopeFunc_Execute_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// NewScopeFunc creates a new instance of ScopeFunc. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewScopeFunc(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *ScopeFunc {
+	mock := &ScopeFunc{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
@@ -1,9 +1,3 @@
 package handlers
 
 const ContentTypeJSON = "application/json"
-
-const (
-	ErrMsgInternal         = "unexpected error"
-	ErrMsgMethodNotAllowed = "method not allowed"
-	ErrMsgBadRequest       = "bad request"
-)
@@ -0,0 +1,8 @@
+//go:generate mockery --all --output ./mocks --case underscore --with-expecter
+package delete_api_key
+
+import "context"
+
+type AuthService interface {
+	RevokeKey(ctx context.Context, id int64) error
+}
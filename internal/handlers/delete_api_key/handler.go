@@ -0,0 +1,74 @@
+package delete_api_key
+
+import (
+	"context"
+	"encoding/json"
+	"log/slog"
+	"net/http"
+
+	"github.com/pollykon/avito_test_task/internal/handlers"
+)
+
+type Handler struct {
+	authService AuthService
+	logger      *slog.Logger
+}
+
+func New(s AuthService, l *slog.Logger) Handler {
+	return Handler{authService: s, logger: l}
+}
+
+func (h Handler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", handlers.ContentTypeJSON)
+
+	if r.Method != http.MethodPost {
+		w.WriteHeader(http.StatusMethodNotAllowed)
+		_ = json.NewEncoder(w).Encode(HandlerResponse{
+			Status: http.StatusMethodNotAllowed,
+			Error:  handlers.ErrMethodNotAllowed(r.Context()),
+		})
+		return
+	}
+
+	var request HandlerRequest
+	err := json.NewDecoder(r.Body).Decode(&request)
+	if err != nil {
+		w.WriteHeader(http.StatusBadRequest)
+		_ = json.NewEncoder(w).Encode(HandlerResponse{
+			Status: http.StatusBadRequest,
+			Error:  handlers.ErrMalformedRequest(r.Context()),
+		})
+		return
+	}
+
+	response := h.handle(r.Context(), request)
+	w.WriteHeader(response.Status)
+	_ = json.NewEncoder(w).Encode(response)
+}
+
+func (h Handler) handle(ctx context.Context, request HandlerRequest) HandlerResponse {
+	if request.ID <= 0 {
+		return HandlerResponse{
+			Status: http.StatusBadRequest,
+			Error:  handlers.NewValidationError(ctx, "id", handlers.MsgPositive),
+		}
+	}
+
+	err := h.authService.RevokeKey(ctx, request.ID)
+	if err != nil {
+		if responseErr := handlers.FromServiceError(ctx, err); responseErr != nil {
+			return HandlerResponse{
+				Status: http.StatusBadRequest,
+				Error:  responseErr,
+			}
+		}
+
+		h.logger.ErrorContext(ctx, "error while revoking api key", "error", err, "request", request)
+		return HandlerResponse{
+			Status: http.StatusInternalServerError,
+			Error:  handlers.ErrInternal(ctx),
+		}
+	}
+
+	return HandlerResponse{Status: http.StatusOK}
+}
@@ -0,0 +1,151 @@
+package delete_api_key
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/pollykon/avito_test_task/internal/handlers"
+	"github.com/pollykon/avito_test_task/internal/handlers/delete_api_key/mocks"
+	"github.com/pollykon/avito_test_task/internal/logger"
+	serviceAuth "github.com/pollykon/avito_test_task/internal/service/auth"
+)
+
+func TestAPIKeyHandler_DeleteAPIKey(t *testing.T) {
+	tt := []struct {
+		name string
+
+		requestMethod string
+		sentBody      string
+
+		buildAuthServiceMock func(service *mocks.AuthService)
+
+		expectedStatusCode int
+		expectedResponse   *HandlerResponse
+	}{
+		{
+			name: "success",
+
+			requestMethod: http.MethodPost,
+			sentBody:      `{"id": 3}`,
+
+			buildAuthServiceMock: func(service *mocks.AuthService) {
+				service.EXPECT().RevokeKey(context.Background(), int64(3)).Return(nil)
+			},
+
+			expectedStatusCode: http.StatusOK,
+			expectedResponse:   &HandlerResponse{Status: http.StatusOK},
+		},
+		{
+			name: "wrong_method",
+
+			requestMethod: http.MethodGet,
+			sentBody:      `{}`,
+
+			buildAuthServiceMock: nil,
+
+			expectedStatusCode: http.StatusMethodNotAllowed,
+			expectedResponse:   nil,
+		},
+		{
+			name: "decode_error",
+
+			requestMethod: http.MethodPost,
+			sentBody:      `[`,
+
+			buildAuthServiceMock: nil,
+
+			expectedStatusCode: http.StatusBadRequest,
+			expectedResponse:   nil,
+		},
+		{
+			name: "wrong_id",
+
+			requestMethod: http.MethodPost,
+			sentBody:      `{"id": 0}`,
+
+			buildAuthServiceMock: nil,
+
+			expectedStatusCode: http.StatusBadRequest,
+			expectedResponse: &HandlerResponse{
+				Status: http.StatusBadRequest,
+				Error: &handlers.Error{
+					Code:    "VALIDATION_FAILED",
+					Message: "id should be more than 0",
+					Details: []handlers.FieldError{{Field: "id", Message: "id should be more than 0"}},
+				},
+			},
+		},
+		{
+			name: "service_error_key_not_exist",
+
+			requestMethod: http.MethodPost,
+			sentBody:      `{"id": 3}`,
+
+			buildAuthServiceMock: func(service *mocks.AuthService) {
+				service.EXPECT().RevokeKey(context.Background(), int64(3)).Return(serviceAuth.ErrKeyNotExist)
+			},
+
+			expectedStatusCode: http.StatusBadRequest,
+			expectedResponse: &HandlerResponse{
+				Status: http.StatusBadRequest,
+				Error:  &handlers.Error{Code: "API_KEY_NOT_FOUND", Message: "api key doesn't exist"},
+			},
+		},
+		{
+			name: "service_error_unexpected_error",
+
+			requestMethod: http.MethodPost,
+			sentBody:      `{"id": 3}`,
+
+			buildAuthServiceMock: func(service *mocks.AuthService) {
+				service.EXPECT().RevokeKey(context.Background(), int64(3)).Return(fmt.Errorf("error from service"))
+			},
+
+			expectedStatusCode: http.StatusInternalServerError,
+			expectedResponse: &HandlerResponse{
+				Status: http.StatusInternalServerError,
+				Error:  &handlers.Error{Code: "INTERNAL", Message: "unexpected error"},
+			},
+		},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			request, err := http.NewRequest(tc.requestMethod, "", strings.NewReader(tc.sentBody))
+			if err != nil {
+				t.Fatalf("error while sending request: %s", err)
+			}
+
+			w := httptest.NewRecorder()
+			authServiceMock := mocks.NewAuthService(t)
+
+			if tc.buildAuthServiceMock != nil {
+				tc.buildAuthServiceMock(authServiceMock)
+			}
+
+			handler := New(authServiceMock, slog.New(logger.NewNoopHandler()))
+			handler.ServeHTTP(w, request)
+
+			responseResult := w.Result()
+
+			assert.Equal(t, handlers.ContentTypeJSON, responseResult.Header.Get("Content-Type"))
+			assert.Equal(t, tc.expectedStatusCode, responseResult.StatusCode)
+
+			if tc.expectedResponse != nil {
+				var response HandlerResponse
+				err = json.NewDecoder(responseResult.Body).Decode(&response)
+				assert.NoError(t, err)
+
+				assert.Equal(t, *tc.expectedResponse, response)
+			}
+		})
+	}
+}
@@ -0,0 +1,79 @@
+// Code generated by mockery v2.33.0. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+
+	mock "github.com/stretchr/testify/mock"
+)
+
+// AuthService is an autogenerated mock type for the AuthService type
+type AuthService struct {
+	mock.Mock
+}
+
+type AuthService_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *AuthService) EXPECT() *AuthService_Expecter {
+	return &AuthService_Expecter{mock: &_m.Mock}
+}
+
+// RevokeKey provides a mock function with given fields: ctx, id
+func (_m *AuthService) RevokeKey(ctx context.Context, id int64) error {
+	ret := _m.Called(ctx, id)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, int64) error); ok {
+		r0 = rf(ctx, id)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// AuthService_RevokeKey_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'RevokeKey'
+type AuthService_RevokeKey_Call struct {
+	*mock.Call
+}
+
+// RevokeKey is a helper method to define mock.On call
+//   - ctx context.Context
+//   - id int64
+func (_e *AuthService_Expecter) RevokeKey(ctx interface{}, id interface{}) *AuthService_RevokeKey_Call {
+	return &AuthService_RevokeKey_Call{Call: _e.mock.On("RevokeKey", ctx, id)}
+}
+
+func (_c *AuthService_RevokeKey_Call) Run(run func(ctx context.Context, id int64)) *AuthService_RevokeKey_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(int64))
+	})
+	return _c
+}
+
+func (_c *AuthService_RevokeKey_Call) Return(_a0 error) *AuthService_RevokeKey_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *AuthService_RevokeKey_Call) RunAndReturn(run func(context.Context, int64) error) *AuthService_RevokeKey_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// NewAuthService creates a new instance of AuthService. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewAuthService(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *AuthService {
+	mock := &AuthService{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
@@ -1,14 +1,12 @@
 package delete_segment
 
+import "github.com/pollykon/avito_test_task/internal/handlers"
+
 type HandlerRequest struct {
 	SegmentSlug string `json:"slug"`
 }
 
 type HandlerResponse struct {
-	Status int                   `json:"status"`
-	Error  *HandlerResponseError `json:"error,omitempty"`
-}
-
-type HandlerResponseError struct {
-	Message string `json:"message"`
+	Status int             `json:"status"`
+	Error  *handlers.Error `json:"error,omitempty"`
 }
@@ -3,9 +3,7 @@ package delete_segment
 import (
 	"context"
 	"encoding/json"
-	"errors"
 	"github.com/pollykon/avito_test_task/internal/handlers"
-	segmentService "github.com/pollykon/avito_test_task/internal/service/segment"
 	"log/slog"
 	"net/http"
 )
@@ -25,9 +23,7 @@ func (h Handler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
 		w.WriteHeader(http.StatusMethodNotAllowed)
 		_ = json.NewEncoder(w).Encode(HandlerResponse{
 			Status: http.StatusMethodNotAllowed,
-			Error: &HandlerResponseError{
-				Message: handlers.ErrMsgMethodNotAllowed,
-			},
+			Error:  handlers.ErrMethodNotAllowed(r.Context()),
 		})
 		return
 	}
@@ -39,9 +35,7 @@ func (h Handler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
 		w.WriteHeader(http.StatusBadRequest)
 		_ = json.NewEncoder(w).Encode(HandlerResponse{
 			Status: http.StatusBadRequest,
-			Error: &HandlerResponseError{
-				Message: handlers.ErrMsgBadRequest,
-			},
+			Error:  handlers.ErrMalformedRequest(r.Context()),
 		})
 		return
 	}
@@ -55,29 +49,23 @@ func (h Handler) handle(ctx context.Context, request HandlerRequest) HandlerResp
 	if request.SegmentSlug == "" {
 		return HandlerResponse{
 			Status: http.StatusBadRequest,
-			Error: &HandlerResponseError{
-				Message: "slug shouldn't be empty",
-			},
+			Error:  handlers.NewValidationError(ctx, "slug", handlers.MsgRequired),
 		}
 	}
 
 	err := h.segmentService.DeleteSegment(ctx, request.SegmentSlug)
 	if err != nil {
-		if errors.Is(err, segmentService.ErrSegmentNotExist) {
+		if responseErr := handlers.FromServiceError(ctx, err); responseErr != nil {
 			return HandlerResponse{
 				Status: http.StatusBadRequest,
-				Error: &HandlerResponseError{
-					Message: "segment doesn't exist",
-				},
+				Error:  responseErr,
 			}
 		}
 
 		h.logger.ErrorContext(ctx, "error while deleting segment", "error", err, "request", request)
 		return HandlerResponse{
 			Status: http.StatusInternalServerError,
-			Error: &HandlerResponseError{
-				Message: handlers.ErrMsgInternal,
-			},
+			Error:  handlers.ErrInternal(ctx),
 		}
 	}
 	return HandlerResponse{Status: http.StatusOK}
@@ -93,8 +93,10 @@ func TestSegmentHandler_DeleteSegment_Error(t *testing.T) {
 			expectedStatusCode: http.StatusBadRequest,
 			expectedResponse: &HandlerResponse{
 				Status: http.StatusBadRequest,
-				Error: &HandlerResponseError{
+				Error: &handlers.Error{
+					Code:    "VALIDATION_FAILED",
 					Message: "slug shouldn't be empty",
+					Details: []handlers.FieldError{{Field: "slug", Message: "slug shouldn't be empty"}},
 				},
 			},
 		},
@@ -112,9 +114,7 @@ func TestSegmentHandler_DeleteSegment_Error(t *testing.T) {
 			expectedStatusCode: http.StatusBadRequest,
 			expectedResponse: &HandlerResponse{
 				Status: http.StatusBadRequest,
-				Error: &HandlerResponseError{
-					Message: "segment doesn't exist",
-				},
+				Error:  &handlers.Error{Code: "SEGMENT_NOT_FOUND", Message: "segment doesn't exist"},
 			},
 		},
 		{
@@ -131,9 +131,7 @@ func TestSegmentHandler_DeleteSegment_Error(t *testing.T) {
 			expectedStatusCode: http.StatusInternalServerError,
 			expectedResponse: &HandlerResponse{
 				Status: http.StatusInternalServerError,
-				Error: &HandlerResponseError{
-					Message: handlers.ErrMsgInternal,
-				},
+				Error:  &handlers.Error{Code: "INTERNAL", Message: "unexpected error"},
 			},
 		},
 	}
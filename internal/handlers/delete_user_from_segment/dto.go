@@ -1,15 +1,13 @@
 package delete_user_from_segment
 
+import "github.com/pollykon/avito_test_task/internal/handlers"
+
 type HandlerRequest struct {
 	UserID       int64    `json:"userId"`
 	SegmentSlugs []string `json:"slugs"`
 }
 
 type HandlerResponse struct {
-	Status int                   `json:"status"`
-	Error  *HandlerResponseError `json:"error,omitempty"`
-}
-
-type HandlerResponseError struct {
-	Message string `json:"message"`
+	Status int             `json:"status"`
+	Error  *handlers.Error `json:"error,omitempty"`
 }
@@ -24,9 +24,7 @@ func (h Handler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
 		w.WriteHeader(http.StatusMethodNotAllowed)
 		_ = json.NewEncoder(w).Encode(HandlerResponse{
 			Status: http.StatusMethodNotAllowed,
-			Error: &HandlerResponseError{
-				Message: handlers.ErrMsgMethodNotAllowed,
-			},
+			Error:  handlers.ErrMethodNotAllowed(r.Context()),
 		})
 		return
 	}
@@ -41,9 +39,7 @@ func (h Handler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
 		w.WriteHeader(http.StatusBadRequest)
 		_ = json.NewEncoder(w).Encode(HandlerResponse{
 			Status: http.StatusBadRequest,
-			Error: &HandlerResponseError{
-				Message: handlers.ErrMsgBadRequest,
-			},
+			Error:  handlers.ErrMalformedRequest(r.Context()),
 		})
 		return
 	}
@@ -59,18 +55,14 @@ func (h Handler) handle(ctx context.Context, request HandlerRequest) HandlerResp
 	if request.UserID <= 0 {
 		return HandlerResponse{
 			Status: http.StatusBadRequest,
-			Error: &HandlerResponseError{
-				Message: "userId should be more than 0",
-			},
+			Error:  handlers.NewValidationError(ctx, "userId", handlers.MsgPositive),
 		}
 	}
 
 	if request.SegmentSlugs == nil {
 		return HandlerResponse{
 			Status: http.StatusBadRequest,
-			Error: &HandlerResponseError{
-				Message: "slugs shouldn't be empty",
-			},
+			Error:  handlers.NewValidationError(ctx, "slugs", handlers.MsgRequired),
 		}
 	}
 
@@ -79,9 +71,7 @@ func (h Handler) handle(ctx context.Context, request HandlerRequest) HandlerResp
 		h.logger.ErrorContext(ctx, "error while deleting user from segment", "error", err, "request", request)
 		return HandlerResponse{
 			Status: http.StatusInternalServerError,
-			Error: &HandlerResponseError{
-				Message: handlers.ErrMsgInternal,
-			},
+			Error:  handlers.ErrInternal(ctx),
 		}
 	}
 
@@ -97,8 +97,10 @@ func TestSegmentHandler_DeleteUserFromSegment_Error(t *testing.T) {
 			expectedStatusCode: http.StatusBadRequest,
 			expectedResponse: &HandlerResponse{
 				Status: http.StatusBadRequest,
-				Error: &HandlerResponseError{
+				Error: &handlers.Error{
+					Code:    "VALIDATION_FAILED",
 					Message: "userId should be more than 0",
+					Details: []handlers.FieldError{{Field: "userId", Message: "userId should be more than 0"}},
 				},
 			},
 		},
@@ -114,8 +116,10 @@ func TestSegmentHandler_DeleteUserFromSegment_Error(t *testing.T) {
 			expectedStatusCode: http.StatusBadRequest,
 			expectedResponse: &HandlerResponse{
 				Status: http.StatusBadRequest,
-				Error: &HandlerResponseError{
+				Error: &handlers.Error{
+					Code:    "VALIDATION_FAILED",
 					Message: "slugs shouldn't be empty",
+					Details: []handlers.FieldError{{Field: "slugs", Message: "slugs shouldn't be empty"}},
 				},
 			},
 		},
@@ -134,9 +138,7 @@ func TestSegmentHandler_DeleteUserFromSegment_Error(t *testing.T) {
 			expectedStatusCode: http.StatusInternalServerError,
 			expectedResponse: &HandlerResponse{
 				Status: http.StatusInternalServerError,
-				Error: &HandlerResponseError{
-					Message: handlers.ErrMsgInternal,
-				},
+				Error:  &handlers.Error{Code: "INTERNAL", Message: "unexpected error"},
 			},
 		},
 	}
@@ -0,0 +1,8 @@
+//go:generate mockery --all --output ./mocks --case underscore --with-expecter
+package delete_webhook
+
+import "context"
+
+type WebhookService interface {
+	DeleteSubscription(ctx context.Context, id int64) error
+}
@@ -0,0 +1,12 @@
+package delete_webhook
+
+import "github.com/pollykon/avito_test_task/internal/handlers"
+
+type HandlerRequest struct {
+	ID int64 `json:"id"`
+}
+
+type HandlerResponse struct {
+	Status int             `json:"status"`
+	Error  *handlers.Error `json:"error,omitempty"`
+}
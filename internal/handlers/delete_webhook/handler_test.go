@@ -0,0 +1,154 @@
+package delete_webhook
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/pollykon/avito_test_task/internal/handlers"
+	"github.com/pollykon/avito_test_task/internal/handlers/delete_webhook/mocks"
+	"github.com/pollykon/avito_test_task/internal/logger"
+	serviceWebhook "github.com/pollykon/avito_test_task/internal/service/webhook"
+)
+
+func TestWebhookHandler_DeleteWebhook(t *testing.T) {
+	tt := []struct {
+		name string
+
+		requestMethod string
+		sentID        interface{}
+
+		buildWebhookServiceMock func(service *mocks.WebhookService)
+
+		expectedStatusCode int
+		expectedResponse   *HandlerResponse
+	}{
+		{
+			name: "success",
+
+			requestMethod: http.MethodPost,
+			sentID:        7,
+
+			buildWebhookServiceMock: func(service *mocks.WebhookService) {
+				service.EXPECT().DeleteSubscription(context.Background(), int64(7)).Return(nil)
+			},
+
+			expectedStatusCode: http.StatusOK,
+			expectedResponse:   &HandlerResponse{Status: http.StatusOK},
+		},
+		{
+			name: "wrong_method",
+
+			requestMethod: http.MethodGet,
+			sentID:        7,
+
+			buildWebhookServiceMock: nil,
+
+			expectedStatusCode: http.StatusMethodNotAllowed,
+			expectedResponse:   nil,
+		},
+		{
+			name: "decode_error",
+
+			requestMethod: http.MethodPost,
+			sentID:        "7",
+
+			buildWebhookServiceMock: nil,
+
+			expectedStatusCode: http.StatusBadRequest,
+			expectedResponse:   nil,
+		},
+		{
+			name: "wrong_id",
+
+			requestMethod: http.MethodPost,
+			sentID:        0,
+
+			buildWebhookServiceMock: nil,
+
+			expectedStatusCode: http.StatusBadRequest,
+			expectedResponse: &HandlerResponse{
+				Status: http.StatusBadRequest,
+				Error: &handlers.Error{
+					Code:    "VALIDATION_FAILED",
+					Message: "id should be more than 0",
+					Details: []handlers.FieldError{{Field: "id", Message: "id should be more than 0"}},
+				},
+			},
+		},
+		{
+			name: "service_error_not_exist",
+
+			requestMethod: http.MethodPost,
+			sentID:        7,
+
+			buildWebhookServiceMock: func(service *mocks.WebhookService) {
+				service.EXPECT().DeleteSubscription(context.Background(), int64(7)).
+					Return(serviceWebhook.ErrSubscriptionNotExist)
+			},
+
+			expectedStatusCode: http.StatusBadRequest,
+			expectedResponse: &HandlerResponse{
+				Status: http.StatusBadRequest,
+				Error:  &handlers.Error{Code: "WEBHOOK_NOT_FOUND", Message: "webhook doesn't exist"},
+			},
+		},
+		{
+			name: "service_error_unexpected_error",
+
+			requestMethod: http.MethodPost,
+			sentID:        7,
+
+			buildWebhookServiceMock: func(service *mocks.WebhookService) {
+				service.EXPECT().DeleteSubscription(context.Background(), int64(7)).
+					Return(fmt.Errorf("error from service"))
+			},
+
+			expectedStatusCode: http.StatusInternalServerError,
+			expectedResponse: &HandlerResponse{
+				Status: http.StatusInternalServerError,
+				Error:  &handlers.Error{Code: "INTERNAL", Message: "unexpected error"},
+			},
+		},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			jsonBodyRequest, _ := json.Marshal(map[string]interface{}{"id": tc.sentID})
+			request, err := http.NewRequest(tc.requestMethod, "", strings.NewReader(string(jsonBodyRequest)))
+			if err != nil {
+				t.Fatalf("error while sending request: %s", err)
+			}
+
+			w := httptest.NewRecorder()
+			webhookServiceMock := mocks.NewWebhookService(t)
+
+			if tc.buildWebhookServiceMock != nil {
+				tc.buildWebhookServiceMock(webhookServiceMock)
+			}
+
+			handler := New(webhookServiceMock, slog.New(logger.NewNoopHandler()))
+			handler.ServeHTTP(w, request)
+
+			responseResult := w.Result()
+
+			assert.Equal(t, handlers.ContentTypeJSON, responseResult.Header.Get("Content-Type"))
+			assert.Equal(t, tc.expectedStatusCode, responseResult.StatusCode)
+
+			if tc.expectedResponse != nil {
+				var response HandlerResponse
+				err = json.NewDecoder(responseResult.Body).Decode(&response)
+				assert.NoError(t, err)
+
+				assert.Equal(t, *tc.expectedResponse, response)
+			}
+		})
+	}
+}
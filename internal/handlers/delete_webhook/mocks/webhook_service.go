@@ -0,0 +1,79 @@
+// Code generated by mockery v2.33.0. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+
+	mock "github.com/stretchr/testify/mock"
+)
+
+// WebhookService is an autogenerated mock type for the WebhookService type
+type WebhookService struct {
+	mock.Mock
+}
+
+type WebhookService_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *WebhookService) EXPECT() *WebhookService_Expecter {
+	return &WebhookService_Expecter{mock: &_m.Mock}
+}
+
+// DeleteSubscription provides a mock function with given fields: ctx, id
+func (_m *WebhookService) DeleteSubscription(ctx context.Context, id int64) error {
+	ret := _m.Called(ctx, id)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, int64) error); ok {
+		r0 = rf(ctx, id)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// WebhookService_DeleteSubscription_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'DeleteSubscription'
+type WebhookService_DeleteSubscription_Call struct {
+	*mock.Call
+}
+
+// DeleteSubscription is a helper method to define mock.On call
+//   - ctx context.Context
+//   - id int64
+func (_e *WebhookService_Expecter) DeleteSubscription(ctx interface{}, id interface{}) *WebhookService_DeleteSubscription_Call {
+	return &WebhookService_DeleteSubscription_Call{Call: _e.mock.On("DeleteSubscription", ctx, id)}
+}
+
+func (_c *WebhookService_DeleteSubscription_Call) Run(run func(ctx context.Context, id int64)) *WebhookService_DeleteSubscription_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(int64))
+	})
+	return _c
+}
+
+func (_c *WebhookService_DeleteSubscription_Call) Return(_a0 error) *WebhookService_DeleteSubscription_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *WebhookService_DeleteSubscription_Call) RunAndReturn(run func(context.Context, int64) error) *WebhookService_DeleteSubscription_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// NewWebhookService creates a new instance of WebhookService. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewWebhookService(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *WebhookService {
+	mock := &WebhookService{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
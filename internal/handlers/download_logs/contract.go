@@ -0,0 +1,17 @@
+//go:generate mockery --all --output ./mocks --case underscore --with-expecter
+package download_logs
+
+import (
+	"context"
+	"net/url"
+
+	serviceLog "github.com/pollykon/avito_test_task/internal/service/log"
+)
+
+type LogService interface {
+	OpenCSV(ctx context.Context, fileName string) (serviceLog.CSVFile, error)
+}
+
+type URLVerifier interface {
+	Verify(fileName string, query url.Values) error
+}
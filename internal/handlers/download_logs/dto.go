@@ -0,0 +1,8 @@
+package download_logs
+
+import "github.com/pollykon/avito_test_task/internal/handlers"
+
+type HandlerResponse struct {
+	Status int             `json:"status"`
+	Error  *handlers.Error `json:"error,omitempty"`
+}
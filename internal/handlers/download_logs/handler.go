@@ -0,0 +1,171 @@
+package download_logs
+
+import (
+	"compress/gzip"
+	"encoding/json"
+	"errors"
+	"io"
+	"log/slog"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/pollykon/avito_test_task/internal/handlers"
+	logService "github.com/pollykon/avito_test_task/internal/service/log"
+	"github.com/pollykon/avito_test_task/internal/signer"
+)
+
+type Handler struct {
+	logService      LogService
+	urlVerifier     URLVerifier
+	staticURIPrefix string
+	logger          *slog.Logger
+}
+
+func New(logService LogService, urlVerifier URLVerifier, staticURIPrefix string, logger *slog.Logger) Handler {
+	return Handler{
+		logService:      logService,
+		urlVerifier:     urlVerifier,
+		staticURIPrefix: staticURIPrefix,
+		logger:          logger,
+	}
+}
+
+const (
+	contentTypeCSV = "text/csv"
+	contentTypeZip = "application/zip"
+	encodingGzip   = "gzip"
+	extensionGzip  = ".gz"
+)
+
+// ServeHTTP sends csv file only if url was signed by get_logs handler and is not expired yet.
+// Directory listing isn't supported
+func (h Handler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet && r.Method != http.MethodHead {
+		writeError(w, handlers.ErrMethodNotAllowed(r.Context()))
+		return
+	}
+
+	fileName := strings.TrimPrefix(r.URL.Path, h.staticURIPrefix+"/")
+	if fileName == "" || strings.ContainsAny(fileName, `/\`) || fileName == "." || fileName == ".." {
+		writeError(w, handlers.ErrNotFound(r.Context()))
+		return
+	}
+
+	err := h.urlVerifier.Verify(fileName, r.URL.Query())
+	if err != nil {
+		if errors.Is(err, signer.ErrExpired) {
+			writeError(w, handlers.NewError(r.Context(), handlers.CodeLinkExpired))
+			return
+		}
+		writeError(w, handlers.NewError(r.Context(), handlers.CodeInvalidSignature))
+		return
+	}
+
+	file, err := h.logService.OpenCSV(r.Context(), fileName)
+	if err != nil {
+		if errors.Is(err, logService.ErrFileNotExist) {
+			writeError(w, handlers.ErrNotFound(r.Context()))
+			return
+		}
+		h.logger.ErrorContext(r.Context(), "error while opening csv", "error", err, "file", fileName)
+		writeError(w, handlers.ErrInternal(r.Context()))
+		return
+	}
+
+	defer func() { _ = file.Content.Close() }()
+
+	switch file.Compression {
+	case logService.CompressionGzip:
+		// gzip is sent as is to clients which accept it and transparently decompressed for the rest
+		csvName := strings.TrimSuffix(fileName, extensionGzip)
+		w.Header().Add("Vary", "Accept-Encoding")
+		w.Header().Set("Content-Type", contentTypeCSV)
+		w.Header().Set("Content-Disposition", `attachment; filename="`+csvName+`"`)
+
+		if acceptsGzip(r.Header.Get("Accept-Encoding")) {
+			w.Header().Set("Content-Encoding", encodingGzip)
+			h.serveContent(w, r, fileName, file.Content, file.Size, file.ModTime)
+			return
+		}
+
+		reader, err := gzip.NewReader(file.Content)
+		if err != nil {
+			h.logger.ErrorContext(r.Context(), "error while reading gzip", "error", err, "file", fileName)
+			writeError(w, handlers.ErrInternal(r.Context()))
+			return
+		}
+		h.serveContent(w, r, fileName, reader, -1, file.ModTime)
+	case logService.CompressionZip:
+		w.Header().Set("Content-Type", contentTypeZip)
+		w.Header().Set("Content-Disposition", `attachment; filename="`+fileName+`"`)
+		h.serveContent(w, r, fileName, file.Content, file.Size, file.ModTime)
+	default:
+		w.Header().Set("Content-Type", contentTypeCSV)
+		w.Header().Set("Content-Disposition", `attachment; filename="`+fileName+`"`)
+		h.serveContent(w, r, fileName, file.Content, file.Size, file.ModTime)
+	}
+}
+
+// serveContent sends content with size (-1 if unknown). Seekable content supports ranges and conditional requests,
+// the rest is streamed as is
+func (h Handler) serveContent(
+	w http.ResponseWriter,
+	r *http.Request,
+	fileName string,
+	content io.Reader,
+	size int64,
+	modTime time.Time,
+) {
+	if seeker, ok := content.(io.ReadSeeker); ok {
+		http.ServeContent(w, r, fileName, modTime, seeker)
+		return
+	}
+
+	if size >= 0 {
+		w.Header().Set("Content-Length", strconv.FormatInt(size, 10))
+	}
+	if !modTime.IsZero() {
+		w.Header().Set("Last-Modified", modTime.UTC().Format(http.TimeFormat))
+	}
+	w.WriteHeader(http.StatusOK)
+
+	if r.Method == http.MethodHead {
+		return
+	}
+
+	_, err := io.Copy(w, content)
+	if err != nil {
+		h.logger.ErrorContext(r.Context(), "error while sending file", "error", err, "file", fileName)
+	}
+}
+
+// acceptsGzip checks if Accept-Encoding header allows gzip, e.g. "gzip, deflate" or "*;q=0.5"
+func acceptsGzip(acceptEncoding string) bool {
+	for _, part := range strings.Split(acceptEncoding, ",") {
+		coding, params, _ := strings.Cut(strings.TrimSpace(part), ";")
+		coding = strings.ToLower(strings.TrimSpace(coding))
+		if coding != encodingGzip && coding != "*" {
+			continue
+		}
+
+		quality := strings.ReplaceAll(strings.TrimSpace(params), " ", "")
+		if quality == "q=0" || strings.HasPrefix(quality, "q=0.") && strings.Trim(quality[len("q=0."):], "0") == "" {
+			return false
+		}
+		return true
+	}
+
+	return false
+}
+
+func writeError(w http.ResponseWriter, responseErr *handlers.Error) {
+	status := responseErr.HTTPStatus()
+	w.Header().Set("Content-Type", handlers.ContentTypeJSON)
+	w.WriteHeader(status)
+	_ = json.NewEncoder(w).Encode(HandlerResponse{
+		Status: status,
+		Error:  responseErr,
+	})
+}
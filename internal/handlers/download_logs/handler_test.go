@@ -0,0 +1,387 @@
+package download_logs
+
+import (
+	"bytes"
+	"compress/gzip"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/pollykon/avito_test_task/internal/handlers"
+	"github.com/pollykon/avito_test_task/internal/handlers/download_logs/mocks"
+	"github.com/pollykon/avito_test_task/internal/logger"
+	logService "github.com/pollykon/avito_test_task/internal/service/log"
+	"github.com/pollykon/avito_test_task/internal/signer"
+)
+
+const (
+	staticURIPrefix = "/static"
+)
+
+type readSeekCloser struct {
+	*strings.Reader
+}
+
+func (readSeekCloser) Close() error {
+	return nil
+}
+
+func TestLogHandler_DownloadLogs_Success(t *testing.T) {
+	fileName := "ef8cde3a-89a1-4fd5-81e2-34dac98a4740.csv"
+	content := "logId,userId,segmentId,operation,insertTime\n1,12,AVITO,add,2023-08-01T00:00:00Z"
+	query := url.Values{"expires": []string{"1693526400"}, "signature": []string{"abcdef"}}
+
+	request, err := http.NewRequest(
+		http.MethodGet,
+		"http://localhost:1011/static/"+fileName+"?"+query.Encode(),
+		nil,
+	)
+	if err != nil {
+		t.Fatalf("error while sending request: %s", err)
+	}
+
+	w := httptest.NewRecorder()
+	urlVerifierMock := mocks.NewURLVerifier(t)
+	urlVerifierMock.EXPECT().Verify(fileName, query).Return(nil)
+
+	logServiceMock := mocks.NewLogService(t)
+	logServiceMock.EXPECT().OpenCSV(context.Background(), fileName).
+		Return(logService.CSVFile{Content: readSeekCloser{strings.NewReader(content)}, ModTime: time.Now()}, nil)
+
+	handler := New(logServiceMock, urlVerifierMock, staticURIPrefix, slog.New(logger.NewNoopHandler()))
+	handler.ServeHTTP(w, request)
+
+	responseResult := w.Result()
+
+	assert.Equal(t, http.StatusOK, responseResult.StatusCode)
+	assert.Equal(t, contentTypeCSV, responseResult.Header.Get("Content-Type"))
+
+	body, err := io.ReadAll(responseResult.Body)
+	assert.NoError(t, err)
+	assert.Equal(t, content, string(body))
+}
+
+func TestLogHandler_DownloadLogs_NotSeekable_Success(t *testing.T) {
+	fileName := "ef8cde3a-89a1-4fd5-81e2-34dac98a4740.csv"
+	content := "logId,userId,segmentId,operation,insertTime\n1,12,AVITO,add,2023-08-01T00:00:00Z"
+	query := url.Values{"expires": []string{"1693526400"}, "signature": []string{"abcdef"}}
+
+	request, err := http.NewRequest(
+		http.MethodGet,
+		"http://localhost:1011/static/"+fileName+"?"+query.Encode(),
+		nil,
+	)
+	if err != nil {
+		t.Fatalf("error while sending request: %s", err)
+	}
+
+	w := httptest.NewRecorder()
+	urlVerifierMock := mocks.NewURLVerifier(t)
+	urlVerifierMock.EXPECT().Verify(fileName, query).Return(nil)
+
+	logServiceMock := mocks.NewLogService(t)
+	logServiceMock.EXPECT().OpenCSV(context.Background(), fileName).
+		Return(logService.CSVFile{Content: io.NopCloser(strings.NewReader(content)), Size: int64(len(content))}, nil)
+
+	handler := New(logServiceMock, urlVerifierMock, staticURIPrefix, slog.New(logger.NewNoopHandler()))
+	handler.ServeHTTP(w, request)
+
+	responseResult := w.Result()
+
+	assert.Equal(t, http.StatusOK, responseResult.StatusCode)
+	assert.Equal(t, contentTypeCSV, responseResult.Header.Get("Content-Type"))
+	assert.Equal(t, strconv.Itoa(len(content)), responseResult.Header.Get("Content-Length"))
+
+	body, err := io.ReadAll(responseResult.Body)
+	assert.NoError(t, err)
+	assert.Equal(t, content, string(body))
+}
+
+func TestLogHandler_DownloadLogs_Compressed_Success(t *testing.T) {
+	content := "logId,userId,segmentId,operation,insertTime\n1,12,AVITO,add,2023-08-01T00:00:00Z"
+	query := url.Values{"expires": []string{"1693526400"}, "signature": []string{"abcdef"}}
+
+	var gzipped bytes.Buffer
+	gzipWriter := gzip.NewWriter(&gzipped)
+	_, err := gzipWriter.Write([]byte(content))
+	if err != nil {
+		t.Fatalf("error while compressing content: %s", err)
+	}
+	err = gzipWriter.Close()
+	if err != nil {
+		t.Fatalf("error while compressing content: %s", err)
+	}
+
+	tt := []struct {
+		name string
+
+		sentFileName       string
+		sentAcceptEncoding string
+
+		returnedFile logService.CSVFile
+
+		expectedContentType        string
+		expectedContentEncoding    string
+		expectedContentDisposition string
+		expectedBody               string
+	}{
+		{
+			name: "gzip_accepted",
+
+			sentFileName:       "ef8cde3a-89a1-4fd5-81e2-34dac98a4740.csv.gz",
+			sentAcceptEncoding: "deflate, gzip;q=0.8",
+
+			returnedFile: logService.CSVFile{
+				Content:     io.NopCloser(bytes.NewReader(gzipped.Bytes())),
+				Size:        int64(gzipped.Len()),
+				Compression: logService.CompressionGzip,
+			},
+
+			expectedContentType:        contentTypeCSV,
+			expectedContentEncoding:    "gzip",
+			expectedContentDisposition: `attachment; filename="ef8cde3a-89a1-4fd5-81e2-34dac98a4740.csv"`,
+			expectedBody:               gzipped.String(),
+		},
+		{
+			name: "gzip_not_accepted",
+
+			sentFileName:       "ef8cde3a-89a1-4fd5-81e2-34dac98a4740.csv.gz",
+			sentAcceptEncoding: "gzip;q=0, deflate",
+
+			returnedFile: logService.CSVFile{
+				Content:     io.NopCloser(bytes.NewReader(gzipped.Bytes())),
+				Size:        int64(gzipped.Len()),
+				Compression: logService.CompressionGzip,
+			},
+
+			expectedContentType:        contentTypeCSV,
+			expectedContentEncoding:    "",
+			expectedContentDisposition: `attachment; filename="ef8cde3a-89a1-4fd5-81e2-34dac98a4740.csv"`,
+			expectedBody:               content,
+		},
+		{
+			name: "zip",
+
+			sentFileName:       "ef8cde3a-89a1-4fd5-81e2-34dac98a4740.zip",
+			sentAcceptEncoding: "gzip",
+
+			returnedFile: logService.CSVFile{
+				Content:     io.NopCloser(strings.NewReader("zip content")),
+				Size:        int64(len("zip content")),
+				Compression: logService.CompressionZip,
+			},
+
+			expectedContentType:        contentTypeZip,
+			expectedContentEncoding:    "",
+			expectedContentDisposition: `attachment; filename="ef8cde3a-89a1-4fd5-81e2-34dac98a4740.zip"`,
+			expectedBody:               "zip content",
+		},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			request, err := http.NewRequest(
+				http.MethodGet,
+				"http://localhost:1011/static/"+tc.sentFileName+"?"+query.Encode(),
+				nil,
+			)
+			if err != nil {
+				t.Fatalf("error while sending request: %s", err)
+			}
+			request.Header.Set("Accept-Encoding", tc.sentAcceptEncoding)
+
+			w := httptest.NewRecorder()
+			urlVerifierMock := mocks.NewURLVerifier(t)
+			urlVerifierMock.EXPECT().Verify(tc.sentFileName, query).Return(nil)
+
+			logServiceMock := mocks.NewLogService(t)
+			logServiceMock.EXPECT().OpenCSV(context.Background(), tc.sentFileName).Return(tc.returnedFile, nil)
+
+			handler := New(logServiceMock, urlVerifierMock, staticURIPrefix, slog.New(logger.NewNoopHandler()))
+			handler.ServeHTTP(w, request)
+
+			responseResult := w.Result()
+
+			assert.Equal(t, http.StatusOK, responseResult.StatusCode)
+			assert.Equal(t, tc.expectedContentType, responseResult.Header.Get("Content-Type"))
+			assert.Equal(t, tc.expectedContentEncoding, responseResult.Header.Get("Content-Encoding"))
+			assert.Equal(t, tc.expectedContentDisposition, responseResult.Header.Get("Content-Disposition"))
+
+			body, err := io.ReadAll(responseResult.Body)
+			assert.NoError(t, err)
+			assert.Equal(t, tc.expectedBody, string(body))
+		})
+	}
+}
+
+func TestLogHandler_DownloadLogs_Error(t *testing.T) {
+	fileName := "ef8cde3a-89a1-4fd5-81e2-34dac98a4740.csv"
+	query := url.Values{"expires": []string{"1693526400"}, "signature": []string{"abcdef"}}
+
+	tt := []struct {
+		name string
+
+		sentMethod string
+		sentPath   string
+
+		buildURLVerifierMock func(mock *mocks.URLVerifier)
+		buildLogServiceMock  func(mock *mocks.LogService)
+
+		expectedStatusCode int
+		expectedResponse   *HandlerResponse
+	}{
+		{
+			name: "wrong_method",
+
+			sentMethod: http.MethodPost,
+			sentPath:   "/static/" + fileName,
+
+			expectedStatusCode: http.StatusMethodNotAllowed,
+			expectedResponse: &HandlerResponse{
+				Status: http.StatusMethodNotAllowed,
+				Error:  &handlers.Error{Code: "METHOD_NOT_ALLOWED", Message: "method not allowed"},
+			},
+		},
+		{
+			name: "directory_listing",
+
+			sentMethod: http.MethodGet,
+			sentPath:   "/static/",
+
+			expectedStatusCode: http.StatusNotFound,
+			expectedResponse: &HandlerResponse{
+				Status: http.StatusNotFound,
+				Error:  &handlers.Error{Code: "NOT_FOUND", Message: "not found"},
+			},
+		},
+		{
+			name: "nested_path",
+
+			sentMethod: http.MethodGet,
+			sentPath:   "/static/dir/" + fileName,
+
+			expectedStatusCode: http.StatusNotFound,
+			expectedResponse:   nil,
+		},
+		{
+			name: "invalid_signature",
+
+			sentMethod: http.MethodGet,
+			sentPath:   "/static/" + fileName,
+
+			buildURLVerifierMock: func(verifier *mocks.URLVerifier) {
+				verifier.EXPECT().Verify(fileName, query).Return(signer.ErrInvalidSignature)
+			},
+
+			expectedStatusCode: http.StatusForbidden,
+			expectedResponse: &HandlerResponse{
+				Status: http.StatusForbidden,
+				Error:  &handlers.Error{Code: "INVALID_SIGNATURE", Message: "invalid signature"},
+			},
+		},
+		{
+			name: "expired_link",
+
+			sentMethod: http.MethodGet,
+			sentPath:   "/static/" + fileName,
+
+			buildURLVerifierMock: func(verifier *mocks.URLVerifier) {
+				verifier.EXPECT().Verify(fileName, query).Return(signer.ErrExpired)
+			},
+
+			expectedStatusCode: http.StatusGone,
+			expectedResponse: &HandlerResponse{
+				Status: http.StatusGone,
+				Error:  &handlers.Error{Code: "LINK_EXPIRED", Message: "link expired"},
+			},
+		},
+		{
+			name: "file_not_exist",
+
+			sentMethod: http.MethodGet,
+			sentPath:   "/static/" + fileName,
+
+			buildURLVerifierMock: func(verifier *mocks.URLVerifier) {
+				verifier.EXPECT().Verify(fileName, query).Return(nil)
+			},
+			buildLogServiceMock: func(service *mocks.LogService) {
+				service.EXPECT().OpenCSV(context.Background(), fileName).
+					Return(logService.CSVFile{}, logService.ErrFileNotExist)
+			},
+
+			expectedStatusCode: http.StatusNotFound,
+			expectedResponse:   nil,
+		},
+		{
+			name: "service_error_unexpected_error",
+
+			sentMethod: http.MethodGet,
+			sentPath:   "/static/" + fileName,
+
+			buildURLVerifierMock: func(verifier *mocks.URLVerifier) {
+				verifier.EXPECT().Verify(fileName, query).Return(nil)
+			},
+			buildLogServiceMock: func(service *mocks.LogService) {
+				service.EXPECT().OpenCSV(context.Background(), fileName).
+					Return(logService.CSVFile{}, fmt.Errorf("error from service"))
+			},
+
+			expectedStatusCode: http.StatusInternalServerError,
+			expectedResponse: &HandlerResponse{
+				Status: http.StatusInternalServerError,
+				Error:  &handlers.Error{Code: "INTERNAL", Message: "unexpected error"},
+			},
+		},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			request, err := http.NewRequest(
+				tc.sentMethod,
+				"http://localhost:1011"+tc.sentPath+"?"+query.Encode(),
+				nil,
+			)
+			if err != nil {
+				t.Fatalf("error while sending request: %s", err)
+			}
+
+			w := httptest.NewRecorder()
+			urlVerifierMock := mocks.NewURLVerifier(t)
+			if tc.buildURLVerifierMock != nil {
+				tc.buildURLVerifierMock(urlVerifierMock)
+			}
+
+			logServiceMock := mocks.NewLogService(t)
+			if tc.buildLogServiceMock != nil {
+				tc.buildLogServiceMock(logServiceMock)
+			}
+
+			handler := New(logServiceMock, urlVerifierMock, staticURIPrefix, slog.New(logger.NewNoopHandler()))
+			handler.ServeHTTP(w, request)
+
+			responseResult := w.Result()
+
+			assert.Equal(t, handlers.ContentTypeJSON, responseResult.Header.Get("Content-Type"))
+			assert.Equal(t, tc.expectedStatusCode, responseResult.StatusCode)
+
+			if tc.expectedResponse != nil {
+				var response HandlerResponse
+				err = json.NewDecoder(responseResult.Body).Decode(&response)
+				assert.NoError(t, err)
+
+				assert.Equal(t, *tc.expectedResponse, response)
+			}
+		})
+	}
+}
@@ -0,0 +1,91 @@
+// Code generated by mockery v2.33.0. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+
+	log "github.com/pollykon/avito_test_task/internal/service/log"
+
+	mock "github.com/stretchr/testify/mock"
+)
+
+// LogService is an autogenerated mock type for the LogService type
+type LogService struct {
+	mock.Mock
+}
+
+type LogService_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *LogService) EXPECT() *LogService_Expecter {
+	return &LogService_Expecter{mock: &_m.Mock}
+}
+
+// OpenCSV provides a mock function with given fields: ctx, fileName
+func (_m *LogService) OpenCSV(ctx context.Context, fileName string) (log.CSVFile, error) {
+	ret := _m.Called(ctx, fileName)
+
+	var r0 log.CSVFile
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) (log.CSVFile, error)); ok {
+		return rf(ctx, fileName)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) log.CSVFile); ok {
+		r0 = rf(ctx, fileName)
+	} else {
+		r0 = ret.Get(0).(log.CSVFile)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, fileName)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// LogService_OpenCSV_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'OpenCSV'
+type LogService_OpenCSV_Call struct {
+	*mock.Call
+}
+
+// OpenCSV is a helper method to define mock.On call
+//   - ctx context.Context
+//   - fileName string
+func (_e *LogService_Expecter) OpenCSV(ctx interface{}, fileName interface{}) *LogService_OpenCSV_Call {
+	return &LogService_OpenCSV_Call{Call: _e.mock.On("OpenCSV", ctx, fileName)}
+}
+
+func (_c *LogService_OpenCSV_Call) Run(run func(ctx context.Context, fileName string)) *LogService_OpenCSV_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string))
+	})
+	return _c
+}
+
+func (_c *LogService_OpenCSV_Call) Return(_a0 log.CSVFile, _a1 error) *LogService_OpenCSV_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *LogService_OpenCSV_Call) RunAndReturn(run func(context.Context, string) (log.CSVFile, error)) *LogService_OpenCSV_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// NewLogService creates a new instance of LogService. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewLogService(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *LogService {
+	mock := &LogService{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
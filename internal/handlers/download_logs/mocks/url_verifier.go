@@ -0,0 +1,79 @@
+// Code generated by mockery v2.33.0. DO NOT EDIT.
+
+package mocks
+
+import (
+	url "net/url"
+
+	mock "github.com/stretchr/testify/mock"
+)
+
+// URLVerifier is an autogenerated mock type for the URLVerifier type
+type URLVerifier struct {
+	mock.Mock
+}
+
+type URLVerifier_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *URLVerifier) EXPECT() *URLVerifier_Expecter {
+	return &URLVerifier_Expecter{mock: &_m.Mock}
+}
+
+// Verify provides a mock function with given fields: fileName, query
+func (_m *URLVerifier) Verify(fileName string, query url.Values) error {
+	ret := _m.Called(fileName, query)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(string, url.Values) error); ok {
+		r0 = rf(fileName, query)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// URLVerifier_Verify_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Verify'
+type URLVerifier_Verify_Call struct {
+	*mock.Call
+}
+
+// Verify is a helper method to define mock.On call
+//   - fileName string
+//   - query url.Values
+func (_e *URLVerifier_Expecter) Verify(fileName interface{}, query interface{}) *URLVerifier_Verify_Call {
+	return &URLVerifier_Verify_Call{Call: _e.mock.On("Verify", fileName, query)}
+}
+
+func (_c *URLVerifier_Verify_Call) Run(run func(fileName string, query url.Values)) *URLVerifier_Verify_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(string), args[1].(url.Values))
+	})
+	return _c
+}
+
+func (_c *URLVerifier_Verify_Call) Return(_a0 error) *URLVerifier_Verify_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *URLVerifier_Verify_Call) RunAndReturn(run func(string, url.Values) error) *URLVerifier_Verify_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// NewURLVerifier creates a new instance of URLVerifier. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewURLVerifier(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *URLVerifier {
+	mock := &URLVerifier{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
@@ -0,0 +1,131 @@
+package handlers
+
+import (
+	"context"
+	"errors"
+	"net/http"
+
+	authService "github.com/pollykon/avito_test_task/internal/service/auth"
+	logService "github.com/pollykon/avito_test_task/internal/service/log"
+	segmentService "github.com/pollykon/avito_test_task/internal/service/segment"
+	webhookService "github.com/pollykon/avito_test_task/internal/service/webhook"
+)
+
+// Error codes are stable and are part of API, clients should rely on them instead of messages
+const (
+	CodeInternal             = "INTERNAL"
+	CodeMethodNotAllowed     = "METHOD_NOT_ALLOWED"
+	CodeMalformedRequest     = "MALFORMED_REQUEST"
+	CodeValidationFailed     = "VALIDATION_FAILED"
+	CodeNotFound             = "NOT_FOUND"
+	CodeSegmentNotFound      = "SEGMENT_NOT_FOUND"
+	CodeSegmentAlreadyExists = "SEGMENT_ALREADY_EXISTS"
+	CodeUserAlreadyInSegment = "USER_ALREADY_IN_SEGMENT"
+	CodeWebhookNotFound      = "WEBHOOK_NOT_FOUND"
+	CodeLinkExpired          = "LINK_EXPIRED"
+	CodeInvalidSignature     = "INVALID_SIGNATURE"
+	CodeUnauthenticated      = "UNAUTHENTICATED"
+	CodeForbidden            = "FORBIDDEN"
+	CodeAPIKeyNotFound       = "API_KEY_NOT_FOUND"
+	CodeRateLimited          = "RATE_LIMITED"
+	CodeNotReady             = "NOT_READY"
+)
+
+// Error is an error in responses of all handlers
+type Error struct {
+	Code    string       `json:"code"`
+	Message string       `json:"message"`
+	Details []FieldError `json:"details,omitempty"`
+}
+
+// FieldError describes invalid field of request
+type FieldError struct {
+	Field   string `json:"field"`
+	Message string `json:"message"`
+}
+
+// NewError returns error with message of code in language from context
+func NewError(ctx context.Context, code string) *Error {
+	return &Error{Code: code, Message: message(LanguageFromContext(ctx), code)}
+}
+
+// NewValidationError returns VALIDATION_FAILED error with field in details.
+// Message is formatted by key from catalog with field as the first argument
+func NewValidationError(ctx context.Context, field string, key string, args ...any) *Error {
+	text := message(LanguageFromContext(ctx), key, append([]any{field}, args...)...)
+	return &Error{
+		Code:    CodeValidationFailed,
+		Message: text,
+		Details: []FieldError{{Field: field, Message: text}},
+	}
+}
+
+func ErrInternal(ctx context.Context) *Error {
+	return NewError(ctx, CodeInternal)
+}
+
+func ErrMethodNotAllowed(ctx context.Context) *Error {
+	return NewError(ctx, CodeMethodNotAllowed)
+}
+
+func ErrMalformedRequest(ctx context.Context) *Error {
+	return NewError(ctx, CodeMalformedRequest)
+}
+
+func ErrNotFound(ctx context.Context) *Error {
+	return NewError(ctx, CodeNotFound)
+}
+
+// FromServiceError maps known errors of services to errors with codes. Returns nil for unknown errors
+func FromServiceError(ctx context.Context, err error) *Error {
+	switch {
+	case errors.Is(err, segmentService.ErrSegmentNotExist):
+		return NewError(ctx, CodeSegmentNotFound)
+	case errors.Is(err, segmentService.ErrSegmentAlreadyExists):
+		return NewError(ctx, CodeSegmentAlreadyExists)
+	case errors.Is(err, segmentService.ErrUserAlreadyInSegment):
+		return NewError(ctx, CodeUserAlreadyInSegment)
+	case errors.Is(err, webhookService.ErrSubscriptionNotExist):
+		return NewError(ctx, CodeWebhookNotFound)
+	case errors.Is(err, webhookService.ErrInvalidURL):
+		return NewValidationError(ctx, "url", MsgHTTPURL)
+	case errors.Is(err, webhookService.ErrUnknownEventType):
+		return NewValidationError(ctx, "eventTypes", MsgUnknownEventType)
+	case errors.Is(err, authService.ErrKeyNotExist):
+		return NewError(ctx, CodeAPIKeyNotFound)
+	case errors.Is(err, authService.ErrEmptyScopes):
+		return NewValidationError(ctx, "scopes", MsgRequired)
+	case errors.Is(err, authService.ErrUnknownScope):
+		return NewValidationError(ctx, "scopes", MsgUnknownScope)
+	case errors.Is(err, logService.ErrFileNotExist):
+		return ErrNotFound(ctx)
+	default:
+		return nil
+	}
+}
+
+// HTTPStatus returns status matching error code. v1 handlers answer 400 to all client errors instead
+func (e *Error) HTTPStatus() int {
+	switch e.Code {
+	case CodeMethodNotAllowed:
+		return http.StatusMethodNotAllowed
+	case CodeMalformedRequest, CodeValidationFailed:
+		return http.StatusBadRequest
+	case CodeUnauthenticated:
+		return http.StatusUnauthorized
+	case CodeInvalidSignature, CodeForbidden:
+		return http.StatusForbidden
+	case CodeNotFound, CodeSegmentNotFound, CodeWebhookNotFound, CodeAPIKeyNotFound:
+		return http.StatusNotFound
+	case CodeSegmentAlreadyExists, CodeUserAlreadyInSegment:
+		return http.StatusConflict
+	case CodeLinkExpired:
+		return http.StatusGone
+	case CodeRateLimited:
+		return http.StatusTooManyRequests
+	case CodeNotReady:
+		return http.StatusServiceUnavailable
+	default:
+		return http.StatusInternalServerError
+	}
+}
@@ -0,0 +1,138 @@
+package handlers
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	authService "github.com/pollykon/avito_test_task/internal/service/auth"
+	segmentService "github.com/pollykon/avito_test_task/internal/service/segment"
+	webhookService "github.com/pollykon/avito_test_task/internal/service/webhook"
+)
+
+func TestFromServiceError(t *testing.T) {
+	ruCtx := WithLanguage(context.Background(), LanguageRU)
+
+	tt := []struct {
+		name string
+
+		ctx context.Context
+		err error
+
+		expectedError  *Error
+		expectedStatus int
+	}{
+		{
+			name:           "segment_not_exist",
+			ctx:            context.Background(),
+			err:            fmt.Errorf("wrapped: %w", segmentService.ErrSegmentNotExist),
+			expectedError:  &Error{Code: CodeSegmentNotFound, Message: "segment doesn't exist"},
+			expectedStatus: http.StatusNotFound,
+		},
+		{
+			name:           "segment_not_exist_ru",
+			ctx:            ruCtx,
+			err:            segmentService.ErrSegmentNotExist,
+			expectedError:  &Error{Code: CodeSegmentNotFound, Message: "сегмент не существует"},
+			expectedStatus: http.StatusNotFound,
+		},
+		{
+			name:           "user_already_in_segment",
+			ctx:            context.Background(),
+			err:            segmentService.ErrUserAlreadyInSegment,
+			expectedError:  &Error{Code: CodeUserAlreadyInSegment, Message: "user already in segment"},
+			expectedStatus: http.StatusConflict,
+		},
+		{
+			name: "invalid_webhook_url",
+			ctx:  context.Background(),
+			err:  webhookService.ErrInvalidURL,
+			expectedError: &Error{
+				Code:    CodeValidationFailed,
+				Message: "url should be absolute https url",
+				Details: []FieldError{{Field: "url", Message: "url should be absolute https url"}},
+			},
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			name: "unknown_scope",
+			ctx:  context.Background(),
+			err:  fmt.Errorf("%w: everything", authService.ErrUnknownScope),
+			expectedError: &Error{
+				Code:    CodeValidationFailed,
+				Message: "scopes contains unknown scope",
+				Details: []FieldError{{Field: "scopes", Message: "scopes contains unknown scope"}},
+			},
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			name:           "api_key_not_exist",
+			ctx:            context.Background(),
+			err:            authService.ErrKeyNotExist,
+			expectedError:  &Error{Code: CodeAPIKeyNotFound, Message: "api key doesn't exist"},
+			expectedStatus: http.StatusNotFound,
+		},
+		{
+			name:          "unknown_error",
+			ctx:           context.Background(),
+			err:           fmt.Errorf("unexpected error"),
+			expectedError: nil,
+		},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			responseErr := FromServiceError(tc.ctx, tc.err)
+			assert.Equal(t, tc.expectedError, responseErr)
+
+			if responseErr != nil {
+				assert.Equal(t, tc.expectedStatus, responseErr.HTTPStatus())
+			}
+		})
+	}
+}
+
+func TestNewValidationError(t *testing.T) {
+	ruCtx := WithLanguage(context.Background(), LanguageRU)
+
+	assert.Equal(t, &Error{
+		Code:    CodeValidationFailed,
+		Message: "limit should be between 0 and 1000",
+		Details: []FieldError{{Field: "limit", Message: "limit should be between 0 and 1000"}},
+	}, NewValidationError(context.Background(), "limit", MsgBetween, 0, 1000))
+
+	assert.Equal(t, &Error{
+		Code:    CodeValidationFailed,
+		Message: "допускается не больше 100 значений userId",
+		Details: []FieldError{{Field: "userId", Message: "допускается не больше 100 значений userId"}},
+	}, NewValidationError(ruCtx, "userId", MsgMaxItems, 100))
+}
+
+// TestCatalog checks that every message is translated to every language with the same arguments
+func TestCatalog(t *testing.T) {
+	for key, text := range catalog[DefaultLanguage] {
+		for language, messages := range catalog {
+			translation, ok := messages[key]
+			assert.True(t, ok, "no %s translation of %s", language, key)
+			assert.Equal(t, countVerbs(text), countVerbs(translation), "arguments of %s in %s", key, language)
+		}
+	}
+
+	for language, messages := range catalog {
+		assert.Len(t, messages, len(catalog[DefaultLanguage]), "messages in %s", language)
+	}
+}
+
+func countVerbs(text string) int {
+	var count int
+	for i := 0; i < len(text)-1; i++ {
+		if text[i] == '%' {
+			count++
+			i++
+		}
+	}
+	return count
+}
@@ -0,0 +1,12 @@
+//go:generate mockery --all --output ./mocks --case underscore --with-expecter
+package get_api_keys
+
+import (
+	"context"
+
+	serviceAuth "github.com/pollykon/avito_test_task/internal/service/auth"
+)
+
+type AuthService interface {
+	GetKeys(ctx context.Context) ([]serviceAuth.Key, error)
+}
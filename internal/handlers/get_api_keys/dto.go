@@ -0,0 +1,25 @@
+package get_api_keys
+
+import (
+	"time"
+
+	"github.com/pollykon/avito_test_task/internal/handlers"
+)
+
+type HandlerRequest struct{}
+
+type HandlerResponse struct {
+	Status int             `json:"status"`
+	Error  *handlers.Error `json:"error,omitempty"`
+	Keys   []APIKey        `json:"keys"`
+}
+
+// APIKey is a key without its value, Prefix helps to recognize it
+type APIKey struct {
+	ID         int64      `json:"id"`
+	Name       string     `json:"name"`
+	Scopes     []string   `json:"scopes"`
+	Prefix     string     `json:"prefix"`
+	InsertTime time.Time  `json:"insertTime"`
+	RevokeTime *time.Time `json:"revokeTime,omitempty"`
+}
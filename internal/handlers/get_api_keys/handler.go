@@ -0,0 +1,72 @@
+package get_api_keys
+
+import (
+	"context"
+	"encoding/json"
+	"log/slog"
+	"net/http"
+
+	"github.com/pollykon/avito_test_task/internal/handlers"
+)
+
+type Handler struct {
+	authService AuthService
+	logger      *slog.Logger
+}
+
+func New(s AuthService, l *slog.Logger) Handler {
+	return Handler{authService: s, logger: l}
+}
+
+func (h Handler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", handlers.ContentTypeJSON)
+
+	if r.Method != http.MethodPost {
+		w.WriteHeader(http.StatusMethodNotAllowed)
+		_ = json.NewEncoder(w).Encode(HandlerResponse{
+			Status: http.StatusMethodNotAllowed,
+			Error:  handlers.ErrMethodNotAllowed(r.Context()),
+		})
+		return
+	}
+
+	var request HandlerRequest
+	err := json.NewDecoder(r.Body).Decode(&request)
+	if err != nil {
+		w.WriteHeader(http.StatusBadRequest)
+		_ = json.NewEncoder(w).Encode(HandlerResponse{
+			Status: http.StatusBadRequest,
+			Error:  handlers.ErrMalformedRequest(r.Context()),
+		})
+		return
+	}
+
+	response := h.handle(r.Context(), request)
+	w.WriteHeader(response.Status)
+	_ = json.NewEncoder(w).Encode(response)
+}
+
+func (h Handler) handle(ctx context.Context, _ HandlerRequest) HandlerResponse {
+	keys, err := h.authService.GetKeys(ctx)
+	if err != nil {
+		h.logger.ErrorContext(ctx, "error while getting api keys", "error", err)
+		return HandlerResponse{
+			Status: http.StatusInternalServerError,
+			Error:  handlers.ErrInternal(ctx),
+		}
+	}
+
+	responseKeys := make([]APIKey, 0, len(keys))
+	for _, key := range keys {
+		responseKeys = append(responseKeys, APIKey{
+			ID:         key.ID,
+			Name:       key.Name,
+			Scopes:     key.Scopes,
+			Prefix:     key.Prefix,
+			InsertTime: key.InsertTime,
+			RevokeTime: key.RevokeTime,
+		})
+	}
+
+	return HandlerResponse{Status: http.StatusOK, Keys: responseKeys}
+}
@@ -0,0 +1,127 @@
+package get_api_keys
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/pollykon/avito_test_task/internal/handlers"
+	"github.com/pollykon/avito_test_task/internal/handlers/get_api_keys/mocks"
+	"github.com/pollykon/avito_test_task/internal/logger"
+	serviceAuth "github.com/pollykon/avito_test_task/internal/service/auth"
+)
+
+func TestAPIKeyHandler_GetAPIKeys(t *testing.T) {
+	insertTime := time.Date(2023, 8, 1, 0, 0, 0, 0, time.UTC)
+
+	tt := []struct {
+		name string
+
+		requestMethod string
+		sentBody      string
+
+		buildAuthServiceMock func(service *mocks.AuthService)
+
+		expectedStatusCode int
+		expectedResponse   *HandlerResponse
+	}{
+		{
+			name: "success",
+
+			requestMethod: http.MethodPost,
+			sentBody:      `{}`,
+
+			buildAuthServiceMock: func(service *mocks.AuthService) {
+				service.EXPECT().GetKeys(context.Background()).Return([]serviceAuth.Key{
+					{ID: 3, Name: "ci", Scopes: []string{"memberships:read"}, Prefix: "sk_abcdefg", InsertTime: insertTime},
+				}, nil)
+			},
+
+			expectedStatusCode: http.StatusOK,
+			expectedResponse: &HandlerResponse{
+				Status: http.StatusOK,
+				Keys: []APIKey{
+					{ID: 3, Name: "ci", Scopes: []string{"memberships:read"}, Prefix: "sk_abcdefg", InsertTime: insertTime},
+				},
+			},
+		},
+		{
+			name: "wrong_method",
+
+			requestMethod: http.MethodGet,
+			sentBody:      `{}`,
+
+			buildAuthServiceMock: nil,
+
+			expectedStatusCode: http.StatusMethodNotAllowed,
+			expectedResponse:   nil,
+		},
+		{
+			name: "decode_error",
+
+			requestMethod: http.MethodPost,
+			sentBody:      `[`,
+
+			buildAuthServiceMock: nil,
+
+			expectedStatusCode: http.StatusBadRequest,
+			expectedResponse:   nil,
+		},
+		{
+			name: "service_error_unexpected_error",
+
+			requestMethod: http.MethodPost,
+			sentBody:      `{}`,
+
+			buildAuthServiceMock: func(service *mocks.AuthService) {
+				service.EXPECT().GetKeys(context.Background()).Return(nil, fmt.Errorf("error from service"))
+			},
+
+			expectedStatusCode: http.StatusInternalServerError,
+			expectedResponse: &HandlerResponse{
+				Status: http.StatusInternalServerError,
+				Error:  &handlers.Error{Code: "INTERNAL", Message: "unexpected error"},
+			},
+		},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			request, err := http.NewRequest(tc.requestMethod, "", strings.NewReader(tc.sentBody))
+			if err != nil {
+				t.Fatalf("error while sending request: %s", err)
+			}
+
+			w := httptest.NewRecorder()
+			authServiceMock := mocks.NewAuthService(t)
+
+			if tc.buildAuthServiceMock != nil {
+				tc.buildAuthServiceMock(authServiceMock)
+			}
+
+			handler := New(authServiceMock, slog.New(logger.NewNoopHandler()))
+			handler.ServeHTTP(w, request)
+
+			responseResult := w.Result()
+
+			assert.Equal(t, handlers.ContentTypeJSON, responseResult.Header.Get("Content-Type"))
+			assert.Equal(t, tc.expectedStatusCode, responseResult.StatusCode)
+
+			if tc.expectedResponse != nil {
+				var response HandlerResponse
+				err = json.NewDecoder(responseResult.Body).Decode(&response)
+				assert.NoError(t, err)
+
+				assert.Equal(t, *tc.expectedResponse, response)
+			}
+		})
+	}
+}
@@ -0,0 +1,92 @@
+// Code generated by mockery v2.33.0. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+
+	auth "github.com/pollykon/avito_test_task/internal/service/auth"
+
+	mock "github.com/stretchr/testify/mock"
+)
+
+// AuthService is an autogenerated mock type for the AuthService type
+type AuthService struct {
+	mock.Mock
+}
+
+type AuthService_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *AuthService) EXPECT() *AuthService_Expecter {
+	return &AuthService_Expecter{mock: &_m.Mock}
+}
+
+// GetKeys provides a mock function with given fields: ctx
+func (_m *AuthService) GetKeys(ctx context.Context) ([]auth.Key, error) {
+	ret := _m.Called(ctx)
+
+	var r0 []auth.Key
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context) ([]auth.Key, error)); ok {
+		return rf(ctx)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context) []auth.Key); ok {
+		r0 = rf(ctx)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]auth.Key)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context) error); ok {
+		r1 = rf(ctx)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// AuthService_GetKeys_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetKeys'
+type AuthService_GetKeys_Call struct {
+	*mock.Call
+}
+
+// GetKeys is a helper method to define mock.On call
+//   - ctx context.Context
+func (_e *AuthService_Expecter) GetKeys(ctx interface{}) *AuthService_GetKeys_Call {
+	return &AuthService_GetKeys_Call{Call: _e.mock.On("GetKeys", ctx)}
+}
+
+func (_c *AuthService_GetKeys_Call) Run(run func(ctx context.Context)) *AuthService_GetKeys_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context))
+	})
+	return _c
+}
+
+func (_c *AuthService_GetKeys_Call) Return(_a0 []auth.Key, _a1 error) *AuthService_GetKeys_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *AuthService_GetKeys_Call) RunAndReturn(run func(context.Context) ([]auth.Key, error)) *AuthService_GetKeys_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// NewAuthService creates a new instance of AuthService. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewAuthService(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *AuthService {
+	mock := &AuthService{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
@@ -10,3 +10,7 @@ import (
 type LogService interface {
 	GenerateCSV(ctx context.Context, request serviceLog.GetCSVRequest) (string, error)
 }
+
+type URLGenerator interface {
+	GenerateURL(host string, fileName string) string
+}
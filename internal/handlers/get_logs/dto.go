@@ -1,18 +1,18 @@
 package get_logs
 
+import "github.com/pollykon/avito_test_task/internal/handlers"
+
 type HandlerRequest struct {
 	UserID    int64   `json:"userId"`
 	From      string  `json:"from"`
 	To        string  `json:"to"`
 	Separator *string `json:"separator"`
+	// Compression is "gzip" or "zip", file isn't compressed if it's omitted
+	Compression *string `json:"compression"`
 }
 
 type HandlerResponse struct {
-	Status int                   `json:"status"`
-	Error  *HandlerResponseError `json:"error,omitempty"`
-	URL    string                `json:"url,omitempty"`
-}
-
-type HandlerResponseError struct {
-	Message string `json:"message"`
+	Status int             `json:"status"`
+	Error  *handlers.Error `json:"error,omitempty"`
+	URL    string          `json:"url,omitempty"`
 }
@@ -12,22 +12,21 @@ import (
 )
 
 type Handler struct {
-	logService      LogService
-	staticURIPrefix string
-	logger          *slog.Logger
+	logService   LogService
+	urlGenerator URLGenerator
+	logger       *slog.Logger
 }
 
-func New(logService LogService, staticURIPrefix string, logger *slog.Logger) Handler {
+func New(logService LogService, urlGenerator URLGenerator, logger *slog.Logger) Handler {
 	return Handler{
-		logService:      logService,
-		staticURIPrefix: staticURIPrefix,
-		logger:          logger,
+		logService:   logService,
+		urlGenerator: urlGenerator,
+		logger:       logger,
 	}
 }
 
 const (
 	defaultSeparator = ","
-	schema           = "http://"
 )
 
 func (h Handler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
@@ -37,9 +36,7 @@ func (h Handler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
 		w.WriteHeader(http.StatusMethodNotAllowed)
 		_ = json.NewEncoder(w).Encode(HandlerResponse{
 			Status: http.StatusMethodNotAllowed,
-			Error: &HandlerResponseError{
-				Message: handlers.ErrMsgMethodNotAllowed,
-			},
+			Error:  handlers.ErrMethodNotAllowed(r.Context()),
 		})
 		return
 	}
@@ -54,9 +51,7 @@ func (h Handler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
 		w.WriteHeader(http.StatusBadRequest)
 		_ = json.NewEncoder(w).Encode(HandlerResponse{
 			Status: http.StatusBadRequest,
-			Error: &HandlerResponseError{
-				Message: handlers.ErrMsgBadRequest,
-			},
+			Error:  handlers.ErrMalformedRequest(r.Context()),
 		})
 		return
 	}
@@ -71,32 +66,34 @@ func (h Handler) handle(ctx context.Context, request HandlerRequest, host string
 	if request.UserID <= 0 {
 		return HandlerResponse{
 			Status: http.StatusBadRequest,
-			Error: &HandlerResponseError{
-				Message: "userId should be more than 0",
-			},
-			URL: "",
+			Error:  handlers.NewValidationError(ctx, "userId", handlers.MsgPositive),
+			URL:    "",
 		}
 	}
 
-	parsedFrom, errFrom := time.Parse("2006-01", request.From)
-	parsedTo, errTo := time.Parse("2006-01", request.To)
-	if errFrom != nil || errTo != nil {
+	parsedFrom, err := time.Parse("2006-01", request.From)
+	if err != nil {
 		return HandlerResponse{
 			Status: http.StatusBadRequest,
-			Error: &HandlerResponseError{
-				Message: "time must be in year-month format",
-			},
-			URL: "",
+			Error:  handlers.NewValidationError(ctx, "from", handlers.MsgMonth),
+			URL:    "",
+		}
+	}
+
+	parsedTo, err := time.Parse("2006-01", request.To)
+	if err != nil {
+		return HandlerResponse{
+			Status: http.StatusBadRequest,
+			Error:  handlers.NewValidationError(ctx, "to", handlers.MsgMonth),
+			URL:    "",
 		}
 	}
 
 	if parsedTo.Equal(parsedFrom) || parsedFrom.After(parsedTo) {
 		return HandlerResponse{
 			Status: http.StatusBadRequest,
-			Error: &HandlerResponseError{
-				Message: "from must be less than to",
-			},
-			URL: "",
+			Error:  handlers.NewValidationError(ctx, "from", handlers.MsgFromBeforeTo),
+			URL:    "",
 		}
 	}
 
@@ -106,11 +103,25 @@ func (h Handler) handle(ctx context.Context, request HandlerRequest, host string
 		requestSeparator = *request.Separator
 	}
 
+	requestCompression := logService.CompressionNone
+
+	if request.Compression != nil {
+		if *request.Compression != logService.CompressionGzip && *request.Compression != logService.CompressionZip {
+			return HandlerResponse{
+				Status: http.StatusBadRequest,
+				Error:  handlers.NewValidationError(ctx, "compression", handlers.MsgOneOf, "gzip, zip"),
+				URL:    "",
+			}
+		}
+		requestCompression = *request.Compression
+	}
+
 	logServiceRequest := logService.GetCSVRequest{
-		UserID:    request.UserID,
-		From:      parsedFrom,
-		To:        parsedTo,
-		Separator: requestSeparator,
+		UserID:      request.UserID,
+		From:        parsedFrom,
+		To:          parsedTo,
+		Separator:   requestSeparator,
+		Compression: requestCompression,
 	}
 
 	URI, err := h.logService.GenerateCSV(ctx, logServiceRequest)
@@ -118,15 +129,13 @@ func (h Handler) handle(ctx context.Context, request HandlerRequest, host string
 		h.logger.ErrorContext(ctx, "error while getting logs", "error", err, "request", request)
 		return HandlerResponse{
 			Status: http.StatusInternalServerError,
-			Error: &HandlerResponseError{
-				Message: handlers.ErrMsgInternal,
-			},
-			URL: "",
+			Error:  handlers.ErrInternal(ctx),
+			URL:    "",
 		}
 	}
 	return HandlerResponse{
 		Status: http.StatusOK,
 		Error:  nil,
-		URL:    schema + host + h.staticURIPrefix + "/" + URI,
+		URL:    h.urlGenerator.GenerateURL(host, URI),
 	}
 }
@@ -19,10 +19,6 @@ import (
 	logService "github.com/pollykon/avito_test_task/internal/service/log"
 )
 
-const (
-	staticURIPrefix = "/static"
-)
-
 func TestLogHandler_GetLogs_Success(t *testing.T) {
 	sentUserID := 13
 	sentFrom := "2023-08"
@@ -53,14 +49,19 @@ func TestLogHandler_GetLogs_Success(t *testing.T) {
 		t.Fatalf("error while sending request: %s", err)
 	}
 
-	expectedURI := "http://localhost:1011/static/ef8cde3a-89a1-4fd5-81e2-34dac98a4740.csv"
+	expectedURI := "http://localhost:1011/static/ef8cde3a-89a1-4fd5-81e2-34dac98a4740.csv" +
+		"?expires=1693526400&signature=abcdef"
 
 	w := httptest.NewRecorder()
 	logServiceMock := mocks.NewLogService(t)
 	logServiceMock.EXPECT().GenerateCSV(context.Background(), sentRequest).
 		Return("ef8cde3a-89a1-4fd5-81e2-34dac98a4740.csv", nil)
 
-	handler := New(logServiceMock, staticURIPrefix, slog.New(logger.NewNoopHandler()))
+	urlGeneratorMock := mocks.NewURLGenerator(t)
+	urlGeneratorMock.EXPECT().GenerateURL("localhost:1011", "ef8cde3a-89a1-4fd5-81e2-34dac98a4740.csv").
+		Return(expectedURI)
+
+	handler := New(logServiceMock, urlGeneratorMock, slog.New(logger.NewNoopHandler()))
 	handler.ServeHTTP(w, request)
 
 	responseResult := w.Result()
@@ -97,11 +98,12 @@ func TestLogHandler_GetLogs_Error(t *testing.T) {
 	tt := []struct {
 		name string
 
-		sentMethod    string
-		sentUserID    interface{}
-		sentFrom      interface{}
-		sentTo        string
-		sentSeparator string
+		sentMethod      string
+		sentUserID      interface{}
+		sentFrom        interface{}
+		sentTo          string
+		sentSeparator   string
+		sentCompression interface{}
 
 		buildLogServiceMock func(mock *mocks.LogService)
 
@@ -178,6 +180,30 @@ func TestLogHandler_GetLogs_Error(t *testing.T) {
 			expectedStatusCode: http.StatusBadRequest,
 			expectedResponse:   nil,
 		},
+		{
+			name: "wrong_compression",
+
+			sentMethod:      http.MethodPost,
+			sentUserID:      sentUserID,
+			sentFrom:        &sentFrom,
+			sentTo:          sentTo,
+			sentSeparator:   separator,
+			sentCompression: "rar",
+
+			buildLogServiceMock: nil,
+
+			expectedStatusCode: http.StatusBadRequest,
+			expectedResponse: &HandlerResponse{
+				Status: http.StatusBadRequest,
+				Error: &handlers.Error{
+					Code:    "VALIDATION_FAILED",
+					Message: "compression must be one of: gzip, zip",
+					Details: []handlers.FieldError{
+						{Field: "compression", Message: "compression must be one of: gzip, zip"},
+					},
+				},
+			},
+		},
 		{
 			name: "service_error_unexpected_error",
 
@@ -200,10 +226,11 @@ func TestLogHandler_GetLogs_Error(t *testing.T) {
 	for _, tc := range tt {
 		t.Run(tc.name, func(t *testing.T) {
 			jsonBodyRequest, _ := json.Marshal(map[string]interface{}{
-				"userId":    tc.sentUserID,
-				"from":      tc.sentFrom,
-				"to":        tc.sentTo,
-				"separator": tc.sentSeparator,
+				"userId":      tc.sentUserID,
+				"from":        tc.sentFrom,
+				"to":          tc.sentTo,
+				"separator":   tc.sentSeparator,
+				"compression": tc.sentCompression,
 			})
 			request, err := http.NewRequest(
 				tc.sentMethod,
@@ -220,7 +247,7 @@ func TestLogHandler_GetLogs_Error(t *testing.T) {
 				tc.buildLogServiceMock(logServiceMock)
 			}
 
-			handler := New(logServiceMock, staticURIPrefix, slog.New(logger.NewNoopHandler()))
+			handler := New(logServiceMock, mocks.NewURLGenerator(t), slog.New(logger.NewNoopHandler()))
 			handler.ServeHTTP(w, request)
 
 			responseResult := w.Result()
@@ -233,7 +260,7 @@ func TestLogHandler_GetLogs_Error(t *testing.T) {
 				err = json.NewDecoder(responseResult.Body).Decode(&response)
 				assert.NoError(t, err)
 
-				assert.Equal(t, tc.expectedResponse, response)
+				assert.Equal(t, *tc.expectedResponse, response)
 			}
 		})
 	}
@@ -1,15 +1,13 @@
 package get_user_active_segments
 
+import "github.com/pollykon/avito_test_task/internal/handlers"
+
 type HandlerRequest struct {
 	UserID int64 `json:"userId"`
 }
 
 type HandlerResponse struct {
-	Status   int                   `json:"status"`
-	Error    *HandlerResponseError `json:"error,omitempty"`
-	Segments []string              `json:"segments"`
-}
-
-type HandlerResponseError struct {
-	Message string `json:"message"`
+	Status   int             `json:"status"`
+	Error    *handlers.Error `json:"error,omitempty"`
+	Segments []string        `json:"segments"`
 }
@@ -25,9 +25,7 @@ func (h Handler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
 		w.WriteHeader(http.StatusMethodNotAllowed)
 		_ = json.NewEncoder(w).Encode(HandlerResponse{
 			Status: http.StatusMethodNotAllowed,
-			Error: &HandlerResponseError{
-				Message: handlers.ErrMsgMethodNotAllowed,
-			},
+			Error:  handlers.ErrMethodNotAllowed(r.Context()),
 		})
 		return
 	}
@@ -38,9 +36,7 @@ func (h Handler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
 		w.WriteHeader(http.StatusBadRequest)
 		_ = json.NewEncoder(w).Encode(HandlerResponse{
 			Status: http.StatusBadRequest,
-			Error: &HandlerResponseError{
-				Message: handlers.ErrMsgBadRequest,
-			},
+			Error:  handlers.ErrMalformedRequest(r.Context()),
 		})
 		return
 	}
@@ -56,9 +52,7 @@ func (h Handler) handle(ctx context.Context, request HandlerRequest) HandlerResp
 	if request.UserID <= 0 {
 		return HandlerResponse{
 			Status: http.StatusBadRequest,
-			Error: &HandlerResponseError{
-				Message: "userId should be more than 0",
-			},
+			Error:  handlers.NewValidationError(ctx, "userId", handlers.MsgPositive),
 		}
 	}
 
@@ -67,9 +61,7 @@ func (h Handler) handle(ctx context.Context, request HandlerRequest) HandlerResp
 		h.logger.ErrorContext(ctx, "error while getting active segment", "error", err, "request", request)
 		return HandlerResponse{
 			Status: http.StatusInternalServerError,
-			Error: &HandlerResponseError{
-				Message: handlers.ErrMsgInternal,
-			},
+			Error:  handlers.ErrInternal(ctx),
 		}
 	}
 
@@ -102,8 +102,10 @@ func TestSegmentHandler_GetUserActiveSegments_Error(t *testing.T) {
 			expectedStatusCode: http.StatusBadRequest,
 			expectedResponse: &HandlerResponse{
 				Status: http.StatusBadRequest,
-				Error: &HandlerResponseError{
+				Error: &handlers.Error{
+					Code:    "VALIDATION_FAILED",
 					Message: "userId should be more than 0",
+					Details: []handlers.FieldError{{Field: "userId", Message: "userId should be more than 0"}},
 				},
 			},
 		},
@@ -124,9 +126,7 @@ func TestSegmentHandler_GetUserActiveSegments_Error(t *testing.T) {
 			expectedStatusCode: http.StatusInternalServerError,
 			expectedResponse: &HandlerResponse{
 				Status: http.StatusInternalServerError,
-				Error: &HandlerResponseError{
-					Message: handlers.ErrMsgInternal,
-				},
+				Error:  &handlers.Error{Code: "INTERNAL", Message: "unexpected error"},
 			},
 		},
 	}
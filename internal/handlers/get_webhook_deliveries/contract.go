@@ -0,0 +1,12 @@
+//go:generate mockery --all --output ./mocks --case underscore --with-expecter
+package get_webhook_deliveries
+
+import (
+	"context"
+
+	serviceWebhook "github.com/pollykon/avito_test_task/internal/service/webhook"
+)
+
+type WebhookService interface {
+	GetDeliveries(ctx context.Context, subscriptionID int64, limit int64, offset int64) ([]serviceWebhook.Delivery, error)
+}
@@ -0,0 +1,33 @@
+package get_webhook_deliveries
+
+import (
+	"encoding/json"
+	"github.com/pollykon/avito_test_task/internal/handlers"
+	"time"
+)
+
+type HandlerRequest struct {
+	WebhookID int64 `json:"webhookId"`
+	Limit     int64 `json:"limit"`
+	Offset    int64 `json:"offset"`
+}
+
+type HandlerResponse struct {
+	Status     int             `json:"status"`
+	Error      *handlers.Error `json:"error,omitempty"`
+	Deliveries []Delivery      `json:"deliveries"`
+}
+
+type Delivery struct {
+	ID             int64           `json:"id"`
+	EventID        int64           `json:"eventId"`
+	EventType      string          `json:"eventType"`
+	Payload        json.RawMessage `json:"payload"`
+	EventTime      time.Time       `json:"eventTime"`
+	Status         string          `json:"status"`
+	Attempts       int64           `json:"attempts"`
+	LastStatusCode *int64          `json:"lastStatusCode,omitempty"`
+	LastError      *string         `json:"lastError,omitempty"`
+	InsertTime     time.Time       `json:"insertTime"`
+	DeliveredTime  *time.Time      `json:"deliveredTime,omitempty"`
+}
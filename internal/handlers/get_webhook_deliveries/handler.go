@@ -0,0 +1,108 @@
+package get_webhook_deliveries
+
+import (
+	"context"
+	"encoding/json"
+	"log/slog"
+	"net/http"
+
+	"github.com/pollykon/avito_test_task/internal/handlers"
+)
+
+const (
+	defaultLimit = 100
+	maxLimit     = 1000
+)
+
+type Handler struct {
+	webhookService WebhookService
+	logger         *slog.Logger
+}
+
+func New(s WebhookService, l *slog.Logger) Handler {
+	return Handler{webhookService: s, logger: l}
+}
+
+func (h Handler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", handlers.ContentTypeJSON)
+
+	if r.Method != http.MethodPost {
+		w.WriteHeader(http.StatusMethodNotAllowed)
+		_ = json.NewEncoder(w).Encode(HandlerResponse{
+			Status: http.StatusMethodNotAllowed,
+			Error:  handlers.ErrMethodNotAllowed(r.Context()),
+		})
+		return
+	}
+
+	var request HandlerRequest
+	err := json.NewDecoder(r.Body).Decode(&request)
+	if err != nil {
+		w.WriteHeader(http.StatusBadRequest)
+		_ = json.NewEncoder(w).Encode(HandlerResponse{
+			Status: http.StatusBadRequest,
+			Error:  handlers.ErrMalformedRequest(r.Context()),
+		})
+		return
+	}
+
+	response := h.handle(r.Context(), request)
+	w.WriteHeader(response.Status)
+	_ = json.NewEncoder(w).Encode(response)
+}
+
+func (h Handler) handle(ctx context.Context, request HandlerRequest) HandlerResponse {
+	if request.WebhookID <= 0 {
+		return HandlerResponse{
+			Status: http.StatusBadRequest,
+			Error:  handlers.NewValidationError(ctx, "webhookId", handlers.MsgPositive),
+		}
+	}
+
+	if request.Limit < 0 || request.Limit > maxLimit {
+		return HandlerResponse{
+			Status: http.StatusBadRequest,
+			Error:  handlers.NewValidationError(ctx, "limit", handlers.MsgBetween, 0, maxLimit),
+		}
+	}
+
+	if request.Offset < 0 {
+		return HandlerResponse{
+			Status: http.StatusBadRequest,
+			Error:  handlers.NewValidationError(ctx, "offset", handlers.MsgNonNegative),
+		}
+	}
+
+	limit := request.Limit
+	if limit == 0 {
+		limit = defaultLimit
+	}
+
+	deliveries, err := h.webhookService.GetDeliveries(ctx, request.WebhookID, limit, request.Offset)
+	if err != nil {
+		h.logger.ErrorContext(ctx, "error while getting webhook deliveries", "error", err, "request", request)
+		return HandlerResponse{
+			Status: http.StatusInternalServerError,
+			Error:  handlers.ErrInternal(ctx),
+		}
+	}
+
+	result := make([]Delivery, 0, len(deliveries))
+	for _, delivery := range deliveries {
+		result = append(result, Delivery{
+			ID:             delivery.ID,
+			EventID:        delivery.EventID,
+			EventType:      delivery.EventType,
+			Payload:        delivery.Payload,
+			EventTime:      delivery.EventTime,
+			Status:         delivery.Status,
+			Attempts:       delivery.Attempts,
+			LastStatusCode: delivery.LastStatusCode,
+			LastError:      delivery.LastError,
+			InsertTime:     delivery.InsertTime,
+			DeliveredTime:  delivery.DeliveredTime,
+		})
+	}
+
+	return HandlerResponse{Status: http.StatusOK, Deliveries: result}
+}
@@ -0,0 +1,208 @@
+package get_webhook_deliveries
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/pollykon/avito_test_task/internal/handlers"
+	"github.com/pollykon/avito_test_task/internal/handlers/get_webhook_deliveries/mocks"
+	"github.com/pollykon/avito_test_task/internal/logger"
+	serviceWebhook "github.com/pollykon/avito_test_task/internal/service/webhook"
+)
+
+func TestWebhookHandler_GetWebhookDeliveries(t *testing.T) {
+	eventTime := time.Date(2023, 8, 1, 0, 0, 0, 0, time.UTC)
+	lastStatusCode := int64(503)
+	lastError := "unexpected status 503 from webhook"
+
+	tt := []struct {
+		name string
+
+		requestMethod string
+		sentRequest   map[string]interface{}
+
+		buildWebhookServiceMock func(service *mocks.WebhookService)
+
+		expectedStatusCode int
+		expectedResponse   *HandlerResponse
+	}{
+		{
+			name: "success",
+
+			requestMethod: http.MethodPost,
+			sentRequest:   map[string]interface{}{"webhookId": 7},
+
+			buildWebhookServiceMock: func(service *mocks.WebhookService) {
+				service.EXPECT().GetDeliveries(context.Background(), int64(7), int64(defaultLimit), int64(0)).
+					Return([]serviceWebhook.Delivery{
+						{
+							ID:             1,
+							EventID:        12,
+							EventType:      "user_segment.added",
+							Payload:        json.RawMessage(`{"segmentId":"AVITO"}`),
+							EventTime:      eventTime,
+							Status:         "pending",
+							Attempts:       1,
+							LastStatusCode: &lastStatusCode,
+							LastError:      &lastError,
+							InsertTime:     eventTime,
+						},
+					}, nil)
+			},
+
+			expectedStatusCode: http.StatusOK,
+			expectedResponse: &HandlerResponse{
+				Status: http.StatusOK,
+				Deliveries: []Delivery{
+					{
+						ID:             1,
+						EventID:        12,
+						EventType:      "user_segment.added",
+						Payload:        json.RawMessage(`{"segmentId":"AVITO"}`),
+						EventTime:      eventTime,
+						Status:         "pending",
+						Attempts:       1,
+						LastStatusCode: &lastStatusCode,
+						LastError:      &lastError,
+						InsertTime:     eventTime,
+					},
+				},
+			},
+		},
+		{
+			name: "wrong_method",
+
+			requestMethod: http.MethodGet,
+			sentRequest:   map[string]interface{}{"webhookId": 7},
+
+			buildWebhookServiceMock: nil,
+
+			expectedStatusCode: http.StatusMethodNotAllowed,
+			expectedResponse:   nil,
+		},
+		{
+			name: "decode_error",
+
+			requestMethod: http.MethodPost,
+			sentRequest:   map[string]interface{}{"webhookId": "7"},
+
+			buildWebhookServiceMock: nil,
+
+			expectedStatusCode: http.StatusBadRequest,
+			expectedResponse:   nil,
+		},
+		{
+			name: "wrong_webhookId",
+
+			requestMethod: http.MethodPost,
+			sentRequest:   map[string]interface{}{"webhookId": 0},
+
+			buildWebhookServiceMock: nil,
+
+			expectedStatusCode: http.StatusBadRequest,
+			expectedResponse: &HandlerResponse{
+				Status: http.StatusBadRequest,
+				Error: &handlers.Error{
+					Code:    "VALIDATION_FAILED",
+					Message: "webhookId should be more than 0",
+					Details: []handlers.FieldError{{Field: "webhookId", Message: "webhookId should be more than 0"}},
+				},
+			},
+		},
+		{
+			name: "wrong_limit",
+
+			requestMethod: http.MethodPost,
+			sentRequest:   map[string]interface{}{"webhookId": 7, "limit": maxLimit + 1},
+
+			buildWebhookServiceMock: nil,
+
+			expectedStatusCode: http.StatusBadRequest,
+			expectedResponse: &HandlerResponse{
+				Status: http.StatusBadRequest,
+				Error: &handlers.Error{
+					Code:    "VALIDATION_FAILED",
+					Message: "limit should be between 0 and 1000",
+					Details: []handlers.FieldError{{Field: "limit", Message: "limit should be between 0 and 1000"}},
+				},
+			},
+		},
+		{
+			name: "wrong_offset",
+
+			requestMethod: http.MethodPost,
+			sentRequest:   map[string]interface{}{"webhookId": 7, "offset": -1},
+
+			buildWebhookServiceMock: nil,
+
+			expectedStatusCode: http.StatusBadRequest,
+			expectedResponse: &HandlerResponse{
+				Status: http.StatusBadRequest,
+				Error: &handlers.Error{
+					Code:    "VALIDATION_FAILED",
+					Message: "offset shouldn't be negative",
+					Details: []handlers.FieldError{{Field: "offset", Message: "offset shouldn't be negative"}},
+				},
+			},
+		},
+		{
+			name: "service_error_unexpected_error",
+
+			requestMethod: http.MethodPost,
+			sentRequest:   map[string]interface{}{"webhookId": 7, "limit": 10, "offset": 20},
+
+			buildWebhookServiceMock: func(service *mocks.WebhookService) {
+				service.EXPECT().GetDeliveries(context.Background(), int64(7), int64(10), int64(20)).
+					Return(nil, fmt.Errorf("error from service"))
+			},
+
+			expectedStatusCode: http.StatusInternalServerError,
+			expectedResponse: &HandlerResponse{
+				Status: http.StatusInternalServerError,
+				Error:  &handlers.Error{Code: "INTERNAL", Message: "unexpected error"},
+			},
+		},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			jsonBodyRequest, _ := json.Marshal(tc.sentRequest)
+			request, err := http.NewRequest(tc.requestMethod, "", strings.NewReader(string(jsonBodyRequest)))
+			if err != nil {
+				t.Fatalf("error while sending request: %s", err)
+			}
+
+			w := httptest.NewRecorder()
+			webhookServiceMock := mocks.NewWebhookService(t)
+
+			if tc.buildWebhookServiceMock != nil {
+				tc.buildWebhookServiceMock(webhookServiceMock)
+			}
+
+			handler := New(webhookServiceMock, slog.New(logger.NewNoopHandler()))
+			handler.ServeHTTP(w, request)
+
+			responseResult := w.Result()
+
+			assert.Equal(t, handlers.ContentTypeJSON, responseResult.Header.Get("Content-Type"))
+			assert.Equal(t, tc.expectedStatusCode, responseResult.StatusCode)
+
+			if tc.expectedResponse != nil {
+				var response HandlerResponse
+				err = json.NewDecoder(responseResult.Body).Decode(&response)
+				assert.NoError(t, err)
+
+				assert.Equal(t, *tc.expectedResponse, response)
+			}
+		})
+	}
+}
@@ -0,0 +1,95 @@
+// Code generated by mockery v2.33.0. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+
+	mock "github.com/stretchr/testify/mock"
+
+	webhook "github.com/pollykon/avito_test_task/internal/service/webhook"
+)
+
+// WebhookService is an autogenerated mock type for the WebhookService type
+type WebhookService struct {
+	mock.Mock
+}
+
+type WebhookService_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *WebhookService) EXPECT() *WebhookService_Expecter {
+	return &WebhookService_Expecter{mock: &_m.Mock}
+}
+
+// GetDeliveries provides a mock function with given fields: ctx, subscriptionID, limit, offset
+func (_m *WebhookService) GetDeliveries(ctx context.Context, subscriptionID int64, limit int64, offset int64) ([]webhook.Delivery, error) {
+	ret := _m.Called(ctx, subscriptionID, limit, offset)
+
+	var r0 []webhook.Delivery
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, int64, int64, int64) ([]webhook.Delivery, error)); ok {
+		return rf(ctx, subscriptionID, limit, offset)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, int64, int64, int64) []webhook.Delivery); ok {
+		r0 = rf(ctx, subscriptionID, limit, offset)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]webhook.Delivery)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, int64, int64, int64) error); ok {
+		r1 = rf(ctx, subscriptionID, limit, offset)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// WebhookService_GetDeliveries_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetDeliveries'
+type WebhookService_GetDeliveries_Call struct {
+	*mock.Call
+}
+
+// GetDeliveries is a helper method to define mock.On call
+//   - ctx context.Context
+//   - subscriptionID int64
+//   - limit int64
+//   - offset int64
+func (_e *WebhookService_Expecter) GetDeliveries(ctx interface{}, subscriptionID interface{}, limit interface{}, offset interface{}) *WebhookService_GetDeliveries_Call {
+	return &WebhookService_GetDeliveries_Call{Call: _e.mock.On("GetDeliveries", ctx, subscriptionID, limit, offset)}
+}
+
+func (_c *WebhookService_GetDeliveries_Call) Run(run func(ctx context.Context, subscriptionID int64, limit int64, offset int64)) *WebhookService_GetDeliveries_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(int64), args[2].(int64), args[3].(int64))
+	})
+	return _c
+}
+
+func (_c *WebhookService_GetDeliveries_Call) Return(_a0 []webhook.Delivery, _a1 error) *WebhookService_GetDeliveries_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *WebhookService_GetDeliveries_Call) RunAndReturn(run func(context.Context, int64, int64, int64) ([]webhook.Delivery, error)) *WebhookService_GetDeliveries_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// NewWebhookService creates a new instance of WebhookService. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewWebhookService(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *WebhookService {
+	mock := &WebhookService{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
@@ -0,0 +1,12 @@
+//go:generate mockery --all --output ./mocks --case underscore --with-expecter
+package get_webhooks
+
+import (
+	"context"
+
+	serviceWebhook "github.com/pollykon/avito_test_task/internal/service/webhook"
+)
+
+type WebhookService interface {
+	GetSubscriptions(ctx context.Context) ([]serviceWebhook.Subscription, error)
+}
@@ -0,0 +1,24 @@
+package get_webhooks
+
+import (
+	"time"
+
+	"github.com/pollykon/avito_test_task/internal/handlers"
+)
+
+type HandlerRequest struct{}
+
+type HandlerResponse struct {
+	Status   int             `json:"status"`
+	Error    *handlers.Error `json:"error,omitempty"`
+	Webhooks []Webhook       `json:"webhooks"`
+}
+
+// Webhook is a subscription without its secret
+type Webhook struct {
+	ID         int64     `json:"id"`
+	URL        string    `json:"url"`
+	EventTypes []string  `json:"eventTypes"`
+	SegmentIDs []string  `json:"segmentIds"`
+	InsertTime time.Time `json:"insertTime"`
+}
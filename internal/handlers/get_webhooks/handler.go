@@ -0,0 +1,71 @@
+package get_webhooks
+
+import (
+	"context"
+	"encoding/json"
+	"log/slog"
+	"net/http"
+
+	"github.com/pollykon/avito_test_task/internal/handlers"
+)
+
+type Handler struct {
+	webhookService WebhookService
+	logger         *slog.Logger
+}
+
+func New(s WebhookService, l *slog.Logger) Handler {
+	return Handler{webhookService: s, logger: l}
+}
+
+func (h Handler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", handlers.ContentTypeJSON)
+
+	if r.Method != http.MethodPost {
+		w.WriteHeader(http.StatusMethodNotAllowed)
+		_ = json.NewEncoder(w).Encode(HandlerResponse{
+			Status: http.StatusMethodNotAllowed,
+			Error:  handlers.ErrMethodNotAllowed(r.Context()),
+		})
+		return
+	}
+
+	var request HandlerRequest
+	err := json.NewDecoder(r.Body).Decode(&request)
+	if err != nil {
+		w.WriteHeader(http.StatusBadRequest)
+		_ = json.NewEncoder(w).Encode(HandlerResponse{
+			Status: http.StatusBadRequest,
+			Error:  handlers.ErrMalformedRequest(r.Context()),
+		})
+		return
+	}
+
+	response := h.handle(r.Context(), request)
+	w.WriteHeader(response.Status)
+	_ = json.NewEncoder(w).Encode(response)
+}
+
+func (h Handler) handle(ctx context.Context, _ HandlerRequest) HandlerResponse {
+	subscriptions, err := h.webhookService.GetSubscriptions(ctx)
+	if err != nil {
+		h.logger.ErrorContext(ctx, "error while getting webhooks", "error", err)
+		return HandlerResponse{
+			Status: http.StatusInternalServerError,
+			Error:  handlers.ErrInternal(ctx),
+		}
+	}
+
+	webhooks := make([]Webhook, 0, len(subscriptions))
+	for _, subscription := range subscriptions {
+		webhooks = append(webhooks, Webhook{
+			ID:         subscription.ID,
+			URL:        subscription.URL,
+			EventTypes: subscription.EventTypes,
+			SegmentIDs: subscription.SegmentIDs,
+			InsertTime: subscription.InsertTime,
+		})
+	}
+
+	return HandlerResponse{Status: http.StatusOK, Webhooks: webhooks}
+}
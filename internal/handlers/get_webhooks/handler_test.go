@@ -0,0 +1,140 @@
+package get_webhooks
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/pollykon/avito_test_task/internal/handlers"
+	"github.com/pollykon/avito_test_task/internal/handlers/get_webhooks/mocks"
+	"github.com/pollykon/avito_test_task/internal/logger"
+	serviceWebhook "github.com/pollykon/avito_test_task/internal/service/webhook"
+)
+
+func TestWebhookHandler_GetWebhooks(t *testing.T) {
+	insertTime := time.Date(2023, 8, 1, 0, 0, 0, 0, time.UTC)
+
+	tt := []struct {
+		name string
+
+		requestMethod string
+		sentBody      string
+
+		buildWebhookServiceMock func(service *mocks.WebhookService)
+
+		expectedStatusCode int
+		expectedResponse   *HandlerResponse
+	}{
+		{
+			name: "success",
+
+			requestMethod: http.MethodPost,
+			sentBody:      `{}`,
+
+			buildWebhookServiceMock: func(service *mocks.WebhookService) {
+				service.EXPECT().GetSubscriptions(context.Background()).Return([]serviceWebhook.Subscription{
+					{
+						ID:         7,
+						URL:        "https://example.com/hook",
+						Secret:     "secret",
+						EventTypes: []string{"user_segment.added"},
+						SegmentIDs: []string{},
+						InsertTime: insertTime,
+					},
+				}, nil)
+			},
+
+			expectedStatusCode: http.StatusOK,
+			expectedResponse: &HandlerResponse{
+				Status: http.StatusOK,
+				Webhooks: []Webhook{
+					{
+						ID:         7,
+						URL:        "https://example.com/hook",
+						EventTypes: []string{"user_segment.added"},
+						SegmentIDs: []string{},
+						InsertTime: insertTime,
+					},
+				},
+			},
+		},
+		{
+			name: "wrong_method",
+
+			requestMethod: http.MethodGet,
+			sentBody:      `{}`,
+
+			buildWebhookServiceMock: nil,
+
+			expectedStatusCode: http.StatusMethodNotAllowed,
+			expectedResponse:   nil,
+		},
+		{
+			name: "decode_error",
+
+			requestMethod: http.MethodPost,
+			sentBody:      `[`,
+
+			buildWebhookServiceMock: nil,
+
+			expectedStatusCode: http.StatusBadRequest,
+			expectedResponse:   nil,
+		},
+		{
+			name: "service_error_unexpected_error",
+
+			requestMethod: http.MethodPost,
+			sentBody:      `{}`,
+
+			buildWebhookServiceMock: func(service *mocks.WebhookService) {
+				service.EXPECT().GetSubscriptions(context.Background()).Return(nil, fmt.Errorf("error from service"))
+			},
+
+			expectedStatusCode: http.StatusInternalServerError,
+			expectedResponse: &HandlerResponse{
+				Status: http.StatusInternalServerError,
+				Error:  &handlers.Error{Code: "INTERNAL", Message: "unexpected error"},
+			},
+		},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			request, err := http.NewRequest(tc.requestMethod, "", strings.NewReader(tc.sentBody))
+			if err != nil {
+				t.Fatalf("error while sending request: %s", err)
+			}
+
+			w := httptest.NewRecorder()
+			webhookServiceMock := mocks.NewWebhookService(t)
+
+			if tc.buildWebhookServiceMock != nil {
+				tc.buildWebhookServiceMock(webhookServiceMock)
+			}
+
+			handler := New(webhookServiceMock, slog.New(logger.NewNoopHandler()))
+			handler.ServeHTTP(w, request)
+
+			responseResult := w.Result()
+
+			assert.Equal(t, handlers.ContentTypeJSON, responseResult.Header.Get("Content-Type"))
+			assert.Equal(t, tc.expectedStatusCode, responseResult.StatusCode)
+
+			if tc.expectedResponse != nil {
+				var response HandlerResponse
+				err = json.NewDecoder(responseResult.Body).Decode(&response)
+				assert.NoError(t, err)
+
+				assert.Equal(t, *tc.expectedResponse, response)
+			}
+		})
+	}
+}
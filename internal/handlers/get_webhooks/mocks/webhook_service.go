@@ -0,0 +1,92 @@
+// Code generated by mockery v2.33.0. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+
+	mock "github.com/stretchr/testify/mock"
+
+	webhook "github.com/pollykon/avito_test_task/internal/service/webhook"
+)
+
+// WebhookService is an autogenerated mock type for the WebhookService type
+type WebhookService struct {
+	mock.Mock
+}
+
+type WebhookService_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *WebhookService) EXPECT() *WebhookService_Expecter {
+	return &WebhookService_Expecter{mock: &_m.Mock}
+}
+
+// GetSubscriptions provides a mock function with given fields: ctx
+func (_m *WebhookService) GetSubscriptions(ctx context.Context) ([]webhook.Subscription, error) {
+	ret := _m.Called(ctx)
+
+	var r0 []webhook.Subscription
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context) ([]webhook.Subscription, error)); ok {
+		return rf(ctx)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context) []webhook.Subscription); ok {
+		r0 = rf(ctx)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]webhook.Subscription)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context) error); ok {
+		r1 = rf(ctx)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// WebhookService_GetSubscriptions_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetSubscriptions'
+type WebhookService_GetSubscriptions_Call struct {
+	*mock.Call
+}
+
+// GetSubscriptions is a helper method to define mock.On call
+//   - ctx context.Context
+func (_e *WebhookService_Expecter) GetSubscriptions(ctx interface{}) *WebhookService_GetSubscriptions_Call {
+	return &WebhookService_GetSubscriptions_Call{Call: _e.mock.On("GetSubscriptions", ctx)}
+}
+
+func (_c *WebhookService_GetSubscriptions_Call) Run(run func(ctx context.Context)) *WebhookService_GetSubscriptions_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context))
+	})
+	return _c
+}
+
+func (_c *WebhookService_GetSubscriptions_Call) Return(_a0 []webhook.Subscription, _a1 error) *WebhookService_GetSubscriptions_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *WebhookService_GetSubscriptions_Call) RunAndReturn(run func(context.Context) ([]webhook.Subscription, error)) *WebhookService_GetSubscriptions_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// NewWebhookService creates a new instance of WebhookService. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewWebhookService(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *WebhookService {
+	mock := &WebhookService{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
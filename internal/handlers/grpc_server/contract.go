@@ -0,0 +1,37 @@
+//go:generate mockery --all --output ./mocks --case underscore --with-expecter
+package grpc_server
+
+import (
+	"context"
+	"time"
+
+	"github.com/pollykon/avito_test_task/internal/actor"
+	serviceLog "github.com/pollykon/avito_test_task/internal/service/log"
+	serviceRateLimit "github.com/pollykon/avito_test_task/internal/service/rate_limit"
+)
+
+type SegmentService interface {
+	AddSegment(ctx context.Context, slug string, percent *int64) error
+	DeleteSegment(ctx context.Context, slug string) error
+	AddUserToSegment(ctx context.Context, userID int64, slugs []string, ttl *time.Duration) error
+	DeleteUserFromSegment(ctx context.Context, userID int64, slugs []string) error
+	GetUserActiveSegments(ctx context.Context, userID int64) ([]string, error)
+}
+
+type LogService interface {
+	GenerateCSV(ctx context.Context, request serviceLog.GetCSVRequest) (string, error)
+}
+
+type URLGenerator interface {
+	GenerateURL(host string, fileName string) string
+}
+
+type Authenticator interface {
+	Authenticate(ctx context.Context, key string) (actor.Actor, error)
+	AuthenticateToken(ctx context.Context, token string) (actor.Actor, error)
+}
+
+type RateLimiter interface {
+	Allow(ctx context.Context, client string, endpoint string) (serviceRateLimit.Decision, error)
+	AllowAddress(ctx context.Context, address string) (serviceRateLimit.Decision, error)
+}
@@ -0,0 +1,59 @@
+package grpc_server
+
+import (
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/protoadapt"
+
+	"github.com/pollykon/avito_test_task/internal/handlers"
+)
+
+// errorDomain is a domain of google.rpc.ErrorInfo, reason of ErrorInfo is a code of handlers.Error
+const errorDomain = "segment.v1"
+
+// statusError converts error with code to gRPC status with google.rpc.ErrorInfo
+// and google.rpc.BadRequest for validation errors in details
+func statusError(responseErr *handlers.Error) error {
+	st := status.New(grpcCode(responseErr.Code), responseErr.Message)
+
+	details := []protoadapt.MessageV1{&errdetails.ErrorInfo{Reason: responseErr.Code, Domain: errorDomain}}
+	if len(responseErr.Details) > 0 {
+		badRequest := &errdetails.BadRequest{}
+		for _, detail := range responseErr.Details {
+			badRequest.FieldViolations = append(badRequest.FieldViolations, &errdetails.BadRequest_FieldViolation{
+				Field:       detail.Field,
+				Description: detail.Message,
+			})
+		}
+		details = append(details, badRequest)
+	}
+
+	stWithDetails, err := st.WithDetails(details...)
+	if err != nil {
+		return st.Err()
+	}
+
+	return stWithDetails.Err()
+}
+
+func grpcCode(code string) codes.Code {
+	switch code {
+	case handlers.CodeMalformedRequest, handlers.CodeValidationFailed:
+		return codes.InvalidArgument
+	case handlers.CodeUnauthenticated:
+		return codes.Unauthenticated
+	case handlers.CodeForbidden:
+		return codes.PermissionDenied
+	case handlers.CodeRateLimited:
+		return codes.ResourceExhausted
+	case handlers.CodeNotReady:
+		return codes.Unavailable
+	case handlers.CodeNotFound, handlers.CodeSegmentNotFound, handlers.CodeWebhookNotFound, handlers.CodeAPIKeyNotFound:
+		return codes.NotFound
+	case handlers.CodeSegmentAlreadyExists, handlers.CodeUserAlreadyInSegment:
+		return codes.AlreadyExists
+	default:
+		return codes.Internal
+	}
+}
@@ -0,0 +1,202 @@
+package grpc_server
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"path"
+	"strings"
+	"time"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/peer"
+	"google.golang.org/grpc/status"
+
+	"github.com/pollykon/avito_test_task/internal/actor"
+	segmentv1 "github.com/pollykon/avito_test_task/internal/api/segment/v1"
+	"github.com/pollykon/avito_test_task/internal/handlers"
+	handlerAuth "github.com/pollykon/avito_test_task/internal/handlers/auth"
+	handlerRateLimit "github.com/pollykon/avito_test_task/internal/handlers/rate_limit"
+	"github.com/pollykon/avito_test_task/internal/request_id"
+	serviceAuth "github.com/pollykon/avito_test_task/internal/service/auth"
+)
+
+const (
+	// metadataAcceptLanguage is a metadata key with the same meaning as Accept-Language header
+	metadataAcceptLanguage = "accept-language"
+	// metadataAPIKey is a metadata key with the same meaning as X-API-Key header
+	metadataAPIKey = "x-api-key"
+	// metadataAuthorization is a metadata key with the same meaning as Authorization header
+	metadataAuthorization = "authorization"
+	// metadataRetryAfter is a response header with the same meaning as Retry-After header
+	metadataRetryAfter = "retry-after"
+	// metadataRequestID is a metadata key and response header with the same meaning as X-Request-ID header
+	metadataRequestID = "x-request-id"
+)
+
+// methodScopes contains scopes required by methods. Methods which aren't listed here,
+// e.g. health checks and reflection, don't require authentication
+var methodScopes = map[string]string{
+	segmentv1.SegmentService_AddSegment_FullMethodName:             actor.ScopeSegmentsManage,
+	segmentv1.SegmentService_DeleteSegment_FullMethodName:          actor.ScopeSegmentsManage,
+	segmentv1.SegmentService_AddUserToSegments_FullMethodName:      actor.ScopeMembershipsWrite,
+	segmentv1.SegmentService_DeleteUserFromSegments_FullMethodName: actor.ScopeMembershipsWrite,
+	segmentv1.SegmentService_GetUserActiveSegments_FullMethodName:  actor.ScopeMembershipsRead,
+	segmentv1.SegmentService_GetUserLogs_FullMethodName:            actor.ScopeHistoryExport,
+}
+
+// AccessLogInterceptor takes request id from x-request-id metadata or generates new one, puts it to request context
+// and returns it in response header. After request is served it writes one log line with its method, code and latency.
+// It must be the first interceptor in chain, so that logs of others contain request id
+func AccessLogInterceptor(logger *slog.Logger) grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, request any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+		start := time.Now()
+
+		var requestID string
+		md, _ := metadata.FromIncomingContext(ctx)
+		if ids := md.Get(metadataRequestID); len(ids) > 0 {
+			requestID = ids[0]
+		}
+		if !request_id.Valid(requestID) {
+			requestID = request_id.New()
+		}
+		ctx = request_id.WithRequestID(ctx, requestID)
+		_ = grpc.SetHeader(ctx, metadata.Pairs(metadataRequestID, requestID))
+
+		response, err := handler(ctx, request)
+
+		var client string
+		if p, ok := peer.FromContext(ctx); ok {
+			client = p.Addr.String()
+		}
+		logger.InfoContext(ctx, "request served",
+			"method", info.FullMethod,
+			"code", status.Code(err).String(),
+			"latency_ms", float64(time.Since(start).Microseconds())/1000,
+			"client", client,
+		)
+
+		return response, err
+	}
+}
+
+// LanguageInterceptor puts language from accept-language metadata to request context
+func LanguageInterceptor(
+	ctx context.Context,
+	request any,
+	_ *grpc.UnaryServerInfo,
+	handler grpc.UnaryHandler,
+) (any, error) {
+	md, _ := metadata.FromIncomingContext(ctx)
+	language := handlers.ParseAcceptLanguage(strings.Join(md.Get(metadataAcceptLanguage), ","))
+
+	return handler(handlers.WithLanguage(ctx, language), request)
+}
+
+// AuthInterceptor authenticates requests by bearer token in authorization metadata or by x-api-key metadata
+// and puts actor to request context.
+// It must be chained after LanguageInterceptor to localize errors
+func AuthInterceptor(authenticator Authenticator, enabled bool, logger *slog.Logger) grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, request any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+		requiredScope, ok := methodScopes[info.FullMethod]
+		if !enabled || !ok {
+			return handler(ctx, request)
+		}
+
+		a, err := authenticate(ctx, authenticator)
+		if err != nil {
+			if errors.Is(err, serviceAuth.ErrUnauthenticated) {
+				logger.InfoContext(ctx, "request isn't authenticated", "error", err, "method", info.FullMethod)
+				return nil, statusError(handlers.NewError(ctx, handlers.CodeUnauthenticated))
+			}
+
+			logger.ErrorContext(ctx, "error while authenticating request", "error", err, "method", info.FullMethod)
+			return nil, statusError(handlers.ErrInternal(ctx))
+		}
+
+		if !a.HasScope(requiredScope) {
+			logger.InfoContext(ctx, "request without required scope", "actor", a.ID, "scope", requiredScope)
+			return nil, statusError(handlers.NewError(ctx, handlers.CodeForbidden))
+		}
+
+		return handler(actor.WithActor(ctx, a), request)
+	}
+}
+
+func authenticate(ctx context.Context, authenticator Authenticator) (actor.Actor, error) {
+	md, _ := metadata.FromIncomingContext(ctx)
+	if values := md.Get(metadataAuthorization); len(values) > 0 {
+		if token, ok := handlerAuth.BearerToken(values[0]); ok {
+			return authenticator.AuthenticateToken(ctx, token)
+		}
+	}
+
+	var key string
+	if keys := md.Get(metadataAPIKey); len(keys) > 0 {
+		key = keys[0]
+	}
+
+	return authenticator.Authenticate(ctx, key)
+}
+
+// AddressRateLimitInterceptor rejects requests of ip addresses which exceeded their limit. It must be chained
+// before AuthInterceptor, so that requests with missing or wrong keys are limited too
+func AddressRateLimitInterceptor(rateLimiter RateLimiter, enabled bool, logger *slog.Logger) grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, request any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+		if !enabled {
+			return handler(ctx, request)
+		}
+
+		var remoteAddr string
+		if p, ok := peer.FromContext(ctx); ok {
+			remoteAddr = p.Addr.String()
+		}
+		address := handlerRateLimit.Address(remoteAddr)
+
+		decision, err := rateLimiter.AllowAddress(ctx, address)
+		if err != nil {
+			logger.ErrorContext(ctx, "error while checking rate limit of address", "error", err, "address", address)
+			return handler(ctx, request)
+		}
+
+		if !decision.Allowed {
+			logger.InfoContext(ctx, "request is rate limited by address", "address", address, "method", info.FullMethod)
+			_ = grpc.SetHeader(ctx, metadata.Pairs(metadataRetryAfter, handlerRateLimit.RetryAfterSeconds(decision.RetryAfter)))
+			return nil, statusError(handlers.NewError(ctx, handlers.CodeRateLimited))
+		}
+
+		return handler(ctx, request)
+	}
+}
+
+// RateLimitInterceptor rejects requests of clients which exceeded limit of method. It must be chained
+// after AuthInterceptor to limit requests by actor, requests without actor are limited by ip address
+func RateLimitInterceptor(rateLimiter RateLimiter, enabled bool, logger *slog.Logger) grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, request any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+		if !enabled {
+			return handler(ctx, request)
+		}
+
+		var remoteAddr string
+		if p, ok := peer.FromContext(ctx); ok {
+			remoteAddr = p.Addr.String()
+		}
+		client := handlerRateLimit.Client(ctx, remoteAddr)
+
+		// limits are configured by method name without service, e.g. GetUserActiveSegments
+		decision, err := rateLimiter.Allow(ctx, client, path.Base(info.FullMethod))
+		if err != nil {
+			logger.ErrorContext(ctx, "error while checking rate limit", "error", err, "client", client)
+			return handler(ctx, request)
+		}
+
+		if !decision.Allowed {
+			logger.InfoContext(ctx, "request is rate limited", "client", client, "method", info.FullMethod)
+			_ = grpc.SetHeader(ctx, metadata.Pairs(metadataRetryAfter, handlerRateLimit.RetryAfterSeconds(decision.RetryAfter)))
+			return nil, statusError(handlers.NewError(ctx, handlers.CodeRateLimited))
+		}
+
+		return handler(ctx, request)
+	}
+}
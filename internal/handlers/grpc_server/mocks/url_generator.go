@@ -0,0 +1,75 @@
+// Code generated by mockery v2.33.0. DO NOT EDIT.
+
+package mocks
+
+import mock "github.com/stretchr/testify/mock"
+
+// URLGenerator is an autogenerated mock type for the URLGenerator type
+type URLGenerator struct {
+	mock.Mock
+}
+
+type URLGenerator_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *URLGenerator) EXPECT() *URLGenerator_Expecter {
+	return &URLGenerator_Expecter{mock: &_m.Mock}
+}
+
+// GenerateURL provides a mock function with given fields: host, fileName
+func (_m *URLGenerator) GenerateURL(host string, fileName string) string {
+	ret := _m.Called(host, fileName)
+
+	var r0 string
+	if rf, ok := ret.Get(0).(func(string, string) string); ok {
+		r0 = rf(host, fileName)
+	} else {
+		r0 = ret.Get(0).(string)
+	}
+
+	return r0
+}
+
+// URLGenerator_GenerateURL_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GenerateURL'
+type URLGenerator_GenerateURL_Call struct {
+	*mock.Call
+}
+
+// GenerateURL is a helper method to define mock.On call
+//   - host string
+//   - fileName string
+func (_e *URLGenerator_Expecter) GenerateURL(host interface{}, fileName interface{}) *URLGenerator_GenerateURL_Call {
+	return &URLGenerator_GenerateURL_Call{Call: _e.mock.On("GenerateURL", host, fileName)}
+}
+
+func (_c *URLGenerator_GenerateURL_Call) Run(run func(host string, fileName string)) *URLGenerator_GenerateURL_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(string), args[1].(string))
+	})
+	return _c
+}
+
+func (_c *URLGenerator_GenerateURL_Call) Return(_a0 string) *URLGenerator_GenerateURL_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *URLGenerator_GenerateURL_Call) RunAndReturn(run func(string, string) string) *URLGenerator_GenerateURL_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// NewURLGenerator creates a new instance of URLGenerator. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewURLGenerator(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *URLGenerator {
+	mock := &URLGenerator{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
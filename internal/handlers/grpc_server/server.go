@@ -0,0 +1,204 @@
+package grpc_server
+
+import (
+	"context"
+	"log/slog"
+	"time"
+
+	segmentv1 "github.com/pollykon/avito_test_task/internal/api/segment/v1"
+	"github.com/pollykon/avito_test_task/internal/handlers"
+	serviceLog "github.com/pollykon/avito_test_task/internal/service/log"
+)
+
+const (
+	defaultSeparator = ","
+)
+
+// Server implements gRPC API with the same validation and services as JSON handlers
+type Server struct {
+	segmentv1.UnimplementedSegmentServiceServer
+
+	segmentService SegmentService
+	logService     LogService
+	urlGenerator   URLGenerator
+	// downloadHost is a host of HTTP server which serves generated files
+	downloadHost string
+	logger       *slog.Logger
+}
+
+func New(
+	segmentService SegmentService,
+	logService LogService,
+	urlGenerator URLGenerator,
+	downloadHost string,
+	logger *slog.Logger,
+) *Server {
+	return &Server{
+		segmentService: segmentService,
+		logService:     logService,
+		urlGenerator:   urlGenerator,
+		downloadHost:   downloadHost,
+		logger:         logger,
+	}
+}
+
+func (s *Server) AddSegment(ctx context.Context, request *segmentv1.AddSegmentRequest) (*segmentv1.AddSegmentResponse, error) {
+	if request.GetSlug() == "" {
+		return nil, statusError(handlers.NewValidationError(ctx, "slug", handlers.MsgRequired))
+	}
+	if request.Percent != nil && (request.GetPercent() < 0 || request.GetPercent() > 100) {
+		return nil, statusError(handlers.NewValidationError(ctx, "percent", handlers.MsgBetween, 0, 100))
+	}
+
+	err := s.segmentService.AddSegment(ctx, request.GetSlug(), request.Percent)
+	if err != nil {
+		if responseErr := handlers.FromServiceError(ctx, err); responseErr != nil {
+			return nil, statusError(responseErr)
+		}
+
+		s.logger.ErrorContext(ctx, "error while adding segment", "error", err, "slug", request.GetSlug())
+		return nil, statusError(handlers.ErrInternal(ctx))
+	}
+
+	return &segmentv1.AddSegmentResponse{}, nil
+}
+
+func (s *Server) DeleteSegment(
+	ctx context.Context,
+	request *segmentv1.DeleteSegmentRequest,
+) (*segmentv1.DeleteSegmentResponse, error) {
+	if request.GetSlug() == "" {
+		return nil, statusError(handlers.NewValidationError(ctx, "slug", handlers.MsgRequired))
+	}
+
+	err := s.segmentService.DeleteSegment(ctx, request.GetSlug())
+	if err != nil {
+		if responseErr := handlers.FromServiceError(ctx, err); responseErr != nil {
+			return nil, statusError(responseErr)
+		}
+
+		s.logger.ErrorContext(ctx, "error while deleting segment", "error", err, "slug", request.GetSlug())
+		return nil, statusError(handlers.ErrInternal(ctx))
+	}
+
+	return &segmentv1.DeleteSegmentResponse{}, nil
+}
+
+func (s *Server) AddUserToSegments(
+	ctx context.Context,
+	request *segmentv1.AddUserToSegmentsRequest,
+) (*segmentv1.AddUserToSegmentsResponse, error) {
+	if request.GetUserId() <= 0 {
+		return nil, statusError(handlers.NewValidationError(ctx, "user_id", handlers.MsgPositive))
+	}
+	if len(request.GetSlugs()) == 0 {
+		return nil, statusError(handlers.NewValidationError(ctx, "slugs", handlers.MsgRequired))
+	}
+	if request.TtlHours != nil && request.GetTtlHours() <= 0 {
+		return nil, statusError(handlers.NewValidationError(ctx, "ttl_hours", handlers.MsgPositive))
+	}
+
+	var ttl *time.Duration
+	if request.TtlHours != nil {
+		duration := time.Duration(request.GetTtlHours()) * time.Hour
+		ttl = &duration
+	}
+
+	err := s.segmentService.AddUserToSegment(ctx, request.GetUserId(), request.GetSlugs(), ttl)
+	if err != nil {
+		if responseErr := handlers.FromServiceError(ctx, err); responseErr != nil {
+			return nil, statusError(responseErr)
+		}
+
+		s.logger.ErrorContext(ctx, "error while adding user to segment", "error", err, "user_id", request.GetUserId())
+		return nil, statusError(handlers.ErrInternal(ctx))
+	}
+
+	return &segmentv1.AddUserToSegmentsResponse{}, nil
+}
+
+func (s *Server) DeleteUserFromSegments(
+	ctx context.Context,
+	request *segmentv1.DeleteUserFromSegmentsRequest,
+) (*segmentv1.DeleteUserFromSegmentsResponse, error) {
+	if request.GetUserId() <= 0 {
+		return nil, statusError(handlers.NewValidationError(ctx, "user_id", handlers.MsgPositive))
+	}
+	if len(request.GetSlugs()) == 0 {
+		return nil, statusError(handlers.NewValidationError(ctx, "slugs", handlers.MsgRequired))
+	}
+
+	err := s.segmentService.DeleteUserFromSegment(ctx, request.GetUserId(), request.GetSlugs())
+	if err != nil {
+		s.logger.ErrorContext(ctx, "error while deleting user from segment", "error", err, "user_id", request.GetUserId())
+		return nil, statusError(handlers.ErrInternal(ctx))
+	}
+
+	return &segmentv1.DeleteUserFromSegmentsResponse{}, nil
+}
+
+func (s *Server) GetUserActiveSegments(
+	ctx context.Context,
+	request *segmentv1.GetUserActiveSegmentsRequest,
+) (*segmentv1.GetUserActiveSegmentsResponse, error) {
+	if request.GetUserId() <= 0 {
+		return nil, statusError(handlers.NewValidationError(ctx, "user_id", handlers.MsgPositive))
+	}
+
+	slugs, err := s.segmentService.GetUserActiveSegments(ctx, request.GetUserId())
+	if err != nil {
+		s.logger.ErrorContext(ctx, "error while getting active segment", "error", err, "user_id", request.GetUserId())
+		return nil, statusError(handlers.ErrInternal(ctx))
+	}
+
+	return &segmentv1.GetUserActiveSegmentsResponse{Slugs: slugs}, nil
+}
+
+func (s *Server) GetUserLogs(ctx context.Context, request *segmentv1.GetUserLogsRequest) (*segmentv1.GetUserLogsResponse, error) {
+	if request.GetUserId() <= 0 {
+		return nil, statusError(handlers.NewValidationError(ctx, "user_id", handlers.MsgPositive))
+	}
+
+	from, err := time.Parse("2006-01", request.GetFrom())
+	if err != nil {
+		return nil, statusError(handlers.NewValidationError(ctx, "from", handlers.MsgMonth))
+	}
+	to, err := time.Parse("2006-01", request.GetTo())
+	if err != nil {
+		return nil, statusError(handlers.NewValidationError(ctx, "to", handlers.MsgMonth))
+	}
+	if !from.Before(to) {
+		return nil, statusError(handlers.NewValidationError(ctx, "from", handlers.MsgFromBeforeTo))
+	}
+
+	separator := defaultSeparator
+	if request.Separator != nil {
+		separator = request.GetSeparator()
+	}
+
+	var compression string
+	switch request.GetCompression() {
+	case segmentv1.Compression_COMPRESSION_UNSPECIFIED:
+		compression = serviceLog.CompressionNone
+	case segmentv1.Compression_COMPRESSION_GZIP:
+		compression = serviceLog.CompressionGzip
+	case segmentv1.Compression_COMPRESSION_ZIP:
+		compression = serviceLog.CompressionZip
+	default:
+		return nil, statusError(handlers.NewValidationError(ctx, "compression", handlers.MsgOneOf, "gzip, zip"))
+	}
+
+	fileName, err := s.logService.GenerateCSV(ctx, serviceLog.GetCSVRequest{
+		UserID:      request.GetUserId(),
+		From:        from,
+		To:          to,
+		Separator:   separator,
+		Compression: compression,
+	})
+	if err != nil {
+		s.logger.ErrorContext(ctx, "error while getting logs", "error", err, "user_id", request.GetUserId())
+		return nil, statusError(handlers.ErrInternal(ctx))
+	}
+
+	return &segmentv1.GetUserLogsResponse{Url: s.urlGenerator.GenerateURL(s.downloadHost, fileName)}, nil
+}
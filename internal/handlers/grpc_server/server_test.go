@@ -0,0 +1,422 @@
+package grpc_server
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"net"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	"google.golang.org/grpc/test/bufconn"
+	"google.golang.org/protobuf/proto"
+
+	"github.com/pollykon/avito_test_task/internal/actor"
+	segmentv1 "github.com/pollykon/avito_test_task/internal/api/segment/v1"
+	"github.com/pollykon/avito_test_task/internal/handlers"
+	"github.com/pollykon/avito_test_task/internal/handlers/grpc_server/mocks"
+	"github.com/pollykon/avito_test_task/internal/logger"
+	serviceAuth "github.com/pollykon/avito_test_task/internal/service/auth"
+	serviceLog "github.com/pollykon/avito_test_task/internal/service/log"
+	serviceRateLimit "github.com/pollykon/avito_test_task/internal/service/rate_limit"
+	serviceSegment "github.com/pollykon/avito_test_task/internal/service/segment"
+)
+
+func newServer(t *testing.T, segmentService *mocks.SegmentService, logService *mocks.LogService) *Server {
+	return New(
+		segmentService,
+		logService,
+		mocks.NewURLGenerator(t),
+		"localhost:1011",
+		slog.New(logger.NewNoopHandler()),
+	)
+}
+
+func TestServer_AddSegment(t *testing.T) {
+	tt := []struct {
+		name string
+
+		request *segmentv1.AddSegmentRequest
+
+		buildSegmentServiceMock func(service *mocks.SegmentService)
+
+		expectedCode codes.Code
+	}{
+		{
+			name: "success",
+
+			request: &segmentv1.AddSegmentRequest{Slug: "AVITO", Percent: proto.Int64(10)},
+
+			buildSegmentServiceMock: func(service *mocks.SegmentService) {
+				service.EXPECT().AddSegment(context.Background(), "AVITO", proto.Int64(10)).Return(nil)
+			},
+
+			expectedCode: codes.OK,
+		},
+		{
+			name: "empty_slug",
+
+			request: &segmentv1.AddSegmentRequest{},
+
+			buildSegmentServiceMock: nil,
+
+			expectedCode: codes.InvalidArgument,
+		},
+		{
+			name: "wrong_percent",
+
+			request: &segmentv1.AddSegmentRequest{Slug: "AVITO", Percent: proto.Int64(101)},
+
+			buildSegmentServiceMock: nil,
+
+			expectedCode: codes.InvalidArgument,
+		},
+		{
+			name: "service_error_already_exists",
+
+			request: &segmentv1.AddSegmentRequest{Slug: "AVITO"},
+
+			buildSegmentServiceMock: func(service *mocks.SegmentService) {
+				service.EXPECT().AddSegment(context.Background(), "AVITO", (*int64)(nil)).
+					Return(serviceSegment.ErrSegmentAlreadyExists)
+			},
+
+			expectedCode: codes.AlreadyExists,
+		},
+		{
+			name: "service_error_unexpected_error",
+
+			request: &segmentv1.AddSegmentRequest{Slug: "AVITO"},
+
+			buildSegmentServiceMock: func(service *mocks.SegmentService) {
+				service.EXPECT().AddSegment(context.Background(), "AVITO", (*int64)(nil)).
+					Return(fmt.Errorf("error from service"))
+			},
+
+			expectedCode: codes.Internal,
+		},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			segmentServiceMock := mocks.NewSegmentService(t)
+			if tc.buildSegmentServiceMock != nil {
+				tc.buildSegmentServiceMock(segmentServiceMock)
+			}
+
+			_, err := newServer(t, segmentServiceMock, mocks.NewLogService(t)).AddSegment(context.Background(), tc.request)
+
+			assert.Equal(t, tc.expectedCode, status.Code(err))
+		})
+	}
+}
+
+func TestServer_DeleteSegment(t *testing.T) {
+	segmentServiceMock := mocks.NewSegmentService(t)
+	segmentServiceMock.EXPECT().DeleteSegment(context.Background(), "AVITO").Return(serviceSegment.ErrSegmentNotExist)
+
+	_, err := newServer(t, segmentServiceMock, mocks.NewLogService(t)).
+		DeleteSegment(context.Background(), &segmentv1.DeleteSegmentRequest{Slug: "AVITO"})
+
+	assert.Equal(t, codes.NotFound, status.Code(err))
+	assertDetails(t, err, &errdetails.ErrorInfo{Reason: handlers.CodeSegmentNotFound, Domain: errorDomain})
+}
+
+func TestServer_ValidationErrorDetails(t *testing.T) {
+	_, err := newServer(t, mocks.NewSegmentService(t), mocks.NewLogService(t)).
+		AddUserToSegments(context.Background(), &segmentv1.AddUserToSegmentsRequest{UserId: 1})
+
+	assert.Equal(t, codes.InvalidArgument, status.Code(err))
+	assertDetails(t, err,
+		&errdetails.ErrorInfo{Reason: handlers.CodeValidationFailed, Domain: errorDomain},
+		&errdetails.BadRequest{FieldViolations: []*errdetails.BadRequest_FieldViolation{
+			{Field: "slugs", Description: "slugs shouldn't be empty"},
+		}},
+	)
+}
+
+func assertDetails(t *testing.T, err error, expected ...proto.Message) {
+	details := status.Convert(err).Details()
+	require.Len(t, details, len(expected))
+	for i, detail := range details {
+		message, ok := detail.(proto.Message)
+		require.True(t, ok, "detail %d isn't proto message: %v", i, detail)
+		assert.True(t, proto.Equal(expected[i], message), "detail %d: expected %v, got %v", i, expected[i], message)
+	}
+}
+
+func TestServer_AddUserToSegments(t *testing.T) {
+	ttl := 2 * time.Hour
+
+	tt := []struct {
+		name string
+
+		request *segmentv1.AddUserToSegmentsRequest
+
+		buildSegmentServiceMock func(service *mocks.SegmentService)
+
+		expectedCode codes.Code
+	}{
+		{
+			name: "success",
+
+			request: &segmentv1.AddUserToSegmentsRequest{UserId: 10, Slugs: []string{"AVITO"}, TtlHours: proto.Int64(2)},
+
+			buildSegmentServiceMock: func(service *mocks.SegmentService) {
+				service.EXPECT().AddUserToSegment(context.Background(), int64(10), []string{"AVITO"}, &ttl).Return(nil)
+			},
+
+			expectedCode: codes.OK,
+		},
+		{
+			name: "wrong_userId",
+
+			request: &segmentv1.AddUserToSegmentsRequest{UserId: 0, Slugs: []string{"AVITO"}},
+
+			buildSegmentServiceMock: nil,
+
+			expectedCode: codes.InvalidArgument,
+		},
+		{
+			name: "empty_slugs",
+
+			request: &segmentv1.AddUserToSegmentsRequest{UserId: 10},
+
+			buildSegmentServiceMock: nil,
+
+			expectedCode: codes.InvalidArgument,
+		},
+		{
+			name: "wrong_ttl",
+
+			request: &segmentv1.AddUserToSegmentsRequest{UserId: 10, Slugs: []string{"AVITO"}, TtlHours: proto.Int64(0)},
+
+			buildSegmentServiceMock: nil,
+
+			expectedCode: codes.InvalidArgument,
+		},
+		{
+			name: "service_error_already_in_segment",
+
+			request: &segmentv1.AddUserToSegmentsRequest{UserId: 10, Slugs: []string{"AVITO"}},
+
+			buildSegmentServiceMock: func(service *mocks.SegmentService) {
+				service.EXPECT().AddUserToSegment(context.Background(), int64(10), []string{"AVITO"}, (*time.Duration)(nil)).
+					Return(serviceSegment.ErrUserAlreadyInSegment)
+			},
+
+			expectedCode: codes.AlreadyExists,
+		},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			segmentServiceMock := mocks.NewSegmentService(t)
+			if tc.buildSegmentServiceMock != nil {
+				tc.buildSegmentServiceMock(segmentServiceMock)
+			}
+
+			_, err := newServer(t, segmentServiceMock, mocks.NewLogService(t)).
+				AddUserToSegments(context.Background(), tc.request)
+
+			assert.Equal(t, tc.expectedCode, status.Code(err))
+		})
+	}
+}
+
+func TestServer_GetUserLogs(t *testing.T) {
+	logServiceMock := mocks.NewLogService(t)
+	logServiceMock.EXPECT().GenerateCSV(context.Background(), serviceLog.GetCSVRequest{
+		UserID:      10,
+		From:        time.Date(2023, 8, 1, 0, 0, 0, 0, time.UTC),
+		To:          time.Date(2023, 9, 1, 0, 0, 0, 0, time.UTC),
+		Separator:   ";",
+		Compression: serviceLog.CompressionGzip,
+	}).Return("file.csv.gz", nil)
+
+	urlGeneratorMock := mocks.NewURLGenerator(t)
+	urlGeneratorMock.EXPECT().GenerateURL("localhost:1011", "file.csv.gz").Return("http://localhost:1011/static/file.csv.gz")
+
+	server := New(
+		mocks.NewSegmentService(t),
+		logServiceMock,
+		urlGeneratorMock,
+		"localhost:1011",
+		slog.New(logger.NewNoopHandler()),
+	)
+
+	response, err := server.GetUserLogs(context.Background(), &segmentv1.GetUserLogsRequest{
+		UserId:      10,
+		From:        "2023-08",
+		To:          "2023-09",
+		Separator:   proto.String(";"),
+		Compression: segmentv1.Compression_COMPRESSION_GZIP,
+	})
+
+	require.NoError(t, err)
+	assert.Equal(t, "http://localhost:1011/static/file.csv.gz", response.GetUrl())
+
+	_, err = server.GetUserLogs(context.Background(), &segmentv1.GetUserLogsRequest{UserId: 10, From: "2023-09", To: "2023-08"})
+	assert.Equal(t, codes.InvalidArgument, status.Code(err))
+}
+
+func TestServer_GetUserActiveSegments_OverConnection(t *testing.T) {
+	segmentServiceMock := mocks.NewSegmentService(t)
+	segmentServiceMock.EXPECT().GetUserActiveSegments(mock.Anything, int64(10)).Return([]string{"AVITO"}, nil)
+
+	client := newClient(t, newServer(t, segmentServiceMock, mocks.NewLogService(t)))
+
+	response, err := client.GetUserActiveSegments(context.Background(), &segmentv1.GetUserActiveSegmentsRequest{UserId: 10})
+
+	require.NoError(t, err)
+	assert.Equal(t, []string{"AVITO"}, response.GetSlugs())
+}
+
+func TestServer_LanguageInterceptor(t *testing.T) {
+	client := newClient(t, newServer(t, mocks.NewSegmentService(t), mocks.NewLogService(t)))
+
+	ctx := metadata.AppendToOutgoingContext(context.Background(), metadataAcceptLanguage, "ru-RU,ru;q=0.9")
+	_, err := client.AddSegment(ctx, &segmentv1.AddSegmentRequest{})
+
+	assert.Equal(t, codes.InvalidArgument, status.Code(err))
+	assert.Equal(t, "slug не должно быть пустым", status.Convert(err).Message())
+}
+
+func TestServer_AuthInterceptor(t *testing.T) {
+	reader := actor.Actor{ID: "api_key:1", Scopes: []string{actor.ScopeMembershipsRead}}
+
+	authenticatorMock := mocks.NewAuthenticator(t)
+	authenticatorMock.EXPECT().Authenticate(mock.Anything, "").Return(actor.Actor{}, serviceAuth.ErrUnauthenticated)
+	authenticatorMock.EXPECT().Authenticate(mock.Anything, "sk_reader").Return(reader, nil)
+
+	segmentServiceMock := mocks.NewSegmentService(t)
+	segmentServiceMock.EXPECT().
+		GetUserActiveSegments(mock.MatchedBy(func(ctx context.Context) bool {
+			a, ok := actor.FromContext(ctx)
+			return ok && a.ID == reader.ID
+		}), int64(10)).
+		Return([]string{"AVITO"}, nil)
+
+	client := newClient(
+		t,
+		newServer(t, segmentServiceMock, mocks.NewLogService(t)),
+		AuthInterceptor(authenticatorMock, true, slog.New(logger.NewNoopHandler())),
+	)
+
+	_, err := client.GetUserActiveSegments(context.Background(), &segmentv1.GetUserActiveSegmentsRequest{UserId: 10})
+	assert.Equal(t, codes.Unauthenticated, status.Code(err))
+
+	ctx := metadata.AppendToOutgoingContext(context.Background(), metadataAPIKey, "sk_reader")
+
+	_, err = client.AddSegment(ctx, &segmentv1.AddSegmentRequest{Slug: "AVITO"})
+	assert.Equal(t, codes.PermissionDenied, status.Code(err))
+	assertDetails(t, err, &errdetails.ErrorInfo{Reason: handlers.CodeForbidden, Domain: errorDomain})
+
+	response, err := client.GetUserActiveSegments(ctx, &segmentv1.GetUserActiveSegmentsRequest{UserId: 10})
+	require.NoError(t, err)
+	assert.Equal(t, []string{"AVITO"}, response.GetSlugs())
+
+	authenticatorMock.EXPECT().AuthenticateToken(mock.Anything, "expired").Return(actor.Actor{}, serviceAuth.ErrUnauthenticated)
+
+	ctx = metadata.AppendToOutgoingContext(context.Background(), metadataAuthorization, "Bearer expired")
+	_, err = client.GetUserActiveSegments(ctx, &segmentv1.GetUserActiveSegmentsRequest{UserId: 10})
+	assert.Equal(t, codes.Unauthenticated, status.Code(err))
+}
+
+func TestServer_RateLimitInterceptor(t *testing.T) {
+	rateLimiterMock := mocks.NewRateLimiter(t)
+	rateLimiterMock.EXPECT().
+		Allow(mock.Anything, mock.AnythingOfType("string"), "GetUserActiveSegments").
+		Return(serviceRateLimit.Decision{Allowed: false, RetryAfter: 3 * time.Second}, nil)
+
+	client := newClient(
+		t,
+		newServer(t, mocks.NewSegmentService(t), mocks.NewLogService(t)),
+		RateLimitInterceptor(rateLimiterMock, true, slog.New(logger.NewNoopHandler())),
+	)
+
+	var header metadata.MD
+	_, err := client.GetUserActiveSegments(
+		context.Background(), &segmentv1.GetUserActiveSegmentsRequest{UserId: 10}, grpc.Header(&header),
+	)
+
+	assert.Equal(t, codes.ResourceExhausted, status.Code(err))
+	assert.Equal(t, []string{"3"}, header.Get(metadataRetryAfter))
+}
+
+func TestServer_AddressRateLimitInterceptor(t *testing.T) {
+	rateLimiterMock := mocks.NewRateLimiter(t)
+	rateLimiterMock.EXPECT().
+		AllowAddress(mock.Anything, mock.AnythingOfType("string")).
+		Return(serviceRateLimit.Decision{Allowed: false, RetryAfter: 3 * time.Second}, nil)
+
+	// request without key is rejected before authentication, so key isn't looked up
+	client := newClient(
+		t,
+		newServer(t, mocks.NewSegmentService(t), mocks.NewLogService(t)),
+		AddressRateLimitInterceptor(rateLimiterMock, true, slog.New(logger.NewNoopHandler())),
+		AuthInterceptor(mocks.NewAuthenticator(t), true, slog.New(logger.NewNoopHandler())),
+	)
+
+	var header metadata.MD
+	_, err := client.GetUserActiveSegments(
+		context.Background(), &segmentv1.GetUserActiveSegmentsRequest{UserId: 10}, grpc.Header(&header),
+	)
+
+	assert.Equal(t, codes.ResourceExhausted, status.Code(err))
+	assert.Equal(t, []string{"3"}, header.Get(metadataRetryAfter))
+}
+
+func TestServer_AccessLogInterceptor(t *testing.T) {
+	var buf bytes.Buffer
+	log := slog.New(logger.NewContextHandler(slog.NewJSONHandler(&buf, nil)))
+
+	client := newClient(
+		t,
+		newServer(t, mocks.NewSegmentService(t), mocks.NewLogService(t)),
+		AccessLogInterceptor(log),
+	)
+
+	var header metadata.MD
+	ctx := metadata.AppendToOutgoingContext(context.Background(), metadataRequestID, "abc-123")
+	_, err := client.GetUserActiveSegments(ctx, &segmentv1.GetUserActiveSegmentsRequest{UserId: 0}, grpc.Header(&header))
+
+	assert.Equal(t, codes.InvalidArgument, status.Code(err))
+	assert.Equal(t, []string{"abc-123"}, header.Get(metadataRequestID))
+
+	var record map[string]any
+	require.NoError(t, json.Unmarshal(buf.Bytes(), &record))
+	assert.Equal(t, "abc-123", record["request_id"])
+	assert.Equal(t, segmentv1.SegmentService_GetUserActiveSegments_FullMethodName, record["method"])
+	assert.Equal(t, codes.InvalidArgument.String(), record["code"])
+}
+
+// newClient serves server over in-memory connection, interceptors are chained after LanguageInterceptor
+func newClient(t *testing.T, server *Server, interceptors ...grpc.UnaryServerInterceptor) segmentv1.SegmentServiceClient {
+	listener := bufconn.Listen(1024 * 1024)
+	grpcServer := grpc.NewServer(grpc.ChainUnaryInterceptor(append([]grpc.UnaryServerInterceptor{LanguageInterceptor}, interceptors...)...))
+	segmentv1.RegisterSegmentServiceServer(grpcServer, server)
+	go func() { _ = grpcServer.Serve(listener) }()
+	t.Cleanup(grpcServer.Stop)
+
+	conn, err := grpc.NewClient(
+		"passthrough:///bufnet",
+		grpc.WithContextDialer(func(ctx context.Context, _ string) (net.Conn, error) { return listener.DialContext(ctx) }),
+		grpc.WithTransportCredentials(insecure.NewCredentials()),
+	)
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = conn.Close() })
+
+	return segmentv1.NewSegmentServiceClient(conn)
+}
@@ -0,0 +1,76 @@
+package handlers
+
+import (
+	"context"
+	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+type languageKey struct{}
+
+// WithLanguage returns context with language of error messages
+func WithLanguage(ctx context.Context, language string) context.Context {
+	return context.WithValue(ctx, languageKey{}, language)
+}
+
+// LanguageFromContext returns language set by WithLanguage or DefaultLanguage
+func LanguageFromContext(ctx context.Context) string {
+	language, ok := ctx.Value(languageKey{}).(string)
+	if !ok {
+		return DefaultLanguage
+	}
+	return language
+}
+
+// ParseAcceptLanguage returns the most preferred supported language from Accept-Language header,
+// e.g. "ru-RU,ru;q=0.9,en;q=0.8" gives "ru". DefaultLanguage is returned when nothing is supported
+func ParseAcceptLanguage(header string) string {
+	type languageRange struct {
+		language string
+		quality  float64
+	}
+
+	var ranges []languageRange
+	for _, part := range strings.Split(header, ",") {
+		tag, params, _ := strings.Cut(strings.TrimSpace(part), ";")
+		language, _, _ := strings.Cut(strings.ToLower(strings.TrimSpace(tag)), "-")
+		if language == "" {
+			continue
+		}
+
+		quality := 1.0
+		if value, ok := strings.CutPrefix(strings.ReplaceAll(params, " ", ""), "q="); ok {
+			parsed, err := strconv.ParseFloat(value, 64)
+			if err != nil {
+				continue
+			}
+			quality = parsed
+		}
+
+		if quality > 0 {
+			ranges = append(ranges, languageRange{language: language, quality: quality})
+		}
+	}
+
+	sort.SliceStable(ranges, func(i, j int) bool { return ranges[i].quality > ranges[j].quality })
+
+	for _, r := range ranges {
+		if _, ok := catalog[r.language]; ok {
+			return r.language
+		}
+	}
+
+	return DefaultLanguage
+}
+
+// LanguageMiddleware puts language from Accept-Language header to request context
+func LanguageMiddleware(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		language := ParseAcceptLanguage(r.Header.Get("Accept-Language"))
+		w.Header().Set("Content-Language", language)
+		w.Header().Add("Vary", "Accept-Language")
+		next.ServeHTTP(w, r.WithContext(WithLanguage(r.Context(), language)))
+	})
+}
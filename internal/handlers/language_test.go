@@ -0,0 +1,48 @@
+package handlers
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseAcceptLanguage(t *testing.T) {
+	tt := []struct {
+		header   string
+		expected string
+	}{
+		{header: "", expected: LanguageEN},
+		{header: "ru", expected: LanguageRU},
+		{header: "ru-RU,ru;q=0.9,en-US;q=0.8,en;q=0.7", expected: LanguageRU},
+		{header: "en;q=0.5, ru;q=0.8", expected: LanguageRU},
+		{header: "de-DE, en;q=0.5", expected: LanguageEN},
+		{header: "ru;q=0, en", expected: LanguageEN},
+		{header: "fr, de", expected: LanguageEN},
+		{header: "ru;q=abc", expected: LanguageEN},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.header, func(t *testing.T) {
+			assert.Equal(t, tc.expected, ParseAcceptLanguage(tc.header))
+		})
+	}
+}
+
+func TestLanguageMiddleware(t *testing.T) {
+	var language string
+	handler := LanguageMiddleware(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		language = LanguageFromContext(r.Context())
+	}))
+
+	request := httptest.NewRequest(http.MethodGet, "/", nil)
+	request.Header.Set("Accept-Language", "ru-RU")
+	w := httptest.NewRecorder()
+
+	handler.ServeHTTP(w, request)
+
+	assert.Equal(t, LanguageRU, language)
+	assert.Equal(t, LanguageRU, w.Header().Get("Content-Language"))
+	assert.Equal(t, "Accept-Language", w.Header().Get("Vary"))
+}
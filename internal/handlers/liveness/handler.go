@@ -0,0 +1,32 @@
+package liveness
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"github.com/pollykon/avito_test_task/internal/handlers"
+)
+
+// Handler answers while process is able to serve http, it doesn't check dependencies,
+// so orchestrator doesn't restart service when database is unavailable
+type Handler struct{}
+
+func New() Handler {
+	return Handler{}
+}
+
+func (h Handler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", handlers.ContentTypeJSON)
+
+	if r.Method != http.MethodGet && r.Method != http.MethodHead {
+		w.WriteHeader(http.StatusMethodNotAllowed)
+		_ = json.NewEncoder(w).Encode(HandlerResponse{
+			Status: http.StatusMethodNotAllowed,
+			Error:  handlers.ErrMethodNotAllowed(r.Context()),
+		})
+		return
+	}
+
+	w.WriteHeader(http.StatusOK)
+	_ = json.NewEncoder(w).Encode(HandlerResponse{Status: http.StatusOK})
+}
@@ -0,0 +1,57 @@
+package liveness
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/pollykon/avito_test_task/internal/handlers"
+)
+
+func TestLivenessHandler(t *testing.T) {
+	tt := []struct {
+		name string
+
+		requestMethod string
+
+		expectedStatusCode int
+		expectedResponse   HandlerResponse
+	}{
+		{
+			name: "success",
+
+			requestMethod: http.MethodGet,
+
+			expectedStatusCode: http.StatusOK,
+			expectedResponse:   HandlerResponse{Status: http.StatusOK},
+		},
+		{
+			name: "wrong_method",
+
+			requestMethod: http.MethodPost,
+
+			expectedStatusCode: http.StatusMethodNotAllowed,
+			expectedResponse: HandlerResponse{
+				Status: http.StatusMethodNotAllowed,
+				Error:  &handlers.Error{Code: "METHOD_NOT_ALLOWED", Message: "method not allowed"},
+			},
+		},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			w := httptest.NewRecorder()
+
+			New().ServeHTTP(w, httptest.NewRequest(tc.requestMethod, "/healthz", nil))
+
+			var response HandlerResponse
+			_ = json.NewDecoder(w.Body).Decode(&response)
+
+			assert.Equal(t, tc.expectedStatusCode, w.Code)
+			assert.Equal(t, tc.expectedResponse, response)
+		})
+	}
+}
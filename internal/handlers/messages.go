@@ -0,0 +1,109 @@
+package handlers
+
+import "fmt"
+
+const (
+	LanguageEN = "en"
+	LanguageRU = "ru"
+
+	DefaultLanguage = LanguageEN
+)
+
+// Keys of validation messages. Messages are formatted with field name as the first argument
+const (
+	MsgRequired         = "required"
+	MsgPositive         = "positive"
+	MsgNonNegative      = "non_negative"
+	MsgBetween          = "between"
+	MsgMaxItems         = "max_items"
+	MsgOneOf            = "one_of"
+	MsgMonth            = "month"
+	MsgFromBeforeTo     = "from_before_to"
+	MsgSlugFormat       = "slug_format"
+	MsgHTTPURL          = "http_url"
+	MsgUnknownEventType = "unknown_event_type"
+	MsgUnknownScope     = "unknown_scope"
+)
+
+// catalog contains messages of error codes and validation messages by language
+var catalog = map[string]map[string]string{
+	LanguageEN: {
+		CodeInternal:             "unexpected error",
+		CodeMethodNotAllowed:     "method not allowed",
+		CodeMalformedRequest:     "bad request",
+		CodeValidationFailed:     "validation failed",
+		CodeNotFound:             "not found",
+		CodeSegmentNotFound:      "segment doesn't exist",
+		CodeSegmentAlreadyExists: "segment already exists",
+		CodeUserAlreadyInSegment: "user already in segment",
+		CodeWebhookNotFound:      "webhook doesn't exist",
+		CodeLinkExpired:          "link expired",
+		CodeInvalidSignature:     "invalid signature",
+		CodeUnauthenticated:      "valid api key is required",
+		CodeForbidden:            "api key doesn't have required scope",
+		CodeAPIKeyNotFound:       "api key doesn't exist",
+		CodeRateLimited:          "too many requests, retry later",
+		CodeNotReady:             "service isn't ready to serve requests",
+
+		MsgRequired:         "%s shouldn't be empty",
+		MsgPositive:         "%s should be more than 0",
+		MsgNonNegative:      "%s shouldn't be negative",
+		MsgBetween:          "%s should be between %d and %d",
+		MsgMaxItems:         "no more than %[2]d %[1]s are allowed",
+		MsgOneOf:            "%s must be one of: %s",
+		MsgMonth:            "%s must be in year-month format",
+		MsgFromBeforeTo:     "%s must be less than to",
+		MsgSlugFormat:       "%s shouldn't be empty or contain '/'",
+		MsgHTTPURL:          "%s should be absolute https url",
+		MsgUnknownEventType: "%s contains unknown event type",
+		MsgUnknownScope:     "%s contains unknown scope",
+	},
+	LanguageRU: {
+		CodeInternal:             "непредвиденная ошибка",
+		CodeMethodNotAllowed:     "метод не поддерживается",
+		CodeMalformedRequest:     "некорректный запрос",
+		CodeValidationFailed:     "ошибка в параметрах запроса",
+		CodeNotFound:             "не найдено",
+		CodeSegmentNotFound:      "сегмент не существует",
+		CodeSegmentAlreadyExists: "сегмент уже существует",
+		CodeUserAlreadyInSegment: "пользователь уже состоит в сегменте",
+		CodeWebhookNotFound:      "вебхук не существует",
+		CodeLinkExpired:          "срок действия ссылки истёк",
+		CodeInvalidSignature:     "неверная подпись ссылки",
+		CodeUnauthenticated:      "требуется действующий api ключ",
+		CodeForbidden:            "у api ключа нет нужного права",
+		CodeAPIKeyNotFound:       "api ключ не существует",
+		CodeRateLimited:          "слишком много запросов, повторите позже",
+		CodeNotReady:             "сервис не готов обрабатывать запросы",
+
+		MsgRequired:         "%s не должно быть пустым",
+		MsgPositive:         "%s должно быть больше 0",
+		MsgNonNegative:      "%s не должно быть отрицательным",
+		MsgBetween:          "%s должно быть от %d до %d",
+		MsgMaxItems:         "допускается не больше %[2]d значений %[1]s",
+		MsgOneOf:            "%s должно быть одним из: %s",
+		MsgMonth:            "%s должно быть в формате год-месяц",
+		MsgFromBeforeTo:     "%s должно быть меньше to",
+		MsgSlugFormat:       "%s не должно быть пустым или содержать '/'",
+		MsgHTTPURL:          "%s должен быть абсолютным https url",
+		MsgUnknownEventType: "%s содержит неизвестный тип события",
+		MsgUnknownScope:     "%s содержит неизвестное право",
+	},
+}
+
+// message returns message by key in language. English message is used when there is no translation
+func message(language string, key string, args ...any) string {
+	text, ok := catalog[language][key]
+	if !ok {
+		text, ok = catalog[DefaultLanguage][key]
+	}
+	if !ok {
+		return key
+	}
+
+	if len(args) == 0 {
+		return text
+	}
+
+	return fmt.Sprintf(text, args...)
+}
@@ -0,0 +1,26 @@
+package metrics
+
+import (
+	"net/http"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+
+	"github.com/pollykon/avito_test_task/internal/metrics"
+)
+
+// Instrument counts requests to handler and measures their duration by method and status code.
+// It must wrap authentication and rate limit middlewares, so that rejected requests are counted too
+func Instrument(handler string, next http.Handler) http.Handler {
+	labels := prometheus.Labels{"handler": handler}
+
+	return promhttp.InstrumentHandlerCounter(
+		metrics.HTTPRequests.MustCurryWith(labels),
+		promhttp.InstrumentHandlerDuration(metrics.HTTPRequestDuration.MustCurryWith(labels), next),
+	)
+}
+
+// Handler exposes metrics in prometheus format
+func Handler() http.Handler {
+	return promhttp.Handler()
+}
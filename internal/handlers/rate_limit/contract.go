@@ -0,0 +1,13 @@
+//go:generate mockery --all --output ./mocks --case underscore --with-expecter
+package rate_limit
+
+import (
+	"context"
+
+	serviceRateLimit "github.com/pollykon/avito_test_task/internal/service/rate_limit"
+)
+
+type RateLimiter interface {
+	Allow(ctx context.Context, client string, endpoint string) (serviceRateLimit.Decision, error)
+	AllowAddress(ctx context.Context, address string) (serviceRateLimit.Decision, error)
+}
@@ -0,0 +1,122 @@
+package rate_limit
+
+import (
+	"context"
+	"encoding/json"
+	"log/slog"
+	"math"
+	"net"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/pollykon/avito_test_task/internal/actor"
+	"github.com/pollykon/avito_test_task/internal/handlers"
+)
+
+// Middleware rejects requests of clients which exceeded limit of endpoint
+type Middleware struct {
+	rateLimiter RateLimiter
+	enabled     bool
+	logger      *slog.Logger
+}
+
+func New(rateLimiter RateLimiter, enabled bool, logger *slog.Logger) Middleware {
+	return Middleware{
+		rateLimiter: rateLimiter,
+		enabled:     enabled,
+		logger:      logger,
+	}
+}
+
+// Limit limits requests to endpoint by client. Client is an actor from authentication middleware which must wrap
+// this one, requests without actor are limited by ip address
+func (m Middleware) Limit(endpoint string, next http.Handler) http.Handler {
+	if !m.enabled {
+		return next
+	}
+
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		ctx := r.Context()
+
+		client := Client(ctx, r.RemoteAddr)
+		decision, err := m.rateLimiter.Allow(ctx, client, endpoint)
+		if err != nil {
+			// limits protect service, so it keeps serving requests when they can't be checked
+			m.logger.ErrorContext(ctx, "error while checking rate limit", "error", err, "client", client)
+			next.ServeHTTP(w, r)
+			return
+		}
+
+		if !decision.Allowed {
+			m.logger.InfoContext(ctx, "request is rate limited", "client", client, "endpoint", endpoint)
+			writeError(w, handlers.NewError(ctx, handlers.CodeRateLimited), decision.RetryAfter)
+			return
+		}
+
+		next.ServeHTTP(w, r)
+	})
+}
+
+// LimitAddress limits all requests of ip address. It must wrap authentication middleware, so that requests with
+// missing or wrong keys can't load database with lookups of keys without limit
+func (m Middleware) LimitAddress(next http.Handler) http.Handler {
+	if !m.enabled {
+		return next
+	}
+
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		ctx := r.Context()
+
+		address := Address(r.RemoteAddr)
+		decision, err := m.rateLimiter.AllowAddress(ctx, address)
+		if err != nil {
+			m.logger.ErrorContext(ctx, "error while checking rate limit of address", "error", err, "address", address)
+			next.ServeHTTP(w, r)
+			return
+		}
+
+		if !decision.Allowed {
+			m.logger.InfoContext(ctx, "request is rate limited by address", "address", address)
+			writeError(w, handlers.NewError(ctx, handlers.CodeRateLimited), decision.RetryAfter)
+			return
+		}
+
+		next.ServeHTTP(w, r)
+	})
+}
+
+// Client returns id of actor or ip address from remoteAddr
+func Client(ctx context.Context, remoteAddr string) string {
+	if a, ok := actor.FromContext(ctx); ok {
+		return a.ID
+	}
+
+	return "ip:" + Address(remoteAddr)
+}
+
+// Address returns ip address from remoteAddr. X-Forwarded-For isn't trusted, it is set by clients
+func Address(remoteAddr string) string {
+	host, _, err := net.SplitHostPort(remoteAddr)
+	if err != nil {
+		return remoteAddr
+	}
+
+	return host
+}
+
+// RetryAfterSeconds rounds delay up to whole seconds for Retry-After header
+func RetryAfterSeconds(retryAfter time.Duration) string {
+	return strconv.Itoa(int(math.Max(1, math.Ceil(retryAfter.Seconds()))))
+}
+
+func writeError(w http.ResponseWriter, responseErr *handlers.Error, retryAfter time.Duration) {
+	status := responseErr.HTTPStatus()
+	w.Header().Set("Content-Type", handlers.ContentTypeJSON)
+	w.Header().Set("Retry-After", RetryAfterSeconds(retryAfter))
+	w.WriteHeader(status)
+	_ = json.NewEncoder(w).Encode(HandlerResponse{
+		Status: status,
+		Error:  responseErr,
+	})
+}
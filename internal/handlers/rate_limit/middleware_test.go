@@ -0,0 +1,191 @@
+package rate_limit
+
+import (
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+
+	"github.com/pollykon/avito_test_task/internal/actor"
+	"github.com/pollykon/avito_test_task/internal/handlers"
+	"github.com/pollykon/avito_test_task/internal/handlers/rate_limit/mocks"
+	"github.com/pollykon/avito_test_task/internal/logger"
+	serviceRateLimit "github.com/pollykon/avito_test_task/internal/service/rate_limit"
+)
+
+func TestMiddleware_Limit(t *testing.T) {
+	tt := []struct {
+		name string
+
+		actor *actor.Actor
+
+		buildRateLimiterMock func(rateLimiter *mocks.RateLimiter)
+
+		expectedStatusCode int
+		expectedRetryAfter string
+		expectedResponse   *HandlerResponse
+	}{
+		{
+			name: "allowed",
+
+			actor: &actor.Actor{ID: "api_key:1"},
+
+			buildRateLimiterMock: func(rateLimiter *mocks.RateLimiter) {
+				rateLimiter.EXPECT().Allow(mock.Anything, "api_key:1", "get_user_active_segments_v1").
+					Return(serviceRateLimit.Decision{Allowed: true}, nil)
+			},
+
+			expectedStatusCode: http.StatusOK,
+		},
+		{
+			name: "rate_limited",
+
+			actor: &actor.Actor{ID: "api_key:1"},
+
+			buildRateLimiterMock: func(rateLimiter *mocks.RateLimiter) {
+				rateLimiter.EXPECT().Allow(mock.Anything, "api_key:1", "get_user_active_segments_v1").
+					Return(serviceRateLimit.Decision{Allowed: false, RetryAfter: 1500 * time.Millisecond}, nil)
+			},
+
+			expectedStatusCode: http.StatusTooManyRequests,
+			expectedRetryAfter: "2",
+			expectedResponse: &HandlerResponse{
+				Status: http.StatusTooManyRequests,
+				Error:  &handlers.Error{Code: "RATE_LIMITED", Message: "too many requests, retry later"},
+			},
+		},
+		{
+			name: "without_actor_limited_by_ip",
+
+			actor: nil,
+
+			buildRateLimiterMock: func(rateLimiter *mocks.RateLimiter) {
+				rateLimiter.EXPECT().Allow(mock.Anything, "ip:192.0.2.1", "get_user_active_segments_v1").
+					Return(serviceRateLimit.Decision{Allowed: false, RetryAfter: 10 * time.Millisecond}, nil)
+			},
+
+			expectedStatusCode: http.StatusTooManyRequests,
+			expectedRetryAfter: "1",
+		},
+		{
+			name: "limiter_error",
+
+			actor: &actor.Actor{ID: "api_key:1"},
+
+			buildRateLimiterMock: func(rateLimiter *mocks.RateLimiter) {
+				rateLimiter.EXPECT().Allow(mock.Anything, "api_key:1", "get_user_active_segments_v1").
+					Return(serviceRateLimit.Decision{}, fmt.Errorf("db is down"))
+			},
+
+			expectedStatusCode: http.StatusOK,
+		},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			rateLimiterMock := mocks.NewRateLimiter(t)
+			if tc.buildRateLimiterMock != nil {
+				tc.buildRateLimiterMock(rateLimiterMock)
+			}
+
+			request := httptest.NewRequest(http.MethodPost, "/get_user_active_segments_v1", nil)
+			if tc.actor != nil {
+				request = request.WithContext(actor.WithActor(request.Context(), *tc.actor))
+			}
+			w := httptest.NewRecorder()
+
+			next := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {})
+			New(rateLimiterMock, true, slog.New(logger.NewNoopHandler())).
+				Limit("get_user_active_segments_v1", next).
+				ServeHTTP(w, request)
+
+			responseResult := w.Result()
+
+			assert.Equal(t, tc.expectedStatusCode, responseResult.StatusCode)
+			assert.Equal(t, tc.expectedRetryAfter, responseResult.Header.Get("Retry-After"))
+
+			if tc.expectedResponse != nil {
+				var response HandlerResponse
+				err := json.NewDecoder(responseResult.Body).Decode(&response)
+				assert.NoError(t, err)
+
+				assert.Equal(t, *tc.expectedResponse, response)
+			}
+		})
+	}
+}
+
+func TestMiddleware_LimitAddress(t *testing.T) {
+	tt := []struct {
+		name string
+
+		buildRateLimiterMock func(rateLimiter *mocks.RateLimiter)
+
+		expectedStatusCode int
+		expectedRetryAfter string
+		expectedNextCalled bool
+	}{
+		{
+			name: "allowed",
+
+			buildRateLimiterMock: func(rateLimiter *mocks.RateLimiter) {
+				rateLimiter.EXPECT().AllowAddress(mock.Anything, "192.0.2.1").
+					Return(serviceRateLimit.Decision{Allowed: true}, nil)
+			},
+
+			expectedStatusCode: http.StatusOK,
+			expectedNextCalled: true,
+		},
+		{
+			name: "rate_limited",
+
+			buildRateLimiterMock: func(rateLimiter *mocks.RateLimiter) {
+				rateLimiter.EXPECT().AllowAddress(mock.Anything, "192.0.2.1").
+					Return(serviceRateLimit.Decision{Allowed: false, RetryAfter: 2 * time.Second}, nil)
+			},
+
+			expectedStatusCode: http.StatusTooManyRequests,
+			expectedRetryAfter: "2",
+			expectedNextCalled: false,
+		},
+		{
+			name: "limiter_error",
+
+			buildRateLimiterMock: func(rateLimiter *mocks.RateLimiter) {
+				rateLimiter.EXPECT().AllowAddress(mock.Anything, "192.0.2.1").
+					Return(serviceRateLimit.Decision{}, fmt.Errorf("db is down"))
+			},
+
+			expectedStatusCode: http.StatusOK,
+			expectedNextCalled: true,
+		},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			rateLimiterMock := mocks.NewRateLimiter(t)
+			tc.buildRateLimiterMock(rateLimiterMock)
+
+			request := httptest.NewRequest(http.MethodPost, "/get_user_active_segments_v1", nil)
+			w := httptest.NewRecorder()
+
+			nextCalled := false
+			next := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) { nextCalled = true })
+			New(rateLimiterMock, true, slog.New(logger.NewNoopHandler())).
+				LimitAddress(next).
+				ServeHTTP(w, request)
+
+			responseResult := w.Result()
+
+			assert.Equal(t, tc.expectedStatusCode, responseResult.StatusCode)
+			assert.Equal(t, tc.expectedRetryAfter, responseResult.Header.Get("Retry-After"))
+			assert.Equal(t, tc.expectedNextCalled, nextCalled)
+		})
+	}
+}
@@ -0,0 +1,145 @@
+// Code generated by mockery v2.33.0. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+
+	mock "github.com/stretchr/testify/mock"
+
+	rate_limit "github.com/pollykon/avito_test_task/internal/service/rate_limit"
+)
+
+// RateLimiter is an autogenerated mock type for the RateLimiter type
+type RateLimiter struct {
+	mock.Mock
+}
+
+type RateLimiter_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *RateLimiter) EXPECT() *RateLimiter_Expecter {
+	return &RateLimiter_Expecter{mock: &_m.Mock}
+}
+
+// Allow provides a mock function with given fields: ctx, client, endpoint
+func (_m *RateLimiter) Allow(ctx context.Context, client string, endpoint string) (rate_limit.Decision, error) {
+	ret := _m.Called(ctx, client, endpoint)
+
+	var r0 rate_limit.Decision
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) (rate_limit.Decision, error)); ok {
+		return rf(ctx, client, endpoint)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) rate_limit.Decision); ok {
+		r0 = rf(ctx, client, endpoint)
+	} else {
+		r0 = ret.Get(0).(rate_limit.Decision)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, string) error); ok {
+		r1 = rf(ctx, client, endpoint)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// RateLimiter_Allow_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Allow'
+type RateLimiter_Allow_Call struct {
+	*mock.Call
+}
+
+// Allow is a helper method to define mock.On call
+//   - ctx context.Context
+//   - client string
+//   - endpoint string
+func (_e *RateLimiter_Expecter) Allow(ctx interface{}, client interface{}, endpoint interface{}) *RateLimiter_Allow_Call {
+	return &RateLimiter_Allow_Call{Call: _e.mock.On("Allow", ctx, client, endpoint)}
+}
+
+func (_c *RateLimiter_Allow_Call) Run(run func(ctx context.Context, client string, endpoint string)) *RateLimiter_Allow_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string), args[2].(string))
+	})
+	return _c
+}
+
+func (_c *RateLimiter_Allow_Call) Return(_a0 rate_limit.Decision, _a1 error) *RateLimiter_Allow_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *RateLimiter_Allow_Call) RunAndReturn(run func(context.Context, string, string) (rate_limit.Decision, error)) *RateLimiter_Allow_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// AllowAddress provides a mock function with given fields: ctx, address
+func (_m *RateLimiter) AllowAddress(ctx context.Context, address string) (rate_limit.Decision, error) {
+	ret := _m.Called(ctx, address)
+
+	var r0 rate_limit.Decision
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) (rate_limit.Decision, error)); ok {
+		return rf(ctx, address)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) rate_limit.Decision); ok {
+		r0 = rf(ctx, address)
+	} else {
+		r0 = ret.Get(0).(rate_limit.Decision)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, address)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// RateLimiter_AllowAddress_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'AllowAddress'
+type RateLimiter_AllowAddress_Call struct {
+	*mock.Call
+}
+
+// AllowAddress is a helper method to define mock.On call
+//   - ctx context.Context
+//   - address string
+func (_e *RateLimiter_Expecter) AllowAddress(ctx interface{}, address interface{}) *RateLimiter_AllowAddress_Call {
+	return &RateLimiter_AllowAddress_Call{Call: _e.mock.On("AllowAddress", ctx, address)}
+}
+
+func (_c *RateLimiter_AllowAddress_Call) Run(run func(ctx context.Context, address string)) *RateLimiter_AllowAddress_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string))
+	})
+	return _c
+}
+
+func (_c *RateLimiter_AllowAddress_Call) Return(_a0 rate_limit.Decision, _a1 error) *RateLimiter_AllowAddress_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *RateLimiter_AllowAddress_Call) RunAndReturn(run func(context.Context, string) (rate_limit.Decision, error)) *RateLimiter_AllowAddress_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// NewRateLimiter creates a new instance of RateLimiter. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewRateLimiter(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *RateLimiter {
+	mock := &RateLimiter{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
@@ -0,0 +1,12 @@
+//go:generate mockery --all --output ./mocks --case underscore --with-expecter
+package readiness
+
+import (
+	"context"
+
+	serviceHealth "github.com/pollykon/avito_test_task/internal/service/health"
+)
+
+type HealthService interface {
+	Ready(ctx context.Context) serviceHealth.Report
+}
@@ -0,0 +1,16 @@
+package readiness
+
+import "github.com/pollykon/avito_test_task/internal/handlers"
+
+type HandlerResponse struct {
+	Status int             `json:"status"`
+	Error  *handlers.Error `json:"error,omitempty"`
+	Checks []Check         `json:"checks,omitempty"`
+}
+
+// Check is a result of readiness check, Error describes why check failed
+type Check struct {
+	Name  string `json:"name"`
+	Ready bool   `json:"ready"`
+	Error string `json:"error,omitempty"`
+}
@@ -0,0 +1,61 @@
+package readiness
+
+import (
+	"context"
+	"encoding/json"
+	"log/slog"
+	"net/http"
+
+	"github.com/pollykon/avito_test_task/internal/handlers"
+)
+
+// Handler answers 503 while service can't serve requests, e.g. database is unavailable or service is shutting down
+type Handler struct {
+	healthService HealthService
+	logger        *slog.Logger
+}
+
+func New(s HealthService, l *slog.Logger) Handler {
+	return Handler{healthService: s, logger: l}
+}
+
+func (h Handler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", handlers.ContentTypeJSON)
+
+	if r.Method != http.MethodGet && r.Method != http.MethodHead {
+		w.WriteHeader(http.StatusMethodNotAllowed)
+		_ = json.NewEncoder(w).Encode(HandlerResponse{
+			Status: http.StatusMethodNotAllowed,
+			Error:  handlers.ErrMethodNotAllowed(r.Context()),
+		})
+		return
+	}
+
+	response := h.handle(r.Context())
+	w.WriteHeader(response.Status)
+	_ = json.NewEncoder(w).Encode(response)
+}
+
+func (h Handler) handle(ctx context.Context) HandlerResponse {
+	report := h.healthService.Ready(ctx)
+
+	checks := make([]Check, 0, len(report.Checks))
+	for _, check := range report.Checks {
+		responseCheck := Check{Name: check.Name, Ready: check.Error == nil}
+		if check.Error != nil {
+			h.logger.WarnContext(ctx, "readiness check failed", "error", check.Error, "check", check.Name)
+			responseCheck.Error = check.Error.Error()
+		}
+		checks = append(checks, responseCheck)
+	}
+
+	if !report.Ready() {
+		return HandlerResponse{
+			Status: http.StatusServiceUnavailable,
+			Error:  handlers.NewError(ctx, handlers.CodeNotReady),
+			Checks: checks,
+		}
+	}
+
+	return HandlerResponse{Status: http.StatusOK, Checks: checks}
+}
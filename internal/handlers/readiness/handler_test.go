@@ -0,0 +1,128 @@
+package readiness
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/pollykon/avito_test_task/internal/handlers"
+	"github.com/pollykon/avito_test_task/internal/handlers/readiness/mocks"
+	"github.com/pollykon/avito_test_task/internal/logger"
+	serviceHealth "github.com/pollykon/avito_test_task/internal/service/health"
+)
+
+func TestReadinessHandler(t *testing.T) {
+	tt := []struct {
+		name string
+
+		requestMethod string
+
+		buildHealthServiceMock func(service *mocks.HealthService)
+
+		expectedStatusCode int
+		expectedResponse   HandlerResponse
+	}{
+		{
+			name: "ready",
+
+			requestMethod: http.MethodGet,
+
+			buildHealthServiceMock: func(service *mocks.HealthService) {
+				service.EXPECT().Ready(context.Background()).Return(serviceHealth.Report{Checks: []serviceHealth.Check{
+					{Name: serviceHealth.CheckDatabase},
+					{Name: serviceHealth.CheckSchema},
+				}})
+			},
+
+			expectedStatusCode: http.StatusOK,
+			expectedResponse: HandlerResponse{
+				Status: http.StatusOK,
+				Checks: []Check{
+					{Name: serviceHealth.CheckDatabase, Ready: true},
+					{Name: serviceHealth.CheckSchema, Ready: true},
+				},
+			},
+		},
+		{
+			name: "not_ready",
+
+			requestMethod: http.MethodGet,
+
+			buildHealthServiceMock: func(service *mocks.HealthService) {
+				service.EXPECT().Ready(context.Background()).Return(serviceHealth.Report{Checks: []serviceHealth.Check{
+					{Name: serviceHealth.CheckDatabase, Error: fmt.Errorf("connection refused")},
+					{Name: serviceHealth.CheckSchema},
+				}})
+			},
+
+			expectedStatusCode: http.StatusServiceUnavailable,
+			expectedResponse: HandlerResponse{
+				Status: http.StatusServiceUnavailable,
+				Error:  &handlers.Error{Code: "NOT_READY", Message: "service isn't ready to serve requests"},
+				Checks: []Check{
+					{Name: serviceHealth.CheckDatabase, Ready: false, Error: "connection refused"},
+					{Name: serviceHealth.CheckSchema, Ready: true},
+				},
+			},
+		},
+		{
+			name: "shutting_down",
+
+			requestMethod: http.MethodGet,
+
+			buildHealthServiceMock: func(service *mocks.HealthService) {
+				service.EXPECT().Ready(context.Background()).Return(serviceHealth.Report{Checks: []serviceHealth.Check{
+					{Name: serviceHealth.CheckShutdown, Error: serviceHealth.ErrShuttingDown},
+				}})
+			},
+
+			expectedStatusCode: http.StatusServiceUnavailable,
+			expectedResponse: HandlerResponse{
+				Status: http.StatusServiceUnavailable,
+				Error:  &handlers.Error{Code: "NOT_READY", Message: "service isn't ready to serve requests"},
+				Checks: []Check{
+					{Name: serviceHealth.CheckShutdown, Ready: false, Error: serviceHealth.ErrShuttingDown.Error()},
+				},
+			},
+		},
+		{
+			name: "wrong_method",
+
+			requestMethod: http.MethodPost,
+
+			buildHealthServiceMock: nil,
+
+			expectedStatusCode: http.StatusMethodNotAllowed,
+			expectedResponse: HandlerResponse{
+				Status: http.StatusMethodNotAllowed,
+				Error:  &handlers.Error{Code: "METHOD_NOT_ALLOWED", Message: "method not allowed"},
+			},
+		},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			healthServiceMock := mocks.NewHealthService(t)
+			if tc.buildHealthServiceMock != nil {
+				tc.buildHealthServiceMock(healthServiceMock)
+			}
+
+			w := httptest.NewRecorder()
+			handler := New(healthServiceMock, slog.New(logger.NewNoopHandler()))
+
+			handler.ServeHTTP(w, httptest.NewRequest(tc.requestMethod, "/readyz", nil))
+
+			var response HandlerResponse
+			_ = json.NewDecoder(w.Body).Decode(&response)
+
+			assert.Equal(t, tc.expectedStatusCode, w.Code)
+			assert.Equal(t, tc.expectedResponse, response)
+		})
+	}
+}
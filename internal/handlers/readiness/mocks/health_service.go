@@ -0,0 +1,79 @@
+// Code generated by mockery v2.33.0. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+
+	health "github.com/pollykon/avito_test_task/internal/service/health"
+	mock "github.com/stretchr/testify/mock"
+)
+
+// HealthService is an autogenerated mock type for the HealthService type
+type HealthService struct {
+	mock.Mock
+}
+
+type HealthService_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *HealthService) EXPECT() *HealthService_Expecter {
+	return &HealthService_Expecter{mock: &_m.Mock}
+}
+
+// Ready provides a mock function with given fields: ctx
+func (_m *HealthService) Ready(ctx context.Context) health.Report {
+	ret := _m.Called(ctx)
+
+	var r0 health.Report
+	if rf, ok := ret.Get(0).(func(context.Context) health.Report); ok {
+		r0 = rf(ctx)
+	} else {
+		r0 = ret.Get(0).(health.Report)
+	}
+
+	return r0
+}
+
+// HealthService_Ready_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Ready'
+type HealthService_Ready_Call struct {
+	*mock.Call
+}
+
+// Ready is a helper method to define mock.On call
+//   - ctx context.Context
+func (_e *HealthService_Expecter) Ready(ctx interface{}) *HealthService_Ready_Call {
+	return &HealthService_Ready_Call{Call: _e.mock.On("Ready", ctx)}
+}
+
+func (_c *HealthService_Ready_Call) Run(run func(ctx context.Context)) *HealthService_Ready_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context))
+	})
+	return _c
+}
+
+func (_c *HealthService_Ready_Call) Return(_a0 health.Report) *HealthService_Ready_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *HealthService_Ready_Call) RunAndReturn(run func(context.Context) health.Report) *HealthService_Ready_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// NewHealthService creates a new instance of HealthService. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewHealthService(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *HealthService {
+	mock := &HealthService{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
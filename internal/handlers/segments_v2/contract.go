@@ -0,0 +1,14 @@
+//go:generate mockery --all --output ./mocks --case underscore --with-expecter
+package segments_v2
+
+import (
+	"context"
+
+	segmentService "github.com/pollykon/avito_test_task/internal/service/segment"
+)
+
+type SegmentService interface {
+	GetSegments(ctx context.Context) ([]segmentService.Segment, error)
+	AddSegment(ctx context.Context, slug string, percent *int64) error
+	DeleteSegment(ctx context.Context, slug string) error
+}
@@ -0,0 +1,21 @@
+package segments_v2
+
+import "github.com/pollykon/avito_test_task/internal/handlers"
+
+type CreateSegmentRequest struct {
+	Slug    string `json:"slug"`
+	Percent *int64 `json:"percent"`
+}
+
+type Segment struct {
+	Slug    string `json:"slug"`
+	Percent *int64 `json:"percent,omitempty"`
+}
+
+type GetSegmentsResponse struct {
+	Segments []Segment `json:"segments"`
+}
+
+type ErrorResponse struct {
+	Error *handlers.Error `json:"error"`
+}
@@ -0,0 +1,137 @@
+package segments_v2
+
+import (
+	"encoding/json"
+	"log/slog"
+	"net/http"
+	"net/url"
+	"strings"
+
+	"github.com/pollykon/avito_test_task/internal/handlers"
+)
+
+// URIPrefix is a path of segments collection. Handler must be registered on URIPrefix and URIPrefix + "/"
+const URIPrefix = "/v2/segments"
+
+type Handler struct {
+	segmentService SegmentService
+	logger         *slog.Logger
+}
+
+func New(s SegmentService, l *slog.Logger) Handler {
+	return Handler{segmentService: s, logger: l}
+}
+
+// ServeHTTP routes requests to segments collection (GET, POST /v2/segments)
+// and to single segment (DELETE /v2/segments/{slug})
+func (h Handler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	path := strings.TrimPrefix(r.URL.Path, URIPrefix)
+	if path == "" || path == "/" {
+		h.serveCollection(w, r)
+		return
+	}
+
+	slug := strings.TrimPrefix(path, "/")
+	if strings.Contains(slug, "/") {
+		writeError(w, handlers.ErrNotFound(r.Context()))
+		return
+	}
+
+	h.serveSegment(w, r, slug)
+}
+
+func (h Handler) serveCollection(w http.ResponseWriter, r *http.Request) {
+	switch r.Method {
+	case http.MethodGet:
+		h.getSegments(w, r)
+	case http.MethodPost:
+		h.createSegment(w, r)
+	default:
+		w.Header().Set("Allow", strings.Join([]string{http.MethodGet, http.MethodPost}, ", "))
+		writeError(w, handlers.ErrMethodNotAllowed(r.Context()))
+	}
+}
+
+func (h Handler) serveSegment(w http.ResponseWriter, r *http.Request, slug string) {
+	if r.Method != http.MethodDelete {
+		w.Header().Set("Allow", http.MethodDelete)
+		writeError(w, handlers.ErrMethodNotAllowed(r.Context()))
+		return
+	}
+
+	err := h.segmentService.DeleteSegment(r.Context(), slug)
+	if err != nil {
+		if responseErr := handlers.FromServiceError(r.Context(), err); responseErr != nil {
+			writeError(w, responseErr)
+			return
+		}
+
+		h.logger.ErrorContext(r.Context(), "error while deleting segment", "error", err, "slug", slug)
+		writeError(w, handlers.ErrInternal(r.Context()))
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func (h Handler) getSegments(w http.ResponseWriter, r *http.Request) {
+	segments, err := h.segmentService.GetSegments(r.Context())
+	if err != nil {
+		h.logger.ErrorContext(r.Context(), "error while getting segments", "error", err)
+		writeError(w, handlers.ErrInternal(r.Context()))
+		return
+	}
+
+	response := GetSegmentsResponse{Segments: make([]Segment, 0, len(segments))}
+	for _, segment := range segments {
+		response.Segments = append(response.Segments, Segment{Slug: segment.Slug, Percent: segment.Percent})
+	}
+
+	writeJSON(w, http.StatusOK, response)
+}
+
+func (h Handler) createSegment(w http.ResponseWriter, r *http.Request) {
+	defer func() { _ = r.Body.Close() }()
+
+	var request CreateSegmentRequest
+	err := json.NewDecoder(r.Body).Decode(&request)
+	if err != nil {
+		h.logger.ErrorContext(r.Context(), "error while parsing request", "error", err, "request", request)
+		writeError(w, handlers.ErrMalformedRequest(r.Context()))
+		return
+	}
+
+	if request.Slug == "" || strings.Contains(request.Slug, "/") {
+		writeError(w, handlers.NewValidationError(r.Context(), "slug", handlers.MsgSlugFormat))
+		return
+	}
+	if request.Percent != nil && (*request.Percent < 0 || *request.Percent > 100) {
+		writeError(w, handlers.NewValidationError(r.Context(), "percent", handlers.MsgBetween, 0, 100))
+		return
+	}
+
+	err = h.segmentService.AddSegment(r.Context(), request.Slug, request.Percent)
+	if err != nil {
+		if responseErr := handlers.FromServiceError(r.Context(), err); responseErr != nil {
+			writeError(w, responseErr)
+			return
+		}
+
+		h.logger.ErrorContext(r.Context(), "error while adding segment", "error", err, "request", request)
+		writeError(w, handlers.ErrInternal(r.Context()))
+		return
+	}
+
+	w.Header().Set("Location", URIPrefix+"/"+url.PathEscape(request.Slug))
+	writeJSON(w, http.StatusCreated, Segment{Slug: request.Slug, Percent: request.Percent})
+}
+
+func writeJSON(w http.ResponseWriter, status int, body any) {
+	w.Header().Set("Content-Type", handlers.ContentTypeJSON)
+	w.WriteHeader(status)
+	_ = json.NewEncoder(w).Encode(body)
+}
+
+func writeError(w http.ResponseWriter, responseErr *handlers.Error) {
+	writeJSON(w, responseErr.HTTPStatus(), ErrorResponse{Error: responseErr})
+}
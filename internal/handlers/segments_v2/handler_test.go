@@ -0,0 +1,280 @@
+package segments_v2
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/pollykon/avito_test_task/internal/handlers"
+	"github.com/pollykon/avito_test_task/internal/handlers/segments_v2/mocks"
+	"github.com/pollykon/avito_test_task/internal/logger"
+	segmentService "github.com/pollykon/avito_test_task/internal/service/segment"
+)
+
+func TestSegmentsV2Handler(t *testing.T) {
+	percent := int64(10)
+	wrongPercent := int64(101)
+
+	tt := []struct {
+		name string
+
+		requestMethod string
+		requestPath   string
+		requestBody   string
+
+		buildSegmentServiceMock func(service *mocks.SegmentService)
+
+		expectedStatusCode int
+		expectedLocation   string
+		expectedAllow      string
+		expectedBody       interface{}
+	}{
+		{
+			name: "get_segments",
+
+			requestMethod: http.MethodGet,
+			requestPath:   "/v2/segments",
+
+			buildSegmentServiceMock: func(service *mocks.SegmentService) {
+				service.EXPECT().GetSegments(context.Background()).Return([]segmentService.Segment{
+					{Slug: "AVITO"},
+					{Slug: "VOICE", Percent: &percent},
+				}, nil)
+			},
+
+			expectedStatusCode: http.StatusOK,
+			expectedBody: &GetSegmentsResponse{Segments: []Segment{
+				{Slug: "AVITO"},
+				{Slug: "VOICE", Percent: &percent},
+			}},
+		},
+		{
+			name: "get_segments_empty",
+
+			requestMethod: http.MethodGet,
+			requestPath:   "/v2/segments/",
+
+			buildSegmentServiceMock: func(service *mocks.SegmentService) {
+				service.EXPECT().GetSegments(context.Background()).Return(nil, nil)
+			},
+
+			expectedStatusCode: http.StatusOK,
+			expectedBody:       &GetSegmentsResponse{Segments: []Segment{}},
+		},
+		{
+			name: "get_segments_unexpected_error",
+
+			requestMethod: http.MethodGet,
+			requestPath:   "/v2/segments",
+
+			buildSegmentServiceMock: func(service *mocks.SegmentService) {
+				service.EXPECT().GetSegments(context.Background()).Return(nil, fmt.Errorf("unexpected error"))
+			},
+
+			expectedStatusCode: http.StatusInternalServerError,
+			expectedBody:       &ErrorResponse{Error: &handlers.Error{Code: "INTERNAL", Message: "unexpected error"}},
+		},
+		{
+			name: "create_segment",
+
+			requestMethod: http.MethodPost,
+			requestPath:   "/v2/segments",
+			requestBody:   `{"slug":"AVITO","percent":10}`,
+
+			buildSegmentServiceMock: func(service *mocks.SegmentService) {
+				service.EXPECT().AddSegment(context.Background(), "AVITO", &percent).Return(nil)
+			},
+
+			expectedStatusCode: http.StatusCreated,
+			expectedLocation:   "/v2/segments/AVITO",
+			expectedBody:       &Segment{Slug: "AVITO", Percent: &percent},
+		},
+		{
+			name: "create_segment_decode_error",
+
+			requestMethod: http.MethodPost,
+			requestPath:   "/v2/segments",
+			requestBody:   `{"slug":0}`,
+
+			expectedStatusCode: http.StatusBadRequest,
+			expectedBody: &ErrorResponse{Error: &handlers.Error{
+				Code:    "MALFORMED_REQUEST",
+				Message: "bad request",
+			}},
+		},
+		{
+			name: "create_segment_empty_slug",
+
+			requestMethod: http.MethodPost,
+			requestPath:   "/v2/segments",
+			requestBody:   `{"slug":""}`,
+
+			expectedStatusCode: http.StatusBadRequest,
+			expectedBody: &ErrorResponse{Error: &handlers.Error{
+				Code:    "VALIDATION_FAILED",
+				Message: "slug shouldn't be empty or contain '/'",
+				Details: []handlers.FieldError{{Field: "slug", Message: "slug shouldn't be empty or contain '/'"}},
+			}},
+		},
+		{
+			name: "create_segment_wrong_percent",
+
+			requestMethod: http.MethodPost,
+			requestPath:   "/v2/segments",
+			requestBody:   fmt.Sprintf(`{"slug":"AVITO","percent":%d}`, wrongPercent),
+
+			expectedStatusCode: http.StatusBadRequest,
+			expectedBody: &ErrorResponse{Error: &handlers.Error{
+				Code:    "VALIDATION_FAILED",
+				Message: "percent should be between 0 and 100",
+				Details: []handlers.FieldError{{Field: "percent", Message: "percent should be between 0 and 100"}},
+			}},
+		},
+		{
+			name: "create_segment_already_exists",
+
+			requestMethod: http.MethodPost,
+			requestPath:   "/v2/segments",
+			requestBody:   `{"slug":"AVITO"}`,
+
+			buildSegmentServiceMock: func(service *mocks.SegmentService) {
+				service.EXPECT().AddSegment(context.Background(), "AVITO", (*int64)(nil)).
+					Return(segmentService.ErrSegmentAlreadyExists)
+			},
+
+			expectedStatusCode: http.StatusConflict,
+			expectedBody: &ErrorResponse{Error: &handlers.Error{
+				Code:    "SEGMENT_ALREADY_EXISTS",
+				Message: "segment already exists",
+			}},
+		},
+		{
+			name: "collection_wrong_method",
+
+			requestMethod: http.MethodDelete,
+			requestPath:   "/v2/segments",
+
+			expectedStatusCode: http.StatusMethodNotAllowed,
+			expectedAllow:      "GET, POST",
+			expectedBody: &ErrorResponse{Error: &handlers.Error{
+				Code:    "METHOD_NOT_ALLOWED",
+				Message: "method not allowed",
+			}},
+		},
+		{
+			name: "delete_segment",
+
+			requestMethod: http.MethodDelete,
+			requestPath:   "/v2/segments/AVITO",
+
+			buildSegmentServiceMock: func(service *mocks.SegmentService) {
+				service.EXPECT().DeleteSegment(context.Background(), "AVITO").Return(nil)
+			},
+
+			expectedStatusCode: http.StatusNoContent,
+		},
+		{
+			name: "delete_segment_not_exist",
+
+			requestMethod: http.MethodDelete,
+			requestPath:   "/v2/segments/AVITO",
+
+			buildSegmentServiceMock: func(service *mocks.SegmentService) {
+				service.EXPECT().DeleteSegment(context.Background(), "AVITO").Return(segmentService.ErrSegmentNotExist)
+			},
+
+			expectedStatusCode: http.StatusNotFound,
+			expectedBody: &ErrorResponse{Error: &handlers.Error{
+				Code:    "SEGMENT_NOT_FOUND",
+				Message: "segment doesn't exist",
+			}},
+		},
+		{
+			name: "delete_segment_unexpected_error",
+
+			requestMethod: http.MethodDelete,
+			requestPath:   "/v2/segments/AVITO",
+
+			buildSegmentServiceMock: func(service *mocks.SegmentService) {
+				service.EXPECT().DeleteSegment(context.Background(), "AVITO").Return(fmt.Errorf("unexpected error"))
+			},
+
+			expectedStatusCode: http.StatusInternalServerError,
+			expectedBody:       &ErrorResponse{Error: &handlers.Error{Code: "INTERNAL", Message: "unexpected error"}},
+		},
+		{
+			name: "segment_wrong_method",
+
+			requestMethod: http.MethodGet,
+			requestPath:   "/v2/segments/AVITO",
+
+			expectedStatusCode: http.StatusMethodNotAllowed,
+			expectedAllow:      "DELETE",
+			expectedBody: &ErrorResponse{Error: &handlers.Error{
+				Code:    "METHOD_NOT_ALLOWED",
+				Message: "method not allowed",
+			}},
+		},
+		{
+			name: "unknown_path",
+
+			requestMethod: http.MethodDelete,
+			requestPath:   "/v2/segments/AVITO/users",
+
+			expectedStatusCode: http.StatusNotFound,
+			expectedBody:       &ErrorResponse{Error: &handlers.Error{Code: "NOT_FOUND", Message: "not found"}},
+		},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			request := httptest.NewRequest(tc.requestMethod, tc.requestPath, strings.NewReader(tc.requestBody))
+			request = request.WithContext(context.Background())
+			w := httptest.NewRecorder()
+
+			segmentServiceMock := mocks.NewSegmentService(t)
+			if tc.buildSegmentServiceMock != nil {
+				tc.buildSegmentServiceMock(segmentServiceMock)
+			}
+
+			handler := New(segmentServiceMock, slog.New(logger.NewNoopHandler()))
+			handler.ServeHTTP(w, request)
+
+			responseResult := w.Result()
+
+			assert.Equal(t, tc.expectedStatusCode, responseResult.StatusCode)
+			assert.Equal(t, tc.expectedLocation, responseResult.Header.Get("Location"))
+			assert.Equal(t, tc.expectedAllow, responseResult.Header.Get("Allow"))
+
+			if tc.expectedBody == nil {
+				assert.Empty(t, w.Body.Bytes())
+				return
+			}
+
+			assert.Equal(t, handlers.ContentTypeJSON, responseResult.Header.Get("Content-Type"))
+
+			response := newEmptyBody(tc.expectedBody)
+			err := json.NewDecoder(responseResult.Body).Decode(response)
+			assert.NoError(t, err)
+			assert.Equal(t, tc.expectedBody, response)
+		})
+	}
+}
+
+func newEmptyBody(body interface{}) interface{} {
+	switch body.(type) {
+	case *GetSegmentsResponse:
+		return &GetSegmentsResponse{}
+	case *Segment:
+		return &Segment{}
+	default:
+		return &ErrorResponse{}
+	}
+}
@@ -0,0 +1,178 @@
+// Code generated by mockery v2.33.0. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+
+	segment "github.com/pollykon/avito_test_task/internal/service/segment"
+	mock "github.com/stretchr/testify/mock"
+)
+
+// SegmentService is an autogenerated mock type for the SegmentService type
+type SegmentService struct {
+	mock.Mock
+}
+
+type SegmentService_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *SegmentService) EXPECT() *SegmentService_Expecter {
+	return &SegmentService_Expecter{mock: &_m.Mock}
+}
+
+// AddSegment provides a mock function with given fields: ctx, slug, percent
+func (_m *SegmentService) AddSegment(ctx context.Context, slug string, percent *int64) error {
+	ret := _m.Called(ctx, slug, percent)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, *int64) error); ok {
+		r0 = rf(ctx, slug, percent)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// SegmentService_AddSegment_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'AddSegment'
+type SegmentService_AddSegment_Call struct {
+	*mock.Call
+}
+
+// AddSegment is a helper method to define mock.On call
+//   - ctx context.Context
+//   - slug string
+//   - percent *int64
+func (_e *SegmentService_Expecter) AddSegment(ctx interface{}, slug interface{}, percent interface{}) *SegmentService_AddSegment_Call {
+	return &SegmentService_AddSegment_Call{Call: _e.mock.On("AddSegment", ctx, slug, percent)}
+}
+
+func (_c *SegmentService_AddSegment_Call) Run(run func(ctx context.Context, slug string, percent *int64)) *SegmentService_AddSegment_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string), args[2].(*int64))
+	})
+	return _c
+}
+
+func (_c *SegmentService_AddSegment_Call) Return(_a0 error) *SegmentService_AddSegment_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *SegmentService_AddSegment_Call) RunAndReturn(run func(context.Context, string, *int64) error) *SegmentService_AddSegment_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// DeleteSegment provides a mock function with given fields: ctx, slug
+func (_m *SegmentService) DeleteSegment(ctx context.Context, slug string) error {
+	ret := _m.Called(ctx, slug)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) error); ok {
+		r0 = rf(ctx, slug)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// SegmentService_DeleteSegment_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'DeleteSegment'
+type SegmentService_DeleteSegment_Call struct {
+	*mock.Call
+}
+
+// DeleteSegment is a helper method to define mock.On call
+//   - ctx context.Context
+//   - slug string
+func (_e *SegmentService_Expecter) DeleteSegment(ctx interface{}, slug interface{}) *SegmentService_DeleteSegment_Call {
+	return &SegmentService_DeleteSegment_Call{Call: _e.mock.On("DeleteSegment", ctx, slug)}
+}
+
+func (_c *SegmentService_DeleteSegment_Call) Run(run func(ctx context.Context, slug string)) *SegmentService_DeleteSegment_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string))
+	})
+	return _c
+}
+
+func (_c *SegmentService_DeleteSegment_Call) Return(_a0 error) *SegmentService_DeleteSegment_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *SegmentService_DeleteSegment_Call) RunAndReturn(run func(context.Context, string) error) *SegmentService_DeleteSegment_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetSegments provides a mock function with given fields: ctx
+func (_m *SegmentService) GetSegments(ctx context.Context) ([]segment.Segment, error) {
+	ret := _m.Called(ctx)
+
+	var r0 []segment.Segment
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context) ([]segment.Segment, error)); ok {
+		return rf(ctx)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context) []segment.Segment); ok {
+		r0 = rf(ctx)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]segment.Segment)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context) error); ok {
+		r1 = rf(ctx)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// SegmentService_GetSegments_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetSegments'
+type SegmentService_GetSegments_Call struct {
+	*mock.Call
+}
+
+// GetSegments is a helper method to define mock.On call
+//   - ctx context.Context
+func (_e *SegmentService_Expecter) GetSegments(ctx interface{}) *SegmentService_GetSegments_Call {
+	return &SegmentService_GetSegments_Call{Call: _e.mock.On("GetSegments", ctx)}
+}
+
+func (_c *SegmentService_GetSegments_Call) Run(run func(ctx context.Context)) *SegmentService_GetSegments_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context))
+	})
+	return _c
+}
+
+func (_c *SegmentService_GetSegments_Call) Return(_a0 []segment.Segment, _a1 error) *SegmentService_GetSegments_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *SegmentService_GetSegments_Call) RunAndReturn(run func(context.Context) ([]segment.Segment, error)) *SegmentService_GetSegments_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// NewSegmentService creates a new instance of SegmentService. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewSegmentService(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *SegmentService {
+	mock := &SegmentService{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
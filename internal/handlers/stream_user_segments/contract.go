@@ -0,0 +1,12 @@
+//go:generate mockery --all --output ./mocks --case underscore --with-expecter
+package stream_user_segments
+
+import (
+	"context"
+
+	serviceStream "github.com/pollykon/avito_test_task/internal/service/stream"
+)
+
+type StreamService interface {
+	Subscribe(ctx context.Context, userIDs []int64, lastEventID int64) (serviceStream.Subscription, func(), error)
+}
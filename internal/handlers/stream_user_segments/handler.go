@@ -0,0 +1,169 @@
+package stream_user_segments
+
+import (
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/pollykon/avito_test_task/internal/handlers"
+	"github.com/pollykon/avito_test_task/internal/publisher"
+)
+
+const (
+	contentTypeEventStream = "text/event-stream"
+	headerLastEventID      = "Last-Event-ID"
+	maxUsers               = 100
+	// eventTypeReset tells client that too many events were missed and it must reload segments of users
+	eventTypeReset = "reset"
+)
+
+type Handler struct {
+	streamService     StreamService
+	heartbeatInterval time.Duration
+	logger            *slog.Logger
+}
+
+func New(s StreamService, heartbeatInterval time.Duration, l *slog.Logger) Handler {
+	return Handler{streamService: s, heartbeatInterval: heartbeatInterval, logger: l}
+}
+
+// ServeHTTP streams membership changes of users from userId query parameters as server-sent events.
+// Event id is an id of outbox event, so client resumes from Last-Event-ID header (or lastEventId parameter)
+// after reconnect
+func (h Handler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		writeError(w, handlers.ErrMethodNotAllowed(r.Context()))
+		return
+	}
+
+	userIDs, lastEventID, validationErr := parseRequest(r)
+	if validationErr != nil {
+		writeError(w, validationErr)
+		return
+	}
+
+	flusher, ok := w.(http.Flusher)
+	if !ok {
+		h.logger.ErrorContext(r.Context(), "response writer doesn't support flushing")
+		writeError(w, handlers.ErrInternal(r.Context()))
+		return
+	}
+
+	subscription, unsubscribe, err := h.streamService.Subscribe(r.Context(), userIDs, lastEventID)
+	if err != nil {
+		h.logger.ErrorContext(r.Context(), "error while subscribing", "error", err, "user_ids", userIDs)
+		writeError(w, handlers.ErrInternal(r.Context()))
+		return
+	}
+
+	defer unsubscribe()
+
+	w.Header().Set("Content-Type", contentTypeEventStream)
+	w.Header().Set("Cache-Control", "no-cache")
+	// disables buffering in nginx, otherwise events are delayed
+	w.Header().Set("X-Accel-Buffering", "no")
+	w.WriteHeader(http.StatusOK)
+
+	// empty id makes client forget last event id, so it doesn't request the same backlog after reconnect
+	if subscription.Reset {
+		if _, err = fmt.Fprint(w, "id\nevent: "+eventTypeReset+"\ndata: {}\n\n"); err != nil {
+			return
+		}
+	}
+
+	sentBacklog := make(map[int64]struct{}, len(subscription.Backlog))
+	for _, message := range subscription.Backlog {
+		if writeEvent(w, message) != nil {
+			return
+		}
+		sentBacklog[message.ID] = struct{}{}
+	}
+	flusher.Flush()
+
+	heartbeat := time.NewTicker(h.heartbeatInterval)
+	defer heartbeat.Stop()
+
+	for {
+		select {
+		case <-r.Context().Done():
+			return
+		case message, ok := <-subscription.Events:
+			if !ok {
+				return
+			}
+			if _, sent := sentBacklog[message.ID]; sent {
+				continue
+			}
+			if writeEvent(w, message) != nil {
+				return
+			}
+		case <-heartbeat.C:
+			// comment keeps connection alive through proxies
+			if _, err = fmt.Fprint(w, ": ping\n\n"); err != nil {
+				return
+			}
+		}
+		flusher.Flush()
+	}
+}
+
+// parseRequest returns user ids and last event id, or validation error
+func parseRequest(r *http.Request) ([]int64, int64, *handlers.Error) {
+	query := r.URL.Query()
+
+	rawUserIDs := query["userId"]
+	if len(rawUserIDs) == 0 {
+		return nil, 0, handlers.NewValidationError(r.Context(), "userId", handlers.MsgRequired)
+	}
+	if len(rawUserIDs) > maxUsers {
+		return nil, 0, handlers.NewValidationError(r.Context(), "userId", handlers.MsgMaxItems, maxUsers)
+	}
+
+	userIDs := make([]int64, 0, len(rawUserIDs))
+	for _, rawUserID := range rawUserIDs {
+		userID, err := strconv.ParseInt(rawUserID, 10, 64)
+		if err != nil || userID <= 0 {
+			return nil, 0, handlers.NewValidationError(r.Context(), "userId", handlers.MsgPositive)
+		}
+		userIDs = append(userIDs, userID)
+	}
+
+	rawLastEventID := r.Header.Get(headerLastEventID)
+	if rawLastEventID == "" {
+		rawLastEventID = query.Get("lastEventId")
+	}
+
+	var lastEventID int64
+	if rawLastEventID != "" {
+		var err error
+		lastEventID, err = strconv.ParseInt(rawLastEventID, 10, 64)
+		if err != nil || lastEventID < 0 {
+			return nil, 0, handlers.NewValidationError(r.Context(), "lastEventId", handlers.MsgNonNegative)
+		}
+	}
+
+	return userIDs, lastEventID, nil
+}
+
+func writeEvent(w http.ResponseWriter, message publisher.Message) error {
+	data, err := json.Marshal(message)
+	if err != nil {
+		return err
+	}
+
+	_, err = fmt.Fprintf(w, "id: %d\nevent: %s\ndata: %s\n\n", message.ID, message.Type, data)
+	return err
+}
+
+func writeError(w http.ResponseWriter, responseErr *handlers.Error) {
+	status := responseErr.HTTPStatus()
+	w.Header().Set("Content-Type", handlers.ContentTypeJSON)
+	w.WriteHeader(status)
+	_ = json.NewEncoder(w).Encode(HandlerResponse{
+		Status: status,
+		Error:  responseErr,
+	})
+}
@@ -0,0 +1,251 @@
+package stream_user_segments
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+
+	"github.com/pollykon/avito_test_task/internal/handlers"
+	"github.com/pollykon/avito_test_task/internal/handlers/stream_user_segments/mocks"
+	"github.com/pollykon/avito_test_task/internal/logger"
+	"github.com/pollykon/avito_test_task/internal/publisher"
+	serviceStream "github.com/pollykon/avito_test_task/internal/service/stream"
+)
+
+func TestStreamHandler_StreamUserSegments_Success(t *testing.T) {
+	firstUserID, secondUserID := int64(10), int64(11)
+	backlogMessage := publisher.Message{ID: 6, Type: "user_segment.added", UserID: &firstUserID, Payload: json.RawMessage(`{}`)}
+	newMessage := publisher.Message{ID: 7, Type: "user_segment.deleted", UserID: &secondUserID, Payload: json.RawMessage(`{}`)}
+
+	events := make(chan publisher.Message, 2)
+	// backlog event may be received again after subscribe, it mustn't be sent twice
+	events <- backlogMessage
+	events <- newMessage
+	close(events)
+
+	unsubscribed := false
+	streamServiceMock := mocks.NewStreamService(t)
+	streamServiceMock.EXPECT().Subscribe(mock.Anything, []int64{10, 11}, int64(5)).Return(
+		serviceStream.Subscription{Backlog: []publisher.Message{backlogMessage}, Events: events},
+		func() { unsubscribed = true },
+		nil,
+	)
+
+	request := httptest.NewRequest(http.MethodGet, "/stream_user_segments_v1?userId=10&userId=11", nil)
+	request.Header.Set(headerLastEventID, "5")
+	w := httptest.NewRecorder()
+
+	handler := New(streamServiceMock, time.Minute, slog.New(logger.NewNoopHandler()))
+	handler.ServeHTTP(w, request)
+
+	responseResult := w.Result()
+
+	assert.Equal(t, http.StatusOK, responseResult.StatusCode)
+	assert.Equal(t, contentTypeEventStream, responseResult.Header.Get("Content-Type"))
+	assert.Equal(t,
+		"id: 6\nevent: user_segment.added\n"+
+			`data: {"id":6,"type":"user_segment.added","userId":10,"payload":{},"occurredAt":"0001-01-01T00:00:00Z"}`+"\n\n"+
+			"id: 7\nevent: user_segment.deleted\n"+
+			`data: {"id":7,"type":"user_segment.deleted","userId":11,"payload":{},"occurredAt":"0001-01-01T00:00:00Z"}`+"\n\n",
+		w.Body.String(),
+	)
+	assert.True(t, unsubscribed)
+}
+
+func TestStreamHandler_StreamUserSegments_Error(t *testing.T) {
+	tt := []struct {
+		name string
+
+		requestMethod string
+		requestURL    string
+		lastEventID   string
+
+		buildStreamServiceMock func(service *mocks.StreamService)
+
+		expectedStatusCode int
+		expectedResponse   *HandlerResponse
+	}{
+		{
+			name: "wrong_method",
+
+			requestMethod: http.MethodPost,
+			requestURL:    "/stream_user_segments_v1?userId=10",
+
+			buildStreamServiceMock: nil,
+
+			expectedStatusCode: http.StatusMethodNotAllowed,
+			expectedResponse: &HandlerResponse{
+				Status: http.StatusMethodNotAllowed,
+				Error:  &handlers.Error{Code: "METHOD_NOT_ALLOWED", Message: "method not allowed"},
+			},
+		},
+		{
+			name: "empty_userId",
+
+			requestMethod: http.MethodGet,
+			requestURL:    "/stream_user_segments_v1",
+
+			buildStreamServiceMock: nil,
+
+			expectedStatusCode: http.StatusBadRequest,
+			expectedResponse: &HandlerResponse{
+				Status: http.StatusBadRequest,
+				Error: &handlers.Error{
+					Code:    "VALIDATION_FAILED",
+					Message: "userId shouldn't be empty",
+					Details: []handlers.FieldError{{Field: "userId", Message: "userId shouldn't be empty"}},
+				},
+			},
+		},
+		{
+			name: "wrong_userId",
+
+			requestMethod: http.MethodGet,
+			requestURL:    "/stream_user_segments_v1?userId=10&userId=-1",
+
+			buildStreamServiceMock: nil,
+
+			expectedStatusCode: http.StatusBadRequest,
+			expectedResponse: &HandlerResponse{
+				Status: http.StatusBadRequest,
+				Error: &handlers.Error{
+					Code:    "VALIDATION_FAILED",
+					Message: "userId should be more than 0",
+					Details: []handlers.FieldError{{Field: "userId", Message: "userId should be more than 0"}},
+				},
+			},
+		},
+		{
+			name: "wrong_last_event_id",
+
+			requestMethod: http.MethodGet,
+			requestURL:    "/stream_user_segments_v1?userId=10",
+			lastEventID:   "abc",
+
+			buildStreamServiceMock: nil,
+
+			expectedStatusCode: http.StatusBadRequest,
+			expectedResponse: &HandlerResponse{
+				Status: http.StatusBadRequest,
+				Error: &handlers.Error{
+					Code:    "VALIDATION_FAILED",
+					Message: "lastEventId shouldn't be negative",
+					Details: []handlers.FieldError{
+						{Field: "lastEventId", Message: "lastEventId shouldn't be negative"},
+					},
+				},
+			},
+		},
+		{
+			name: "service_error_unexpected_error",
+
+			requestMethod: http.MethodGet,
+			requestURL:    "/stream_user_segments_v1?userId=10&lastEventId=3",
+
+			buildStreamServiceMock: func(service *mocks.StreamService) {
+				service.EXPECT().Subscribe(mock.Anything, []int64{10}, int64(3)).
+					Return(serviceStream.Subscription{}, nil, fmt.Errorf("error from service"))
+			},
+
+			expectedStatusCode: http.StatusInternalServerError,
+			expectedResponse: &HandlerResponse{
+				Status: http.StatusInternalServerError,
+				Error:  &handlers.Error{Code: "INTERNAL", Message: "unexpected error"},
+			},
+		},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			request := httptest.NewRequest(tc.requestMethod, tc.requestURL, nil)
+			if tc.lastEventID != "" {
+				request.Header.Set(headerLastEventID, tc.lastEventID)
+			}
+			w := httptest.NewRecorder()
+
+			streamServiceMock := mocks.NewStreamService(t)
+			if tc.buildStreamServiceMock != nil {
+				tc.buildStreamServiceMock(streamServiceMock)
+			}
+
+			handler := New(streamServiceMock, time.Minute, slog.New(logger.NewNoopHandler()))
+			handler.ServeHTTP(w, request)
+
+			responseResult := w.Result()
+
+			assert.Equal(t, handlers.ContentTypeJSON, responseResult.Header.Get("Content-Type"))
+			assert.Equal(t, tc.expectedStatusCode, responseResult.StatusCode)
+
+			var response HandlerResponse
+			err := json.NewDecoder(responseResult.Body).Decode(&response)
+			assert.NoError(t, err)
+
+			assert.Equal(t, *tc.expectedResponse, response)
+		})
+	}
+}
+
+func TestStreamHandler_StreamUserSegments_ClientDisconnected(t *testing.T) {
+	events := make(chan publisher.Message)
+
+	streamServiceMock := mocks.NewStreamService(t)
+	streamServiceMock.EXPECT().Subscribe(mock.Anything, []int64{10}, int64(0)).
+		Return(serviceStream.Subscription{Events: events}, func() {}, nil)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	request := httptest.NewRequest(http.MethodGet, "/stream_user_segments_v1?userId=10", nil).WithContext(ctx)
+	w := httptest.NewRecorder()
+
+	done := make(chan struct{})
+	go func() {
+		New(streamServiceMock, time.Minute, slog.New(logger.NewNoopHandler())).ServeHTTP(w, request)
+		close(done)
+	}()
+
+	cancel()
+
+	select {
+	case <-done:
+	case <-time.After(time.Second):
+		t.Fatal("handler didn't stop after client disconnected")
+	}
+}
+
+func TestStreamHandler_StreamUserSegments_Reset(t *testing.T) {
+	userID := int64(10)
+	newMessage := publisher.Message{ID: 7, Type: "user_segment.deleted", UserID: &userID, Payload: json.RawMessage(`{}`)}
+
+	events := make(chan publisher.Message, 1)
+	events <- newMessage
+	close(events)
+
+	streamServiceMock := mocks.NewStreamService(t)
+	streamServiceMock.EXPECT().Subscribe(mock.Anything, []int64{10}, int64(5)).Return(
+		serviceStream.Subscription{Reset: true, Events: events},
+		func() {},
+		nil,
+	)
+
+	request := httptest.NewRequest(http.MethodGet, "/stream_user_segments_v1?userId=10", nil)
+	request.Header.Set(headerLastEventID, "5")
+	w := httptest.NewRecorder()
+
+	handler := New(streamServiceMock, time.Minute, slog.New(logger.NewNoopHandler()))
+	handler.ServeHTTP(w, request)
+
+	assert.Equal(t, http.StatusOK, w.Result().StatusCode)
+	assert.Equal(t,
+		"id\nevent: reset\ndata: {}\n\n"+
+			"id: 7\nevent: user_segment.deleted\n"+
+			`data: {"id":7,"type":"user_segment.deleted","userId":10,"payload":{},"occurredAt":"0001-01-01T00:00:00Z"}`+"\n\n",
+		w.Body.String(),
+	)
+}
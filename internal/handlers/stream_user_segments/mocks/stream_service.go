@@ -0,0 +1,100 @@
+// Code generated by mockery v2.33.0. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+
+	stream "github.com/pollykon/avito_test_task/internal/service/stream"
+	mock "github.com/stretchr/testify/mock"
+)
+
+// StreamService is an autogenerated mock type for the StreamService type
+type StreamService struct {
+	mock.Mock
+}
+
+type StreamService_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *StreamService) EXPECT() *StreamService_Expecter {
+	return &StreamService_Expecter{mock: &_m.Mock}
+}
+
+// Subscribe provides a mock function with given fields: ctx, userIDs, lastEventID
+func (_m *StreamService) Subscribe(ctx context.Context, userIDs []int64, lastEventID int64) (stream.Subscription, func(), error) {
+	ret := _m.Called(ctx, userIDs, lastEventID)
+
+	var r0 stream.Subscription
+	var r1 func()
+	var r2 error
+	if rf, ok := ret.Get(0).(func(context.Context, []int64, int64) (stream.Subscription, func(), error)); ok {
+		return rf(ctx, userIDs, lastEventID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, []int64, int64) stream.Subscription); ok {
+		r0 = rf(ctx, userIDs, lastEventID)
+	} else {
+		r0 = ret.Get(0).(stream.Subscription)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, []int64, int64) func()); ok {
+		r1 = rf(ctx, userIDs, lastEventID)
+	} else {
+		if ret.Get(1) != nil {
+			r1 = ret.Get(1).(func())
+		}
+	}
+
+	if rf, ok := ret.Get(2).(func(context.Context, []int64, int64) error); ok {
+		r2 = rf(ctx, userIDs, lastEventID)
+	} else {
+		r2 = ret.Error(2)
+	}
+
+	return r0, r1, r2
+}
+
+// StreamService_Subscribe_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Subscribe'
+type StreamService_Subscribe_Call struct {
+	*mock.Call
+}
+
+// Subscribe is a helper method to define mock.On call
+//   - ctx context.Context
+//   - userIDs []int64
+//   - lastEventID int64
+func (_e *StreamService_Expecter) Subscribe(ctx interface{}, userIDs interface{}, lastEventID interface{}) *StreamService_Subscribe_Call {
+	return &StreamService_Subscribe_Call{Call: _e.mock.On("Subscribe", ctx, userIDs, lastEventID)}
+}
+
+func (_c *StreamService_Subscribe_Call) Run(run func(ctx context.Context, userIDs []int64, lastEventID int64)) *StreamService_Subscribe_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].([]int64), args[2].(int64))
+	})
+	return _c
+}
+
+func (_c *StreamService_Subscribe_Call) Return(_a0 stream.Subscription, _a1 func(), _a2 error) *StreamService_Subscribe_Call {
+	_c.Call.Return(_a0, _a1, _a2)
+	return _c
+}
+
+func (_c *StreamService_Subscribe_Call) RunAndReturn(run func(context.Context, []int64, int64) (stream.Subscription, func(), error)) *StreamService_Subscribe_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// NewStreamService creates a new instance of StreamService. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewStreamService(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *StreamService {
+	mock := &StreamService{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
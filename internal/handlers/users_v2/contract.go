@@ -0,0 +1,19 @@
+//go:generate mockery --all --output ./mocks --case underscore --with-expecter
+package users_v2
+
+import (
+	"context"
+	"time"
+
+	logService "github.com/pollykon/avito_test_task/internal/service/log"
+)
+
+type SegmentService interface {
+	GetUserActiveSegments(ctx context.Context, userID int64) ([]string, error)
+	AddUserToSegment(ctx context.Context, userID int64, slugs []string, ttl *time.Duration) error
+	DeleteUserFromSegment(ctx context.Context, userID int64, slugs []string) error
+}
+
+type LogService interface {
+	GetHistory(ctx context.Context, userID int64, from time.Time, to time.Time) ([]logService.HistoryRecord, error)
+}
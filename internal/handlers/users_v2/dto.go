@@ -0,0 +1,32 @@
+package users_v2
+
+import (
+	"time"
+
+	"github.com/pollykon/avito_test_task/internal/handlers"
+)
+
+type PutUserSegmentsRequest struct {
+	SegmentSlugs []string `json:"segments"`
+	TTLHours     *int64   `json:"ttl"`
+}
+
+type GetUserSegmentsResponse struct {
+	Segments []string `json:"segments"`
+}
+
+type HistoryRecord struct {
+	ID        int64     `json:"id"`
+	Segment   string    `json:"segment"`
+	Operation string    `json:"operation"`
+	Actor     string    `json:"actor,omitempty"`
+	Time      time.Time `json:"time"`
+}
+
+type GetUserHistoryResponse struct {
+	History []HistoryRecord `json:"history"`
+}
+
+type ErrorResponse struct {
+	Error *handlers.Error `json:"error"`
+}
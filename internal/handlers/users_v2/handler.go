@@ -0,0 +1,210 @@
+package users_v2
+
+import (
+	"encoding/json"
+	"log/slog"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/pollykon/avito_test_task/internal/actor"
+	"github.com/pollykon/avito_test_task/internal/handlers"
+)
+
+// URIPrefix is a path prefix of users resources. Handler must be registered on URIPrefix
+const URIPrefix = "/v2/users/"
+
+const (
+	resourceSegments = "segments"
+	resourceHistory  = "history"
+
+	monthLayout = "2006-01"
+)
+
+type Handler struct {
+	segmentService SegmentService
+	logService     LogService
+	logger         *slog.Logger
+}
+
+func New(s SegmentService, ls LogService, l *slog.Logger) Handler {
+	return Handler{segmentService: s, logService: ls, logger: l}
+}
+
+// ServeHTTP routes requests to user's segments (GET, PUT, DELETE /v2/users/{id}/segments)
+// and to user's history (GET /v2/users/{id}/history)
+func (h Handler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	parts := strings.Split(strings.TrimPrefix(r.URL.Path, URIPrefix), "/")
+	if len(parts) != 2 || (parts[1] != resourceSegments && parts[1] != resourceHistory) {
+		writeError(w, handlers.ErrNotFound(r.Context()))
+		return
+	}
+
+	userID, err := strconv.ParseInt(parts[0], 10, 64)
+	if err != nil || userID <= 0 {
+		writeError(w, handlers.NewValidationError(r.Context(), "id", handlers.MsgPositive))
+		return
+	}
+
+	if parts[1] == resourceHistory {
+		h.serveHistory(w, r, userID)
+		return
+	}
+
+	h.serveSegments(w, r, userID)
+}
+
+// RequiredScope returns scope of API key required for request: history export for history,
+// reading or writing memberships for user's segments
+func RequiredScope(r *http.Request) string {
+	if strings.HasSuffix(r.URL.Path, "/"+resourceHistory) {
+		return actor.ScopeHistoryExport
+	}
+	if r.Method == http.MethodGet {
+		return actor.ScopeMembershipsRead
+	}
+	return actor.ScopeMembershipsWrite
+}
+
+func (h Handler) serveSegments(w http.ResponseWriter, r *http.Request, userID int64) {
+	switch r.Method {
+	case http.MethodGet:
+		h.getSegments(w, r, userID)
+	case http.MethodPut:
+		h.putSegments(w, r, userID)
+	case http.MethodDelete:
+		h.deleteSegments(w, r, userID)
+	default:
+		w.Header().Set("Allow", strings.Join([]string{http.MethodGet, http.MethodPut, http.MethodDelete}, ", "))
+		writeError(w, handlers.ErrMethodNotAllowed(r.Context()))
+	}
+}
+
+func (h Handler) getSegments(w http.ResponseWriter, r *http.Request, userID int64) {
+	segments, err := h.segmentService.GetUserActiveSegments(r.Context(), userID)
+	if err != nil {
+		h.logger.ErrorContext(r.Context(), "error while getting user's active segments", "error", err, "user_id", userID)
+		writeError(w, handlers.ErrInternal(r.Context()))
+		return
+	}
+
+	if segments == nil {
+		segments = []string{}
+	}
+
+	writeJSON(w, http.StatusOK, GetUserSegmentsResponse{Segments: segments})
+}
+
+// putSegments adds user to segments from request body
+func (h Handler) putSegments(w http.ResponseWriter, r *http.Request, userID int64) {
+	defer func() { _ = r.Body.Close() }()
+
+	var request PutUserSegmentsRequest
+	err := json.NewDecoder(r.Body).Decode(&request)
+	if err != nil {
+		h.logger.ErrorContext(r.Context(), "error while parsing request", "error", err, "request", request)
+		writeError(w, handlers.ErrMalformedRequest(r.Context()))
+		return
+	}
+
+	if len(request.SegmentSlugs) == 0 {
+		writeError(w, handlers.NewValidationError(r.Context(), "segments", handlers.MsgRequired))
+		return
+	}
+	if request.TTLHours != nil && *request.TTLHours <= 0 {
+		writeError(w, handlers.NewValidationError(r.Context(), "ttl", handlers.MsgPositive))
+		return
+	}
+
+	var ttlDuration *time.Duration
+	if request.TTLHours != nil {
+		ttl := time.Duration(*request.TTLHours) * time.Hour
+		ttlDuration = &ttl
+	}
+
+	err = h.segmentService.AddUserToSegment(r.Context(), userID, request.SegmentSlugs, ttlDuration)
+	if err != nil {
+		if responseErr := handlers.FromServiceError(r.Context(), err); responseErr != nil {
+			writeError(w, responseErr)
+			return
+		}
+
+		h.logger.ErrorContext(r.Context(), "error while adding user to segment", "error", err, "user_id", userID, "request", request)
+		writeError(w, handlers.ErrInternal(r.Context()))
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// deleteSegments deletes user from segments from slug query parameters
+func (h Handler) deleteSegments(w http.ResponseWriter, r *http.Request, userID int64) {
+	slugs := r.URL.Query()["slug"]
+	if len(slugs) == 0 {
+		writeError(w, handlers.NewValidationError(r.Context(), "slug", handlers.MsgRequired))
+		return
+	}
+
+	err := h.segmentService.DeleteUserFromSegment(r.Context(), userID, slugs)
+	if err != nil {
+		h.logger.ErrorContext(r.Context(), "error while deleting user from segment", "error", err, "user_id", userID, "slugs", slugs)
+		writeError(w, handlers.ErrInternal(r.Context()))
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func (h Handler) serveHistory(w http.ResponseWriter, r *http.Request, userID int64) {
+	if r.Method != http.MethodGet {
+		w.Header().Set("Allow", http.MethodGet)
+		writeError(w, handlers.ErrMethodNotAllowed(r.Context()))
+		return
+	}
+
+	from, err := time.Parse(monthLayout, r.URL.Query().Get("from"))
+	if err != nil {
+		writeError(w, handlers.NewValidationError(r.Context(), "from", handlers.MsgMonth))
+		return
+	}
+	to, err := time.Parse(monthLayout, r.URL.Query().Get("to"))
+	if err != nil {
+		writeError(w, handlers.NewValidationError(r.Context(), "to", handlers.MsgMonth))
+		return
+	}
+	if !from.Before(to) {
+		writeError(w, handlers.NewValidationError(r.Context(), "from", handlers.MsgFromBeforeTo))
+		return
+	}
+
+	history, err := h.logService.GetHistory(r.Context(), userID, from, to)
+	if err != nil {
+		h.logger.ErrorContext(r.Context(), "error while getting user's history", "error", err, "user_id", userID)
+		writeError(w, handlers.ErrInternal(r.Context()))
+		return
+	}
+
+	response := GetUserHistoryResponse{History: make([]HistoryRecord, 0, len(history))}
+	for _, record := range history {
+		response.History = append(response.History, HistoryRecord{
+			ID:        record.ID,
+			Segment:   record.SegmentID,
+			Operation: record.Operation,
+			Actor:     record.Actor,
+			Time:      record.InsertTime,
+		})
+	}
+
+	writeJSON(w, http.StatusOK, response)
+}
+
+func writeJSON(w http.ResponseWriter, status int, body any) {
+	w.Header().Set("Content-Type", handlers.ContentTypeJSON)
+	w.WriteHeader(status)
+	_ = json.NewEncoder(w).Encode(body)
+}
+
+func writeError(w http.ResponseWriter, responseErr *handlers.Error) {
+	writeJSON(w, responseErr.HTTPStatus(), ErrorResponse{Error: responseErr})
+}
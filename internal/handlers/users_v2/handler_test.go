@@ -0,0 +1,375 @@
+package users_v2
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/pollykon/avito_test_task/internal/actor"
+	"github.com/pollykon/avito_test_task/internal/handlers"
+	"github.com/pollykon/avito_test_task/internal/handlers/users_v2/mocks"
+	"github.com/pollykon/avito_test_task/internal/logger"
+	logService "github.com/pollykon/avito_test_task/internal/service/log"
+	segmentService "github.com/pollykon/avito_test_task/internal/service/segment"
+)
+
+func TestUsersV2Handler(t *testing.T) {
+	ttl := 24 * time.Hour
+	from := time.Date(2023, 8, 1, 0, 0, 0, 0, time.UTC)
+	to := time.Date(2023, 9, 1, 0, 0, 0, 0, time.UTC)
+	insertTime := time.Date(2023, 8, 10, 12, 0, 0, 0, time.UTC)
+
+	tt := []struct {
+		name string
+
+		requestMethod string
+		requestPath   string
+		requestBody   string
+
+		buildSegmentServiceMock func(service *mocks.SegmentService)
+		buildLogServiceMock     func(service *mocks.LogService)
+
+		expectedStatusCode int
+		expectedAllow      string
+		expectedBody       interface{}
+	}{
+		{
+			name: "get_segments",
+
+			requestMethod: http.MethodGet,
+			requestPath:   "/v2/users/10/segments",
+
+			buildSegmentServiceMock: func(service *mocks.SegmentService) {
+				service.EXPECT().GetUserActiveSegments(context.Background(), int64(10)).Return([]string{"AVITO"}, nil)
+			},
+
+			expectedStatusCode: http.StatusOK,
+			expectedBody:       &GetUserSegmentsResponse{Segments: []string{"AVITO"}},
+		},
+		{
+			name: "get_segments_empty",
+
+			requestMethod: http.MethodGet,
+			requestPath:   "/v2/users/10/segments",
+
+			buildSegmentServiceMock: func(service *mocks.SegmentService) {
+				service.EXPECT().GetUserActiveSegments(context.Background(), int64(10)).Return(nil, nil)
+			},
+
+			expectedStatusCode: http.StatusOK,
+			expectedBody:       &GetUserSegmentsResponse{Segments: []string{}},
+		},
+		{
+			name: "get_segments_unexpected_error",
+
+			requestMethod: http.MethodGet,
+			requestPath:   "/v2/users/10/segments",
+
+			buildSegmentServiceMock: func(service *mocks.SegmentService) {
+				service.EXPECT().GetUserActiveSegments(context.Background(), int64(10)).
+					Return(nil, fmt.Errorf("unexpected error"))
+			},
+
+			expectedStatusCode: http.StatusInternalServerError,
+			expectedBody:       &ErrorResponse{Error: &handlers.Error{Code: "INTERNAL", Message: "unexpected error"}},
+		},
+		{
+			name: "wrong_user_id",
+
+			requestMethod: http.MethodGet,
+			requestPath:   "/v2/users/abc/segments",
+
+			expectedStatusCode: http.StatusBadRequest,
+			expectedBody: &ErrorResponse{Error: &handlers.Error{
+				Code:    "VALIDATION_FAILED",
+				Message: "id should be more than 0",
+				Details: []handlers.FieldError{{Field: "id", Message: "id should be more than 0"}},
+			}},
+		},
+		{
+			name: "unknown_resource",
+
+			requestMethod: http.MethodGet,
+			requestPath:   "/v2/users/10/webhooks",
+
+			expectedStatusCode: http.StatusNotFound,
+			expectedBody:       &ErrorResponse{Error: &handlers.Error{Code: "NOT_FOUND", Message: "not found"}},
+		},
+		{
+			name: "put_segments",
+
+			requestMethod: http.MethodPut,
+			requestPath:   "/v2/users/10/segments",
+			requestBody:   `{"segments":["AVITO","VOICE"],"ttl":24}`,
+
+			buildSegmentServiceMock: func(service *mocks.SegmentService) {
+				service.EXPECT().AddUserToSegment(context.Background(), int64(10), []string{"AVITO", "VOICE"}, &ttl).
+					Return(nil)
+			},
+
+			expectedStatusCode: http.StatusNoContent,
+		},
+		{
+			name: "put_segments_decode_error",
+
+			requestMethod: http.MethodPut,
+			requestPath:   "/v2/users/10/segments",
+			requestBody:   `{"segments":"AVITO"}`,
+
+			expectedStatusCode: http.StatusBadRequest,
+			expectedBody: &ErrorResponse{Error: &handlers.Error{
+				Code:    "MALFORMED_REQUEST",
+				Message: "bad request",
+			}},
+		},
+		{
+			name: "put_segments_empty",
+
+			requestMethod: http.MethodPut,
+			requestPath:   "/v2/users/10/segments",
+			requestBody:   `{"segments":[]}`,
+
+			expectedStatusCode: http.StatusBadRequest,
+			expectedBody: &ErrorResponse{Error: &handlers.Error{
+				Code:    "VALIDATION_FAILED",
+				Message: "segments shouldn't be empty",
+				Details: []handlers.FieldError{{Field: "segments", Message: "segments shouldn't be empty"}},
+			}},
+		},
+		{
+			name: "put_segments_negative_ttl",
+
+			requestMethod: http.MethodPut,
+			requestPath:   "/v2/users/10/segments",
+			requestBody:   `{"segments":["AVITO"],"ttl":-1}`,
+
+			expectedStatusCode: http.StatusBadRequest,
+			expectedBody: &ErrorResponse{Error: &handlers.Error{
+				Code:    "VALIDATION_FAILED",
+				Message: "ttl should be more than 0",
+				Details: []handlers.FieldError{{Field: "ttl", Message: "ttl should be more than 0"}},
+			}},
+		},
+		{
+			name: "put_segments_segment_not_exist",
+
+			requestMethod: http.MethodPut,
+			requestPath:   "/v2/users/10/segments",
+			requestBody:   `{"segments":["AVITO"]}`,
+
+			buildSegmentServiceMock: func(service *mocks.SegmentService) {
+				service.EXPECT().AddUserToSegment(context.Background(), int64(10), []string{"AVITO"}, (*time.Duration)(nil)).
+					Return(segmentService.ErrSegmentNotExist)
+			},
+
+			expectedStatusCode: http.StatusNotFound,
+			expectedBody: &ErrorResponse{Error: &handlers.Error{
+				Code:    "SEGMENT_NOT_FOUND",
+				Message: "segment doesn't exist",
+			}},
+		},
+		{
+			name: "put_segments_user_already_in_segment",
+
+			requestMethod: http.MethodPut,
+			requestPath:   "/v2/users/10/segments",
+			requestBody:   `{"segments":["AVITO"]}`,
+
+			buildSegmentServiceMock: func(service *mocks.SegmentService) {
+				service.EXPECT().AddUserToSegment(context.Background(), int64(10), []string{"AVITO"}, (*time.Duration)(nil)).
+					Return(segmentService.ErrUserAlreadyInSegment)
+			},
+
+			expectedStatusCode: http.StatusConflict,
+			expectedBody: &ErrorResponse{Error: &handlers.Error{
+				Code:    "USER_ALREADY_IN_SEGMENT",
+				Message: "user already in segment",
+			}},
+		},
+		{
+			name: "delete_segments",
+
+			requestMethod: http.MethodDelete,
+			requestPath:   "/v2/users/10/segments?slug=AVITO&slug=VOICE",
+
+			buildSegmentServiceMock: func(service *mocks.SegmentService) {
+				service.EXPECT().DeleteUserFromSegment(context.Background(), int64(10), []string{"AVITO", "VOICE"}).
+					Return(nil)
+			},
+
+			expectedStatusCode: http.StatusNoContent,
+		},
+		{
+			name: "delete_segments_without_slugs",
+
+			requestMethod: http.MethodDelete,
+			requestPath:   "/v2/users/10/segments",
+
+			expectedStatusCode: http.StatusBadRequest,
+			expectedBody: &ErrorResponse{Error: &handlers.Error{
+				Code:    "VALIDATION_FAILED",
+				Message: "slug shouldn't be empty",
+				Details: []handlers.FieldError{{Field: "slug", Message: "slug shouldn't be empty"}},
+			}},
+		},
+		{
+			name: "segments_wrong_method",
+
+			requestMethod: http.MethodPost,
+			requestPath:   "/v2/users/10/segments",
+
+			expectedStatusCode: http.StatusMethodNotAllowed,
+			expectedAllow:      "GET, PUT, DELETE",
+			expectedBody: &ErrorResponse{Error: &handlers.Error{
+				Code:    "METHOD_NOT_ALLOWED",
+				Message: "method not allowed",
+			}},
+		},
+		{
+			name: "get_history",
+
+			requestMethod: http.MethodGet,
+			requestPath:   "/v2/users/10/history?from=2023-08&to=2023-09",
+
+			buildLogServiceMock: func(service *mocks.LogService) {
+				service.EXPECT().GetHistory(context.Background(), int64(10), from, to).Return([]logService.HistoryRecord{
+					{ID: 1, SegmentID: "AVITO", Operation: "add", Actor: "api_key:12", InsertTime: insertTime},
+				}, nil)
+			},
+
+			expectedStatusCode: http.StatusOK,
+			expectedBody: &GetUserHistoryResponse{History: []HistoryRecord{
+				{ID: 1, Segment: "AVITO", Operation: "add", Actor: "api_key:12", Time: insertTime},
+			}},
+		},
+		{
+			name: "get_history_wrong_time_format",
+
+			requestMethod: http.MethodGet,
+			requestPath:   "/v2/users/10/history?from=2023-08-01&to=2023-09",
+
+			expectedStatusCode: http.StatusBadRequest,
+			expectedBody: &ErrorResponse{Error: &handlers.Error{
+				Code:    "VALIDATION_FAILED",
+				Message: "from must be in year-month format",
+				Details: []handlers.FieldError{{Field: "from", Message: "from must be in year-month format"}},
+			}},
+		},
+		{
+			name: "get_history_from_after_to",
+
+			requestMethod: http.MethodGet,
+			requestPath:   "/v2/users/10/history?from=2023-09&to=2023-08",
+
+			expectedStatusCode: http.StatusBadRequest,
+			expectedBody: &ErrorResponse{Error: &handlers.Error{
+				Code:    "VALIDATION_FAILED",
+				Message: "from must be less than to",
+				Details: []handlers.FieldError{{Field: "from", Message: "from must be less than to"}},
+			}},
+		},
+		{
+			name: "get_history_unexpected_error",
+
+			requestMethod: http.MethodGet,
+			requestPath:   "/v2/users/10/history?from=2023-08&to=2023-09",
+
+			buildLogServiceMock: func(service *mocks.LogService) {
+				service.EXPECT().GetHistory(context.Background(), int64(10), from, to).
+					Return(nil, fmt.Errorf("unexpected error"))
+			},
+
+			expectedStatusCode: http.StatusInternalServerError,
+			expectedBody:       &ErrorResponse{Error: &handlers.Error{Code: "INTERNAL", Message: "unexpected error"}},
+		},
+		{
+			name: "history_wrong_method",
+
+			requestMethod: http.MethodDelete,
+			requestPath:   "/v2/users/10/history",
+
+			expectedStatusCode: http.StatusMethodNotAllowed,
+			expectedAllow:      "GET",
+			expectedBody: &ErrorResponse{Error: &handlers.Error{
+				Code:    "METHOD_NOT_ALLOWED",
+				Message: "method not allowed",
+			}},
+		},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			request := httptest.NewRequest(tc.requestMethod, tc.requestPath, strings.NewReader(tc.requestBody))
+			request = request.WithContext(context.Background())
+			w := httptest.NewRecorder()
+
+			segmentServiceMock := mocks.NewSegmentService(t)
+			if tc.buildSegmentServiceMock != nil {
+				tc.buildSegmentServiceMock(segmentServiceMock)
+			}
+
+			logServiceMock := mocks.NewLogService(t)
+			if tc.buildLogServiceMock != nil {
+				tc.buildLogServiceMock(logServiceMock)
+			}
+
+			handler := New(segmentServiceMock, logServiceMock, slog.New(logger.NewNoopHandler()))
+			handler.ServeHTTP(w, request)
+
+			responseResult := w.Result()
+
+			assert.Equal(t, tc.expectedStatusCode, responseResult.StatusCode)
+			assert.Equal(t, tc.expectedAllow, responseResult.Header.Get("Allow"))
+
+			if tc.expectedBody == nil {
+				assert.Empty(t, w.Body.Bytes())
+				return
+			}
+
+			assert.Equal(t, handlers.ContentTypeJSON, responseResult.Header.Get("Content-Type"))
+
+			response := newEmptyBody(tc.expectedBody)
+			err := json.NewDecoder(responseResult.Body).Decode(response)
+			assert.NoError(t, err)
+			assert.Equal(t, tc.expectedBody, response)
+		})
+	}
+}
+
+func newEmptyBody(body interface{}) interface{} {
+	switch body.(type) {
+	case *GetUserSegmentsResponse:
+		return &GetUserSegmentsResponse{}
+	case *GetUserHistoryResponse:
+		return &GetUserHistoryResponse{}
+	default:
+		return &ErrorResponse{}
+	}
+}
+
+func TestRequiredScope(t *testing.T) {
+	tt := []struct {
+		method        string
+		path          string
+		expectedScope string
+	}{
+		{method: http.MethodGet, path: "/v2/users/10/segments", expectedScope: actor.ScopeMembershipsRead},
+		{method: http.MethodPut, path: "/v2/users/10/segments", expectedScope: actor.ScopeMembershipsWrite},
+		{method: http.MethodDelete, path: "/v2/users/10/segments", expectedScope: actor.ScopeMembershipsWrite},
+		{method: http.MethodGet, path: "/v2/users/10/history", expectedScope: actor.ScopeHistoryExport},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.method+" "+tc.path, func(t *testing.T) {
+			assert.Equal(t, tc.expectedScope, RequiredScope(httptest.NewRequest(tc.method, tc.path, nil)))
+		})
+	}
+}
@@ -0,0 +1,96 @@
+// Code generated by mockery v2.33.0. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+
+	log "github.com/pollykon/avito_test_task/internal/service/log"
+	mock "github.com/stretchr/testify/mock"
+
+	time "time"
+)
+
+// LogService is an autogenerated mock type for the LogService type
+type LogService struct {
+	mock.Mock
+}
+
+type LogService_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *LogService) EXPECT() *LogService_Expecter {
+	return &LogService_Expecter{mock: &_m.Mock}
+}
+
+// GetHistory provides a mock function with given fields: ctx, userID, from, to
+func (_m *LogService) GetHistory(ctx context.Context, userID int64, from time.Time, to time.Time) ([]log.HistoryRecord, error) {
+	ret := _m.Called(ctx, userID, from, to)
+
+	var r0 []log.HistoryRecord
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, int64, time.Time, time.Time) ([]log.HistoryRecord, error)); ok {
+		return rf(ctx, userID, from, to)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, int64, time.Time, time.Time) []log.HistoryRecord); ok {
+		r0 = rf(ctx, userID, from, to)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]log.HistoryRecord)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, int64, time.Time, time.Time) error); ok {
+		r1 = rf(ctx, userID, from, to)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// LogService_GetHistory_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetHistory'
+type LogService_GetHistory_Call struct {
+	*mock.Call
+}
+
+// GetHistory is a helper method to define mock.On call
+//   - ctx context.Context
+//   - userID int64
+//   - from time.Time
+//   - to time.Time
+func (_e *LogService_Expecter) GetHistory(ctx interface{}, userID interface{}, from interface{}, to interface{}) *LogService_GetHistory_Call {
+	return &LogService_GetHistory_Call{Call: _e.mock.On("GetHistory", ctx, userID, from, to)}
+}
+
+func (_c *LogService_GetHistory_Call) Run(run func(ctx context.Context, userID int64, from time.Time, to time.Time)) *LogService_GetHistory_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(int64), args[2].(time.Time), args[3].(time.Time))
+	})
+	return _c
+}
+
+func (_c *LogService_GetHistory_Call) Return(_a0 []log.HistoryRecord, _a1 error) *LogService_GetHistory_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *LogService_GetHistory_Call) RunAndReturn(run func(context.Context, int64, time.Time, time.Time) ([]log.HistoryRecord, error)) *LogService_GetHistory_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// NewLogService creates a new instance of LogService. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewLogService(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *LogService {
+	mock := &LogService{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
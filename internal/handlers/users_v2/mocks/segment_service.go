@@ -0,0 +1,181 @@
+// Code generated by mockery v2.33.0. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+	time "time"
+
+	mock "github.com/stretchr/testify/mock"
+)
+
+// SegmentService is an autogenerated mock type for the SegmentService type
+type SegmentService struct {
+	mock.Mock
+}
+
+type SegmentService_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *SegmentService) EXPECT() *SegmentService_Expecter {
+	return &SegmentService_Expecter{mock: &_m.Mock}
+}
+
+// AddUserToSegment provides a mock function with given fields: ctx, userID, slugs, ttl
+func (_m *SegmentService) AddUserToSegment(ctx context.Context, userID int64, slugs []string, ttl *time.Duration) error {
+	ret := _m.Called(ctx, userID, slugs, ttl)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, int64, []string, *time.Duration) error); ok {
+		r0 = rf(ctx, userID, slugs, ttl)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// SegmentService_AddUserToSegment_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'AddUserToSegment'
+type SegmentService_AddUserToSegment_Call struct {
+	*mock.Call
+}
+
+// AddUserToSegment is a helper method to define mock.On call
+//   - ctx context.Context
+//   - userID int64
+//   - slugs []string
+//   - ttl *time.Duration
+func (_e *SegmentService_Expecter) AddUserToSegment(ctx interface{}, userID interface{}, slugs interface{}, ttl interface{}) *SegmentService_AddUserToSegment_Call {
+	return &SegmentService_AddUserToSegment_Call{Call: _e.mock.On("AddUserToSegment", ctx, userID, slugs, ttl)}
+}
+
+func (_c *SegmentService_AddUserToSegment_Call) Run(run func(ctx context.Context, userID int64, slugs []string, ttl *time.Duration)) *SegmentService_AddUserToSegment_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(int64), args[2].([]string), args[3].(*time.Duration))
+	})
+	return _c
+}
+
+func (_c *SegmentService_AddUserToSegment_Call) Return(_a0 error) *SegmentService_AddUserToSegment_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *SegmentService_AddUserToSegment_Call) RunAndReturn(run func(context.Context, int64, []string, *time.Duration) error) *SegmentService_AddUserToSegment_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// DeleteUserFromSegment provides a mock function with given fields: ctx, userID, slugs
+func (_m *SegmentService) DeleteUserFromSegment(ctx context.Context, userID int64, slugs []string) error {
+	ret := _m.Called(ctx, userID, slugs)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, int64, []string) error); ok {
+		r0 = rf(ctx, userID, slugs)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// SegmentService_DeleteUserFromSegment_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'DeleteUserFromSegment'
+type SegmentService_DeleteUserFromSegment_Call struct {
+	*mock.Call
+}
+
+// DeleteUserFromSegment is a helper method to define mock.On call
+//   - ctx context.Context
+//   - userID int64
+//   - slugs []string
+func (_e *SegmentService_Expecter) DeleteUserFromSegment(ctx interface{}, userID interface{}, slugs interface{}) *SegmentService_DeleteUserFromSegment_Call {
+	return &SegmentService_DeleteUserFromSegment_Call{Call: _e.mock.On("DeleteUserFromSegment", ctx, userID, slugs)}
+}
+
+func (_c *SegmentService_DeleteUserFromSegment_Call) Run(run func(ctx context.Context, userID int64, slugs []string)) *SegmentService_DeleteUserFromSegment_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(int64), args[2].([]string))
+	})
+	return _c
+}
+
+func (_c *SegmentService_DeleteUserFromSegment_Call) Return(_a0 error) *SegmentService_DeleteUserFromSegment_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *SegmentService_DeleteUserFromSegment_Call) RunAndReturn(run func(context.Context, int64, []string) error) *SegmentService_DeleteUserFromSegment_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetUserActiveSegments provides a mock function with given fields: ctx, userID
+func (_m *SegmentService) GetUserActiveSegments(ctx context.Context, userID int64) ([]string, error) {
+	ret := _m.Called(ctx, userID)
+
+	var r0 []string
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, int64) ([]string, error)); ok {
+		return rf(ctx, userID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, int64) []string); ok {
+		r0 = rf(ctx, userID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]string)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, int64) error); ok {
+		r1 = rf(ctx, userID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// SegmentService_GetUserActiveSegments_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetUserActiveSegments'
+type SegmentService_GetUserActiveSegments_Call struct {
+	*mock.Call
+}
+
+// GetUserActiveSegments is a helper method to define mock.On call
+//   - ctx context.Context
+//   - userID int64
+func (_e *SegmentService_Expecter) GetUserActiveSegments(ctx interface{}, userID interface{}) *SegmentService_GetUserActiveSegments_Call {
+	return &SegmentService_GetUserActiveSegments_Call{Call: _e.mock.On("GetUserActiveSegments", ctx, userID)}
+}
+
+func (_c *SegmentService_GetUserActiveSegments_Call) Run(run func(ctx context.Context, userID int64)) *SegmentService_GetUserActiveSegments_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(int64))
+	})
+	return _c
+}
+
+func (_c *SegmentService_GetUserActiveSegments_Call) Return(_a0 []string, _a1 error) *SegmentService_GetUserActiveSegments_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *SegmentService_GetUserActiveSegments_Call) RunAndReturn(run func(context.Context, int64) ([]string, error)) *SegmentService_GetUserActiveSegments_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// NewSegmentService creates a new instance of SegmentService. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewSegmentService(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *SegmentService {
+	mock := &SegmentService{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
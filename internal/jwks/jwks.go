@@ -0,0 +1,266 @@
+package jwks
+
+import (
+	"context"
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rsa"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"math/big"
+	"net/http"
+	"os"
+	"sync"
+	"time"
+)
+
+var ErrKeyNotFound = errors.New("key not found")
+
+// minRefreshInterval limits refreshes of remote key set caused by tokens with unknown key id
+const minRefreshInterval = time.Minute
+
+// KeySet contains public keys of identity provider by their ids (kid)
+type KeySet struct {
+	mu   sync.RWMutex
+	keys map[string]crypto.PublicKey
+
+	// fetch loads key set, it is nil for key sets which are loaded once
+	fetch           func(ctx context.Context) ([]byte, error)
+	refreshInterval time.Duration
+	refreshTime     time.Time
+	now             func() time.Time
+}
+
+// NewFromFile loads key set from local file once, it is used for tests and for providers with static keys
+func NewFromFile(path string) (*KeySet, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("error while reading jwks file: %w", err)
+	}
+
+	keys, err := Parse(data)
+	if err != nil {
+		return nil, err
+	}
+
+	return &KeySet{keys: keys, now: time.Now}, nil
+}
+
+// NewRemote loads key set from url and reloads it every refreshInterval or when token is signed with unknown key,
+// so keys rotated by provider are picked up without restart
+func NewRemote(ctx context.Context, url string, client *http.Client, refreshInterval time.Duration) (*KeySet, error) {
+	s := &KeySet{
+		fetch: func(ctx context.Context) ([]byte, error) {
+			return fetch(ctx, client, url)
+		},
+		refreshInterval: refreshInterval,
+		now:             time.Now,
+	}
+
+	err := s.refresh(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	return s, nil
+}
+
+// Key returns key by id. Empty id matches the only key of set
+func (s *KeySet) Key(ctx context.Context, kid string) (crypto.PublicKey, error) {
+	s.mu.RLock()
+	key, ok := s.lookup(kid)
+	sinceRefresh := s.now().Sub(s.refreshTime)
+	s.mu.RUnlock()
+
+	if s.fetch == nil || (ok && sinceRefresh < s.refreshInterval) || (!ok && sinceRefresh < minRefreshInterval) {
+		if !ok {
+			return nil, ErrKeyNotFound
+		}
+		return key, nil
+	}
+
+	err := s.refresh(ctx)
+	if err != nil && !ok {
+		return nil, err
+	}
+
+	// old keys are used if provider is unavailable
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	key, ok = s.lookup(kid)
+	if !ok {
+		return nil, ErrKeyNotFound
+	}
+
+	return key, nil
+}
+
+func (s *KeySet) lookup(kid string) (crypto.PublicKey, bool) {
+	if kid == "" && len(s.keys) == 1 {
+		for _, key := range s.keys {
+			return key, true
+		}
+	}
+
+	key, ok := s.keys[kid]
+	return key, ok
+}
+
+func (s *KeySet) refresh(ctx context.Context) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	// refresh time is updated even on errors, so unavailable provider isn't requested on every token
+	s.refreshTime = s.now()
+
+	data, err := s.fetch(ctx)
+	if err != nil {
+		return err
+	}
+
+	keys, err := Parse(data)
+	if err != nil {
+		return err
+	}
+
+	s.keys = keys
+
+	return nil
+}
+
+func fetch(ctx context.Context, client *http.Client, url string) ([]byte, error) {
+	request, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return nil, fmt.Errorf("error while creating jwks request: %w", err)
+	}
+
+	response, err := client.Do(request)
+	if err != nil {
+		return nil, fmt.Errorf("error while fetching jwks: %w", err)
+	}
+
+	defer func() { _ = response.Body.Close() }()
+
+	if response.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("error while fetching jwks: unexpected status %d", response.StatusCode)
+	}
+
+	data, err := io.ReadAll(response.Body)
+	if err != nil {
+		return nil, fmt.Errorf("error while reading jwks: %w", err)
+	}
+
+	return data, nil
+}
+
+type jsonWebKeySet struct {
+	Keys []jsonWebKey `json:"keys"`
+}
+
+type jsonWebKey struct {
+	Kty string `json:"kty"`
+	Kid string `json:"kid"`
+	Use string `json:"use"`
+	// RSA keys
+	N string `json:"n"`
+	E string `json:"e"`
+	// EC keys
+	Crv string `json:"crv"`
+	X   string `json:"x"`
+	Y   string `json:"y"`
+}
+
+// Parse returns RSA and EC signing keys of JSON Web Key Set by their ids, other keys are skipped
+func Parse(data []byte) (map[string]crypto.PublicKey, error) {
+	var set jsonWebKeySet
+	err := json.Unmarshal(data, &set)
+	if err != nil {
+		return nil, fmt.Errorf("error while decoding jwks: %w", err)
+	}
+
+	keys := make(map[string]crypto.PublicKey, len(set.Keys))
+	for _, jwk := range set.Keys {
+		if jwk.Use != "" && jwk.Use != "sig" {
+			continue
+		}
+
+		var key crypto.PublicKey
+		switch jwk.Kty {
+		case "RSA":
+			key, err = parseRSA(jwk)
+		case "EC":
+			key, err = parseEC(jwk)
+		default:
+			continue
+		}
+		if err != nil {
+			return nil, fmt.Errorf("error while parsing key %q: %w", jwk.Kid, err)
+		}
+
+		keys[jwk.Kid] = key
+	}
+
+	return keys, nil
+}
+
+func parseRSA(jwk jsonWebKey) (*rsa.PublicKey, error) {
+	n, err := decodeInt(jwk.N)
+	if err != nil {
+		return nil, err
+	}
+	e, err := decodeInt(jwk.E)
+	if err != nil {
+		return nil, err
+	}
+	if !e.IsInt64() {
+		return nil, errors.New("too big exponent")
+	}
+
+	return &rsa.PublicKey{N: n, E: int(e.Int64())}, nil
+}
+
+func parseEC(jwk jsonWebKey) (*ecdsa.PublicKey, error) {
+	var curve elliptic.Curve
+	switch jwk.Crv {
+	case "P-256":
+		curve = elliptic.P256()
+	case "P-384":
+		curve = elliptic.P384()
+	case "P-521":
+		curve = elliptic.P521()
+	default:
+		return nil, fmt.Errorf("unsupported curve %q", jwk.Crv)
+	}
+
+	x, err := decodeInt(jwk.X)
+	if err != nil {
+		return nil, err
+	}
+	y, err := decodeInt(jwk.Y)
+	if err != nil {
+		return nil, err
+	}
+	if !curve.IsOnCurve(x, y) {
+		return nil, errors.New("point isn't on curve")
+	}
+
+	return &ecdsa.PublicKey{Curve: curve, X: x, Y: y}, nil
+}
+
+func decodeInt(value string) (*big.Int, error) {
+	if value == "" {
+		return nil, errors.New("empty key parameter")
+	}
+
+	data, err := base64.RawURLEncoding.DecodeString(value)
+	if err != nil {
+		return nil, fmt.Errorf("error while decoding key parameter: %w", err)
+	}
+
+	return new(big.Int).SetBytes(data), nil
+}
@@ -0,0 +1,110 @@
+package jwks
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/rsa"
+	"encoding/base64"
+	"fmt"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewFromFile(t *testing.T) {
+	rsaKey, err := rsa.GenerateKey(rand.Reader, 2048)
+	require.NoError(t, err)
+	ecKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+
+	path := filepath.Join(t.TempDir(), "jwks.json")
+	err = os.WriteFile(path, []byte(fmt.Sprintf(`{"keys": [%s, %s, {"kty": "oct", "kid": "hmac", "k": "c2VjcmV0"}]}`,
+		rsaJWK("rsa", &rsaKey.PublicKey), ecJWK("ec", &ecKey.PublicKey),
+	)), 0o600)
+	require.NoError(t, err)
+
+	keySet, err := NewFromFile(path)
+	require.NoError(t, err)
+
+	key, err := keySet.Key(context.Background(), "rsa")
+	require.NoError(t, err)
+	assert.True(t, rsaKey.PublicKey.Equal(key))
+
+	key, err = keySet.Key(context.Background(), "ec")
+	require.NoError(t, err)
+	assert.True(t, ecKey.PublicKey.Equal(key))
+
+	_, err = keySet.Key(context.Background(), "hmac")
+	assert.ErrorIs(t, err, ErrKeyNotFound)
+
+	_, err = keySet.Key(context.Background(), "")
+	assert.ErrorIs(t, err, ErrKeyNotFound)
+}
+
+func TestParse_Error(t *testing.T) {
+	_, err := Parse([]byte(`{"keys": [{"kty": "EC", "kid": "ec", "crv": "P-256", "x": "AQ", "y": "AQ"}]}`))
+	assert.Error(t, err)
+
+	_, err = Parse([]byte(`{"keys": [{"kty": "RSA", "kid": "rsa", "n": "", "e": "AQAB"}]}`))
+	assert.Error(t, err)
+}
+
+func TestNewRemote_Rotation(t *testing.T) {
+	oldKey, err := rsa.GenerateKey(rand.Reader, 2048)
+	require.NoError(t, err)
+	newKey, err := rsa.GenerateKey(rand.Reader, 2048)
+	require.NoError(t, err)
+
+	requests := 0
+	body := fmt.Sprintf(`{"keys": [%s]}`, rsaJWK("old", &oldKey.PublicKey))
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		_, _ = w.Write([]byte(body))
+	}))
+	defer server.Close()
+
+	keySet, err := NewRemote(context.Background(), server.URL, server.Client(), time.Hour)
+	require.NoError(t, err)
+
+	now := time.Now()
+	keySet.now = func() time.Time { return now }
+
+	key, err := keySet.Key(context.Background(), "")
+	require.NoError(t, err)
+	assert.True(t, oldKey.PublicKey.Equal(key))
+
+	// provider rotated keys, but set was refreshed recently
+	body = fmt.Sprintf(`{"keys": [%s]}`, rsaJWK("new", &newKey.PublicKey))
+	_, err = keySet.Key(context.Background(), "new")
+	assert.ErrorIs(t, err, ErrKeyNotFound)
+	assert.Equal(t, 1, requests)
+
+	now = now.Add(minRefreshInterval)
+	key, err = keySet.Key(context.Background(), "new")
+	require.NoError(t, err)
+	assert.True(t, newKey.PublicKey.Equal(key))
+	assert.Equal(t, 2, requests)
+}
+
+func rsaJWK(kid string, key *rsa.PublicKey) string {
+	return fmt.Sprintf(`{"kty": "RSA", "kid": %q, "use": "sig", "n": %q, "e": %q}`,
+		kid, encodeInt(key.N), encodeInt(big.NewInt(int64(key.E))),
+	)
+}
+
+func ecJWK(kid string, key *ecdsa.PublicKey) string {
+	return fmt.Sprintf(`{"kty": "EC", "kid": %q, "crv": "P-256", "x": %q, "y": %q}`, kid, encodeInt(key.X), encodeInt(key.Y))
+}
+
+func encodeInt(value *big.Int) string {
+	return base64.RawURLEncoding.EncodeToString(value.Bytes())
+}
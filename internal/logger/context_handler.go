@@ -0,0 +1,47 @@
+package logger
+
+import (
+	"context"
+	"log/slog"
+
+	"go.opentelemetry.io/otel/trace"
+
+	"github.com/pollykon/avito_test_task/internal/actor"
+	"github.com/pollykon/avito_test_task/internal/request_id"
+)
+
+// ContextHandler adds id of request, actor and trace from context to records of handler
+
+type ContextHandler struct {
+	handler slog.Handler
+}
+
+func NewContextHandler(handler slog.Handler) slog.Handler {
+	return ContextHandler{handler: handler}
+}
+
+func (h ContextHandler) Enabled(ctx context.Context, level slog.Level) bool {
+	return h.handler.Enabled(ctx, level)
+}
+
+func (h ContextHandler) Handle(ctx context.Context, record slog.Record) error {
+	if requestID, ok := request_id.FromContext(ctx); ok {
+		record.AddAttrs(slog.String("request_id", requestID))
+	}
+	if a, ok := actor.FromContext(ctx); ok {
+		record.AddAttrs(slog.String("actor", a.ID))
+	}
+	if spanContext := trace.SpanContextFromContext(ctx); spanContext.IsValid() {
+		record.AddAttrs(slog.String("trace_id", spanContext.TraceID().String()))
+	}
+
+	return h.handler.Handle(ctx, record)
+}
+
+func (h ContextHandler) WithAttrs(attrs []slog.Attr) slog.Handler {
+	return ContextHandler{handler: h.handler.WithAttrs(attrs)}
+}
+
+func (h ContextHandler) WithGroup(name string) slog.Handler {
+	return ContextHandler{handler: h.handler.WithGroup(name)}
+}
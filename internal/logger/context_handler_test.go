@@ -0,0 +1,82 @@
+package logger
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"log/slog"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel/trace"
+
+	"github.com/pollykon/avito_test_task/internal/actor"
+	"github.com/pollykon/avito_test_task/internal/request_id"
+)
+
+func TestContextHandler(t *testing.T) {
+	tt := []struct {
+		name string
+
+		ctx context.Context
+
+		expectedRecord map[string]any
+	}{
+		{
+			name: "request_with_actor",
+
+			ctx: actor.WithActor(request_id.WithRequestID(context.Background(), "abc"), actor.Actor{ID: "api_key:1"}),
+
+			expectedRecord: map[string]any{"msg": "message", "key": "value", "request_id": "abc", "actor": "api_key:1"},
+		},
+		{
+			name: "traced_request",
+
+			ctx: trace.ContextWithSpanContext(
+				request_id.WithRequestID(context.Background(), "abc"),
+				trace.NewSpanContext(trace.SpanContextConfig{
+					TraceID: trace.TraceID{0x01},
+					SpanID:  trace.SpanID{0x02},
+				}),
+			),
+
+			expectedRecord: map[string]any{
+				"msg": "message", "key": "value", "request_id": "abc", "trace_id": "01000000000000000000000000000000",
+			},
+		},
+		{
+			name: "request_without_actor",
+
+			ctx: request_id.WithRequestID(context.Background(), "abc"),
+
+			expectedRecord: map[string]any{"msg": "message", "key": "value", "request_id": "abc"},
+		},
+		{
+			name: "not_request",
+
+			ctx: context.Background(),
+
+			expectedRecord: map[string]any{"msg": "message", "key": "value"},
+		},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			var buf bytes.Buffer
+			replaceAttr := func(_ []string, attr slog.Attr) slog.Attr {
+				if attr.Key == slog.TimeKey || attr.Key == slog.LevelKey {
+					return slog.Attr{}
+				}
+				return attr
+			}
+			logger := slog.New(NewContextHandler(slog.NewJSONHandler(&buf, &slog.HandlerOptions{ReplaceAttr: replaceAttr})))
+
+			logger.With("key", "value").InfoContext(tc.ctx, "message")
+
+			var record map[string]any
+			require.NoError(t, json.Unmarshal(buf.Bytes(), &record))
+			assert.Equal(t, tc.expectedRecord, record)
+		})
+	}
+}
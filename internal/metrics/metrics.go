@@ -0,0 +1,53 @@
+package metrics
+
+import (
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+)
+
+// Metrics are registered in default registry, which is exposed on /metrics of service and crons
+
+var (
+	HTTPRequests = promauto.NewCounterVec(prometheus.CounterOpts{
+		Name: "http_requests_total",
+		Help: "Number of served http requests by handler, method and status code.",
+	}, []string{"handler", "method", "code"})
+
+	HTTPRequestDuration = promauto.NewHistogramVec(prometheus.HistogramOpts{
+		Name:    "http_request_duration_seconds",
+		Help:    "Duration of http requests by handler, method and status code.",
+		Buckets: prometheus.DefBuckets,
+	}, []string{"handler", "method", "code"})
+
+	DBQueryDuration = promauto.NewHistogramVec(prometheus.HistogramOpts{
+		Name:    "db_query_duration_seconds",
+		Help:    "Duration of database queries by repository method.",
+		Buckets: prometheus.DefBuckets,
+	}, []string{"method"})
+
+	DBTransactionRollbacks = promauto.NewCounter(prometheus.CounterOpts{
+		Name: "db_transaction_rollbacks_total",
+		Help: "Number of rolled back transactions.",
+	})
+
+	DBTransactionRetries = promauto.NewCounterVec(prometheus.CounterOpts{
+		Name: "db_transaction_retries_total",
+		Help: "Number of transactions run again after serialization failure or deadlock by SQLSTATE code.",
+	}, []string{"code"})
+
+	DBReplicaFallbacks = promauto.NewCounterVec(prometheus.CounterOpts{
+		Name: "db_replica_fallbacks_total",
+		Help: "Number of read-only queries run on primary because replica was unavailable or lagging.",
+	}, []string{"reason"})
+
+	PercentMembershipsMaterialized = promauto.NewCounter(prometheus.CounterOpts{
+		Name: "segment_percent_memberships_materialized_total",
+		Help: "Number of users added to percent segments when their active segments are requested.",
+	})
+
+	CronDeletedRows = promauto.NewHistogramVec(prometheus.HistogramOpts{
+		Name:    "cron_deleted_rows",
+		Help:    "Number of rows deleted per run by cron job.",
+		Buckets: prometheus.ExponentialBuckets(1, 4, 8),
+	}, []string{"job"})
+)
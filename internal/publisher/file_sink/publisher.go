@@ -0,0 +1,53 @@
+package file_sink
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"sync"
+
+	"github.com/pollykon/avito_test_task/internal/publisher"
+)
+
+// Publisher appends messages to file as NDJSON
+type Publisher struct {
+	mu   sync.Mutex
+	file *os.File
+}
+
+func New(path string) (*Publisher, error) {
+	file, err := os.OpenFile(path, os.O_CREATE|os.O_APPEND|os.O_WRONLY, 0644)
+	if err != nil {
+		return nil, fmt.Errorf("error while opening file: %w", err)
+	}
+
+	return &Publisher{file: file}, nil
+}
+
+func (p *Publisher) Publish(_ context.Context, message publisher.Message) error {
+	line, err := json.Marshal(message)
+	if err != nil {
+		return fmt.Errorf("error while marshalling message: %w", err)
+	}
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	_, err = p.file.Write(append(line, '\n'))
+	if err != nil {
+		return fmt.Errorf("error while writing message: %w", err)
+	}
+
+	// message is considered delivered only after it is flushed to disk
+	err = p.file.Sync()
+	if err != nil {
+		return fmt.Errorf("error while syncing file: %w", err)
+	}
+
+	return nil
+}
+
+func (p *Publisher) Close() error {
+	return p.file.Close()
+}
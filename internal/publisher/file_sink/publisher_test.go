@@ -0,0 +1,42 @@
+package file_sink
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/pollykon/avito_test_task/internal/publisher"
+)
+
+func TestPublisher_Publish(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "events.ndjson")
+
+	sink, err := New(path)
+	require.NoError(t, err)
+
+	userID := int64(10)
+	for _, id := range []int64{1, 2} {
+		err = sink.Publish(context.Background(), publisher.Message{
+			ID:         id,
+			Type:       "user_segment.added",
+			UserID:     &userID,
+			Payload:    []byte(`{}`),
+			OccurredAt: time.Date(2023, 8, 1, 0, 0, 0, 0, time.UTC),
+		})
+		require.NoError(t, err)
+	}
+	require.NoError(t, sink.Close())
+
+	content, err := os.ReadFile(path)
+	require.NoError(t, err)
+	assert.Equal(t,
+		`{"id":1,"type":"user_segment.added","userId":10,"payload":{},"occurredAt":"2023-08-01T00:00:00Z"}`+"\n"+
+			`{"id":2,"type":"user_segment.added","userId":10,"payload":{},"occurredAt":"2023-08-01T00:00:00Z"}`+"\n",
+		string(content),
+	)
+}
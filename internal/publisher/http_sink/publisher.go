@@ -0,0 +1,57 @@
+package http_sink
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+
+	"github.com/pollykon/avito_test_task/internal/publisher"
+)
+
+// headerIdempotencyKey lets receiver deduplicate messages which were delivered more than once
+const headerIdempotencyKey = "Idempotency-Key"
+
+// Publisher sends messages as JSON in POST requests. Any 2xx status means that message is delivered
+type Publisher struct {
+	url    string
+	client *http.Client
+}
+
+func New(url string, client *http.Client) *Publisher {
+	return &Publisher{url: url, client: client}
+}
+
+func (p *Publisher) Publish(ctx context.Context, message publisher.Message) error {
+	body, err := json.Marshal(message)
+	if err != nil {
+		return fmt.Errorf("error while marshalling message: %w", err)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, p.url, bytes.NewReader(body))
+	if err != nil {
+		return fmt.Errorf("error while creating request: %w", err)
+	}
+
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set(headerIdempotencyKey, strconv.FormatInt(message.ID, 10))
+
+	resp, err := p.client.Do(req)
+	if err != nil {
+		return fmt.Errorf("error while sending message: %w", err)
+	}
+
+	defer func() { _ = resp.Body.Close() }()
+
+	if resp.StatusCode < http.StatusOK || resp.StatusCode >= http.StatusMultipleChoices {
+		responseBody, _ := io.ReadAll(io.LimitReader(resp.Body, 1024))
+		return fmt.Errorf("unexpected status %d from sink: %s", resp.StatusCode, responseBody)
+	}
+
+	_, _ = io.Copy(io.Discard, resp.Body)
+
+	return nil
+}
@@ -0,0 +1,47 @@
+package http_sink
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/pollykon/avito_test_task/internal/publisher"
+)
+
+func TestPublisher_Publish(t *testing.T) {
+	userID := int64(10)
+	message := publisher.Message{
+		ID:         12,
+		Type:       "user_segment.added",
+		UserID:     &userID,
+		Payload:    json.RawMessage(`{"userId":10,"segmentId":"AVITO","reason":"request"}`),
+		OccurredAt: time.Date(2023, 8, 1, 0, 0, 0, 0, time.UTC),
+	}
+
+	var received publisher.Message
+	var idempotencyKey string
+	status := http.StatusAccepted
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		idempotencyKey = r.Header.Get(headerIdempotencyKey)
+		_ = json.NewDecoder(r.Body).Decode(&received)
+		w.WriteHeader(status)
+	}))
+	defer server.Close()
+
+	sink := New(server.URL, server.Client())
+
+	err := sink.Publish(context.Background(), message)
+	require.NoError(t, err)
+	assert.Equal(t, message, received)
+	assert.Equal(t, "12", idempotencyKey)
+
+	status = http.StatusServiceUnavailable
+	err = sink.Publish(context.Background(), message)
+	assert.Error(t, err)
+}
@@ -0,0 +1,16 @@
+package publisher
+
+import (
+	"encoding/json"
+	"time"
+)
+
+// Message is an event delivered to downstream services. Delivery is at-least-once,
+// so consumers must deduplicate messages by id. UserID is nil for events about segments
+type Message struct {
+	ID         int64           `json:"id"`
+	Type       string          `json:"type"`
+	UserID     *int64          `json:"userId,omitempty"`
+	Payload    json.RawMessage `json:"payload"`
+	OccurredAt time.Time       `json:"occurredAt"`
+}
@@ -0,0 +1,48 @@
+package webhook_sink
+
+import (
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"net/netip"
+	"syscall"
+	"time"
+)
+
+// ErrForbiddenAddress is returned when host of webhook resolves to address of internal network
+var ErrForbiddenAddress = errors.New("forbidden address of webhook")
+
+// NewClient creates http client for webhooks. Unless allowPrivate is set, client doesn't connect to loopback,
+// link-local, private and unspecified addresses, so subscribers can't make service call internal hosts. Address is
+// checked after DNS resolution right before connecting, so redirects and hosts resolving to internal addresses are
+// refused too
+func NewClient(timeout time.Duration, allowPrivate bool) *http.Client {
+	dialer := &net.Dialer{Timeout: timeout, KeepAlive: 30 * time.Second}
+	if !allowPrivate {
+		dialer.Control = checkAddress
+	}
+
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	// with proxy address of proxy would be checked instead of address of webhook
+	transport.Proxy = nil
+	transport.DialContext = dialer.DialContext
+
+	return &http.Client{Timeout: timeout, Transport: transport}
+}
+
+// checkAddress returns ErrForbiddenAddress if resolved address of connection belongs to internal network
+func checkAddress(_ string, address string, _ syscall.RawConn) error {
+	addrPort, err := netip.ParseAddrPort(address)
+	if err != nil {
+		return fmt.Errorf("error while parsing address %s: %w", address, err)
+	}
+
+	addr := addrPort.Addr().Unmap()
+	if addr.IsLoopback() || addr.IsLinkLocalUnicast() || addr.IsLinkLocalMulticast() || addr.IsPrivate() ||
+		addr.IsUnspecified() {
+		return fmt.Errorf("%w: %s", ErrForbiddenAddress, addr)
+	}
+
+	return nil
+}
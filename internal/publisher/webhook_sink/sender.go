@@ -0,0 +1,84 @@
+package webhook_sink
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/pollykon/avito_test_task/internal/publisher"
+)
+
+// Headers of webhook request. Receiver verifies signature as
+// hex(hmac_sha256(secret, timestamp + "." + body)) and rejects too old timestamps to prevent replays
+const (
+	HeaderTimestamp      = "X-Webhook-Timestamp"
+	HeaderSignature      = "X-Webhook-Signature"
+	HeaderEvent          = "X-Webhook-Event"
+	headerIdempotencyKey = "Idempotency-Key"
+
+	signaturePrefix = "sha256="
+)
+
+// Sender posts messages to webhook subscribers. Any 2xx status means that message is delivered
+type Sender struct {
+	client *http.Client
+	now    func() time.Time
+}
+
+func New(client *http.Client) *Sender {
+	return &Sender{client: client, now: time.Now}
+}
+
+// Send delivers message to url and returns response status code, which is 0 if no response was received
+func (s *Sender) Send(ctx context.Context, url string, secret string, message publisher.Message) (int64, error) {
+	body, err := json.Marshal(message)
+	if err != nil {
+		return 0, fmt.Errorf("error while marshalling message: %w", err)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, url, bytes.NewReader(body))
+	if err != nil {
+		return 0, fmt.Errorf("error while creating request: %w", err)
+	}
+
+	timestamp := strconv.FormatInt(s.now().Unix(), 10)
+
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set(HeaderTimestamp, timestamp)
+	req.Header.Set(HeaderSignature, signaturePrefix+Sign(secret, timestamp, body))
+	req.Header.Set(HeaderEvent, message.Type)
+	req.Header.Set(headerIdempotencyKey, strconv.FormatInt(message.ID, 10))
+
+	resp, err := s.client.Do(req)
+	if err != nil {
+		return 0, fmt.Errorf("error while sending webhook: %w", err)
+	}
+
+	defer func() { _ = resp.Body.Close() }()
+
+	if resp.StatusCode < http.StatusOK || resp.StatusCode >= http.StatusMultipleChoices {
+		responseBody, _ := io.ReadAll(io.LimitReader(resp.Body, 1024))
+		return int64(resp.StatusCode), fmt.Errorf("unexpected status %d from webhook: %s", resp.StatusCode, responseBody)
+	}
+
+	_, _ = io.Copy(io.Discard, resp.Body)
+
+	return int64(resp.StatusCode), nil
+}
+
+// Sign returns hex encoded HMAC-SHA256 of timestamp and body
+func Sign(secret string, timestamp string, body []byte) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte(timestamp))
+	mac.Write([]byte("."))
+	mac.Write(body)
+	return hex.EncodeToString(mac.Sum(nil))
+}
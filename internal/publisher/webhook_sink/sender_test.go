@@ -0,0 +1,165 @@
+package webhook_sink
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/pollykon/avito_test_task/internal/publisher"
+)
+
+func TestSender_Send(t *testing.T) {
+	userID := int64(10)
+	message := publisher.Message{
+		ID:         12,
+		Type:       "user_segment.added",
+		UserID:     &userID,
+		Payload:    json.RawMessage(`{"userId":10,"segmentId":"AVITO","reason":"request"}`),
+		OccurredAt: time.Date(2023, 8, 1, 0, 0, 0, 0, time.UTC),
+	}
+
+	var body []byte
+	var header http.Header
+	status := http.StatusNoContent
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		header = r.Header
+		body, _ = io.ReadAll(r.Body)
+		w.WriteHeader(status)
+	}))
+	defer server.Close()
+
+	sender := New(server.Client())
+	sender.now = func() time.Time { return time.Unix(1690848000, 0) }
+
+	statusCode, err := sender.Send(context.Background(), server.URL, "secret", message)
+	require.NoError(t, err)
+	assert.Equal(t, int64(http.StatusNoContent), statusCode)
+
+	var received publisher.Message
+	require.NoError(t, json.Unmarshal(body, &received))
+	assert.Equal(t, message, received)
+
+	assert.Equal(t, "1690848000", header.Get(HeaderTimestamp))
+	assert.Equal(t, "sha256="+Sign("secret", "1690848000", body), header.Get(HeaderSignature))
+	assert.NotEqual(t, "sha256="+Sign("other", "1690848000", body), header.Get(HeaderSignature))
+	assert.Equal(t, "user_segment.added", header.Get(HeaderEvent))
+	assert.Equal(t, "12", header.Get(headerIdempotencyKey))
+
+	status = http.StatusInternalServerError
+	statusCode, err = sender.Send(context.Background(), server.URL, "secret", message)
+	assert.Error(t, err)
+	assert.Equal(t, int64(http.StatusInternalServerError), statusCode)
+}
+
+func TestNewClient(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	defer server.Close()
+
+	message := publisher.Message{ID: 12, Type: "segment.created"}
+
+	statusCode, err := New(NewClient(time.Second, false)).Send(context.Background(), server.URL, "secret", message)
+	assert.ErrorIs(t, err, ErrForbiddenAddress)
+	assert.Equal(t, int64(0), statusCode)
+
+	statusCode, err = New(NewClient(time.Second, true)).Send(context.Background(), server.URL, "secret", message)
+	assert.NoError(t, err)
+	assert.Equal(t, int64(http.StatusNoContent), statusCode)
+}
+
+func TestCheckAddress(t *testing.T) {
+	tt := []struct {
+		name string
+
+		address string
+
+		expectedError error
+	}{
+		{
+			name: "public_ipv4",
+
+			address: "93.184.216.34:443",
+
+			expectedError: nil,
+		},
+		{
+			name: "public_ipv6",
+
+			address: "[2606:2800:220:1:248:1893:25c8:1946]:443",
+
+			expectedError: nil,
+		},
+		{
+			name: "loopback",
+
+			address: "127.0.0.1:8080",
+
+			expectedError: ErrForbiddenAddress,
+		},
+		{
+			name: "loopback_ipv6",
+
+			address: "[::1]:8080",
+
+			expectedError: ErrForbiddenAddress,
+		},
+		{
+			name: "loopback_mapped_to_ipv6",
+
+			address: "[::ffff:127.0.0.1]:8080",
+
+			expectedError: ErrForbiddenAddress,
+		},
+		{
+			name: "private",
+
+			address: "10.0.0.5:80",
+
+			expectedError: ErrForbiddenAddress,
+		},
+		{
+			name: "private_ipv6",
+
+			address: "[fd00::1]:80",
+
+			expectedError: ErrForbiddenAddress,
+		},
+		{
+			name: "link_local_metadata",
+
+			address: "169.254.169.254:80",
+
+			expectedError: ErrForbiddenAddress,
+		},
+		{
+			name: "link_local_ipv6",
+
+			address: "[fe80::1]:80",
+
+			expectedError: ErrForbiddenAddress,
+		},
+		{
+			name: "unspecified",
+
+			address: "0.0.0.0:80",
+
+			expectedError: ErrForbiddenAddress,
+		},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			err := checkAddress("tcp", tc.address, nil)
+
+			assert.ErrorIs(t, err, tc.expectedError)
+		})
+	}
+}
@@ -0,0 +1,5 @@
+package api_key
+
+import "errors"
+
+var ErrKeyNotExist = errors.New("api key doesn't exist")
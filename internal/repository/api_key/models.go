@@ -0,0 +1,13 @@
+package api_key
+
+import "time"
+
+type Key struct {
+	ID     int64
+	Name   string
+	Scopes []string
+	// Prefix is the beginning of key, it helps to recognize key without storing it
+	Prefix     string
+	InsertTime time.Time
+	RevokeTime *time.Time
+}
@@ -0,0 +1,109 @@
+package api_key
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/lib/pq"
+
+	"github.com/pollykon/avito_test_task/internal/storage"
+)
+
+type Repository struct {
+	db storage.Database
+}
+
+func New(db storage.Database) *Repository {
+	return &Repository{
+		db: db,
+	}
+}
+
+// Add saves key by its hash and returns its id. Plain key is never stored
+func (r *Repository) Add(ctx context.Context, key Key, hash string) (int64, error) {
+	query := `insert into api_key (name, key_hash, prefix, scopes) values ($1, $2, $3, $4) returning id`
+
+	rows, err := r.db.QueryContext(ctx, query, key.Name, hash, key.Prefix, pq.Array(key.Scopes))
+	if err != nil {
+		return 0, fmt.Errorf("error while inserting into api_key: %w", err)
+	}
+
+	defer func() { _ = rows.Close() }()
+
+	var id int64
+	for rows.Next() {
+		err = rows.Scan(&id)
+		if err != nil {
+			return 0, fmt.Errorf("error while scanning rows: %w", err)
+		}
+	}
+
+	return id, nil
+}
+
+// GetByHash returns key which isn't revoked
+func (r *Repository) GetByHash(ctx context.Context, hash string) (Key, error) {
+	query := `select id, name, scopes, prefix, insert_time, revoke_time from api_key
+			  where key_hash = $1 and revoke_time is null`
+
+	keys, err := r.queryKeys(ctx, query, hash)
+	if err != nil {
+		return Key{}, err
+	}
+
+	if len(keys) == 0 {
+		return Key{}, ErrKeyNotExist
+	}
+
+	return keys[0], nil
+}
+
+// GetKeys returns all keys including revoked ones
+func (r *Repository) GetKeys(ctx context.Context) ([]Key, error) {
+	query := `select id, name, scopes, prefix, insert_time, revoke_time from api_key order by id`
+
+	return r.queryKeys(ctx, query)
+}
+
+func (r *Repository) Revoke(ctx context.Context, id int64) error {
+	res, err := r.db.ExecContext(
+		ctx, `update api_key set revoke_time = now() where id = $1 and revoke_time is null`, id,
+	)
+	if err != nil {
+		return fmt.Errorf("error while revoking api key: %w", err)
+	}
+
+	numberOfRevokedKeys, err := res.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("error while getting affected rows: %w", err)
+	}
+
+	if numberOfRevokedKeys == 0 {
+		return ErrKeyNotExist
+	}
+
+	return nil
+}
+
+func (r *Repository) queryKeys(ctx context.Context, query string, args ...interface{}) ([]Key, error) {
+	rows, err := r.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("error while getting api keys: %w", err)
+	}
+
+	defer func() { _ = rows.Close() }()
+
+	var keys []Key
+	for rows.Next() {
+		var key Key
+
+		err = rows.Scan(&key.ID, &key.Name, pq.Array(&key.Scopes), &key.Prefix, &key.InsertTime, &key.RevokeTime)
+		if err != nil {
+			return nil, fmt.Errorf("error while scanning api keys: %w", err)
+		}
+
+		keys = append(keys, key)
+	}
+
+	return keys, nil
+}
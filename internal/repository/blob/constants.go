@@ -0,0 +1,5 @@
+package blob
+
+import "errors"
+
+var ErrObjectNotExist = errors.New("object doesn't exist")
@@ -0,0 +1,81 @@
+package local
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path"
+
+	"github.com/pollykon/avito_test_task/internal/repository/blob"
+)
+
+// Repository stores files on local disk
+type Repository struct {
+	folderPath string
+}
+
+func New(folderPath string) Repository {
+	return Repository{folderPath: folderPath}
+}
+
+// Save creates file with given name and content
+func (r Repository) Save(_ context.Context, name string, content []byte) error {
+	err := os.WriteFile(path.Join(r.folderPath, name), content, 0o644)
+	if err != nil {
+		return fmt.Errorf("error while writing: %w", err)
+	}
+
+	return nil
+}
+
+// Open opens previously saved file. Caller must close object content
+func (r Repository) Open(_ context.Context, name string) (blob.Object, error) {
+	file, err := os.Open(path.Join(r.folderPath, name))
+	if err != nil {
+		if errors.Is(err, fs.ErrNotExist) {
+			return blob.Object{}, blob.ErrObjectNotExist
+		}
+		return blob.Object{}, fmt.Errorf("error while opening: %w", err)
+	}
+
+	info, err := file.Stat()
+	if err != nil {
+		_ = file.Close()
+		return blob.Object{}, fmt.Errorf("error while getting file info: %w", err)
+	}
+
+	if info.IsDir() {
+		_ = file.Close()
+		return blob.Object{}, blob.ErrObjectNotExist
+	}
+
+	return blob.Object{Content: file, Size: info.Size(), ModTime: info.ModTime()}, nil
+}
+
+// CheckWritable checks that files can be created in folder by creating and removing temporary file
+func (r Repository) CheckWritable(_ context.Context) error {
+	file, err := os.CreateTemp(r.folderPath, ".writable_check_*")
+	if err != nil {
+		return fmt.Errorf("error while creating file: %w", err)
+	}
+
+	_ = file.Close()
+	err = os.Remove(file.Name())
+	if err != nil {
+		return fmt.Errorf("error while removing file: %w", err)
+	}
+
+	return nil
+}
+
+// Delete removes file. Missing file isn't considered an error
+func (r Repository) Delete(_ context.Context, name string) error {
+	err := os.Remove(path.Join(r.folderPath, name))
+	if err != nil && !errors.Is(err, fs.ErrNotExist) {
+		return fmt.Errorf("error while removing: %w", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,13 @@
+package blob
+
+import (
+	"io"
+	"time"
+)
+
+// Object is content of stored file. Content is io.ReadSeekCloser when storage supports seeking
+type Object struct {
+	Content io.ReadCloser
+	Size    int64
+	ModTime time.Time
+}
@@ -0,0 +1,158 @@
+package s3
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/pollykon/avito_test_task/internal/repository/blob"
+)
+
+type Config struct {
+	// Endpoint is base url of S3-compatible storage, e.g. https://s3.amazonaws.com or http://minio:9000
+	Endpoint        string
+	Region          string
+	Bucket          string
+	AccessKeyID     string
+	SecretAccessKey string
+	// UsePathStyle addresses objects as endpoint/bucket/name instead of bucket.endpoint/name (required by MinIO)
+	UsePathStyle bool
+}
+
+// Repository stores files in S3-compatible object storage
+type Repository struct {
+	endpoint     *url.URL
+	bucket       string
+	usePathStyle bool
+	credentials  credentials
+	client       *http.Client
+	now          func() time.Time
+}
+
+func New(config Config, client *http.Client) (*Repository, error) {
+	endpoint, err := url.Parse(config.Endpoint)
+	if err != nil {
+		return nil, fmt.Errorf("error while parsing endpoint: %w", err)
+	}
+
+	if endpoint.Scheme == "" || endpoint.Host == "" {
+		return nil, fmt.Errorf("endpoint must be absolute url: %q", config.Endpoint)
+	}
+
+	return &Repository{
+		endpoint:     endpoint,
+		bucket:       config.Bucket,
+		usePathStyle: config.UsePathStyle,
+		credentials: credentials{
+			accessKeyID:     config.AccessKeyID,
+			secretAccessKey: config.SecretAccessKey,
+			region:          config.Region,
+		},
+		client: client,
+		now:    time.Now,
+	}, nil
+}
+
+// Save uploads object with given name and content
+func (r *Repository) Save(ctx context.Context, name string, content []byte) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodPut, r.objectURL(name).String(), bytes.NewReader(content))
+	if err != nil {
+		return fmt.Errorf("error while creating request: %w", err)
+	}
+
+	r.credentials.signRequest(req, hashSHA256(content), r.now())
+
+	resp, err := r.client.Do(req)
+	if err != nil {
+		return fmt.Errorf("error while uploading object: %w", err)
+	}
+
+	defer func() { _ = resp.Body.Close() }()
+
+	if resp.StatusCode != http.StatusOK {
+		return unexpectedStatusError(resp)
+	}
+
+	return nil
+}
+
+// Open downloads object. Caller must close object content
+func (r *Repository) Open(ctx context.Context, name string) (blob.Object, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, r.objectURL(name).String(), nil)
+	if err != nil {
+		return blob.Object{}, fmt.Errorf("error while creating request: %w", err)
+	}
+
+	r.credentials.signRequest(req, hashSHA256(nil), r.now())
+
+	resp, err := r.client.Do(req)
+	if err != nil {
+		return blob.Object{}, fmt.Errorf("error while downloading object: %w", err)
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		defer func() { _ = resp.Body.Close() }()
+
+		if resp.StatusCode == http.StatusNotFound {
+			return blob.Object{}, blob.ErrObjectNotExist
+		}
+		return blob.Object{}, unexpectedStatusError(resp)
+	}
+
+	modTime, _ := http.ParseTime(resp.Header.Get("Last-Modified"))
+
+	return blob.Object{Content: resp.Body, Size: resp.ContentLength, ModTime: modTime}, nil
+}
+
+// Delete removes object. Missing object isn't considered an error
+func (r *Repository) Delete(ctx context.Context, name string) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodDelete, r.objectURL(name).String(), nil)
+	if err != nil {
+		return fmt.Errorf("error while creating request: %w", err)
+	}
+
+	r.credentials.signRequest(req, hashSHA256(nil), r.now())
+
+	resp, err := r.client.Do(req)
+	if err != nil {
+		return fmt.Errorf("error while deleting object: %w", err)
+	}
+
+	defer func() { _ = resp.Body.Close() }()
+
+	if resp.StatusCode != http.StatusNoContent && resp.StatusCode != http.StatusOK &&
+		resp.StatusCode != http.StatusNotFound {
+		return unexpectedStatusError(resp)
+	}
+
+	return nil
+}
+
+// PresignGet returns url which allows downloading object without credentials during ttl
+func (r *Repository) PresignGet(name string, ttl time.Duration) string {
+	return r.credentials.presign(r.objectURL(name), ttl, r.now())
+}
+
+func (r *Repository) objectURL(name string) *url.URL {
+	objectURL := *r.endpoint
+	basePath := strings.TrimSuffix(objectURL.Path, "/")
+
+	if r.usePathStyle {
+		objectURL.Path = basePath + "/" + r.bucket + "/" + name
+	} else {
+		objectURL.Host = r.bucket + "." + objectURL.Host
+		objectURL.Path = basePath + "/" + name
+	}
+
+	return &objectURL
+}
+
+func unexpectedStatusError(resp *http.Response) error {
+	body, _ := io.ReadAll(io.LimitReader(resp.Body, 1024))
+	return fmt.Errorf("unexpected status %d from object storage: %s", resp.StatusCode, body)
+}
@@ -0,0 +1,172 @@
+package s3
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"net/http"
+	"net/url"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// AWS Signature Version 4, see https://docs.aws.amazon.com/AmazonS3/latest/API/sig-v4-authenticating-requests.html
+
+const (
+	signAlgorithm   = "AWS4-HMAC-SHA256"
+	service         = "s3"
+	unsignedPayload = "UNSIGNED-PAYLOAD"
+	amzDateFormat   = "20060102T150405Z"
+	scopeDateFormat = "20060102"
+
+	headerAmzDate          = "X-Amz-Date"
+	headerAmzContentSHA256 = "X-Amz-Content-Sha256"
+)
+
+type credentials struct {
+	accessKeyID     string
+	secretAccessKey string
+	region          string
+}
+
+// signRequest adds Authorization header to request
+func (c credentials) signRequest(req *http.Request, payloadHash string, now time.Time) {
+	amzDate := now.UTC().Format(amzDateFormat)
+	req.Header.Set(headerAmzDate, amzDate)
+	req.Header.Set(headerAmzContentSHA256, payloadHash)
+
+	headerNames := []string{"host"}
+	for name := range req.Header {
+		lowerName := strings.ToLower(name)
+		if lowerName == "content-type" || strings.HasPrefix(lowerName, "x-amz-") {
+			headerNames = append(headerNames, lowerName)
+		}
+	}
+	sort.Strings(headerNames)
+
+	var canonicalHeaders strings.Builder
+	for _, name := range headerNames {
+		value := req.Host
+		if name != "host" {
+			value = strings.TrimSpace(req.Header.Get(name))
+		}
+		canonicalHeaders.WriteString(name + ":" + value + "\n")
+	}
+	signedHeaders := strings.Join(headerNames, ";")
+
+	canonicalRequest := strings.Join([]string{
+		req.Method,
+		encodePath(req.URL.Path),
+		canonicalQuery(req.URL.Query()),
+		canonicalHeaders.String(),
+		signedHeaders,
+		payloadHash,
+	}, "\n")
+
+	scope := c.scope(now)
+	signature := c.signature(canonicalRequest, amzDate, scope, now)
+
+	req.Header.Set(
+		"Authorization",
+		signAlgorithm+" Credential="+c.accessKeyID+"/"+scope+", SignedHeaders="+signedHeaders+", Signature="+signature,
+	)
+}
+
+// presign returns url which grants GET access to object without credentials until expires passes
+func (c credentials) presign(objectURL *url.URL, expires time.Duration, now time.Time) string {
+	amzDate := now.UTC().Format(amzDateFormat)
+	scope := c.scope(now)
+
+	query := objectURL.Query()
+	query.Set("X-Amz-Algorithm", signAlgorithm)
+	query.Set("X-Amz-Credential", c.accessKeyID+"/"+scope)
+	query.Set("X-Amz-Date", amzDate)
+	query.Set("X-Amz-Expires", strconv.FormatInt(int64(expires/time.Second), 10))
+	query.Set("X-Amz-SignedHeaders", "host")
+
+	canonicalRequest := strings.Join([]string{
+		http.MethodGet,
+		encodePath(objectURL.Path),
+		canonicalQuery(query),
+		"host:" + objectURL.Host + "\n",
+		"host",
+		unsignedPayload,
+	}, "\n")
+
+	query.Set("X-Amz-Signature", c.signature(canonicalRequest, amzDate, scope, now))
+
+	presigned := *objectURL
+	presigned.RawQuery = canonicalQuery(query)
+	return presigned.String()
+}
+
+func (c credentials) scope(now time.Time) string {
+	return now.UTC().Format(scopeDateFormat) + "/" + c.region + "/" + service + "/aws4_request"
+}
+
+func (c credentials) signature(canonicalRequest string, amzDate string, scope string, now time.Time) string {
+	stringToSign := strings.Join([]string{signAlgorithm, amzDate, scope, hashSHA256([]byte(canonicalRequest))}, "\n")
+
+	key := hmacSHA256([]byte("AWS4"+c.secretAccessKey), now.UTC().Format(scopeDateFormat))
+	key = hmacSHA256(key, c.region)
+	key = hmacSHA256(key, service)
+	key = hmacSHA256(key, "aws4_request")
+
+	return hex.EncodeToString(hmacSHA256(key, stringToSign))
+}
+
+func canonicalQuery(query url.Values) string {
+	keys := make([]string, 0, len(query))
+	for key := range query {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	pairs := make([]string, 0, len(keys))
+	for _, key := range keys {
+		values := query[key]
+		sort.Strings(values)
+		for _, value := range values {
+			pairs = append(pairs, encode(key, true)+"="+encode(value, true))
+		}
+	}
+
+	return strings.Join(pairs, "&")
+}
+
+func encodePath(path string) string {
+	if path == "" {
+		return "/"
+	}
+	return encode(path, false)
+}
+
+// encode escapes everything except unreserved characters as required by signature
+func encode(s string, encodeSlash bool) string {
+	var encoded strings.Builder
+	for _, b := range []byte(s) {
+		switch {
+		case 'a' <= b && b <= 'z', 'A' <= b && b <= 'Z', '0' <= b && b <= '9',
+			b == '-', b == '_', b == '.', b == '~':
+			encoded.WriteByte(b)
+		case b == '/' && !encodeSlash:
+			encoded.WriteByte(b)
+		default:
+			encoded.WriteString("%" + strings.ToUpper(hex.EncodeToString([]byte{b})))
+		}
+	}
+	return encoded.String()
+}
+
+func hashSHA256(data []byte) string {
+	sum := sha256.Sum256(data)
+	return hex.EncodeToString(sum[:])
+}
+
+func hmacSHA256(key []byte, data string) []byte {
+	mac := hmac.New(sha256.New, key)
+	_, _ = mac.Write([]byte(data))
+	return mac.Sum(nil)
+}
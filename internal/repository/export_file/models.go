@@ -0,0 +1,9 @@
+package export_file
+
+import "time"
+
+type ExportFile struct {
+	FileName   string
+	Size       int64
+	InsertTime time.Time
+}
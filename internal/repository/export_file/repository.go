@@ -0,0 +1,80 @@
+package export_file
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/pollykon/avito_test_task/internal/storage"
+)
+
+type Repository struct {
+	db storage.Database
+}
+
+func New(db storage.Database) *Repository {
+	return &Repository{
+		db: db,
+	}
+}
+
+// Add saves metadata of generated export file
+func (r *Repository) Add(ctx context.Context, fileName string, size int64) error {
+	_, err := r.db.ExecContext(ctx, `insert into export_file (file_name, size) values ($1, $2)`, fileName, size)
+	if err != nil {
+		return fmt.Errorf("error while inserting into export_file: %w", err)
+	}
+
+	return nil
+}
+
+// GetExpired returns files which were generated more than maxAge ago
+func (r *Repository) GetExpired(ctx context.Context, maxAge time.Duration, limit int64) ([]ExportFile, error) {
+	query := `select file_name, size, insert_time from export_file
+			  where insert_time < $1
+			  order by insert_time
+			  limit $2`
+
+	rows, err := r.db.QueryContext(ctx, query, time.Now().Add(-maxAge), limit)
+	if err != nil {
+		return nil, fmt.Errorf("error while getting expired export files: %w", err)
+	}
+
+	defer func() { _ = rows.Close() }()
+
+	var files []ExportFile
+	for rows.Next() {
+		var file ExportFile
+
+		err = rows.Scan(&file.FileName, &file.Size, &file.InsertTime)
+		if err != nil {
+			return nil, fmt.Errorf("error while scanning rows: %w", err)
+		}
+
+		files = append(files, file)
+	}
+
+	return files, nil
+}
+
+func (r *Repository) Delete(ctx context.Context, fileNames []string) error {
+	if len(fileNames) == 0 {
+		return nil
+	}
+
+	values := make([]string, 0, len(fileNames))
+	fileNamesAny := make([]interface{}, 0, len(fileNames))
+	for i, fileName := range fileNames {
+		values = append(values, fmt.Sprintf("$%d", i+1))
+		fileNamesAny = append(fileNamesAny, fileName)
+	}
+
+	query := fmt.Sprintf(`delete from export_file where file_name in (%s)`, strings.Join(values, ","))
+	_, err := r.db.ExecContext(ctx, query, fileNamesAny...)
+	if err != nil {
+		return fmt.Errorf("error while deleting from export_file: %w", err)
+	}
+
+	return nil
+}
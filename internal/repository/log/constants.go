@@ -6,3 +6,9 @@ const (
 	OperationTypeAdd    = "add"
 	OperationTypeDelete = "delete"
 )
+
+// Operations lists all operation types which can be stored in logger table
+var Operations = []string{OperationTypeAdd, OperationTypeDelete}
+
+// partitionNameLayout is time layout of monthly partition names, e.g. log_2023_08
+const partitionNameLayout = "log_2006_01"
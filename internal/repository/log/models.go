@@ -3,9 +3,18 @@ package log
 import "time"
 
 type Log struct {
-	ID         int64
-	UserID     int64
-	SegmentID  string
-	Operation  string
+	ID        int64
+	UserID    int64
+	SegmentID string
+	Operation string
+	// Actor is empty for operations made by service itself
+	Actor      string
 	InsertTime time.Time
 }
+
+// Partition is a monthly partition of logger table with logs inserted in [From, To)
+type Partition struct {
+	Name string
+	From time.Time
+	To   time.Time
+}
@@ -2,6 +2,7 @@ package log
 
 import (
 	"context"
+	"database/sql"
 	"fmt"
 	"strings"
 	"time"
@@ -19,20 +20,23 @@ func New(db storage.Database) *Repository {
 	}
 }
 
-func (l *Repository) Add(ctx context.Context, userID int64, segments []string, operation string) error {
+// Add saves operation with user's segments made by actor, empty actor means that operation is made by service itself
+func (l *Repository) Add(ctx context.Context, userID int64, segments []string, operation string, actor string) error {
 	if len(segments) == 0 {
 		return nil
 	}
 
 	values := make([]string, 0, len(segments))
-	queryArgs := make([]interface{}, 0, len(segments)+1)
-	queryArgs = append(queryArgs, operation)
+	queryArgs := make([]interface{}, 0, len(segments)+2)
+	queryArgs = append(queryArgs, operation, sql.NullString{String: actor, Valid: actor != ""})
 	for i, segment := range segments {
-		values = append(values, fmt.Sprintf("(%d, $%d, $1)", userID, i+2))
+		values = append(values, fmt.Sprintf("(%d, $%d, $1, $2)", userID, i+3))
 		queryArgs = append(queryArgs, segment)
 	}
 
-	query := fmt.Sprintf(`insert into log (user_id, segment_id, operation) values %s`, strings.Join(values, ","))
+	query := fmt.Sprintf(
+		`insert into log (user_id, segment_id, operation, actor) values %s`, strings.Join(values, ","),
+	)
 	_, err := l.db.ExecContext(ctx, query, queryArgs...)
 	if err != nil {
 		return fmt.Errorf("error while inserting into log: %w", err)
@@ -41,9 +45,55 @@ func (l *Repository) Add(ctx context.Context, userID int64, segments []string, o
 	return nil
 }
 
-func (l *Repository) Delete(ctx context.Context, limit int64) error {
-	query := `delete from log where id in (select id from log where insert_time + interval '3 months' < now() limit $1)`
-	_, err := l.db.ExecContext(ctx, query, limit)
+// GetExpired returns logs of given operation which were inserted more than retention ago
+func (l *Repository) GetExpired(
+	ctx context.Context,
+	operation string,
+	retention time.Duration,
+	limit int64,
+) ([]Log, error) {
+	query := `select id, user_id, segment_id, operation, coalesce(actor, ''), insert_time from log
+			  where operation = $1
+			  and insert_time < $2
+			  order by id
+			  limit $3`
+
+	rows, err := l.db.QueryContext(ctx, query, operation, time.Now().Add(-retention), limit)
+	if err != nil {
+		return nil, fmt.Errorf("error while getting expired logs: %w", err)
+	}
+
+	defer func() { _ = rows.Close() }()
+
+	var logs []Log
+	for rows.Next() {
+		var log = Log{}
+
+		err = rows.Scan(&log.ID, &log.UserID, &log.SegmentID, &log.Operation, &log.Actor, &log.InsertTime)
+		if err != nil {
+			return nil, fmt.Errorf("error while scanning rows: %w", err)
+		}
+
+		logs = append(logs, log)
+	}
+
+	return logs, nil
+}
+
+func (l *Repository) Delete(ctx context.Context, ids []int64) error {
+	if len(ids) == 0 {
+		return nil
+	}
+
+	values := make([]string, 0, len(ids))
+	idsAny := make([]interface{}, 0, len(ids))
+	for i, id := range ids {
+		values = append(values, fmt.Sprintf("$%d", i+1))
+		idsAny = append(idsAny, id)
+	}
+
+	query := fmt.Sprintf(`delete from log where id in (%s)`, strings.Join(values, ","))
+	_, err := l.db.ExecContext(ctx, query, idsAny...)
 	if err != nil {
 		return fmt.Errorf("error while deleting from log: %w", err)
 	}
@@ -51,13 +101,15 @@ func (l *Repository) Delete(ctx context.Context, limit int64) error {
 	return nil
 }
 
+// Get returns history of user for period, it may be read from replica
 func (l *Repository) Get(ctx context.Context, userID int64, from time.Time, to time.Time) ([]Log, error) {
-	query := `select id, user_id, segment_id, operation, insert_time from log
+	query := `select id, user_id, segment_id, operation, coalesce(actor, ''), insert_time from log
                   where user_id = $1 
 				  and insert_time >= $2
-				  and insert_time < $3`
+				  and insert_time < $3
+				  order by insert_time, id`
 
-	rows, err := l.db.QueryContext(ctx, query, userID, from, to)
+	rows, err := l.db.QueryReadContext(ctx, query, userID, from, to)
 	if err != nil {
 		return nil, fmt.Errorf("error while getting logs: %w", err)
 	}
@@ -68,7 +120,91 @@ func (l *Repository) Get(ctx context.Context, userID int64, from time.Time, to t
 	for rows.Next() {
 		var log = Log{}
 
-		err = rows.Scan(&log.ID, &log.UserID, &log.SegmentID, &log.Operation, &log.InsertTime)
+		err = rows.Scan(&log.ID, &log.UserID, &log.SegmentID, &log.Operation, &log.Actor, &log.InsertTime)
+		if err != nil {
+			return nil, fmt.Errorf("error while scanning rows: %w", err)
+		}
+
+		logs = append(logs, log)
+	}
+
+	return logs, nil
+}
+
+// CreatePartition creates partition for month which contains given time if it doesn't exist
+func (l *Repository) CreatePartition(ctx context.Context, month time.Time) error {
+	partition := newPartition(month)
+
+	// ddl doesn't support placeholders, so values are formatted by the service itself
+	query := fmt.Sprintf(
+		`create table if not exists %s partition of log for values from ('%s') to ('%s')`,
+		partition.Name,
+		partition.From.Format(time.RFC3339),
+		partition.To.Format(time.RFC3339),
+	)
+	_, err := l.db.ExecContext(ctx, query)
+	if err != nil {
+		return fmt.Errorf("error while creating partition %s: %w", partition.Name, err)
+	}
+
+	return nil
+}
+
+// GetPartitions returns monthly partitions of logger table ordered by time
+func (l *Repository) GetPartitions(ctx context.Context) ([]Partition, error) {
+	query := `select child.relname from pg_inherits
+			  join pg_class parent on parent.oid = pg_inherits.inhparent
+			  join pg_class child on child.oid = pg_inherits.inhrelid
+			  where parent.relname = 'log'
+			  order by child.relname`
+
+	rows, err := l.db.QueryContext(ctx, query)
+	if err != nil {
+		return nil, fmt.Errorf("error while getting partitions: %w", err)
+	}
+
+	defer func() { _ = rows.Close() }()
+
+	var partitions []Partition
+	for rows.Next() {
+		var name string
+
+		err = rows.Scan(&name)
+		if err != nil {
+			return nil, fmt.Errorf("error while scanning rows: %w", err)
+		}
+
+		month, err := time.Parse(partitionNameLayout, name)
+		if err != nil {
+			// partitions which weren't created by CreatePartition are not managed
+			continue
+		}
+
+		partitions = append(partitions, newPartition(month))
+	}
+
+	return partitions, nil
+}
+
+// GetPartitionLogs returns logs of partition with id greater than afterID ordered by id
+func (l *Repository) GetPartitionLogs(ctx context.Context, partition Partition, afterID int64, limit int64) ([]Log, error) {
+	query := fmt.Sprintf(`select id, user_id, segment_id, operation, coalesce(actor, ''), insert_time from %s
+			  where id > $1
+			  order by id
+			  limit $2`, partition.Name)
+
+	rows, err := l.db.QueryContext(ctx, query, afterID, limit)
+	if err != nil {
+		return nil, fmt.Errorf("error while getting logs of partition %s: %w", partition.Name, err)
+	}
+
+	defer func() { _ = rows.Close() }()
+
+	var logs []Log
+	for rows.Next() {
+		var log = Log{}
+
+		err = rows.Scan(&log.ID, &log.UserID, &log.SegmentID, &log.Operation, &log.Actor, &log.InsertTime)
 		if err != nil {
 			return nil, fmt.Errorf("error while scanning rows: %w", err)
 		}
@@ -78,3 +214,22 @@ func (l *Repository) Get(ctx context.Context, userID int64, from time.Time, to t
 
 	return logs, nil
 }
+
+// DropPartition drops partition of logger table with all logs inside
+func (l *Repository) DropPartition(ctx context.Context, partition Partition) error {
+	_, err := l.db.ExecContext(ctx, fmt.Sprintf(`drop table if exists %s`, partition.Name))
+	if err != nil {
+		return fmt.Errorf("error while dropping partition %s: %w", partition.Name, err)
+	}
+
+	return nil
+}
+
+func newPartition(month time.Time) Partition {
+	from := time.Date(month.Year(), month.Month(), 1, 0, 0, 0, 0, time.UTC)
+	return Partition{
+		Name: from.Format(partitionNameLayout),
+		From: from,
+		To:   from.AddDate(0, 1, 0),
+	}
+}
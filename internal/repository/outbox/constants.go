@@ -0,0 +1,34 @@
+package outbox
+
+// Types of events about user's membership in segments
+
+const (
+	EventTypeUserAddedToSegment     = "user_segment.added"
+	EventTypeUserDeletedFromSegment = "user_segment.deleted"
+	EventTypeSegmentCreated         = "segment.created"
+	EventTypeSegmentDeleted         = "segment.deleted"
+)
+
+// EventTypes lists all types of events
+var EventTypes = []string{
+	EventTypeUserAddedToSegment,
+	EventTypeUserDeletedFromSegment,
+	EventTypeSegmentCreated,
+	EventTypeSegmentDeleted,
+}
+
+// Reasons of membership changes
+
+const (
+	ReasonRequest        = "request"
+	ReasonPercent        = "percent"
+	ReasonTTLExpired     = "ttl_expired"
+	ReasonSegmentDeleted = "segment_deleted"
+)
+
+// NotifyChannel is a channel of Postgres notifications about added events
+const NotifyChannel = "outbox_events"
+
+// relayLockID is an id of advisory lock which guarantees that only one relay sends events at a time,
+// otherwise events of one user may be sent out of order
+const relayLockID = 7_362_201
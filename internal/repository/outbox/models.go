@@ -0,0 +1,49 @@
+package outbox
+
+import (
+	"encoding/json"
+	"time"
+)
+
+// Event is a change of user's membership or of segment itself. Events are ordered by user, UserID is nil
+// for events about segments, so they are ordered among themselves
+type Event struct {
+	ID         int64
+	UserID     *int64
+	Type       string
+	Payload    []byte
+	InsertTime time.Time
+	Attempts   int64
+}
+
+// MembershipPayload is a payload of events about user's membership in segment
+type MembershipPayload struct {
+	UserID    int64  `json:"userId"`
+	SegmentID string `json:"segmentId"`
+	Reason    string `json:"reason"`
+}
+
+// SegmentPayload is a payload of events about segment itself
+type SegmentPayload struct {
+	SegmentID string `json:"segmentId"`
+	Percent   *int64 `json:"percent,omitempty"`
+}
+
+// NewMembershipEvents returns event of given type for every segment
+func NewMembershipEvents(eventType string, userID int64, segmentIDs []string, reason string) []Event {
+	events := make([]Event, 0, len(segmentIDs))
+	for _, segmentID := range segmentIDs {
+		// payload consists of plain fields, so marshalling can't fail
+		payload, _ := json.Marshal(MembershipPayload{UserID: userID, SegmentID: segmentID, Reason: reason})
+		events = append(events, Event{UserID: &userID, Type: eventType, Payload: payload})
+	}
+
+	return events
+}
+
+// NewSegmentEvent returns event of given type about segment
+func NewSegmentEvent(eventType string, segmentID string, percent *int64) Event {
+	// payload consists of plain fields, so marshalling can't fail
+	payload, _ := json.Marshal(SegmentPayload{SegmentID: segmentID, Percent: percent})
+	return Event{Type: eventType, Payload: payload}
+}
@@ -0,0 +1,306 @@
+package outbox
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/pollykon/avito_test_task/internal/storage"
+)
+
+type Repository struct {
+	db storage.Database
+}
+
+func New(db storage.Database) *Repository {
+	return &Repository{
+		db: db,
+	}
+}
+
+// Add saves events. It must be called in the same transaction as the change which events describe
+func (r *Repository) Add(ctx context.Context, events []Event) error {
+	if len(events) == 0 {
+		return nil
+	}
+
+	values := make([]string, 0, len(events))
+	queryArgs := make([]interface{}, 0, len(events)*3+1)
+	for i, event := range events {
+		values = append(values, fmt.Sprintf("($%d, $%d, $%d)", i*3+1, i*3+2, i*3+3))
+		queryArgs = append(queryArgs, event.UserID, event.Type, string(event.Payload))
+	}
+	queryArgs = append(queryArgs, NotifyChannel)
+
+	// notification is sent only when transaction commits, its payload has the same format as publisher.Message
+	query := fmt.Sprintf(
+		`with inserted as (
+			insert into outbox (user_id, event_type, payload) values %s
+			returning id, user_id, event_type, payload, insert_time
+		)
+		select pg_notify($%d, json_build_object(
+			'id', id, 'type', event_type, 'userId', user_id, 'payload', payload, 'occurredAt', insert_time
+		)::text) from inserted`,
+		strings.Join(values, ","),
+		len(queryArgs),
+	)
+	_, err := r.db.ExecContext(ctx, query, queryArgs...)
+	if err != nil {
+		return fmt.Errorf("error while inserting into outbox: %w", err)
+	}
+
+	return nil
+}
+
+// ClaimPending returns events which are ready to be sent ordered by id and postpones their next attempt until
+// leaseUntil, so they aren't sent by another relay while they are being sent. Event isn't returned while previous
+// event of the same user (or previous event about segments) waits for retry or is claimed, so events of one user
+// are delivered in order
+func (r *Repository) ClaimPending(ctx context.Context, limit int64, leaseUntil time.Time) ([]Event, error) {
+	query := `with claimed as (
+				update outbox set next_attempt_time = $2
+				where id in (
+					select id from outbox
+					where sent_time is null
+					and next_attempt_time <= now()
+					and not exists (
+						select 1 from outbox previous
+						where (previous.user_id = outbox.user_id
+							or previous.user_id is null and outbox.user_id is null)
+						and previous.sent_time is null
+						and previous.id < outbox.id
+						and previous.next_attempt_time > now()
+					)
+					order by id
+					limit $1
+				)
+				returning id, user_id, event_type, payload, insert_time, attempts
+			  )
+			  select id, user_id, event_type, payload, insert_time, attempts from claimed order by id`
+
+	rows, err := r.db.QueryContext(ctx, query, limit, leaseUntil)
+	if err != nil {
+		return nil, fmt.Errorf("error while claiming pending events: %w", err)
+	}
+
+	defer func() { _ = rows.Close() }()
+
+	var events []Event
+	for rows.Next() {
+		var event Event
+		var userID sql.NullInt64
+
+		err = rows.Scan(&event.ID, &userID, &event.Type, &event.Payload, &event.InsertTime, &event.Attempts)
+		if err != nil {
+			return nil, fmt.Errorf("error while scanning rows: %w", err)
+		}
+
+		if userID.Valid {
+			event.UserID = &userID.Int64
+		}
+
+		events = append(events, event)
+	}
+
+	return events, nil
+}
+
+func (r *Repository) MarkSent(ctx context.Context, ids []int64) error {
+	if len(ids) == 0 {
+		return nil
+	}
+
+	values := make([]string, 0, len(ids))
+	idsAny := make([]interface{}, 0, len(ids))
+	for i, id := range ids {
+		values = append(values, fmt.Sprintf("$%d", i+1))
+		idsAny = append(idsAny, id)
+	}
+
+	query := fmt.Sprintf(`update outbox set sent_time = now() where id in (%s)`, strings.Join(values, ","))
+	_, err := r.db.ExecContext(ctx, query, idsAny...)
+	if err != nil {
+		return fmt.Errorf("error while marking events as sent: %w", err)
+	}
+
+	return nil
+}
+
+// Release makes claimed events ready to be sent again without counting attempt
+func (r *Repository) Release(ctx context.Context, ids []int64) error {
+	if len(ids) == 0 {
+		return nil
+	}
+
+	values := make([]string, 0, len(ids))
+	idsAny := make([]interface{}, 0, len(ids))
+	for i, id := range ids {
+		values = append(values, fmt.Sprintf("$%d", i+1))
+		idsAny = append(idsAny, id)
+	}
+
+	query := fmt.Sprintf(`update outbox set next_attempt_time = now() where id in (%s)`, strings.Join(values, ","))
+	_, err := r.db.ExecContext(ctx, query, idsAny...)
+	if err != nil {
+		return fmt.Errorf("error while releasing events: %w", err)
+	}
+
+	return nil
+}
+
+// MarkFailed postpones next attempt to send event
+func (r *Repository) MarkFailed(ctx context.Context, id int64, nextAttemptTime time.Time, lastError string) error {
+	query := `update outbox set attempts = attempts + 1, next_attempt_time = $2, last_error = $3 where id = $1`
+	_, err := r.db.ExecContext(ctx, query, id, nextAttemptTime, lastError)
+	if err != nil {
+		return fmt.Errorf("error while marking event as failed: %w", err)
+	}
+
+	return nil
+}
+
+// GetUsersEvents returns events of users with id greater than afterID ordered by id, events about segments
+// aren't returned
+func (r *Repository) GetUsersEvents(ctx context.Context, userIDs []int64, afterID int64, limit int64) ([]Event, error) {
+	if len(userIDs) == 0 {
+		return nil, nil
+	}
+
+	values := make([]string, 0, len(userIDs))
+	queryArgs := make([]interface{}, 0, len(userIDs)+2)
+	queryArgs = append(queryArgs, afterID, limit)
+	for i, userID := range userIDs {
+		values = append(values, fmt.Sprintf("$%d", i+3))
+		queryArgs = append(queryArgs, userID)
+	}
+
+	query := fmt.Sprintf(
+		`select id, user_id, event_type, payload, insert_time, attempts from outbox
+		 where id > $1 and user_id in (%s)
+		 order by id
+		 limit $2`,
+		strings.Join(values, ","),
+	)
+
+	rows, err := r.db.QueryContext(ctx, query, queryArgs...)
+	if err != nil {
+		return nil, fmt.Errorf("error while getting users events: %w", err)
+	}
+
+	defer func() { _ = rows.Close() }()
+
+	var events []Event
+	for rows.Next() {
+		var event Event
+		var userID sql.NullInt64
+
+		err = rows.Scan(&event.ID, &userID, &event.Type, &event.Payload, &event.InsertTime, &event.Attempts)
+		if err != nil {
+			return nil, fmt.Errorf("error while scanning rows: %w", err)
+		}
+
+		if userID.Valid {
+			event.UserID = &userID.Int64
+		}
+
+		events = append(events, event)
+	}
+
+	return events, nil
+}
+
+// GetNotDispatched returns events which weren't dispatched to webhook subscriptions yet ordered by id
+func (r *Repository) GetNotDispatched(ctx context.Context, limit int64) ([]Event, error) {
+	query := `select id, user_id, event_type, payload, insert_time, attempts from outbox
+			  where webhook_dispatch_time is null
+			  order by id
+			  limit $1
+			  for update skip locked`
+
+	rows, err := r.db.QueryContext(ctx, query, limit)
+	if err != nil {
+		return nil, fmt.Errorf("error while getting not dispatched events: %w", err)
+	}
+
+	defer func() { _ = rows.Close() }()
+
+	var events []Event
+	for rows.Next() {
+		var event Event
+		var userID sql.NullInt64
+
+		err = rows.Scan(&event.ID, &userID, &event.Type, &event.Payload, &event.InsertTime, &event.Attempts)
+		if err != nil {
+			return nil, fmt.Errorf("error while scanning rows: %w", err)
+		}
+
+		if userID.Valid {
+			event.UserID = &userID.Int64
+		}
+
+		events = append(events, event)
+	}
+
+	return events, nil
+}
+
+func (r *Repository) MarkDispatched(ctx context.Context, ids []int64) error {
+	if len(ids) == 0 {
+		return nil
+	}
+
+	values := make([]string, 0, len(ids))
+	idsAny := make([]interface{}, 0, len(ids))
+	for i, id := range ids {
+		values = append(values, fmt.Sprintf("$%d", i+1))
+		idsAny = append(idsAny, id)
+	}
+
+	query := fmt.Sprintf(`update outbox set webhook_dispatch_time = now() where id in (%s)`, strings.Join(values, ","))
+	_, err := r.db.ExecContext(ctx, query, idsAny...)
+	if err != nil {
+		return fmt.Errorf("error while marking events as dispatched: %w", err)
+	}
+
+	return nil
+}
+
+// DeleteSent removes events which were sent and dispatched to webhooks more than retention ago
+func (r *Repository) DeleteSent(ctx context.Context, retention time.Duration, limit int64) error {
+	query := `delete from outbox where id in (
+				select id from outbox where sent_time < $1 and webhook_dispatch_time < $1 limit $2
+			  )`
+	_, err := r.db.ExecContext(ctx, query, time.Now().Add(-retention), limit)
+	if err != nil {
+		return fmt.Errorf("error while deleting sent events: %w", err)
+	}
+
+	return nil
+}
+
+// TryLock takes relay lock until the end of transaction, so relays claim events one by one. Returns false if lock
+// is held by another relay
+func (r *Repository) TryLock(ctx context.Context) (bool, error) {
+	rows, err := r.db.QueryContext(ctx, `select pg_try_advisory_xact_lock($1)`, relayLockID)
+	if err != nil {
+		return false, fmt.Errorf("error while taking relay lock: %w", err)
+	}
+
+	defer func() { _ = rows.Close() }()
+
+	var locked bool
+	for rows.Next() {
+		err = rows.Scan(&locked)
+		if err != nil {
+			return false, fmt.Errorf("error while scanning rows: %w", err)
+		}
+	}
+
+	return locked, nil
+}
+
+func (r *Repository) InTransaction(ctx context.Context, f func(ctx context.Context) error) error {
+	return r.db.WithTransaction(ctx, f)
+}
@@ -0,0 +1,75 @@
+package memory
+
+import (
+	"context"
+	"math"
+	"sync"
+	"time"
+
+	"github.com/pollykon/avito_test_task/internal/repository/rate_limit"
+)
+
+// cleanupInterval is how often full buckets are removed, full bucket is the same as absent one
+const cleanupInterval = time.Minute
+
+type bucket struct {
+	tokens     float64
+	updateTime time.Time
+	limit      rate_limit.Limit
+}
+
+// Repository keeps token buckets in memory of one replica
+type Repository struct {
+	mu          sync.Mutex
+	buckets     map[string]*bucket
+	cleanupTime time.Time
+	now         func() time.Time
+}
+
+func New() *Repository {
+	return &Repository{
+		buckets: make(map[string]*bucket),
+		now:     time.Now,
+	}
+}
+
+// Take refills bucket by key and takes one token from it if there is one
+func (r *Repository) Take(_ context.Context, key string, limit rate_limit.Limit) (rate_limit.Result, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	now := r.now()
+	r.cleanup(now)
+
+	b, ok := r.buckets[key]
+	if !ok {
+		b = &bucket{tokens: float64(limit.Burst), updateTime: now}
+		r.buckets[key] = b
+	}
+
+	b.tokens = math.Min(float64(limit.Burst), b.tokens+now.Sub(b.updateTime).Seconds()*limit.Rate)
+	b.updateTime = now
+	b.limit = limit
+
+	if b.tokens < 1 {
+		return rate_limit.Result{Allowed: false, Tokens: b.tokens}, nil
+	}
+
+	b.tokens--
+
+	return rate_limit.Result{Allowed: true, Tokens: b.tokens}, nil
+}
+
+// cleanup removes buckets which are refilled by now, so buckets of gone clients don't stay in memory
+func (r *Repository) cleanup(now time.Time) {
+	if now.Sub(r.cleanupTime) < cleanupInterval {
+		return
+	}
+	r.cleanupTime = now
+
+	for key, b := range r.buckets {
+		if b.tokens+now.Sub(b.updateTime).Seconds()*b.limit.Rate >= float64(b.limit.Burst) {
+			delete(r.buckets, key)
+		}
+	}
+}
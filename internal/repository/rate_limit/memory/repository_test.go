@@ -0,0 +1,62 @@
+package memory
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/pollykon/avito_test_task/internal/repository/rate_limit"
+)
+
+func TestRepository_Take(t *testing.T) {
+	now := time.Date(2023, 8, 1, 0, 0, 0, 0, time.UTC)
+	repo := New()
+	repo.now = func() time.Time { return now }
+
+	limit := rate_limit.Limit{Rate: 2, Burst: 3}
+
+	for i := 0; i < 3; i++ {
+		result, err := repo.Take(context.Background(), "client", limit)
+		require.NoError(t, err)
+		assert.True(t, result.Allowed, "request %d", i)
+	}
+
+	result, err := repo.Take(context.Background(), "client", limit)
+	require.NoError(t, err)
+	assert.Equal(t, rate_limit.Result{Allowed: false, Tokens: 0}, result)
+
+	// other keys have their own buckets
+	result, err = repo.Take(context.Background(), "other_client", limit)
+	require.NoError(t, err)
+	assert.True(t, result.Allowed)
+
+	now = now.Add(500 * time.Millisecond)
+	result, err = repo.Take(context.Background(), "client", limit)
+	require.NoError(t, err)
+	assert.Equal(t, rate_limit.Result{Allowed: true, Tokens: 0}, result)
+
+	// bucket isn't refilled above burst
+	now = now.Add(time.Hour)
+	result, err = repo.Take(context.Background(), "client", limit)
+	require.NoError(t, err)
+	assert.Equal(t, rate_limit.Result{Allowed: true, Tokens: 2}, result)
+}
+
+func TestRepository_Take_Cleanup(t *testing.T) {
+	now := time.Date(2023, 8, 1, 0, 0, 0, 0, time.UTC)
+	repo := New()
+	repo.now = func() time.Time { return now }
+
+	_, err := repo.Take(context.Background(), "gone_client", rate_limit.Limit{Rate: 1, Burst: 10})
+	require.NoError(t, err)
+
+	now = now.Add(cleanupInterval)
+	_, err = repo.Take(context.Background(), "client", rate_limit.Limit{Rate: 1, Burst: 10})
+	require.NoError(t, err)
+
+	assert.NotContains(t, repo.buckets, "gone_client")
+	assert.Contains(t, repo.buckets, "client")
+}
@@ -0,0 +1,14 @@
+package rate_limit
+
+// Limit of token bucket: bucket holds up to Burst tokens and is refilled with Rate tokens per second,
+// each request takes one token
+type Limit struct {
+	Rate  float64
+	Burst int64
+}
+
+// Result of taking token from bucket. Tokens are left in bucket after request
+type Result struct {
+	Allowed bool
+	Tokens  float64
+}
@@ -0,0 +1,73 @@
+package postgres
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/pollykon/avito_test_task/internal/repository/rate_limit"
+	"github.com/pollykon/avito_test_task/internal/storage"
+)
+
+// Repository keeps token buckets in Postgres, so limits are shared by all replicas
+type Repository struct {
+	db storage.Database
+	// refillTime is time after which unused bucket is full
+	refillTime time.Duration
+}
+
+func New(db storage.Database, refillTime time.Duration) *Repository {
+	return &Repository{
+		db:         db,
+		refillTime: refillTime,
+	}
+}
+
+// Take refills bucket by key and takes one token from it if there is one. Bucket is updated by one statement,
+// row lock serializes concurrent requests of the same client. now() is the same in all expressions of statement
+func (r *Repository) Take(ctx context.Context, key string, limit rate_limit.Limit) (rate_limit.Result, error) {
+	refilled := `least($2::double precision, b.tokens + extract(epoch from now() - b.update_time) * $3::double precision)`
+	query := fmt.Sprintf(`
+		insert into rate_limit_bucket as b (key, tokens, allowed, update_time)
+		values ($1, $2::double precision - 1, true, now())
+		on conflict (key) do update set
+			tokens = case when %[1]s >= 1 then %[1]s - 1 else %[1]s end,
+			allowed = %[1]s >= 1,
+			update_time = now()
+		returning allowed, tokens`, refilled,
+	)
+
+	rows, err := r.db.QueryContext(ctx, query, key, limit.Burst, limit.Rate)
+	if err != nil {
+		return rate_limit.Result{}, fmt.Errorf("error while taking token from rate_limit_bucket: %w", err)
+	}
+
+	defer func() { _ = rows.Close() }()
+
+	var result rate_limit.Result
+	for rows.Next() {
+		err = rows.Scan(&result.Allowed, &result.Tokens)
+		if err != nil {
+			return rate_limit.Result{}, fmt.Errorf("error while scanning rate_limit_bucket: %w", err)
+		}
+	}
+
+	return result, nil
+}
+
+// DeleteFull removes buckets which weren't used for refillTime and returns number of removed ones. Such buckets are
+// full and the same as absent ones, so buckets of gone clients don't stay in table
+func (r *Repository) DeleteFull(ctx context.Context) (int64, error) {
+	query := `delete from rate_limit_bucket where update_time < now() - make_interval(secs => $1)`
+	res, err := r.db.ExecContext(ctx, query, r.refillTime.Seconds())
+	if err != nil {
+		return 0, fmt.Errorf("error while deleting full buckets from rate_limit_bucket: %w", err)
+	}
+
+	numberOfDeletedBuckets, err := res.RowsAffected()
+	if err != nil {
+		return 0, fmt.Errorf("error while getting affected rows: %w", err)
+	}
+
+	return numberOfDeletedBuckets, nil
+}
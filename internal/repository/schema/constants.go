@@ -0,0 +1,5 @@
+package schema
+
+import "errors"
+
+var ErrVersionNotExist = errors.New("schema version doesn't exist")
@@ -0,0 +1,55 @@
+package schema
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/pollykon/avito_test_task/internal/storage"
+)
+
+type Repository struct {
+	db storage.Database
+}
+
+func New(db storage.Database) *Repository {
+	return &Repository{
+		db: db,
+	}
+}
+
+// Ping checks that database is reachable
+func (r *Repository) Ping(ctx context.Context) error {
+	err := r.db.PingContext(ctx)
+	if err != nil {
+		return fmt.Errorf("error while pinging database: %w", err)
+	}
+
+	return nil
+}
+
+// GetVersion returns version of schema applied by migration.sql
+func (r *Repository) GetVersion(ctx context.Context) (int64, error) {
+	query := `select version from schema_version`
+
+	rows, err := r.db.QueryContext(ctx, query)
+	if err != nil {
+		return 0, fmt.Errorf("error while selecting from schema_version: %w", err)
+	}
+
+	defer func() { _ = rows.Close() }()
+
+	if !rows.Next() {
+		if err = rows.Err(); err != nil {
+			return 0, fmt.Errorf("error while iterating rows: %w", err)
+		}
+		return 0, ErrVersionNotExist
+	}
+
+	var version int64
+	err = rows.Scan(&version)
+	if err != nil {
+		return 0, fmt.Errorf("error while scanning rows: %w", err)
+	}
+
+	return version, nil
+}
@@ -1,6 +1,17 @@
 package segment
 
+type Segment struct {
+	ID      string
+	Percent *int64
+}
+
 type UserSegments struct {
 	ActiveSegments []string
 	NewSegments    []string
 }
+
+// UserSegment is a membership of user in segment
+type UserSegment struct {
+	UserID    int64
+	SegmentID string
+}
@@ -12,15 +12,21 @@ import (
 	"github.com/pollykon/avito_test_task/internal/storage"
 )
 
-const errCodeUniqueViolation = "23505"
+const (
+	errCodeUniqueViolation     = "23505"
+	errCodeForeignKeyViolation = "23503"
+)
 
 type Repository struct {
-	db storage.Database
+	db        storage.Database
+	isolation sql.IsolationLevel
 }
 
-func New(db storage.Database) *Repository {
+// New creates repository which runs transactions of segments with isolation level
+func New(db storage.Database, isolation sql.IsolationLevel) *Repository {
 	return &Repository{
-		db: db,
+		db:        db,
+		isolation: isolation,
 	}
 }
 
@@ -61,12 +67,41 @@ func (r *Repository) DeleteSegment(ctx context.Context, slug string) error {
 	return nil
 }
 
+// GetSegments returns segments which aren't deleted ordered by slug, they may be read from replica
+func (r *Repository) GetSegments(ctx context.Context) ([]Segment, error) {
+	rows, err := r.db.QueryReadContext(ctx, `select id, percent from segment where deleted = false order by id`)
+	if err != nil {
+		return nil, fmt.Errorf("error while getting segments: %w", err)
+	}
+
+	defer func() { _ = rows.Close() }()
+
+	var segments []Segment
+	for rows.Next() {
+		var segment Segment
+		var percent sql.NullInt64
+
+		err = rows.Scan(&segment.ID, &percent)
+		if err != nil {
+			return nil, fmt.Errorf("error while scanning segments: %w", err)
+		}
+
+		if percent.Valid {
+			segment.Percent = &percent.Int64
+		}
+
+		segments = append(segments, segment)
+	}
+
+	return segments, nil
+}
+
 func (r *Repository) AddUserToSegment(ctx context.Context, userID int64, slugs []string, ttl *time.Duration) error {
 	if len(slugs) == 0 {
 		return nil
 	}
 
-	err := r.db.WithTransaction(ctx, func(ctx context.Context) error {
+	err := r.InTransaction(ctx, func(ctx context.Context) error {
 		_, err := r.db.ExecContext(ctx, `insert into "user" (id) values ($1) on conflict do nothing`, userID)
 		if err != nil {
 			return fmt.Errorf("error while inserting into user: %w", err)
@@ -98,16 +133,15 @@ func (r *Repository) AddUserToSegment(ctx context.Context, userID int64, slugs [
 			if errors.As(err, &pqErr) && pqErr.Code == errCodeUniqueViolation {
 				return ErrUserAlreadyInSegment
 			}
+			if errors.As(err, &pqErr) && pqErr.Code == errCodeForeignKeyViolation {
+				return ErrSegmentNotExist
+			}
 			return fmt.Errorf("error while inserting into user_segment: %w", err)
 		}
 		return nil
 	})
 
-	if err != nil {
-		return fmt.Errorf("error while beginning transaction: %w", err)
-	}
-
-	return nil
+	return err
 }
 
 func (r *Repository) DeleteUserFromSegment(ctx context.Context, userID int64, slugs []string) error {
@@ -139,6 +173,8 @@ func (r *Repository) DeleteUserFromSegment(ctx context.Context, userID int64, sl
 // 1. were added to user and weren't deleted
 // 2. were added with ttl, and they are still actual
 // 3. were added to user by counting segment's percent
+//
+// Outside of transaction segments may be read from replica
 func (r *Repository) GetUserActiveSegments(ctx context.Context, userID int64, userHash int64) (UserSegments, error) {
 	participationUserSign := userHash % 100
 
@@ -150,7 +186,7 @@ func (r *Repository) GetUserActiveSegments(ctx context.Context, userID int64, us
 			  and (userseg.user_id = $1 or segment.percent >= $2 and userseg.user_id is null)
 			  and (userseg.ttl is null or NOW() < (userseg.insert_time + userseg.ttl))`
 
-	rows, err := r.db.QueryContext(ctx, query, userID, participationUserSign)
+	rows, err := r.db.QueryReadContext(ctx, query, userID, participationUserSign)
 	if err != nil {
 		return UserSegments{}, fmt.Errorf("error while getting active user's segments: %w", err)
 	}
@@ -179,23 +215,27 @@ func (r *Repository) GetUserActiveSegments(ctx context.Context, userID int64, us
 	return UserSegments{ActiveSegments: activeSegments, NewSegments: newSegments}, nil
 }
 
+// InTransaction runs f in transaction with isolation level of repository. With serializable level concurrent
+// materialization of percent segments and adding of the same user conflict and one of them is retried instead of
+// failing with unique violation. f may be run several times, so it must only write to database in ctx (segments,
+// log and outbox rows are rolled back together before retry) and mustn't do anything outside of database or
+// change state in memory
 func (r *Repository) InTransaction(ctx context.Context, f func(ctx context.Context) error) error {
-	return r.db.WithTransaction(ctx, f)
+	return r.db.WithTransaction(ctx, f, storage.WithIsolation(r.isolation), storage.WithRetries())
 }
 
-func (r *Repository) DeleteUserSegmentsWithBadTTL(ctx context.Context, limit int64) error {
+// DeleteUserSegmentsWithBadTTL deletes memberships with expired ttl and returns them
+func (r *Repository) DeleteUserSegmentsWithBadTTL(ctx context.Context, limit int64) ([]UserSegment, error) {
 	query := `delete from user_segment where id in (
 				select id from user_segment where ttl is not null and ttl + insert_time < now() limit $1
-			  )`
-	_, err := r.db.ExecContext(ctx, query, limit)
-	if err != nil {
-		return err
-	}
+			  )
+			  returning user_id, segment_id`
 
-	return nil
+	return r.queryUserSegments(ctx, query, limit)
 }
 
-func (r *Repository) DeleteSegments(ctx context.Context, limit int64) error {
+// DeleteSegments deletes segments with flag 'deleted' = true with their memberships and returns deleted memberships
+func (r *Repository) DeleteSegments(ctx context.Context, limit int64) ([]UserSegment, error) {
 	query := `with deleted_rows AS (
 				delete from segment where id in (
 				  select id from segment where deleted = true limit $1
@@ -203,11 +243,31 @@ func (r *Repository) DeleteSegments(ctx context.Context, limit int64) error {
 			    returning id
 			  )
 			  delete from user_segment
-			  where segment_id in (select id from deleted_rows)`
-	_, err := r.db.ExecContext(ctx, query, limit)
+			  where segment_id in (select id from deleted_rows)
+			  returning user_id, segment_id`
+
+	return r.queryUserSegments(ctx, query, limit)
+}
+
+func (r *Repository) queryUserSegments(ctx context.Context, query string, args ...interface{}) ([]UserSegment, error) {
+	rows, err := r.db.QueryContext(ctx, query, args...)
 	if err != nil {
-		return err
+		return nil, err
 	}
 
-	return nil
+	defer func() { _ = rows.Close() }()
+
+	var userSegments []UserSegment
+	for rows.Next() {
+		var userSegment UserSegment
+
+		err = rows.Scan(&userSegment.UserID, &userSegment.SegmentID)
+		if err != nil {
+			return nil, fmt.Errorf("error while scanning user segments: %w", err)
+		}
+
+		userSegments = append(userSegments, userSegment)
+	}
+
+	return userSegments, nil
 }
@@ -0,0 +1,14 @@
+package webhook
+
+import "errors"
+
+var ErrSubscriptionNotExist = errors.New("subscription doesn't exist")
+
+// Statuses of webhook delivery
+
+const (
+	DeliveryStatusPending   = "pending"
+	DeliveryStatusDelivered = "delivered"
+	// DeliveryStatusDead means that delivery is given up after too many failed attempts
+	DeliveryStatusDead = "dead"
+)
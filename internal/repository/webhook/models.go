@@ -0,0 +1,35 @@
+package webhook
+
+import "time"
+
+type Subscription struct {
+	ID         int64
+	URL        string
+	Secret     string
+	EventTypes []string
+	SegmentIDs []string
+	InsertTime time.Time
+}
+
+type Delivery struct {
+	ID             int64
+	SubscriptionID int64
+	EventID        int64
+	UserID         *int64
+	EventType      string
+	Payload        []byte
+	EventTime      time.Time
+	Status         string
+	Attempts       int64
+	LastStatusCode *int64
+	LastError      *string
+	InsertTime     time.Time
+	DeliveredTime  *time.Time
+}
+
+// PendingDelivery is a delivery with url and secret of its subscription
+type PendingDelivery struct {
+	Delivery
+	URL    string
+	Secret string
+}
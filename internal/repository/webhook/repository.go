@@ -0,0 +1,319 @@
+package webhook
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/lib/pq"
+
+	"github.com/pollykon/avito_test_task/internal/storage"
+)
+
+type Repository struct {
+	db storage.Database
+}
+
+func New(db storage.Database) *Repository {
+	return &Repository{
+		db: db,
+	}
+}
+
+// AddSubscription saves subscription and returns its id
+func (r *Repository) AddSubscription(ctx context.Context, subscription Subscription) (int64, error) {
+	query := `insert into webhook_subscription (url, secret, event_types, segment_ids) values ($1, $2, $3, $4)
+			  returning id`
+
+	rows, err := r.db.QueryContext(
+		ctx,
+		query,
+		subscription.URL,
+		subscription.Secret,
+		pq.Array(nonNil(subscription.EventTypes)),
+		pq.Array(nonNil(subscription.SegmentIDs)),
+	)
+	if err != nil {
+		return 0, fmt.Errorf("error while inserting into webhook_subscription: %w", err)
+	}
+
+	defer func() { _ = rows.Close() }()
+
+	var id int64
+	for rows.Next() {
+		err = rows.Scan(&id)
+		if err != nil {
+			return 0, fmt.Errorf("error while scanning rows: %w", err)
+		}
+	}
+
+	return id, nil
+}
+
+// DeleteSubscription deletes subscription with its delivery history
+func (r *Repository) DeleteSubscription(ctx context.Context, id int64) error {
+	res, err := r.db.ExecContext(ctx, `delete from webhook_subscription where id = $1`, id)
+	if err != nil {
+		return fmt.Errorf("error while deleting from webhook_subscription: %w", err)
+	}
+
+	numberOfDeletedSubscriptions, err := res.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("error while getting affected rows: %w", err)
+	}
+
+	if numberOfDeletedSubscriptions == 0 {
+		return ErrSubscriptionNotExist
+	}
+
+	return nil
+}
+
+func (r *Repository) GetSubscriptions(ctx context.Context) ([]Subscription, error) {
+	query := `select id, url, secret, event_types, segment_ids, insert_time from webhook_subscription order by id`
+
+	rows, err := r.db.QueryContext(ctx, query)
+	if err != nil {
+		return nil, fmt.Errorf("error while getting subscriptions: %w", err)
+	}
+
+	defer func() { _ = rows.Close() }()
+
+	var subscriptions []Subscription
+	for rows.Next() {
+		var subscription Subscription
+
+		err = rows.Scan(
+			&subscription.ID,
+			&subscription.URL,
+			&subscription.Secret,
+			pq.Array(&subscription.EventTypes),
+			pq.Array(&subscription.SegmentIDs),
+			&subscription.InsertTime,
+		)
+		if err != nil {
+			return nil, fmt.Errorf("error while scanning rows: %w", err)
+		}
+
+		subscriptions = append(subscriptions, subscription)
+	}
+
+	return subscriptions, nil
+}
+
+func (r *Repository) AddDeliveries(ctx context.Context, deliveries []Delivery) error {
+	if len(deliveries) == 0 {
+		return nil
+	}
+
+	values := make([]string, 0, len(deliveries))
+	queryArgs := make([]interface{}, 0, len(deliveries)*6)
+	for i, delivery := range deliveries {
+		values = append(
+			values,
+			fmt.Sprintf("($%d, $%d, $%d, $%d, $%d, $%d)", i*6+1, i*6+2, i*6+3, i*6+4, i*6+5, i*6+6),
+		)
+		queryArgs = append(
+			queryArgs,
+			delivery.SubscriptionID,
+			delivery.EventID,
+			delivery.UserID,
+			delivery.EventType,
+			string(delivery.Payload),
+			delivery.EventTime,
+		)
+	}
+
+	query := fmt.Sprintf(
+		`insert into webhook_delivery (subscription_id, event_id, user_id, event_type, payload, event_time) values %s`,
+		strings.Join(values, ","),
+	)
+	_, err := r.db.ExecContext(ctx, query, queryArgs...)
+	if err != nil {
+		return fmt.Errorf("error while inserting into webhook_delivery: %w", err)
+	}
+
+	return nil
+}
+
+// ClaimPendingDeliveries returns deliveries which are ready to be sent and postpones their next attempt until
+// leaseUntil, so several workers don't send the same delivery and deliveries of failed worker are sent again
+// after lease expires. Deliveries are claimed by one statement, so no transaction is held while they are sent
+func (r *Repository) ClaimPendingDeliveries(ctx context.Context, limit int64, leaseUntil time.Time) ([]PendingDelivery, error) {
+	query := `with claimed as (
+				update webhook_delivery set next_attempt_time = $3
+				where id in (
+					select id from webhook_delivery
+					where status = $1
+					and next_attempt_time <= now()
+					order by next_attempt_time
+					limit $2
+					for update skip locked
+				)
+				returning id, subscription_id, event_id, user_id, event_type, payload, event_time, attempts
+			  )
+			  select claimed.id, claimed.subscription_id, claimed.event_id, claimed.user_id, claimed.event_type,
+				   claimed.payload, claimed.event_time, claimed.attempts, subscription.url, subscription.secret
+			  from claimed
+			  join webhook_subscription subscription on subscription.id = claimed.subscription_id
+			  order by claimed.id`
+
+	rows, err := r.db.QueryContext(ctx, query, DeliveryStatusPending, limit, leaseUntil)
+	if err != nil {
+		return nil, fmt.Errorf("error while claiming pending deliveries: %w", err)
+	}
+
+	defer func() { _ = rows.Close() }()
+
+	var deliveries []PendingDelivery
+	for rows.Next() {
+		delivery := PendingDelivery{Delivery: Delivery{Status: DeliveryStatusPending}}
+		var userID sql.NullInt64
+
+		err = rows.Scan(
+			&delivery.ID,
+			&delivery.SubscriptionID,
+			&delivery.EventID,
+			&userID,
+			&delivery.EventType,
+			&delivery.Payload,
+			&delivery.EventTime,
+			&delivery.Attempts,
+			&delivery.URL,
+			&delivery.Secret,
+		)
+		if err != nil {
+			return nil, fmt.Errorf("error while scanning rows: %w", err)
+		}
+
+		if userID.Valid {
+			delivery.UserID = &userID.Int64
+		}
+
+		deliveries = append(deliveries, delivery)
+	}
+
+	return deliveries, nil
+}
+
+// GetDeliveries returns delivery history of subscription, the newest deliveries go first. History may be read
+// from replica
+func (r *Repository) GetDeliveries(ctx context.Context, subscriptionID int64, limit int64, offset int64) ([]Delivery, error) {
+	query := `select id, subscription_id, event_id, user_id, event_type, payload, event_time, status, attempts,
+				   last_status_code, last_error, insert_time, delivered_time
+			  from webhook_delivery
+			  where subscription_id = $1
+			  order by id desc
+			  limit $2 offset $3`
+
+	rows, err := r.db.QueryReadContext(ctx, query, subscriptionID, limit, offset)
+	if err != nil {
+		return nil, fmt.Errorf("error while getting deliveries: %w", err)
+	}
+
+	defer func() { _ = rows.Close() }()
+
+	var deliveries []Delivery
+	for rows.Next() {
+		var delivery Delivery
+		var userID sql.NullInt64
+		var lastStatusCode sql.NullInt64
+		var lastError sql.NullString
+		var deliveredTime sql.NullTime
+
+		err = rows.Scan(
+			&delivery.ID,
+			&delivery.SubscriptionID,
+			&delivery.EventID,
+			&userID,
+			&delivery.EventType,
+			&delivery.Payload,
+			&delivery.EventTime,
+			&delivery.Status,
+			&delivery.Attempts,
+			&lastStatusCode,
+			&lastError,
+			&delivery.InsertTime,
+			&deliveredTime,
+		)
+		if err != nil {
+			return nil, fmt.Errorf("error while scanning rows: %w", err)
+		}
+
+		if userID.Valid {
+			delivery.UserID = &userID.Int64
+		}
+		if lastStatusCode.Valid {
+			delivery.LastStatusCode = &lastStatusCode.Int64
+		}
+		if lastError.Valid {
+			delivery.LastError = &lastError.String
+		}
+		if deliveredTime.Valid {
+			delivery.DeliveredTime = &deliveredTime.Time
+		}
+
+		deliveries = append(deliveries, delivery)
+	}
+
+	return deliveries, nil
+}
+
+// MarkDelivered marks delivery as successfully sent. Status code is 0 if no response was received
+func (r *Repository) MarkDelivered(ctx context.Context, id int64, statusCode int64) error {
+	query := `update webhook_delivery
+			  set status = $2, attempts = attempts + 1, last_status_code = $3, last_error = null, delivered_time = now()
+			  where id = $1`
+	_, err := r.db.ExecContext(ctx, query, id, DeliveryStatusDelivered, statusCode)
+	if err != nil {
+		return fmt.Errorf("error while marking delivery as delivered: %w", err)
+	}
+
+	return nil
+}
+
+// MarkFailed postpones next attempt of delivery
+func (r *Repository) MarkFailed(
+	ctx context.Context,
+	id int64,
+	nextAttemptTime time.Time,
+	statusCode int64,
+	lastError string,
+) error {
+	query := `update webhook_delivery
+			  set attempts = attempts + 1, next_attempt_time = $2, last_status_code = nullif($3, 0), last_error = $4
+			  where id = $1`
+	_, err := r.db.ExecContext(ctx, query, id, nextAttemptTime, statusCode, lastError)
+	if err != nil {
+		return fmt.Errorf("error while marking delivery as failed: %w", err)
+	}
+
+	return nil
+}
+
+// MarkDead gives up delivery after last failed attempt
+func (r *Repository) MarkDead(ctx context.Context, id int64, statusCode int64, lastError string) error {
+	query := `update webhook_delivery
+			  set status = $2, attempts = attempts + 1, last_status_code = nullif($3, 0), last_error = $4
+			  where id = $1`
+	_, err := r.db.ExecContext(ctx, query, id, DeliveryStatusDead, statusCode, lastError)
+	if err != nil {
+		return fmt.Errorf("error while marking delivery as dead: %w", err)
+	}
+
+	return nil
+}
+
+func (r *Repository) InTransaction(ctx context.Context, f func(ctx context.Context) error) error {
+	return r.db.WithTransaction(ctx, f)
+}
+
+// nonNil replaces nil slice with empty one, because nil is stored as null array
+func nonNil(values []string) []string {
+	if values == nil {
+		return []string{}
+	}
+	return values
+}
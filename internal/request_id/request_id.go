@@ -0,0 +1,45 @@
+package request_id
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+)
+
+// maxLength limits length of request id received from client, longer ids are replaced
+const maxLength = 128
+
+type requestIDKey struct{}
+
+func WithRequestID(ctx context.Context, requestID string) context.Context {
+	return context.WithValue(ctx, requestIDKey{}, requestID)
+}
+
+// FromContext returns id of request, false if context doesn't belong to request (e.g. crons)
+func FromContext(ctx context.Context) (string, bool) {
+	requestID, ok := ctx.Value(requestIDKey{}).(string)
+	return requestID, ok
+}
+
+// New generates random request id
+func New() string {
+	b := make([]byte, 16)
+	_, _ = rand.Read(b)
+	return hex.EncodeToString(b)
+}
+
+// Valid checks if request id received from client can be written to logs and headers as is:
+// it isn't empty, isn't too long and contains only printable ascii characters
+func Valid(requestID string) bool {
+	if requestID == "" || len(requestID) > maxLength {
+		return false
+	}
+
+	for i := 0; i < len(requestID); i++ {
+		if requestID[i] < '!' || requestID[i] > '~' {
+			return false
+		}
+	}
+
+	return true
+}
@@ -0,0 +1,23 @@
+package auth
+
+import "errors"
+
+const (
+	// keyPrefix marks keys of service, so they can be found by secret scanners
+	keyPrefix = "sk_"
+	// keyBytes is a number of random bytes in key
+	keyBytes = 32
+	// displayPrefixLength is a number of key's characters stored to recognize key in list
+	displayPrefixLength = 10
+
+	actorIDPrefix = "api_key:"
+	userIDPrefix  = "user:"
+)
+
+// signingMethods are algorithms of tokens signed with public keys, symmetric algorithms and "none" aren't accepted
+var signingMethods = []string{"RS256", "RS384", "RS512", "PS256", "PS384", "PS512", "ES256", "ES384", "ES512"}
+
+var ErrUnauthenticated = errors.New("unauthenticated")
+var ErrKeyNotExist = errors.New("api key doesn't exist")
+var ErrUnknownScope = errors.New("unknown scope")
+var ErrEmptyScopes = errors.New("empty scopes")
@@ -0,0 +1,20 @@
+//go:generate mockery --all --output ./mocks --case underscore --with-expecter
+package auth
+
+import (
+	"context"
+	"crypto"
+
+	apiKeyRepo "github.com/pollykon/avito_test_task/internal/repository/api_key"
+)
+
+type APIKeyRepository interface {
+	Add(ctx context.Context, key apiKeyRepo.Key, hash string) (int64, error)
+	GetByHash(ctx context.Context, hash string) (apiKeyRepo.Key, error)
+	GetKeys(ctx context.Context) ([]apiKeyRepo.Key, error)
+	Revoke(ctx context.Context, id int64) error
+}
+
+type KeySet interface {
+	Key(ctx context.Context, kid string) (crypto.PublicKey, error)
+}
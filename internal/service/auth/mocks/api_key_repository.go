@@ -0,0 +1,242 @@
+// Code generated by mockery v2.33.0. DO NOT EDIT.
+
+package mocks
+
+import (
+	api_key "github.com/pollykon/avito_test_task/internal/repository/api_key"
+
+	context "context"
+
+	mock "github.com/stretchr/testify/mock"
+)
+
+// APIKeyRepository is an autogenerated mock type for the APIKeyRepository type
+type APIKeyRepository struct {
+	mock.Mock
+}
+
+type APIKeyRepository_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *APIKeyRepository) EXPECT() *APIKeyRepository_Expecter {
+	return &APIKeyRepository_Expecter{mock: &_m.Mock}
+}
+
+// Add provides a mock function with given fields: ctx, key, hash
+func (_m *APIKeyRepository) Add(ctx context.Context, key api_key.Key, hash string) (int64, error) {
+	ret := _m.Called(ctx, key, hash)
+
+	var r0 int64
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, api_key.Key, string) (int64, error)); ok {
+		return rf(ctx, key, hash)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, api_key.Key, string) int64); ok {
+		r0 = rf(ctx, key, hash)
+	} else {
+		r0 = ret.Get(0).(int64)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, api_key.Key, string) error); ok {
+		r1 = rf(ctx, key, hash)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// APIKeyRepository_Add_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Add'
+type APIKeyRepository_Add_Call struct {
+	*mock.Call
+}
+
+// Add is a helper method to define mock.On call
+//   - ctx context.Context
+//   - key api_key.Key
+//   - hash string
+func (_e *APIKeyRepository_Expecter) Add(ctx interface{}, key interface{}, hash interface{}) *APIKeyRepository_Add_Call {
+	return &APIKeyRepository_Add_Call{Call: _e.mock.On("Add", ctx, key, hash)}
+}
+
+func (_c *APIKeyRepository_Add_Call) Run(run func(ctx context.Context, key api_key.Key, hash string)) *APIKeyRepository_Add_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(api_key.Key), args[2].(string))
+	})
+	return _c
+}
+
+func (_c *APIKeyRepository_Add_Call) Return(_a0 int64, _a1 error) *APIKeyRepository_Add_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *APIKeyRepository_Add_Call) RunAndReturn(run func(context.Context, api_key.Key, string) (int64, error)) *APIKeyRepository_Add_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetByHash provides a mock function with given fields: ctx, hash
+func (_m *APIKeyRepository) GetByHash(ctx context.Context, hash string) (api_key.Key, error) {
+	ret := _m.Called(ctx, hash)
+
+	var r0 api_key.Key
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) (api_key.Key, error)); ok {
+		return rf(ctx, hash)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) api_key.Key); ok {
+		r0 = rf(ctx, hash)
+	} else {
+		r0 = ret.Get(0).(api_key.Key)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, hash)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// APIKeyRepository_GetByHash_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetByHash'
+type APIKeyRepository_GetByHash_Call struct {
+	*mock.Call
+}
+
+// GetByHash is a helper method to define mock.On call
+//   - ctx context.Context
+//   - hash string
+func (_e *APIKeyRepository_Expecter) GetByHash(ctx interface{}, hash interface{}) *APIKeyRepository_GetByHash_Call {
+	return &APIKeyRepository_GetByHash_Call{Call: _e.mock.On("GetByHash", ctx, hash)}
+}
+
+func (_c *APIKeyRepository_GetByHash_Call) Run(run func(ctx context.Context, hash string)) *APIKeyRepository_GetByHash_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string))
+	})
+	return _c
+}
+
+func (_c *APIKeyRepository_GetByHash_Call) Return(_a0 api_key.Key, _a1 error) *APIKeyRepository_GetByHash_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *APIKeyRepository_GetByHash_Call) RunAndReturn(run func(context.Context, string) (api_key.Key, error)) *APIKeyRepository_GetByHash_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetKeys provides a mock function with given fields: ctx
+func (_m *APIKeyRepository) GetKeys(ctx context.Context) ([]api_key.Key, error) {
+	ret := _m.Called(ctx)
+
+	var r0 []api_key.Key
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context) ([]api_key.Key, error)); ok {
+		return rf(ctx)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context) []api_key.Key); ok {
+		r0 = rf(ctx)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]api_key.Key)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context) error); ok {
+		r1 = rf(ctx)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// APIKeyRepository_GetKeys_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetKeys'
+type APIKeyRepository_GetKeys_Call struct {
+	*mock.Call
+}
+
+// GetKeys is a helper method to define mock.On call
+//   - ctx context.Context
+func (_e *APIKeyRepository_Expecter) GetKeys(ctx interface{}) *APIKeyRepository_GetKeys_Call {
+	return &APIKeyRepository_GetKeys_Call{Call: _e.mock.On("GetKeys", ctx)}
+}
+
+func (_c *APIKeyRepository_GetKeys_Call) Run(run func(ctx context.Context)) *APIKeyRepository_GetKeys_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context))
+	})
+	return _c
+}
+
+func (_c *APIKeyRepository_GetKeys_Call) Return(_a0 []api_key.Key, _a1 error) *APIKeyRepository_GetKeys_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *APIKeyRepository_GetKeys_Call) RunAndReturn(run func(context.Context) ([]api_key.Key, error)) *APIKeyRepository_GetKeys_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Revoke provides a mock function with given fields: ctx, id
+func (_m *APIKeyRepository) Revoke(ctx context.Context, id int64) error {
+	ret := _m.Called(ctx, id)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, int64) error); ok {
+		r0 = rf(ctx, id)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// APIKeyRepository_Revoke_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Revoke'
+type APIKeyRepository_Revoke_Call struct {
+	*mock.Call
+}
+
+// Revoke is a helper method to define mock.On call
+//   - ctx context.Context
+//   - id int64
+func (_e *APIKeyRepository_Expecter) Revoke(ctx interface{}, id interface{}) *APIKeyRepository_Revoke_Call {
+	return &APIKeyRepository_Revoke_Call{Call: _e.mock.On("Revoke", ctx, id)}
+}
+
+func (_c *APIKeyRepository_Revoke_Call) Run(run func(ctx context.Context, id int64)) *APIKeyRepository_Revoke_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(int64))
+	})
+	return _c
+}
+
+func (_c *APIKeyRepository_Revoke_Call) Return(_a0 error) *APIKeyRepository_Revoke_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *APIKeyRepository_Revoke_Call) RunAndReturn(run func(context.Context, int64) error) *APIKeyRepository_Revoke_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// NewAPIKeyRepository creates a new instance of APIKeyRepository. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewAPIKeyRepository(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *APIKeyRepository {
+	mock := &APIKeyRepository{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
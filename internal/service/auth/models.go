@@ -0,0 +1,18 @@
+package auth
+
+import "time"
+
+// CreatedKey contains plain key, it is returned only once on creation
+type CreatedKey struct {
+	ID  int64
+	Key string
+}
+
+type Key struct {
+	ID         int64
+	Name       string
+	Scopes     []string
+	Prefix     string
+	InsertTime time.Time
+	RevokeTime *time.Time
+}
@@ -0,0 +1,126 @@
+package auth
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"strconv"
+
+	"github.com/pollykon/avito_test_task/internal/actor"
+	apiKeyRepository "github.com/pollykon/avito_test_task/internal/repository/api_key"
+)
+
+// Service issues API keys and authenticates requests by them or by JWTs of admin UI users.
+// Keys are stored as sha256 hashes, they are random enough to not need slow hashing
+type Service struct {
+	apiKeyRepo APIKeyRepository
+	// tokenVerifier is nil when authentication by JWTs isn't configured
+	tokenVerifier *JWTVerifier
+}
+
+func New(apiKeyRepo APIKeyRepository, tokenVerifier *JWTVerifier) Service {
+	return Service{apiKeyRepo: apiKeyRepo, tokenVerifier: tokenVerifier}
+}
+
+// CreateKey generates key with scopes. Plain key is returned only here and can't be restored later
+func (s Service) CreateKey(ctx context.Context, name string, scopes []string) (CreatedKey, error) {
+	if len(scopes) == 0 {
+		return CreatedKey{}, ErrEmptyScopes
+	}
+	for _, scope := range scopes {
+		if !actor.IsScope(scope) {
+			return CreatedKey{}, fmt.Errorf("%w: %s", ErrUnknownScope, scope)
+		}
+	}
+
+	randomBytes := make([]byte, keyBytes)
+	_, err := rand.Read(randomBytes)
+	if err != nil {
+		return CreatedKey{}, fmt.Errorf("error from auth service while generating key: %w", err)
+	}
+	key := keyPrefix + base64.RawURLEncoding.EncodeToString(randomBytes)
+
+	id, err := s.apiKeyRepo.Add(ctx, apiKeyRepository.Key{
+		Name:   name,
+		Scopes: scopes,
+		Prefix: key[:displayPrefixLength],
+	}, hashKey(key))
+	if err != nil {
+		return CreatedKey{}, fmt.Errorf("error from auth service while adding key: %w", err)
+	}
+
+	return CreatedKey{ID: id, Key: key}, nil
+}
+
+// Authenticate returns actor which owns key, ErrUnauthenticated if key is unknown or revoked
+func (s Service) Authenticate(ctx context.Context, key string) (actor.Actor, error) {
+	if key == "" {
+		return actor.Actor{}, ErrUnauthenticated
+	}
+
+	storedKey, err := s.apiKeyRepo.GetByHash(ctx, hashKey(key))
+	if err != nil {
+		if errors.Is(err, apiKeyRepository.ErrKeyNotExist) {
+			return actor.Actor{}, ErrUnauthenticated
+		}
+		return actor.Actor{}, fmt.Errorf("error from auth service while getting key: %w", err)
+	}
+
+	return actor.Actor{
+		ID:     actorIDPrefix + strconv.FormatInt(storedKey.ID, 10),
+		Name:   storedKey.Name,
+		Scopes: storedKey.Scopes,
+	}, nil
+}
+
+// AuthenticateToken returns user of admin UI by JWT, ErrUnauthenticated if token is invalid
+// or authentication by JWTs isn't configured
+func (s Service) AuthenticateToken(ctx context.Context, token string) (actor.Actor, error) {
+	if s.tokenVerifier == nil {
+		return actor.Actor{}, fmt.Errorf("%w: authentication by tokens isn't configured", ErrUnauthenticated)
+	}
+
+	return s.tokenVerifier.Verify(ctx, token)
+}
+
+func (s Service) RevokeKey(ctx context.Context, id int64) error {
+	err := s.apiKeyRepo.Revoke(ctx, id)
+	if err != nil {
+		if errors.Is(err, apiKeyRepository.ErrKeyNotExist) {
+			return ErrKeyNotExist
+		}
+		return fmt.Errorf("error from auth service while revoking key: %w", err)
+	}
+
+	return nil
+}
+
+func (s Service) GetKeys(ctx context.Context) ([]Key, error) {
+	keys, err := s.apiKeyRepo.GetKeys(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("error from auth service while getting keys: %w", err)
+	}
+
+	result := make([]Key, 0, len(keys))
+	for _, key := range keys {
+		result = append(result, Key{
+			ID:         key.ID,
+			Name:       key.Name,
+			Scopes:     key.Scopes,
+			Prefix:     key.Prefix,
+			InsertTime: key.InsertTime,
+			RevokeTime: key.RevokeTime,
+		})
+	}
+
+	return result, nil
+}
+
+func hashKey(key string) string {
+	hash := sha256.Sum256([]byte(key))
+	return hex.EncodeToString(hash[:])
+}
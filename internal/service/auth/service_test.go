@@ -0,0 +1,155 @@
+package auth
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+
+	"github.com/pollykon/avito_test_task/internal/actor"
+	apiKeyRepository "github.com/pollykon/avito_test_task/internal/repository/api_key"
+	"github.com/pollykon/avito_test_task/internal/service/auth/mocks"
+)
+
+func TestService_CreateKey_Success(t *testing.T) {
+	var storedHash string
+
+	apiKeyRepoMock := mocks.NewAPIKeyRepository(t)
+	apiKeyRepoMock.EXPECT().
+		Add(context.Background(), mock.AnythingOfType("api_key.Key"), mock.AnythingOfType("string")).
+		Run(func(_ context.Context, key apiKeyRepository.Key, hash string) {
+			assert.Equal(t, "ci", key.Name)
+			assert.Equal(t, []string{actor.ScopeMembershipsRead}, key.Scopes)
+			storedHash = hash
+		}).
+		Return(int64(3), nil)
+
+	created, err := New(apiKeyRepoMock, nil).CreateKey(context.Background(), "ci", []string{actor.ScopeMembershipsRead})
+
+	assert.NoError(t, err)
+	assert.Equal(t, int64(3), created.ID)
+	assert.True(t, strings.HasPrefix(created.Key, keyPrefix))
+	assert.Equal(t, hashKey(created.Key), storedHash)
+	assert.NotContains(t, storedHash, created.Key)
+}
+
+func TestService_CreateKey_Error(t *testing.T) {
+	tt := []struct {
+		name string
+
+		scopes []string
+
+		expectedError error
+	}{
+		{
+			name: "empty_scopes",
+
+			scopes: nil,
+
+			expectedError: ErrEmptyScopes,
+		},
+		{
+			name: "unknown_scope",
+
+			scopes: []string{actor.ScopeMembershipsRead, "everything"},
+
+			expectedError: ErrUnknownScope,
+		},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			_, err := New(mocks.NewAPIKeyRepository(t), nil).CreateKey(context.Background(), "ci", tc.scopes)
+
+			assert.ErrorIs(t, err, tc.expectedError)
+		})
+	}
+}
+
+func TestService_Authenticate(t *testing.T) {
+	errFromRepo := fmt.Errorf("error from repo")
+
+	tt := []struct {
+		name string
+
+		key string
+
+		buildAPIKeyRepoMock func(repo *mocks.APIKeyRepository)
+
+		expectedActor actor.Actor
+		expectedError error
+	}{
+		{
+			name: "success",
+
+			key: "sk_key",
+
+			buildAPIKeyRepoMock: func(repo *mocks.APIKeyRepository) {
+				repo.EXPECT().GetByHash(context.Background(), hashKey("sk_key")).
+					Return(apiKeyRepository.Key{ID: 12, Name: "ci", Scopes: []string{actor.ScopeHistoryExport}}, nil)
+			},
+
+			expectedActor: actor.Actor{ID: "api_key:12", Name: "ci", Scopes: []string{actor.ScopeHistoryExport}},
+			expectedError: nil,
+		},
+		{
+			name: "empty_key",
+
+			key: "",
+
+			buildAPIKeyRepoMock: nil,
+
+			expectedError: ErrUnauthenticated,
+		},
+		{
+			name: "unknown_key",
+
+			key: "sk_unknown",
+
+			buildAPIKeyRepoMock: func(repo *mocks.APIKeyRepository) {
+				repo.EXPECT().GetByHash(context.Background(), hashKey("sk_unknown")).
+					Return(apiKeyRepository.Key{}, apiKeyRepository.ErrKeyNotExist)
+			},
+
+			expectedError: ErrUnauthenticated,
+		},
+		{
+			name: "repo_error",
+
+			key: "sk_key",
+
+			buildAPIKeyRepoMock: func(repo *mocks.APIKeyRepository) {
+				repo.EXPECT().GetByHash(context.Background(), hashKey("sk_key")).
+					Return(apiKeyRepository.Key{}, errFromRepo)
+			},
+
+			expectedError: errFromRepo,
+		},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			apiKeyRepoMock := mocks.NewAPIKeyRepository(t)
+			if tc.buildAPIKeyRepoMock != nil {
+				tc.buildAPIKeyRepoMock(apiKeyRepoMock)
+			}
+
+			a, err := New(apiKeyRepoMock, nil).Authenticate(context.Background(), tc.key)
+
+			assert.ErrorIs(t, err, tc.expectedError)
+			assert.Equal(t, tc.expectedActor, a)
+		})
+	}
+}
+
+func TestService_RevokeKey(t *testing.T) {
+	apiKeyRepoMock := mocks.NewAPIKeyRepository(t)
+	apiKeyRepoMock.EXPECT().Revoke(context.Background(), int64(5)).Return(apiKeyRepository.ErrKeyNotExist)
+
+	err := New(apiKeyRepoMock, nil).RevokeKey(context.Background(), 5)
+
+	assert.ErrorIs(t, err, ErrKeyNotExist)
+}
@@ -0,0 +1,120 @@
+package auth
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+
+	"github.com/pollykon/avito_test_task/internal/actor"
+)
+
+// TokenConfig describes tokens of identity provider
+type TokenConfig struct {
+	Issuer   string
+	Audience string
+	// RolesClaim is a path to claim with roles, nested claims are separated by dots, e.g. "realm_access.roles"
+	RolesClaim string
+	// RoleMapping maps values of RolesClaim to roles, values which aren't mapped are used as roles as is
+	RoleMapping map[string]string
+	// Leeway is allowed clock skew between service and identity provider
+	Leeway time.Duration
+}
+
+// JWTVerifier authenticates users of admin UI by JWTs signed with keys of identity provider
+type JWTVerifier struct {
+	keySet KeySet
+	config TokenConfig
+	parser *jwt.Parser
+}
+
+func NewJWTVerifier(keySet KeySet, config TokenConfig) *JWTVerifier {
+	options := []jwt.ParserOption{
+		jwt.WithValidMethods(signingMethods),
+		jwt.WithExpirationRequired(),
+		jwt.WithLeeway(config.Leeway),
+	}
+	if config.Issuer != "" {
+		options = append(options, jwt.WithIssuer(config.Issuer))
+	}
+	if config.Audience != "" {
+		options = append(options, jwt.WithAudience(config.Audience))
+	}
+
+	return &JWTVerifier{
+		keySet: keySet,
+		config: config,
+		parser: jwt.NewParser(options...),
+	}
+}
+
+// Verify checks token and returns user with scopes of roles from token
+func (v *JWTVerifier) Verify(ctx context.Context, token string) (actor.Actor, error) {
+	claims := jwt.MapClaims{}
+	_, err := v.parser.ParseWithClaims(token, claims, func(token *jwt.Token) (interface{}, error) {
+		kid, _ := token.Header["kid"].(string)
+		return v.keySet.Key(ctx, kid)
+	})
+	if err != nil {
+		return actor.Actor{}, fmt.Errorf("%w: %w", ErrUnauthenticated, err)
+	}
+
+	subject, err := claims.GetSubject()
+	if err != nil || subject == "" {
+		return actor.Actor{}, fmt.Errorf("%w: token without subject", ErrUnauthenticated)
+	}
+
+	name := subject
+	if email, ok := claims["email"].(string); ok && email != "" {
+		name = email
+	}
+
+	return actor.Actor{
+		ID:     userIDPrefix + subject,
+		Name:   name,
+		Scopes: v.scopes(claims),
+	}, nil
+}
+
+// scopes returns union of scopes of known roles from claims
+func (v *JWTVerifier) scopes(claims jwt.MapClaims) []string {
+	var value interface{} = map[string]interface{}(claims)
+	for _, key := range strings.Split(v.config.RolesClaim, ".") {
+		object, ok := value.(map[string]interface{})
+		if !ok {
+			return nil
+		}
+		value = object[key]
+	}
+
+	var values []string
+	switch typedValue := value.(type) {
+	case string:
+		values = strings.Fields(typedValue)
+	case []interface{}:
+		for _, item := range typedValue {
+			if s, ok := item.(string); ok {
+				values = append(values, s)
+			}
+		}
+	}
+
+	var scopes []string
+	for _, roleValue := range values {
+		role, ok := v.config.RoleMapping[roleValue]
+		if !ok {
+			role = roleValue
+		}
+
+		for _, scope := range actor.RoleScopes[role] {
+			if !slices.Contains(scopes, scope) {
+				scopes = append(scopes, scope)
+			}
+		}
+	}
+
+	return scopes
+}
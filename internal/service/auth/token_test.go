@@ -0,0 +1,203 @@
+package auth
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/rsa"
+	"testing"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/pollykon/avito_test_task/internal/actor"
+	"github.com/pollykon/avito_test_task/internal/jwks"
+	"github.com/pollykon/avito_test_task/internal/service/auth/mocks"
+)
+
+func TestJWTVerifier_Verify(t *testing.T) {
+	privateKey, err := rsa.GenerateKey(rand.Reader, 2048)
+	require.NoError(t, err)
+
+	config := TokenConfig{
+		Issuer:      "https://idp.example.com",
+		Audience:    "segments-admin",
+		RolesClaim:  "realm_access.roles",
+		RoleMapping: map[string]string{"segments-editors": actor.RoleEditor},
+		Leeway:      time.Second,
+	}
+
+	validClaims := func() jwt.MapClaims {
+		return jwt.MapClaims{
+			"iss":          config.Issuer,
+			"aud":          config.Audience,
+			"sub":          "42",
+			"email":        "admin@example.com",
+			"exp":          time.Now().Add(time.Hour).Unix(),
+			"realm_access": map[string]interface{}{"roles": []string{"viewer"}},
+		}
+	}
+
+	sign := func(method jwt.SigningMethod, key interface{}, claims jwt.MapClaims) string {
+		token := jwt.NewWithClaims(method, claims)
+		token.Header["kid"] = "main"
+		signed, err := token.SignedString(key)
+		require.NoError(t, err)
+		return signed
+	}
+
+	tt := []struct {
+		name string
+
+		token func() string
+
+		expectedActor actor.Actor
+		expectedError error
+	}{
+		{
+			name: "viewer",
+
+			token: func() string { return sign(jwt.SigningMethodRS256, privateKey, validClaims()) },
+
+			expectedActor: actor.Actor{
+				ID:     "user:42",
+				Name:   "admin@example.com",
+				Scopes: []string{actor.ScopeMembershipsRead, actor.ScopeHistoryExport},
+			},
+		},
+		{
+			name: "mapped_role",
+
+			token: func() string {
+				claims := validClaims()
+				claims["realm_access"] = map[string]interface{}{"roles": []string{"offline_access", "segments-editors"}}
+				return sign(jwt.SigningMethodRS256, privateKey, claims)
+			},
+
+			expectedActor: actor.Actor{
+				ID:     "user:42",
+				Name:   "admin@example.com",
+				Scopes: actor.RoleScopes[actor.RoleEditor],
+			},
+		},
+		{
+			name: "without_roles",
+
+			token: func() string {
+				claims := validClaims()
+				delete(claims, "realm_access")
+				return sign(jwt.SigningMethodRS256, privateKey, claims)
+			},
+
+			expectedActor: actor.Actor{ID: "user:42", Name: "admin@example.com"},
+		},
+		{
+			name: "expired",
+
+			token: func() string {
+				claims := validClaims()
+				claims["exp"] = time.Now().Add(-time.Minute).Unix()
+				return sign(jwt.SigningMethodRS256, privateKey, claims)
+			},
+
+			expectedError: ErrUnauthenticated,
+		},
+		{
+			name: "without_expiration",
+
+			token: func() string {
+				claims := validClaims()
+				delete(claims, "exp")
+				return sign(jwt.SigningMethodRS256, privateKey, claims)
+			},
+
+			expectedError: ErrUnauthenticated,
+		},
+		{
+			name: "wrong_issuer",
+
+			token: func() string {
+				claims := validClaims()
+				claims["iss"] = "https://evil.example.com"
+				return sign(jwt.SigningMethodRS256, privateKey, claims)
+			},
+
+			expectedError: ErrUnauthenticated,
+		},
+		{
+			name: "wrong_audience",
+
+			token: func() string {
+				claims := validClaims()
+				claims["aud"] = "other-app"
+				return sign(jwt.SigningMethodRS256, privateKey, claims)
+			},
+
+			expectedError: ErrUnauthenticated,
+		},
+		{
+			name: "without_subject",
+
+			token: func() string {
+				claims := validClaims()
+				delete(claims, "sub")
+				return sign(jwt.SigningMethodRS256, privateKey, claims)
+			},
+
+			expectedError: ErrUnauthenticated,
+		},
+		{
+			name: "symmetric_algorithm",
+
+			token: func() string { return sign(jwt.SigningMethodHS256, []byte("secret"), validClaims()) },
+
+			expectedError: ErrUnauthenticated,
+		},
+		{
+			name: "malformed",
+
+			token: func() string { return "not.a.token" },
+
+			expectedError: ErrUnauthenticated,
+		},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			keySetMock := mocks.NewKeySet(t)
+			keySetMock.EXPECT().Key(mock.Anything, "main").Return(&privateKey.PublicKey, nil).Maybe()
+
+			a, err := NewJWTVerifier(keySetMock, config).Verify(context.Background(), tc.token())
+
+			assert.ErrorIs(t, err, tc.expectedError)
+			assert.Equal(t, tc.expectedActor, a)
+		})
+	}
+}
+
+func TestJWTVerifier_Verify_UnknownKey(t *testing.T) {
+	privateKey, err := rsa.GenerateKey(rand.Reader, 2048)
+	require.NoError(t, err)
+
+	token, err := jwt.NewWithClaims(jwt.SigningMethodRS256, jwt.MapClaims{
+		"sub": "42",
+		"exp": time.Now().Add(time.Hour).Unix(),
+	}).SignedString(privateKey)
+	require.NoError(t, err)
+
+	keySetMock := mocks.NewKeySet(t)
+	keySetMock.EXPECT().Key(mock.Anything, "").Return(nil, jwks.ErrKeyNotFound)
+
+	_, err = NewJWTVerifier(keySetMock, TokenConfig{RolesClaim: "roles"}).Verify(context.Background(), token)
+
+	assert.ErrorIs(t, err, ErrUnauthenticated)
+	assert.ErrorIs(t, err, jwks.ErrKeyNotFound)
+}
+
+func TestService_AuthenticateToken_NotConfigured(t *testing.T) {
+	_, err := New(mocks.NewAPIKeyRepository(t), nil).AuthenticateToken(context.Background(), "token")
+
+	assert.ErrorIs(t, err, ErrUnauthenticated)
+}
@@ -0,0 +1,49 @@
+package deleters
+
+import (
+	"bytes"
+	"compress/gzip"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	logRepository "github.com/pollykon/avito_test_task/internal/repository/log"
+)
+
+// archivedLog is a single line of log archive, fields are named like csv columns of exported logs
+type archivedLog struct {
+	LogID      int64     `json:"logId"`
+	UserID     int64     `json:"userId"`
+	SegmentID  string    `json:"segmentId"`
+	Operation  string    `json:"operation"`
+	Actor      string    `json:"actor,omitempty"`
+	InsertTime time.Time `json:"insertTime"`
+}
+
+// archiveLogs encodes logs as gzip compressed NDJSON
+func archiveLogs(logs []logRepository.Log) ([]byte, error) {
+	var buffer bytes.Buffer
+	writer := gzip.NewWriter(&buffer)
+	encoder := json.NewEncoder(writer)
+
+	for _, log := range logs {
+		err := encoder.Encode(archivedLog{
+			LogID:      log.ID,
+			UserID:     log.UserID,
+			SegmentID:  log.SegmentID,
+			Operation:  log.Operation,
+			Actor:      log.Actor,
+			InsertTime: log.InsertTime,
+		})
+		if err != nil {
+			return nil, fmt.Errorf("error while encoding log: %w", err)
+		}
+	}
+
+	err := writer.Close()
+	if err != nil {
+		return nil, fmt.Errorf("error while closing gzip: %w", err)
+	}
+
+	return buffer.Bytes(), nil
+}
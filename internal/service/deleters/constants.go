@@ -0,0 +1,17 @@
+package deleters
+
+const (
+	logArchivePrefix    = "log_archive_"
+	logArchiveExtension = ".ndjson.gz"
+	// logArchivePartitionLayout is time layout of archives of dropped partitions, e.g. log_archive_2023_08_1_100.ndjson.gz
+	logArchivePartitionLayout = "2006_01"
+)
+
+// jobs label metrics of cron
+const (
+	jobDeleteSegments    = "delete_segments"
+	jobDeleteTTLSegments = "delete_ttl_segments"
+	jobDeleteLogs        = "delete_logs"
+	jobDeleteExportFiles = "delete_export_files"
+	jobDeleteRateLimits  = "delete_rate_limit_buckets"
+)
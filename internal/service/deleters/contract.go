@@ -1,12 +1,45 @@
+//go:generate mockery --all --output ./mocks --case underscore --with-expecter
 package deleters
 
-import "context"
+import (
+	"context"
+	"time"
+
+	exportFileRepo "github.com/pollykon/avito_test_task/internal/repository/export_file"
+	logRepo "github.com/pollykon/avito_test_task/internal/repository/log"
+	outboxRepo "github.com/pollykon/avito_test_task/internal/repository/outbox"
+	segmentRepo "github.com/pollykon/avito_test_task/internal/repository/segment"
+)
 
 type SegmentRepository interface {
-	DeleteUserSegmentsWithBadTTL(ctx context.Context, limit int64) error
-	DeleteSegments(ctx context.Context, limit int64) error
+	DeleteUserSegmentsWithBadTTL(ctx context.Context, limit int64) ([]segmentRepo.UserSegment, error)
+	DeleteSegments(ctx context.Context, limit int64) ([]segmentRepo.UserSegment, error)
+	InTransaction(ctx context.Context, f func(ctx context.Context) error) error
 }
 
 type LogRepository interface {
-	Delete(ctx context.Context, limit int64) error
+	GetExpired(ctx context.Context, operation string, retention time.Duration, limit int64) ([]logRepo.Log, error)
+	Delete(ctx context.Context, ids []int64) error
+	CreatePartition(ctx context.Context, month time.Time) error
+	GetPartitions(ctx context.Context) ([]logRepo.Partition, error)
+	GetPartitionLogs(ctx context.Context, partition logRepo.Partition, afterID int64, limit int64) ([]logRepo.Log, error)
+	DropPartition(ctx context.Context, partition logRepo.Partition) error
+}
+
+type ExportFileRepository interface {
+	GetExpired(ctx context.Context, maxAge time.Duration, limit int64) ([]exportFileRepo.ExportFile, error)
+	Delete(ctx context.Context, fileNames []string) error
+}
+
+type BlobStorage interface {
+	Save(ctx context.Context, name string, content []byte) error
+	Delete(ctx context.Context, name string) error
+}
+
+type OutboxRepository interface {
+	Add(ctx context.Context, events []outboxRepo.Event) error
+}
+
+type RateLimitBucketRepository interface {
+	DeleteFull(ctx context.Context) (int64, error)
 }
@@ -0,0 +1,139 @@
+// Code generated by mockery v2.33.0. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+
+	export_file "github.com/pollykon/avito_test_task/internal/repository/export_file"
+
+	mock "github.com/stretchr/testify/mock"
+
+	time "time"
+)
+
+// ExportFileRepository is an autogenerated mock type for the ExportFileRepository type
+type ExportFileRepository struct {
+	mock.Mock
+}
+
+type ExportFileRepository_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *ExportFileRepository) EXPECT() *ExportFileRepository_Expecter {
+	return &ExportFileRepository_Expecter{mock: &_m.Mock}
+}
+
+// Delete provides a mock function with given fields: ctx, fileNames
+func (_m *ExportFileRepository) Delete(ctx context.Context, fileNames []string) error {
+	ret := _m.Called(ctx, fileNames)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, []string) error); ok {
+		r0 = rf(ctx, fileNames)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// ExportFileRepository_Delete_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Delete'
+type ExportFileRepository_Delete_Call struct {
+	*mock.Call
+}
+
+// Delete is a helper method to define mock.On call
+//   - ctx context.Context
+//   - fileNames []string
+func (_e *ExportFileRepository_Expecter) Delete(ctx interface{}, fileNames interface{}) *ExportFileRepository_Delete_Call {
+	return &ExportFileRepository_Delete_Call{Call: _e.mock.On("Delete", ctx, fileNames)}
+}
+
+func (_c *ExportFileRepository_Delete_Call) Run(run func(ctx context.Context, fileNames []string)) *ExportFileRepository_Delete_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].([]string))
+	})
+	return _c
+}
+
+func (_c *ExportFileRepository_Delete_Call) Return(_a0 error) *ExportFileRepository_Delete_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *ExportFileRepository_Delete_Call) RunAndReturn(run func(context.Context, []string) error) *ExportFileRepository_Delete_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetExpired provides a mock function with given fields: ctx, maxAge, limit
+func (_m *ExportFileRepository) GetExpired(ctx context.Context, maxAge time.Duration, limit int64) ([]export_file.ExportFile, error) {
+	ret := _m.Called(ctx, maxAge, limit)
+
+	var r0 []export_file.ExportFile
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, time.Duration, int64) ([]export_file.ExportFile, error)); ok {
+		return rf(ctx, maxAge, limit)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, time.Duration, int64) []export_file.ExportFile); ok {
+		r0 = rf(ctx, maxAge, limit)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]export_file.ExportFile)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, time.Duration, int64) error); ok {
+		r1 = rf(ctx, maxAge, limit)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// ExportFileRepository_GetExpired_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetExpired'
+type ExportFileRepository_GetExpired_Call struct {
+	*mock.Call
+}
+
+// GetExpired is a helper method to define mock.On call
+//   - ctx context.Context
+//   - maxAge time.Duration
+//   - limit int64
+func (_e *ExportFileRepository_Expecter) GetExpired(ctx interface{}, maxAge interface{}, limit interface{}) *ExportFileRepository_GetExpired_Call {
+	return &ExportFileRepository_GetExpired_Call{Call: _e.mock.On("GetExpired", ctx, maxAge, limit)}
+}
+
+func (_c *ExportFileRepository_GetExpired_Call) Run(run func(ctx context.Context, maxAge time.Duration, limit int64)) *ExportFileRepository_GetExpired_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(time.Duration), args[2].(int64))
+	})
+	return _c
+}
+
+func (_c *ExportFileRepository_GetExpired_Call) Return(_a0 []export_file.ExportFile, _a1 error) *ExportFileRepository_GetExpired_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *ExportFileRepository_GetExpired_Call) RunAndReturn(run func(context.Context, time.Duration, int64) ([]export_file.ExportFile, error)) *ExportFileRepository_GetExpired_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// NewExportFileRepository creates a new instance of ExportFileRepository. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewExportFileRepository(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *ExportFileRepository {
+	mock := &ExportFileRepository{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
@@ -0,0 +1,337 @@
+// Code generated by mockery v2.33.0. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+
+	log "github.com/pollykon/avito_test_task/internal/repository/log"
+
+	mock "github.com/stretchr/testify/mock"
+
+	time "time"
+)
+
+// LogRepository is an autogenerated mock type for the LogRepository type
+type LogRepository struct {
+	mock.Mock
+}
+
+type LogRepository_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *LogRepository) EXPECT() *LogRepository_Expecter {
+	return &LogRepository_Expecter{mock: &_m.Mock}
+}
+
+// CreatePartition provides a mock function with given fields: ctx, month
+func (_m *LogRepository) CreatePartition(ctx context.Context, month time.Time) error {
+	ret := _m.Called(ctx, month)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, time.Time) error); ok {
+		r0 = rf(ctx, month)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// LogRepository_CreatePartition_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'CreatePartition'
+type LogRepository_CreatePartition_Call struct {
+	*mock.Call
+}
+
+// CreatePartition is a helper method to define mock.On call
+//   - ctx context.Context
+//   - month time.Time
+func (_e *LogRepository_Expecter) CreatePartition(ctx interface{}, month interface{}) *LogRepository_CreatePartition_Call {
+	return &LogRepository_CreatePartition_Call{Call: _e.mock.On("CreatePartition", ctx, month)}
+}
+
+func (_c *LogRepository_CreatePartition_Call) Run(run func(ctx context.Context, month time.Time)) *LogRepository_CreatePartition_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(time.Time))
+	})
+	return _c
+}
+
+func (_c *LogRepository_CreatePartition_Call) Return(_a0 error) *LogRepository_CreatePartition_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *LogRepository_CreatePartition_Call) RunAndReturn(run func(context.Context, time.Time) error) *LogRepository_CreatePartition_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Delete provides a mock function with given fields: ctx, ids
+func (_m *LogRepository) Delete(ctx context.Context, ids []int64) error {
+	ret := _m.Called(ctx, ids)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, []int64) error); ok {
+		r0 = rf(ctx, ids)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// LogRepository_Delete_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Delete'
+type LogRepository_Delete_Call struct {
+	*mock.Call
+}
+
+// Delete is a helper method to define mock.On call
+//   - ctx context.Context
+//   - ids []int64
+func (_e *LogRepository_Expecter) Delete(ctx interface{}, ids interface{}) *LogRepository_Delete_Call {
+	return &LogRepository_Delete_Call{Call: _e.mock.On("Delete", ctx, ids)}
+}
+
+func (_c *LogRepository_Delete_Call) Run(run func(ctx context.Context, ids []int64)) *LogRepository_Delete_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].([]int64))
+	})
+	return _c
+}
+
+func (_c *LogRepository_Delete_Call) Return(_a0 error) *LogRepository_Delete_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *LogRepository_Delete_Call) RunAndReturn(run func(context.Context, []int64) error) *LogRepository_Delete_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// DropPartition provides a mock function with given fields: ctx, partition
+func (_m *LogRepository) DropPartition(ctx context.Context, partition log.Partition) error {
+	ret := _m.Called(ctx, partition)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, log.Partition) error); ok {
+		r0 = rf(ctx, partition)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// LogRepository_DropPartition_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'DropPartition'
+type LogRepository_DropPartition_Call struct {
+	*mock.Call
+}
+
+// DropPartition is a helper method to define mock.On call
+//   - ctx context.Context
+//   - partition log.Partition
+func (_e *LogRepository_Expecter) DropPartition(ctx interface{}, partition interface{}) *LogRepository_DropPartition_Call {
+	return &LogRepository_DropPartition_Call{Call: _e.mock.On("DropPartition", ctx, partition)}
+}
+
+func (_c *LogRepository_DropPartition_Call) Run(run func(ctx context.Context, partition log.Partition)) *LogRepository_DropPartition_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(log.Partition))
+	})
+	return _c
+}
+
+func (_c *LogRepository_DropPartition_Call) Return(_a0 error) *LogRepository_DropPartition_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *LogRepository_DropPartition_Call) RunAndReturn(run func(context.Context, log.Partition) error) *LogRepository_DropPartition_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetExpired provides a mock function with given fields: ctx, operation, retention, limit
+func (_m *LogRepository) GetExpired(ctx context.Context, operation string, retention time.Duration, limit int64) ([]log.Log, error) {
+	ret := _m.Called(ctx, operation, retention, limit)
+
+	var r0 []log.Log
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, time.Duration, int64) ([]log.Log, error)); ok {
+		return rf(ctx, operation, retention, limit)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, time.Duration, int64) []log.Log); ok {
+		r0 = rf(ctx, operation, retention, limit)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]log.Log)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, time.Duration, int64) error); ok {
+		r1 = rf(ctx, operation, retention, limit)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// LogRepository_GetExpired_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetExpired'
+type LogRepository_GetExpired_Call struct {
+	*mock.Call
+}
+
+// GetExpired is a helper method to define mock.On call
+//   - ctx context.Context
+//   - operation string
+//   - retention time.Duration
+//   - limit int64
+func (_e *LogRepository_Expecter) GetExpired(ctx interface{}, operation interface{}, retention interface{}, limit interface{}) *LogRepository_GetExpired_Call {
+	return &LogRepository_GetExpired_Call{Call: _e.mock.On("GetExpired", ctx, operation, retention, limit)}
+}
+
+func (_c *LogRepository_GetExpired_Call) Run(run func(ctx context.Context, operation string, retention time.Duration, limit int64)) *LogRepository_GetExpired_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string), args[2].(time.Duration), args[3].(int64))
+	})
+	return _c
+}
+
+func (_c *LogRepository_GetExpired_Call) Return(_a0 []log.Log, _a1 error) *LogRepository_GetExpired_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *LogRepository_GetExpired_Call) RunAndReturn(run func(context.Context, string, time.Duration, int64) ([]log.Log, error)) *LogRepository_GetExpired_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetPartitionLogs provides a mock function with given fields: ctx, partition, afterID, limit
+func (_m *LogRepository) GetPartitionLogs(ctx context.Context, partition log.Partition, afterID int64, limit int64) ([]log.Log, error) {
+	ret := _m.Called(ctx, partition, afterID, limit)
+
+	var r0 []log.Log
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, log.Partition, int64, int64) ([]log.Log, error)); ok {
+		return rf(ctx, partition, afterID, limit)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, log.Partition, int64, int64) []log.Log); ok {
+		r0 = rf(ctx, partition, afterID, limit)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]log.Log)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, log.Partition, int64, int64) error); ok {
+		r1 = rf(ctx, partition, afterID, limit)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// LogRepository_GetPartitionLogs_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetPartitionLogs'
+type LogRepository_GetPartitionLogs_Call struct {
+	*mock.Call
+}
+
+// GetPartitionLogs is a helper method to define mock.On call
+//   - ctx context.Context
+//   - partition log.Partition
+//   - afterID int64
+//   - limit int64
+func (_e *LogRepository_Expecter) GetPartitionLogs(ctx interface{}, partition interface{}, afterID interface{}, limit interface{}) *LogRepository_GetPartitionLogs_Call {
+	return &LogRepository_GetPartitionLogs_Call{Call: _e.mock.On("GetPartitionLogs", ctx, partition, afterID, limit)}
+}
+
+func (_c *LogRepository_GetPartitionLogs_Call) Run(run func(ctx context.Context, partition log.Partition, afterID int64, limit int64)) *LogRepository_GetPartitionLogs_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(log.Partition), args[2].(int64), args[3].(int64))
+	})
+	return _c
+}
+
+func (_c *LogRepository_GetPartitionLogs_Call) Return(_a0 []log.Log, _a1 error) *LogRepository_GetPartitionLogs_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *LogRepository_GetPartitionLogs_Call) RunAndReturn(run func(context.Context, log.Partition, int64, int64) ([]log.Log, error)) *LogRepository_GetPartitionLogs_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetPartitions provides a mock function with given fields: ctx
+func (_m *LogRepository) GetPartitions(ctx context.Context) ([]log.Partition, error) {
+	ret := _m.Called(ctx)
+
+	var r0 []log.Partition
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context) ([]log.Partition, error)); ok {
+		return rf(ctx)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context) []log.Partition); ok {
+		r0 = rf(ctx)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]log.Partition)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context) error); ok {
+		r1 = rf(ctx)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// LogRepository_GetPartitions_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetPartitions'
+type LogRepository_GetPartitions_Call struct {
+	*mock.Call
+}
+
+// GetPartitions is a helper method to define mock.On call
+//   - ctx context.Context
+func (_e *LogRepository_Expecter) GetPartitions(ctx interface{}) *LogRepository_GetPartitions_Call {
+	return &LogRepository_GetPartitions_Call{Call: _e.mock.On("GetPartitions", ctx)}
+}
+
+func (_c *LogRepository_GetPartitions_Call) Run(run func(ctx context.Context)) *LogRepository_GetPartitions_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context))
+	})
+	return _c
+}
+
+func (_c *LogRepository_GetPartitions_Call) Return(_a0 []log.Partition, _a1 error) *LogRepository_GetPartitions_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *LogRepository_GetPartitions_Call) RunAndReturn(run func(context.Context) ([]log.Partition, error)) *LogRepository_GetPartitions_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// NewLogRepository creates a new instance of LogRepository. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewLogRepository(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *LogRepository {
+	mock := &LogRepository{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
@@ -0,0 +1,81 @@
+// Code generated by mockery v2.33.0. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+
+	mock "github.com/stretchr/testify/mock"
+
+	outbox "github.com/pollykon/avito_test_task/internal/repository/outbox"
+)
+
+// OutboxRepository is an autogenerated mock type for the OutboxRepository type
+type OutboxRepository struct {
+	mock.Mock
+}
+
+type OutboxRepository_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *OutboxRepository) EXPECT() *OutboxRepository_Expecter {
+	return &OutboxRepository_Expecter{mock: &_m.Mock}
+}
+
+// Add provides a mock function with given fields: ctx, events
+func (_m *OutboxRepository) Add(ctx context.Context, events []outbox.Event) error {
+	ret := _m.Called(ctx, events)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, []outbox.Event) error); ok {
+		r0 = rf(ctx, events)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// OutboxRepository_Add_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Add'
+type OutboxRepository_Add_Call struct {
+	*mock.Call
+}
+
+// Add is a helper method to define mock.On call
+//   - ctx context.Context
+//   - events []outbox.Event
+func (_e *OutboxRepository_Expecter) Add(ctx interface{}, events interface{}) *OutboxRepository_Add_Call {
+	return &OutboxRepository_Add_Call{Call: _e.mock.On("Add", ctx, events)}
+}
+
+func (_c *OutboxRepository_Add_Call) Run(run func(ctx context.Context, events []outbox.Event)) *OutboxRepository_Add_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].([]outbox.Event))
+	})
+	return _c
+}
+
+func (_c *OutboxRepository_Add_Call) Return(_a0 error) *OutboxRepository_Add_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *OutboxRepository_Add_Call) RunAndReturn(run func(context.Context, []outbox.Event) error) *OutboxRepository_Add_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// NewOutboxRepository creates a new instance of OutboxRepository. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewOutboxRepository(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *OutboxRepository {
+	mock := &OutboxRepository{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
@@ -0,0 +1,88 @@
+// Code generated by mockery v2.33.0. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+
+	mock "github.com/stretchr/testify/mock"
+)
+
+// RateLimitBucketRepository is an autogenerated mock type for the RateLimitBucketRepository type
+type RateLimitBucketRepository struct {
+	mock.Mock
+}
+
+type RateLimitBucketRepository_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *RateLimitBucketRepository) EXPECT() *RateLimitBucketRepository_Expecter {
+	return &RateLimitBucketRepository_Expecter{mock: &_m.Mock}
+}
+
+// DeleteFull provides a mock function with given fields: ctx
+func (_m *RateLimitBucketRepository) DeleteFull(ctx context.Context) (int64, error) {
+	ret := _m.Called(ctx)
+
+	var r0 int64
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context) (int64, error)); ok {
+		return rf(ctx)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context) int64); ok {
+		r0 = rf(ctx)
+	} else {
+		r0 = ret.Get(0).(int64)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context) error); ok {
+		r1 = rf(ctx)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// RateLimitBucketRepository_DeleteFull_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'DeleteFull'
+type RateLimitBucketRepository_DeleteFull_Call struct {
+	*mock.Call
+}
+
+// DeleteFull is a helper method to define mock.On call
+//   - ctx context.Context
+func (_e *RateLimitBucketRepository_Expecter) DeleteFull(ctx interface{}) *RateLimitBucketRepository_DeleteFull_Call {
+	return &RateLimitBucketRepository_DeleteFull_Call{Call: _e.mock.On("DeleteFull", ctx)}
+}
+
+func (_c *RateLimitBucketRepository_DeleteFull_Call) Run(run func(ctx context.Context)) *RateLimitBucketRepository_DeleteFull_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context))
+	})
+	return _c
+}
+
+func (_c *RateLimitBucketRepository_DeleteFull_Call) Return(_a0 int64, _a1 error) *RateLimitBucketRepository_DeleteFull_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *RateLimitBucketRepository_DeleteFull_Call) RunAndReturn(run func(context.Context) (int64, error)) *RateLimitBucketRepository_DeleteFull_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// NewRateLimitBucketRepository creates a new instance of RateLimitBucketRepository. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewRateLimitBucketRepository(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *RateLimitBucketRepository {
+	mock := &RateLimitBucketRepository{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
@@ -0,0 +1,191 @@
+// Code generated by mockery v2.33.0. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+
+	mock "github.com/stretchr/testify/mock"
+
+	segment "github.com/pollykon/avito_test_task/internal/repository/segment"
+)
+
+// SegmentRepository is an autogenerated mock type for the SegmentRepository type
+type SegmentRepository struct {
+	mock.Mock
+}
+
+type SegmentRepository_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *SegmentRepository) EXPECT() *SegmentRepository_Expecter {
+	return &SegmentRepository_Expecter{mock: &_m.Mock}
+}
+
+// DeleteSegments provides a mock function with given fields: ctx, limit
+func (_m *SegmentRepository) DeleteSegments(ctx context.Context, limit int64) ([]segment.UserSegment, error) {
+	ret := _m.Called(ctx, limit)
+
+	var r0 []segment.UserSegment
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, int64) ([]segment.UserSegment, error)); ok {
+		return rf(ctx, limit)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, int64) []segment.UserSegment); ok {
+		r0 = rf(ctx, limit)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]segment.UserSegment)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, int64) error); ok {
+		r1 = rf(ctx, limit)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// SegmentRepository_DeleteSegments_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'DeleteSegments'
+type SegmentRepository_DeleteSegments_Call struct {
+	*mock.Call
+}
+
+// DeleteSegments is a helper method to define mock.On call
+//   - ctx context.Context
+//   - limit int64
+func (_e *SegmentRepository_Expecter) DeleteSegments(ctx interface{}, limit interface{}) *SegmentRepository_DeleteSegments_Call {
+	return &SegmentRepository_DeleteSegments_Call{Call: _e.mock.On("DeleteSegments", ctx, limit)}
+}
+
+func (_c *SegmentRepository_DeleteSegments_Call) Run(run func(ctx context.Context, limit int64)) *SegmentRepository_DeleteSegments_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(int64))
+	})
+	return _c
+}
+
+func (_c *SegmentRepository_DeleteSegments_Call) Return(_a0 []segment.UserSegment, _a1 error) *SegmentRepository_DeleteSegments_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *SegmentRepository_DeleteSegments_Call) RunAndReturn(run func(context.Context, int64) ([]segment.UserSegment, error)) *SegmentRepository_DeleteSegments_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// DeleteUserSegmentsWithBadTTL provides a mock function with given fields: ctx, limit
+func (_m *SegmentRepository) DeleteUserSegmentsWithBadTTL(ctx context.Context, limit int64) ([]segment.UserSegment, error) {
+	ret := _m.Called(ctx, limit)
+
+	var r0 []segment.UserSegment
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, int64) ([]segment.UserSegment, error)); ok {
+		return rf(ctx, limit)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, int64) []segment.UserSegment); ok {
+		r0 = rf(ctx, limit)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]segment.UserSegment)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, int64) error); ok {
+		r1 = rf(ctx, limit)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// SegmentRepository_DeleteUserSegmentsWithBadTTL_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'DeleteUserSegmentsWithBadTTL'
+type SegmentRepository_DeleteUserSegmentsWithBadTTL_Call struct {
+	*mock.Call
+}
+
+// DeleteUserSegmentsWithBadTTL is a helper method to define mock.On call
+//   - ctx context.Context
+//   - limit int64
+func (_e *SegmentRepository_Expecter) DeleteUserSegmentsWithBadTTL(ctx interface{}, limit interface{}) *SegmentRepository_DeleteUserSegmentsWithBadTTL_Call {
+	return &SegmentRepository_DeleteUserSegmentsWithBadTTL_Call{Call: _e.mock.On("DeleteUserSegmentsWithBadTTL", ctx, limit)}
+}
+
+func (_c *SegmentRepository_DeleteUserSegmentsWithBadTTL_Call) Run(run func(ctx context.Context, limit int64)) *SegmentRepository_DeleteUserSegmentsWithBadTTL_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(int64))
+	})
+	return _c
+}
+
+func (_c *SegmentRepository_DeleteUserSegmentsWithBadTTL_Call) Return(_a0 []segment.UserSegment, _a1 error) *SegmentRepository_DeleteUserSegmentsWithBadTTL_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *SegmentRepository_DeleteUserSegmentsWithBadTTL_Call) RunAndReturn(run func(context.Context, int64) ([]segment.UserSegment, error)) *SegmentRepository_DeleteUserSegmentsWithBadTTL_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// InTransaction provides a mock function with given fields: ctx, f
+func (_m *SegmentRepository) InTransaction(ctx context.Context, f func(context.Context) error) error {
+	ret := _m.Called(ctx, f)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, func(context.Context) error) error); ok {
+		r0 = rf(ctx, f)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// SegmentRepository_InTransaction_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'InTransaction'
+type SegmentRepository_InTransaction_Call struct {
+	*mock.Call
+}
+
+// InTransaction is a helper method to define mock.On call
+//   - ctx context.Context
+//   - f func(context.Context) error
+func (_e *SegmentRepository_Expecter) InTransaction(ctx interface{}, f interface{}) *SegmentRepository_InTransaction_Call {
+	return &SegmentRepository_InTransaction_Call{Call: _e.mock.On("InTransaction", ctx, f)}
+}
+
+func (_c *SegmentRepository_InTransaction_Call) Run(run func(ctx context.Context, f func(context.Context) error)) *SegmentRepository_InTransaction_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(func(context.Context) error))
+	})
+	return _c
+}
+
+func (_c *SegmentRepository_InTransaction_Call) Return(_a0 error) *SegmentRepository_InTransaction_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *SegmentRepository_InTransaction_Call) RunAndReturn(run func(context.Context, func(context.Context) error) error) *SegmentRepository_InTransaction_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// NewSegmentRepository creates a new instance of SegmentRepository. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewSegmentRepository(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *SegmentRepository {
+	mock := &SegmentRepository{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
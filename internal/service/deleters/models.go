@@ -0,0 +1,29 @@
+package deleters
+
+import "time"
+
+// LogRetention describes how long logs are stored before archiving. Operations without own retention use Default
+type LogRetention struct {
+	Default     time.Duration
+	ByOperation map[string]time.Duration
+}
+
+func (r LogRetention) For(operation string) time.Duration {
+	if retention, ok := r.ByOperation[operation]; ok && retention > 0 {
+		return retention
+	}
+
+	return r.Default
+}
+
+// Max returns the longest retention among all operations
+func (r LogRetention) Max() time.Duration {
+	maxRetention := r.Default
+	for _, retention := range r.ByOperation {
+		if retention > maxRetention {
+			maxRetention = retention
+		}
+	}
+
+	return maxRetention
+}
@@ -2,40 +2,269 @@ package deleters
 
 import (
 	"context"
+	"fmt"
+	"time"
+
+	"github.com/pollykon/avito_test_task/internal/metrics"
+	logRepository "github.com/pollykon/avito_test_task/internal/repository/log"
+	outboxRepository "github.com/pollykon/avito_test_task/internal/repository/outbox"
+	segmentRepository "github.com/pollykon/avito_test_task/internal/repository/segment"
 )
 
 type Cron struct {
-	segmentRepo SegmentRepository
-	logRepo     LogRepository
+	segmentRepo    SegmentRepository
+	logRepo        LogRepository
+	exportFileRepo ExportFileRepository
+	blobStorage    BlobStorage
+	outboxRepo     OutboxRepository
+	bucketRepo     RateLimitBucketRepository
+	now            func() time.Time
 }
 
-func New(segmentRepo SegmentRepository, logRepo LogRepository) *Cron {
-	return &Cron{segmentRepo: segmentRepo, logRepo: logRepo}
+func New(
+	segmentRepo SegmentRepository,
+	logRepo LogRepository,
+	exportFileRepo ExportFileRepository,
+	blobStorage BlobStorage,
+	outboxRepo OutboxRepository,
+	bucketRepo RateLimitBucketRepository,
+) *Cron {
+	return &Cron{
+		segmentRepo:    segmentRepo,
+		logRepo:        logRepo,
+		exportFileRepo: exportFileRepo,
+		blobStorage:    blobStorage,
+		outboxRepo:     outboxRepo,
+		bucketRepo:     bucketRepo,
+		now:            time.Now,
+	}
 }
 
 func (c *Cron) DeleteSegments(ctx context.Context, batchSize int64) error {
-	err := c.segmentRepo.DeleteSegments(ctx, batchSize)
+	deleted, err := c.deleteUserSegments(
+		ctx, c.segmentRepo.DeleteSegments, batchSize, outboxRepository.ReasonSegmentDeleted,
+	)
 	if err != nil {
 		return err
 	}
 
+	metrics.CronDeletedRows.WithLabelValues(jobDeleteSegments).Observe(float64(deleted))
 	return nil
 }
 
 func (c *Cron) DeleteTTLSegments(ctx context.Context, batchSize int64) error {
-	err := c.segmentRepo.DeleteUserSegmentsWithBadTTL(ctx, batchSize)
+	deleted, err := c.deleteUserSegments(
+		ctx, c.segmentRepo.DeleteUserSegmentsWithBadTTL, batchSize, outboxRepository.ReasonTTLExpired,
+	)
+	if err != nil {
+		return err
+	}
+
+	metrics.CronDeletedRows.WithLabelValues(jobDeleteTTLSegments).Observe(float64(deleted))
+	return nil
+}
+
+// deleteUserSegments deletes memberships with given function and saves events about it in the same transaction.
+// Returns number of deleted memberships
+func (c *Cron) deleteUserSegments(
+	ctx context.Context,
+	deleteFunc func(ctx context.Context, limit int64) ([]segmentRepository.UserSegment, error),
+	batchSize int64,
+	reason string,
+) (int, error) {
+	var deleted int
+	err := c.segmentRepo.InTransaction(ctx, func(ctx context.Context) error {
+		userSegments, err := deleteFunc(ctx, batchSize)
+		if err != nil {
+			return err
+		}
+		deleted = len(userSegments)
+
+		events := make([]outboxRepository.Event, 0, len(userSegments))
+		for _, userSegment := range userSegments {
+			events = append(events, outboxRepository.NewMembershipEvents(
+				outboxRepository.EventTypeUserDeletedFromSegment,
+				userSegment.UserID,
+				[]string{userSegment.SegmentID},
+				reason,
+			)...)
+		}
+
+		return c.outboxRepo.Add(ctx, events)
+	})
+	if err != nil {
+		return 0, err
+	}
+
+	return deleted, nil
+}
+
+// CreateLogPartitions creates partitions of logger table for current month and monthsAhead next ones
+func (c *Cron) CreateLogPartitions(ctx context.Context, monthsAhead int) error {
+	now := c.now().UTC()
+	currentMonth := time.Date(now.Year(), now.Month(), 1, 0, 0, 0, 0, time.UTC)
+
+	for i := 0; i <= monthsAhead; i++ {
+		err := c.logRepo.CreatePartition(ctx, currentMonth.AddDate(0, i, 0))
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// DeleteLogs archives logs older than retention to blob storage and deletes them. Returns number of deleted logs.
+// Partitions which are older than the longest retention are archived and dropped as a whole, logs of operations
+// with shorter retention are deleted in batches
+func (c *Cron) DeleteLogs(ctx context.Context, retention LogRetention, batchSize int64) (deleted int64, err error) {
+	defer func() { metrics.CronDeletedRows.WithLabelValues(jobDeleteLogs).Observe(float64(deleted)) }()
+
+	maxRetention := retention.Max()
+
+	deleted, err = c.dropExpiredLogPartitions(ctx, maxRetention, batchSize)
+	if err != nil {
+		return deleted, err
+	}
+
+	for _, operation := range logRepository.Operations {
+		if retention.For(operation) >= maxRetention {
+			continue
+		}
+
+		logs, err := c.logRepo.GetExpired(ctx, operation, retention.For(operation), batchSize)
+		if err != nil {
+			return deleted, err
+		}
+
+		if len(logs) == 0 {
+			continue
+		}
+
+		err = c.archiveLogs(ctx, operation, logs)
+		if err != nil {
+			return deleted, err
+		}
+
+		ids := make([]int64, 0, len(logs))
+		for _, log := range logs {
+			ids = append(ids, log.ID)
+		}
+
+		err = c.logRepo.Delete(ctx, ids)
+		if err != nil {
+			return deleted, err
+		}
+
+		deleted += int64(len(logs))
+	}
+
+	return deleted, nil
+}
+
+// dropExpiredLogPartitions archives and drops partitions which contain only logs older than retention
+func (c *Cron) dropExpiredLogPartitions(ctx context.Context, retention time.Duration, batchSize int64) (int64, error) {
+	partitions, err := c.logRepo.GetPartitions(ctx)
+	if err != nil {
+		return 0, err
+	}
+
+	expirationTime := c.now().Add(-retention)
+
+	var deleted int64
+	for _, partition := range partitions {
+		if partition.To.After(expirationTime) {
+			continue
+		}
+
+		var afterID int64
+		for {
+			logs, err := c.logRepo.GetPartitionLogs(ctx, partition, afterID, batchSize)
+			if err != nil {
+				return deleted, err
+			}
+
+			if len(logs) == 0 {
+				break
+			}
+
+			err = c.archiveLogs(ctx, partition.From.Format(logArchivePartitionLayout), logs)
+			if err != nil {
+				return deleted, err
+			}
+
+			afterID = logs[len(logs)-1].ID
+			deleted += int64(len(logs))
+		}
+
+		err = c.logRepo.DropPartition(ctx, partition)
+		if err != nil {
+			return deleted, err
+		}
+	}
+
+	return deleted, nil
+}
+
+// archiveLogs saves logs to blob storage. Archive name depends only on logs inside,
+// so retry after failed deletion overwrites the same file
+func (c *Cron) archiveLogs(ctx context.Context, key string, logs []logRepository.Log) error {
+	content, err := archiveLogs(logs)
 	if err != nil {
 		return err
 	}
 
+	name := fmt.Sprintf("%s%s_%d_%d%s", logArchivePrefix, key, logs[0].ID, logs[len(logs)-1].ID, logArchiveExtension)
+	err = c.blobStorage.Save(ctx, name, content)
+	if err != nil {
+		return fmt.Errorf("error while saving log archive: %w", err)
+	}
+
 	return nil
 }
 
-func (c *Cron) DeleteLogs(ctx context.Context, batchSize int64) error {
-	err := c.logRepo.Delete(ctx, batchSize)
+// DeleteExportFiles removes export files generated more than maxAge ago and returns number of reclaimed bytes
+func (c *Cron) DeleteExportFiles(ctx context.Context, maxAge time.Duration, batchSize int64) (int64, error) {
+	files, err := c.exportFileRepo.GetExpired(ctx, maxAge, batchSize)
+	if err != nil {
+		return 0, err
+	}
+
+	var reclaimedBytes int64
+	var errRemove error
+	fileNames := make([]string, 0, len(files))
+	for _, file := range files {
+		errRemove = c.blobStorage.Delete(ctx, file.FileName)
+		if errRemove != nil {
+			// metadata of already removed files is deleted anyway, the rest is retried on next run
+			break
+		}
+
+		reclaimedBytes += file.Size
+		fileNames = append(fileNames, file.FileName)
+	}
+
+	err = c.exportFileRepo.Delete(ctx, fileNames)
+	if err != nil {
+		return 0, err
+	}
+	metrics.CronDeletedRows.WithLabelValues(jobDeleteExportFiles).Observe(float64(len(fileNames)))
+
+	if errRemove != nil {
+		return reclaimedBytes, fmt.Errorf("error while removing export file: %w", errRemove)
+	}
+
+	return reclaimedBytes, nil
+}
+
+// DeleteRateLimitBuckets removes buckets of rate limits which are full, they are kept in database only
+// with postgres backend of rate limits
+func (c *Cron) DeleteRateLimitBuckets(ctx context.Context) error {
+	deleted, err := c.bucketRepo.DeleteFull(ctx)
 	if err != nil {
 		return err
 	}
 
+	metrics.CronDeletedRows.WithLabelValues(jobDeleteRateLimits).Observe(float64(deleted))
 	return nil
 }
@@ -0,0 +1,640 @@
+package deleters
+
+import (
+	"bytes"
+	"compress/gzip"
+	"context"
+	"fmt"
+	"io"
+	"testing"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	dto "github.com/prometheus/client_model/go"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/pollykon/avito_test_task/internal/metrics"
+	exportFileRepo "github.com/pollykon/avito_test_task/internal/repository/export_file"
+	logRepository "github.com/pollykon/avito_test_task/internal/repository/log"
+	outboxRepository "github.com/pollykon/avito_test_task/internal/repository/outbox"
+	segmentRepository "github.com/pollykon/avito_test_task/internal/repository/segment"
+	"github.com/pollykon/avito_test_task/internal/service/deleters/mocks"
+)
+
+func TestCron_DeleteSegments_Success(t *testing.T) {
+	batchSize := int64(100)
+
+	segmentRepoMock := mocks.NewSegmentRepository(t)
+	segmentRepoMock.EXPECT().InTransaction(context.Background(), mock.Anything).
+		RunAndReturn(func(ctx context.Context, f func(context.Context) error) error {
+			return f(ctx)
+		})
+	segmentRepoMock.EXPECT().DeleteSegments(context.Background(), batchSize).
+		Return([]segmentRepository.UserSegment{{UserID: 10, SegmentID: "AVITO"}, {UserID: 11, SegmentID: "AVITO"}}, nil)
+
+	firstUserID, secondUserID := int64(10), int64(11)
+	outboxRepoMock := mocks.NewOutboxRepository(t)
+	outboxRepoMock.EXPECT().Add(context.Background(), []outboxRepository.Event{
+		{
+			UserID:  &firstUserID,
+			Type:    outboxRepository.EventTypeUserDeletedFromSegment,
+			Payload: []byte(`{"userId":10,"segmentId":"AVITO","reason":"segment_deleted"}`),
+		},
+		{
+			UserID:  &secondUserID,
+			Type:    outboxRepository.EventTypeUserDeletedFromSegment,
+			Payload: []byte(`{"userId":11,"segmentId":"AVITO","reason":"segment_deleted"}`),
+		},
+	}).Return(nil)
+
+	cron := New(
+		segmentRepoMock,
+		mocks.NewLogRepository(t),
+		mocks.NewExportFileRepository(t),
+		mocks.NewBlobStorage(t),
+		outboxRepoMock,
+		mocks.NewRateLimitBucketRepository(t),
+	)
+
+	deletedBefore := deletedRows(t, jobDeleteSegments)
+
+	err := cron.DeleteSegments(context.Background(), batchSize)
+
+	assert.NoError(t, err)
+	assert.Equal(t, float64(2), deletedRows(t, jobDeleteSegments)-deletedBefore)
+}
+
+// deletedRows returns total number of rows deleted by job according to metrics
+func deletedRows(t *testing.T, job string) float64 {
+	var m dto.Metric
+	require.NoError(t, metrics.CronDeletedRows.WithLabelValues(job).(prometheus.Metric).Write(&m))
+	return m.GetHistogram().GetSampleSum()
+}
+
+func TestCron_DeleteTTLSegments_Success(t *testing.T) {
+	batchSize := int64(100)
+
+	segmentRepoMock := mocks.NewSegmentRepository(t)
+	segmentRepoMock.EXPECT().InTransaction(context.Background(), mock.Anything).
+		RunAndReturn(func(ctx context.Context, f func(context.Context) error) error {
+			return f(ctx)
+		})
+	segmentRepoMock.EXPECT().DeleteUserSegmentsWithBadTTL(context.Background(), batchSize).
+		Return([]segmentRepository.UserSegment{{UserID: 10, SegmentID: "AVITO"}}, nil)
+
+	userID := int64(10)
+	outboxRepoMock := mocks.NewOutboxRepository(t)
+	outboxRepoMock.EXPECT().Add(context.Background(), []outboxRepository.Event{{
+		UserID:  &userID,
+		Type:    outboxRepository.EventTypeUserDeletedFromSegment,
+		Payload: []byte(`{"userId":10,"segmentId":"AVITO","reason":"ttl_expired"}`),
+	}}).Return(nil)
+
+	cron := New(
+		segmentRepoMock,
+		mocks.NewLogRepository(t),
+		mocks.NewExportFileRepository(t),
+		mocks.NewBlobStorage(t),
+		outboxRepoMock,
+		mocks.NewRateLimitBucketRepository(t),
+	)
+
+	err := cron.DeleteTTLSegments(context.Background(), batchSize)
+
+	assert.NoError(t, err)
+}
+
+func TestCron_DeleteSegments_Error(t *testing.T) {
+	batchSize := int64(100)
+
+	errFromSegmentRepo := fmt.Errorf("error from segment repo")
+	errFromOutboxRepo := fmt.Errorf("error from outbox repo")
+
+	tt := []struct {
+		name string
+
+		buildSegmentRepoMock func(mock *mocks.SegmentRepository)
+		buildOutboxRepoMock  func(mock *mocks.OutboxRepository)
+
+		expectedError error
+	}{
+		{
+			name: "unexpected_error_from_segment_repo",
+
+			buildSegmentRepoMock: func(repo *mocks.SegmentRepository) {
+				repo.EXPECT().DeleteSegments(context.Background(), batchSize).Return(nil, errFromSegmentRepo)
+			},
+			buildOutboxRepoMock: nil,
+
+			expectedError: errFromSegmentRepo,
+		},
+		{
+			name: "unexpected_error_from_outbox_repo",
+
+			buildSegmentRepoMock: func(repo *mocks.SegmentRepository) {
+				repo.EXPECT().DeleteSegments(context.Background(), batchSize).
+					Return([]segmentRepository.UserSegment{{UserID: 10, SegmentID: "AVITO"}}, nil)
+			},
+			buildOutboxRepoMock: func(repo *mocks.OutboxRepository) {
+				repo.EXPECT().Add(context.Background(), mock.Anything).Return(errFromOutboxRepo)
+			},
+
+			expectedError: errFromOutboxRepo,
+		},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			segmentRepoMock := mocks.NewSegmentRepository(t)
+			segmentRepoMock.EXPECT().InTransaction(context.Background(), mock.Anything).
+				RunAndReturn(func(ctx context.Context, f func(context.Context) error) error {
+					return f(ctx)
+				})
+			if tc.buildSegmentRepoMock != nil {
+				tc.buildSegmentRepoMock(segmentRepoMock)
+			}
+
+			outboxRepoMock := mocks.NewOutboxRepository(t)
+			if tc.buildOutboxRepoMock != nil {
+				tc.buildOutboxRepoMock(outboxRepoMock)
+			}
+
+			cron := New(
+				segmentRepoMock,
+				mocks.NewLogRepository(t),
+				mocks.NewExportFileRepository(t),
+				mocks.NewBlobStorage(t),
+				outboxRepoMock,
+				mocks.NewRateLimitBucketRepository(t),
+			)
+
+			err := cron.DeleteSegments(context.Background(), batchSize)
+
+			assert.ErrorIs(t, err, tc.expectedError)
+		})
+	}
+}
+
+func TestCron_DeleteExportFiles_Success(t *testing.T) {
+	maxAge := 24 * time.Hour
+	batchSize := int64(100)
+
+	expiredFiles := []exportFileRepo.ExportFile{
+		{FileName: "first.csv", Size: 100},
+		{FileName: "second.csv", Size: 20},
+	}
+
+	exportFileRepoMock := mocks.NewExportFileRepository(t)
+	exportFileRepoMock.EXPECT().GetExpired(context.Background(), maxAge, batchSize).Return(expiredFiles, nil)
+	exportFileRepoMock.EXPECT().Delete(context.Background(), []string{"first.csv", "second.csv"}).Return(nil)
+
+	blobStorageMock := mocks.NewBlobStorage(t)
+	blobStorageMock.EXPECT().Delete(context.Background(), "first.csv").Return(nil)
+	blobStorageMock.EXPECT().Delete(context.Background(), "second.csv").Return(nil)
+
+	cron := New(
+		mocks.NewSegmentRepository(t),
+		mocks.NewLogRepository(t),
+		exportFileRepoMock,
+		blobStorageMock,
+		mocks.NewOutboxRepository(t),
+		mocks.NewRateLimitBucketRepository(t),
+	)
+
+	reclaimedBytes, err := cron.DeleteExportFiles(context.Background(), maxAge, batchSize)
+
+	assert.NoError(t, err)
+	assert.Equal(t, int64(120), reclaimedBytes)
+}
+
+func TestCron_DeleteExportFiles_Error(t *testing.T) {
+	maxAge := 24 * time.Hour
+	batchSize := int64(100)
+
+	expiredFiles := []exportFileRepo.ExportFile{
+		{FileName: "first.csv", Size: 100},
+		{FileName: "second.csv", Size: 20},
+	}
+
+	errFromExportFileRepo := fmt.Errorf("error from export file repo")
+	errFromBlobStorage := fmt.Errorf("error from blob storage")
+
+	tt := []struct {
+		name string
+
+		buildExportFileRepoMock func(mock *mocks.ExportFileRepository)
+		buildBlobStorageMock    func(mock *mocks.BlobStorage)
+
+		expectedReclaimedBytes int64
+		expectedError          error
+	}{
+		{
+			name: "unexpected_error_from_get_expired",
+
+			buildExportFileRepoMock: func(repo *mocks.ExportFileRepository) {
+				repo.EXPECT().GetExpired(context.Background(), maxAge, batchSize).Return(nil, errFromExportFileRepo)
+			},
+			buildBlobStorageMock: nil,
+
+			expectedReclaimedBytes: 0,
+			expectedError:          errFromExportFileRepo,
+		},
+		{
+			name: "unexpected_error_from_blob_storage",
+
+			buildExportFileRepoMock: func(repo *mocks.ExportFileRepository) {
+				repo.EXPECT().GetExpired(context.Background(), maxAge, batchSize).Return(expiredFiles, nil)
+				repo.EXPECT().Delete(context.Background(), []string{"first.csv"}).Return(nil)
+			},
+			buildBlobStorageMock: func(storage *mocks.BlobStorage) {
+				storage.EXPECT().Delete(context.Background(), "first.csv").Return(nil)
+				storage.EXPECT().Delete(context.Background(), "second.csv").Return(errFromBlobStorage)
+			},
+
+			expectedReclaimedBytes: 100,
+			expectedError:          errFromBlobStorage,
+		},
+		{
+			name: "unexpected_error_from_delete",
+
+			buildExportFileRepoMock: func(repo *mocks.ExportFileRepository) {
+				repo.EXPECT().GetExpired(context.Background(), maxAge, batchSize).Return(expiredFiles, nil)
+				repo.EXPECT().Delete(context.Background(), []string{"first.csv", "second.csv"}).
+					Return(errFromExportFileRepo)
+			},
+			buildBlobStorageMock: func(storage *mocks.BlobStorage) {
+				storage.EXPECT().Delete(context.Background(), "first.csv").Return(nil)
+				storage.EXPECT().Delete(context.Background(), "second.csv").Return(nil)
+			},
+
+			expectedReclaimedBytes: 0,
+			expectedError:          errFromExportFileRepo,
+		},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			exportFileRepoMock := mocks.NewExportFileRepository(t)
+			if tc.buildExportFileRepoMock != nil {
+				tc.buildExportFileRepoMock(exportFileRepoMock)
+			}
+
+			blobStorageMock := mocks.NewBlobStorage(t)
+			if tc.buildBlobStorageMock != nil {
+				tc.buildBlobStorageMock(blobStorageMock)
+			}
+
+			cron := New(
+				mocks.NewSegmentRepository(t),
+				mocks.NewLogRepository(t),
+				exportFileRepoMock,
+				blobStorageMock,
+				mocks.NewOutboxRepository(t),
+				mocks.NewRateLimitBucketRepository(t),
+			)
+
+			reclaimedBytes, err := cron.DeleteExportFiles(context.Background(), maxAge, batchSize)
+
+			assert.ErrorIs(t, err, tc.expectedError)
+			assert.Equal(t, tc.expectedReclaimedBytes, reclaimedBytes)
+		})
+	}
+}
+
+func TestCron_DeleteRateLimitBuckets(t *testing.T) {
+	errFromBucketRepo := fmt.Errorf("error from rate limit bucket repo")
+
+	tt := []struct {
+		name string
+
+		buildBucketRepoMock func(mock *mocks.RateLimitBucketRepository)
+
+		expectedDeletedRows float64
+		expectedError       error
+	}{
+		{
+			name: "success",
+
+			buildBucketRepoMock: func(repo *mocks.RateLimitBucketRepository) {
+				repo.EXPECT().DeleteFull(context.Background()).Return(3, nil)
+			},
+
+			expectedDeletedRows: 3,
+			expectedError:       nil,
+		},
+		{
+			name: "unexpected_error_from_delete_full",
+
+			buildBucketRepoMock: func(repo *mocks.RateLimitBucketRepository) {
+				repo.EXPECT().DeleteFull(context.Background()).Return(0, errFromBucketRepo)
+			},
+
+			expectedDeletedRows: 0,
+			expectedError:       errFromBucketRepo,
+		},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			bucketRepoMock := mocks.NewRateLimitBucketRepository(t)
+			tc.buildBucketRepoMock(bucketRepoMock)
+
+			cron := New(
+				mocks.NewSegmentRepository(t),
+				mocks.NewLogRepository(t),
+				mocks.NewExportFileRepository(t),
+				mocks.NewBlobStorage(t),
+				mocks.NewOutboxRepository(t),
+				bucketRepoMock,
+			)
+
+			deletedBefore := deletedRows(t, jobDeleteRateLimits)
+
+			err := cron.DeleteRateLimitBuckets(context.Background())
+
+			assert.ErrorIs(t, err, tc.expectedError)
+			assert.Equal(t, tc.expectedDeletedRows, deletedRows(t, jobDeleteRateLimits)-deletedBefore)
+		})
+	}
+}
+
+func TestCron_CreateLogPartitions_Success(t *testing.T) {
+	logRepoMock := mocks.NewLogRepository(t)
+	logRepoMock.EXPECT().CreatePartition(context.Background(), time.Date(2023, 11, 1, 0, 0, 0, 0, time.UTC)).Return(nil)
+	logRepoMock.EXPECT().CreatePartition(context.Background(), time.Date(2023, 12, 1, 0, 0, 0, 0, time.UTC)).Return(nil)
+	logRepoMock.EXPECT().CreatePartition(context.Background(), time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)).Return(nil)
+
+	cron := New(
+		mocks.NewSegmentRepository(t),
+		logRepoMock,
+		mocks.NewExportFileRepository(t),
+		mocks.NewBlobStorage(t),
+		mocks.NewOutboxRepository(t),
+		mocks.NewRateLimitBucketRepository(t),
+	)
+	cron.now = func() time.Time { return time.Date(2023, 11, 15, 12, 0, 0, 0, time.UTC) }
+
+	err := cron.CreateLogPartitions(context.Background(), 2)
+
+	assert.NoError(t, err)
+}
+
+func TestCron_CreateLogPartitions_Error(t *testing.T) {
+	errFromLogRepo := fmt.Errorf("error from log repo")
+
+	logRepoMock := mocks.NewLogRepository(t)
+	logRepoMock.EXPECT().CreatePartition(context.Background(), time.Date(2023, 11, 1, 0, 0, 0, 0, time.UTC)).
+		Return(errFromLogRepo)
+
+	cron := New(
+		mocks.NewSegmentRepository(t),
+		logRepoMock,
+		mocks.NewExportFileRepository(t),
+		mocks.NewBlobStorage(t),
+		mocks.NewOutboxRepository(t),
+		mocks.NewRateLimitBucketRepository(t),
+	)
+	cron.now = func() time.Time { return time.Date(2023, 11, 15, 12, 0, 0, 0, time.UTC) }
+
+	err := cron.CreateLogPartitions(context.Background(), 2)
+
+	assert.ErrorIs(t, err, errFromLogRepo)
+}
+
+func TestCron_DeleteLogs_Success(t *testing.T) {
+	retention := LogRetention{
+		Default:     90 * 24 * time.Hour,
+		ByOperation: map[string]time.Duration{logRepository.OperationTypeDelete: 30 * 24 * time.Hour},
+	}
+	batchSize := int64(2)
+	insertTime := time.Date(2023, 5, 1, 0, 0, 0, 0, time.UTC)
+
+	expiredPartition := logRepository.Partition{
+		Name: "log_2023_05",
+		From: time.Date(2023, 5, 1, 0, 0, 0, 0, time.UTC),
+		To:   time.Date(2023, 6, 1, 0, 0, 0, 0, time.UTC),
+	}
+	actualPartition := logRepository.Partition{
+		Name: "log_2023_06",
+		From: time.Date(2023, 6, 1, 0, 0, 0, 0, time.UTC),
+		To:   time.Date(2023, 7, 1, 0, 0, 0, 0, time.UTC),
+	}
+
+	logRepoMock := mocks.NewLogRepository(t)
+	logRepoMock.EXPECT().GetPartitions(context.Background()).
+		Return([]logRepository.Partition{expiredPartition, actualPartition}, nil)
+	logRepoMock.EXPECT().GetPartitionLogs(context.Background(), expiredPartition, int64(0), batchSize).
+		Return([]logRepository.Log{
+			{ID: 1, UserID: 10, SegmentID: "AVITO", Operation: logRepository.OperationTypeAdd, InsertTime: insertTime},
+			{
+				ID: 2, UserID: 11, SegmentID: "AVITO", Operation: logRepository.OperationTypeAdd, Actor: "api_key:12",
+				InsertTime: insertTime,
+			},
+		}, nil)
+	logRepoMock.EXPECT().GetPartitionLogs(context.Background(), expiredPartition, int64(2), batchSize).
+		Return([]logRepository.Log{{ID: 3, UserID: 10, SegmentID: "AVITO", Operation: logRepository.OperationTypeDelete}}, nil)
+	logRepoMock.EXPECT().GetPartitionLogs(context.Background(), expiredPartition, int64(3), batchSize).
+		Return(nil, nil)
+	logRepoMock.EXPECT().DropPartition(context.Background(), expiredPartition).Return(nil)
+	logRepoMock.EXPECT().GetExpired(context.Background(), logRepository.OperationTypeDelete, 30*24*time.Hour, batchSize).
+		Return([]logRepository.Log{{ID: 7, UserID: 12, SegmentID: "AVITO", Operation: logRepository.OperationTypeDelete}}, nil)
+	logRepoMock.EXPECT().Delete(context.Background(), []int64{7}).Return(nil)
+
+	var archive []byte
+	blobStorageMock := mocks.NewBlobStorage(t)
+	blobStorageMock.EXPECT().Save(context.Background(), "log_archive_2023_05_1_2.ndjson.gz", mock.Anything).
+		Run(func(_ context.Context, _ string, content []byte) { archive = content }).
+		Return(nil)
+	blobStorageMock.EXPECT().Save(context.Background(), "log_archive_2023_05_3_3.ndjson.gz", mock.Anything).Return(nil)
+	blobStorageMock.EXPECT().Save(context.Background(), "log_archive_delete_7_7.ndjson.gz", mock.Anything).Return(nil)
+
+	cron := New(
+		mocks.NewSegmentRepository(t),
+		logRepoMock,
+		mocks.NewExportFileRepository(t),
+		blobStorageMock,
+		mocks.NewOutboxRepository(t),
+		mocks.NewRateLimitBucketRepository(t),
+	)
+	cron.now = func() time.Time { return time.Date(2023, 9, 15, 0, 0, 0, 0, time.UTC) }
+
+	deleted, err := cron.DeleteLogs(context.Background(), retention, batchSize)
+
+	assert.NoError(t, err)
+	assert.Equal(t, int64(4), deleted)
+
+	reader, err := gzip.NewReader(bytes.NewReader(archive))
+	assert.NoError(t, err)
+	content, err := io.ReadAll(reader)
+	assert.NoError(t, err)
+	assert.Equal(t,
+		`{"logId":1,"userId":10,"segmentId":"AVITO","operation":"add","insertTime":"2023-05-01T00:00:00Z"}`+"\n"+
+			`{"logId":2,"userId":11,"segmentId":"AVITO","operation":"add","actor":"api_key:12","insertTime":"2023-05-01T00:00:00Z"}`+"\n",
+		string(content),
+	)
+}
+
+func TestCron_DeleteLogs_Error(t *testing.T) {
+	retention := LogRetention{
+		Default:     24 * time.Hour,
+		ByOperation: map[string]time.Duration{logRepository.OperationTypeDelete: time.Hour},
+	}
+	batchSize := int64(100)
+
+	partition := logRepository.Partition{
+		Name: "log_2023_05",
+		From: time.Date(2023, 5, 1, 0, 0, 0, 0, time.UTC),
+		To:   time.Date(2023, 6, 1, 0, 0, 0, 0, time.UTC),
+	}
+	partitionLogs := []logRepository.Log{{ID: 1, UserID: 10, SegmentID: "AVITO", Operation: logRepository.OperationTypeAdd}}
+	deleteLogs := []logRepository.Log{{ID: 7, UserID: 10, SegmentID: "AVITO", Operation: logRepository.OperationTypeDelete}}
+
+	errFromLogRepo := fmt.Errorf("error from log repo")
+	errFromBlobStorage := fmt.Errorf("error from blob storage")
+
+	tt := []struct {
+		name string
+
+		buildLogRepoMock     func(mock *mocks.LogRepository)
+		buildBlobStorageMock func(mock *mocks.BlobStorage)
+
+		expectedDeleted int64
+		expectedError   error
+	}{
+		{
+			name: "unexpected_error_from_get_partitions",
+
+			buildLogRepoMock: func(repo *mocks.LogRepository) {
+				repo.EXPECT().GetPartitions(context.Background()).Return(nil, errFromLogRepo)
+			},
+			buildBlobStorageMock: nil,
+
+			expectedDeleted: 0,
+			expectedError:   errFromLogRepo,
+		},
+		{
+			name: "unexpected_error_from_get_partition_logs",
+
+			buildLogRepoMock: func(repo *mocks.LogRepository) {
+				repo.EXPECT().GetPartitions(context.Background()).Return([]logRepository.Partition{partition}, nil)
+				repo.EXPECT().GetPartitionLogs(context.Background(), partition, int64(0), batchSize).
+					Return(nil, errFromLogRepo)
+			},
+			buildBlobStorageMock: nil,
+
+			expectedDeleted: 0,
+			expectedError:   errFromLogRepo,
+		},
+		{
+			name: "unexpected_error_from_blob_storage_while_archiving_partition",
+
+			buildLogRepoMock: func(repo *mocks.LogRepository) {
+				repo.EXPECT().GetPartitions(context.Background()).Return([]logRepository.Partition{partition}, nil)
+				repo.EXPECT().GetPartitionLogs(context.Background(), partition, int64(0), batchSize).
+					Return(partitionLogs, nil)
+			},
+			buildBlobStorageMock: func(storage *mocks.BlobStorage) {
+				storage.EXPECT().Save(context.Background(), "log_archive_2023_05_1_1.ndjson.gz", mock.Anything).
+					Return(errFromBlobStorage)
+			},
+
+			expectedDeleted: 0,
+			expectedError:   errFromBlobStorage,
+		},
+		{
+			name: "unexpected_error_from_drop_partition",
+
+			buildLogRepoMock: func(repo *mocks.LogRepository) {
+				repo.EXPECT().GetPartitions(context.Background()).Return([]logRepository.Partition{partition}, nil)
+				repo.EXPECT().GetPartitionLogs(context.Background(), partition, int64(0), batchSize).
+					Return(partitionLogs, nil)
+				repo.EXPECT().GetPartitionLogs(context.Background(), partition, int64(1), batchSize).
+					Return(nil, nil)
+				repo.EXPECT().DropPartition(context.Background(), partition).Return(errFromLogRepo)
+			},
+			buildBlobStorageMock: func(storage *mocks.BlobStorage) {
+				storage.EXPECT().Save(context.Background(), "log_archive_2023_05_1_1.ndjson.gz", mock.Anything).
+					Return(nil)
+			},
+
+			expectedDeleted: 1,
+			expectedError:   errFromLogRepo,
+		},
+		{
+			name: "unexpected_error_from_get_expired",
+
+			buildLogRepoMock: func(repo *mocks.LogRepository) {
+				repo.EXPECT().GetPartitions(context.Background()).Return(nil, nil)
+				repo.EXPECT().GetExpired(context.Background(), logRepository.OperationTypeDelete, time.Hour, batchSize).
+					Return(nil, errFromLogRepo)
+			},
+			buildBlobStorageMock: nil,
+
+			expectedDeleted: 0,
+			expectedError:   errFromLogRepo,
+		},
+		{
+			name: "unexpected_error_from_blob_storage",
+
+			buildLogRepoMock: func(repo *mocks.LogRepository) {
+				repo.EXPECT().GetPartitions(context.Background()).Return(nil, nil)
+				repo.EXPECT().GetExpired(context.Background(), logRepository.OperationTypeDelete, time.Hour, batchSize).
+					Return(deleteLogs, nil)
+			},
+			buildBlobStorageMock: func(storage *mocks.BlobStorage) {
+				storage.EXPECT().Save(context.Background(), "log_archive_delete_7_7.ndjson.gz", mock.Anything).
+					Return(errFromBlobStorage)
+			},
+
+			expectedDeleted: 0,
+			expectedError:   errFromBlobStorage,
+		},
+		{
+			name: "unexpected_error_from_delete",
+
+			buildLogRepoMock: func(repo *mocks.LogRepository) {
+				repo.EXPECT().GetPartitions(context.Background()).Return(nil, nil)
+				repo.EXPECT().GetExpired(context.Background(), logRepository.OperationTypeDelete, time.Hour, batchSize).
+					Return(deleteLogs, nil)
+				repo.EXPECT().Delete(context.Background(), []int64{7}).Return(errFromLogRepo)
+			},
+			buildBlobStorageMock: func(storage *mocks.BlobStorage) {
+				storage.EXPECT().Save(context.Background(), "log_archive_delete_7_7.ndjson.gz", mock.Anything).Return(nil)
+			},
+
+			expectedDeleted: 0,
+			expectedError:   errFromLogRepo,
+		},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			logRepoMock := mocks.NewLogRepository(t)
+			if tc.buildLogRepoMock != nil {
+				tc.buildLogRepoMock(logRepoMock)
+			}
+
+			blobStorageMock := mocks.NewBlobStorage(t)
+			if tc.buildBlobStorageMock != nil {
+				tc.buildBlobStorageMock(blobStorageMock)
+			}
+
+			cron := New(
+				mocks.NewSegmentRepository(t),
+				logRepoMock,
+				mocks.NewExportFileRepository(t),
+				blobStorageMock,
+				mocks.NewOutboxRepository(t),
+				mocks.NewRateLimitBucketRepository(t),
+			)
+			cron.now = func() time.Time { return time.Date(2023, 9, 15, 0, 0, 0, 0, time.UTC) }
+
+			deleted, err := cron.DeleteLogs(context.Background(), retention, batchSize)
+
+			assert.ErrorIs(t, err, tc.expectedError)
+			assert.Equal(t, tc.expectedDeleted, deleted)
+		})
+	}
+}
@@ -0,0 +1,25 @@
+package health
+
+import (
+	"errors"
+	"time"
+)
+
+// SchemaVersion is a version of migration.sql which service works with, it must be increased with schema_version
+const SchemaVersion = 3
+
+// checkTimeout limits duration of each check, orchestrator shouldn't wait for hanging database
+const checkTimeout = 2 * time.Second
+
+const (
+	CheckShutdown        = "shutdown"
+	CheckDatabase        = "database"
+	CheckSchema          = "schema"
+	CheckExportDirectory = "exportDirectory"
+	CheckNotifications   = "notifications"
+)
+
+var (
+	ErrShuttingDown   = errors.New("service is shutting down")
+	ErrSchemaOutdated = errors.New("schema is outdated")
+)
@@ -0,0 +1,17 @@
+//go:generate mockery --all --output ./mocks --case underscore --with-expecter
+package health
+
+import "context"
+
+type SchemaRepository interface {
+	Ping(ctx context.Context) error
+	GetVersion(ctx context.Context) (int64, error)
+}
+
+type ExportDirectory interface {
+	CheckWritable(ctx context.Context) error
+}
+
+type Notifications interface {
+	CheckListening(ctx context.Context) error
+}
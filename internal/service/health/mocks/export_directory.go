@@ -0,0 +1,78 @@
+// Code generated by mockery v2.33.0. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+
+	mock "github.com/stretchr/testify/mock"
+)
+
+// ExportDirectory is an autogenerated mock type for the ExportDirectory type
+type ExportDirectory struct {
+	mock.Mock
+}
+
+type ExportDirectory_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *ExportDirectory) EXPECT() *ExportDirectory_Expecter {
+	return &ExportDirectory_Expecter{mock: &_m.Mock}
+}
+
+// CheckWritable provides a mock function with given fields: ctx
+func (_m *ExportDirectory) CheckWritable(ctx context.Context) error {
+	ret := _m.Called(ctx)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context) error); ok {
+		r0 = rf(ctx)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// ExportDirectory_CheckWritable_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'CheckWritable'
+type ExportDirectory_CheckWritable_Call struct {
+	*mock.Call
+}
+
+// CheckWritable is a helper method to define mock.On call
+//   - ctx context.Context
+func (_e *ExportDirectory_Expecter) CheckWritable(ctx interface{}) *ExportDirectory_CheckWritable_Call {
+	return &ExportDirectory_CheckWritable_Call{Call: _e.mock.On("CheckWritable", ctx)}
+}
+
+func (_c *ExportDirectory_CheckWritable_Call) Run(run func(ctx context.Context)) *ExportDirectory_CheckWritable_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context))
+	})
+	return _c
+}
+
+func (_c *ExportDirectory_CheckWritable_Call) Return(_a0 error) *ExportDirectory_CheckWritable_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *ExportDirectory_CheckWritable_Call) RunAndReturn(run func(context.Context) error) *ExportDirectory_CheckWritable_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// NewExportDirectory creates a new instance of ExportDirectory. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewExportDirectory(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *ExportDirectory {
+	mock := &ExportDirectory{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
@@ -0,0 +1,78 @@
+// Code generated by mockery v2.33.0. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+
+	mock "github.com/stretchr/testify/mock"
+)
+
+// Notifications is an autogenerated mock type for the Notifications type
+type Notifications struct {
+	mock.Mock
+}
+
+type Notifications_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *Notifications) EXPECT() *Notifications_Expecter {
+	return &Notifications_Expecter{mock: &_m.Mock}
+}
+
+// CheckListening provides a mock function with given fields: ctx
+func (_m *Notifications) CheckListening(ctx context.Context) error {
+	ret := _m.Called(ctx)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context) error); ok {
+		r0 = rf(ctx)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// Notifications_CheckListening_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'CheckListening'
+type Notifications_CheckListening_Call struct {
+	*mock.Call
+}
+
+// CheckListening is a helper method to define mock.On call
+//   - ctx context.Context
+func (_e *Notifications_Expecter) CheckListening(ctx interface{}) *Notifications_CheckListening_Call {
+	return &Notifications_CheckListening_Call{Call: _e.mock.On("CheckListening", ctx)}
+}
+
+func (_c *Notifications_CheckListening_Call) Run(run func(ctx context.Context)) *Notifications_CheckListening_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context))
+	})
+	return _c
+}
+
+func (_c *Notifications_CheckListening_Call) Return(_a0 error) *Notifications_CheckListening_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *Notifications_CheckListening_Call) RunAndReturn(run func(context.Context) error) *Notifications_CheckListening_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// NewNotifications creates a new instance of Notifications. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewNotifications(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *Notifications {
+	mock := &Notifications{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
@@ -0,0 +1,22 @@
+package health
+
+// Check is a result of one readiness check, Error is nil if check passed
+type Check struct {
+	Name  string
+	Error error
+}
+
+// Report contains results of readiness checks in order they were made
+type Report struct {
+	Checks []Check
+}
+
+// Ready is true if all checks passed
+func (r Report) Ready() bool {
+	for _, check := range r.Checks {
+		if check.Error != nil {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,71 @@
+package health
+
+import (
+	"context"
+	"fmt"
+	"sync/atomic"
+)
+
+type Service struct {
+	schemaRepo      SchemaRepository
+	exportDirectory ExportDirectory
+	notifications   Notifications
+	draining        *atomic.Bool
+}
+
+// New creates service which checks readiness. exportDirectory is nil if export files aren't stored on local disk,
+// notifications is nil if service doesn't listen to outbox notifications
+func New(schemaRepo SchemaRepository, exportDirectory ExportDirectory, notifications Notifications) Service {
+	return Service{
+		schemaRepo:      schemaRepo,
+		exportDirectory: exportDirectory,
+		notifications:   notifications,
+		draining:        &atomic.Bool{},
+	}
+}
+
+// Drain makes service not ready, so that orchestrator stops sending traffic before server shuts down
+func (s Service) Drain() {
+	s.draining.Store(true)
+}
+
+// Ready checks that service can serve requests: it isn't shutting down, database is reachable and migrated
+// to SchemaVersion, export files can be written and outbox notifications are listened
+func (s Service) Ready(ctx context.Context) Report {
+	if s.draining.Load() {
+		return Report{Checks: []Check{{Name: CheckShutdown, Error: ErrShuttingDown}}}
+	}
+
+	checks := []Check{
+		{Name: CheckDatabase, Error: s.check(ctx, s.schemaRepo.Ping)},
+		{Name: CheckSchema, Error: s.check(ctx, s.checkSchema)},
+	}
+	if s.exportDirectory != nil {
+		checks = append(checks, Check{Name: CheckExportDirectory, Error: s.check(ctx, s.exportDirectory.CheckWritable)})
+	}
+	if s.notifications != nil {
+		checks = append(checks, Check{Name: CheckNotifications, Error: s.check(ctx, s.notifications.CheckListening)})
+	}
+
+	return Report{Checks: checks}
+}
+
+func (s Service) check(ctx context.Context, f func(ctx context.Context) error) error {
+	ctx, cancel := context.WithTimeout(ctx, checkTimeout)
+	defer cancel()
+
+	return f(ctx)
+}
+
+func (s Service) checkSchema(ctx context.Context) error {
+	version, err := s.schemaRepo.GetVersion(ctx)
+	if err != nil {
+		return fmt.Errorf("error from health service while getting schema version: %w", err)
+	}
+
+	if version < SchemaVersion {
+		return fmt.Errorf("%w: version %d, expected %d", ErrSchemaOutdated, version, SchemaVersion)
+	}
+
+	return nil
+}
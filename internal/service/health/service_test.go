@@ -0,0 +1,189 @@
+package health
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+
+	"github.com/pollykon/avito_test_task/internal/service/health/mocks"
+)
+
+func TestService_Ready(t *testing.T) {
+	errFromRepo := fmt.Errorf("error from repo")
+	errFromDirectory := fmt.Errorf("error from directory")
+	errFromNotifications := fmt.Errorf("error from notifications")
+
+	tt := []struct {
+		name string
+
+		draining bool
+
+		buildSchemaRepoMock      func(repo *mocks.SchemaRepository)
+		buildExportDirectoryMock func(directory *mocks.ExportDirectory)
+		buildNotificationsMock   func(notifications *mocks.Notifications)
+
+		expectedReady  bool
+		expectedChecks []string
+		expectedErrors map[string]error
+	}{
+		{
+			name: "ready",
+
+			buildSchemaRepoMock: func(repo *mocks.SchemaRepository) {
+				repo.EXPECT().Ping(mock.Anything).Return(nil)
+				repo.EXPECT().GetVersion(mock.Anything).Return(SchemaVersion, nil)
+			},
+			buildExportDirectoryMock: func(directory *mocks.ExportDirectory) {
+				directory.EXPECT().CheckWritable(mock.Anything).Return(nil)
+			},
+
+			expectedReady:  true,
+			expectedChecks: []string{CheckDatabase, CheckSchema, CheckExportDirectory},
+		},
+		{
+			name: "without_export_directory",
+
+			buildSchemaRepoMock: func(repo *mocks.SchemaRepository) {
+				repo.EXPECT().Ping(mock.Anything).Return(nil)
+				repo.EXPECT().GetVersion(mock.Anything).Return(SchemaVersion+1, nil)
+			},
+			buildExportDirectoryMock: nil,
+
+			expectedReady:  true,
+			expectedChecks: []string{CheckDatabase, CheckSchema},
+		},
+		{
+			name: "database_unavailable",
+
+			buildSchemaRepoMock: func(repo *mocks.SchemaRepository) {
+				repo.EXPECT().Ping(mock.Anything).Return(errFromRepo)
+				repo.EXPECT().GetVersion(mock.Anything).Return(0, errFromRepo)
+			},
+			buildExportDirectoryMock: func(directory *mocks.ExportDirectory) {
+				directory.EXPECT().CheckWritable(mock.Anything).Return(nil)
+			},
+
+			expectedReady:  false,
+			expectedChecks: []string{CheckDatabase, CheckSchema, CheckExportDirectory},
+			expectedErrors: map[string]error{CheckDatabase: errFromRepo, CheckSchema: errFromRepo},
+		},
+		{
+			name: "schema_outdated",
+
+			buildSchemaRepoMock: func(repo *mocks.SchemaRepository) {
+				repo.EXPECT().Ping(mock.Anything).Return(nil)
+				repo.EXPECT().GetVersion(mock.Anything).Return(SchemaVersion-1, nil)
+			},
+			buildExportDirectoryMock: func(directory *mocks.ExportDirectory) {
+				directory.EXPECT().CheckWritable(mock.Anything).Return(nil)
+			},
+
+			expectedReady:  false,
+			expectedChecks: []string{CheckDatabase, CheckSchema, CheckExportDirectory},
+			expectedErrors: map[string]error{CheckSchema: ErrSchemaOutdated},
+		},
+		{
+			name: "export_directory_not_writable",
+
+			buildSchemaRepoMock: func(repo *mocks.SchemaRepository) {
+				repo.EXPECT().Ping(mock.Anything).Return(nil)
+				repo.EXPECT().GetVersion(mock.Anything).Return(SchemaVersion, nil)
+			},
+			buildExportDirectoryMock: func(directory *mocks.ExportDirectory) {
+				directory.EXPECT().CheckWritable(mock.Anything).Return(errFromDirectory)
+			},
+
+			expectedReady:  false,
+			expectedChecks: []string{CheckDatabase, CheckSchema, CheckExportDirectory},
+			expectedErrors: map[string]error{CheckExportDirectory: errFromDirectory},
+		},
+		{
+			name: "with_notifications",
+
+			buildSchemaRepoMock: func(repo *mocks.SchemaRepository) {
+				repo.EXPECT().Ping(mock.Anything).Return(nil)
+				repo.EXPECT().GetVersion(mock.Anything).Return(SchemaVersion, nil)
+			},
+			buildExportDirectoryMock: nil,
+			buildNotificationsMock: func(notifications *mocks.Notifications) {
+				notifications.EXPECT().CheckListening(mock.Anything).Return(nil)
+			},
+
+			expectedReady:  true,
+			expectedChecks: []string{CheckDatabase, CheckSchema, CheckNotifications},
+		},
+		{
+			name: "notifications_not_listened",
+
+			buildSchemaRepoMock: func(repo *mocks.SchemaRepository) {
+				repo.EXPECT().Ping(mock.Anything).Return(nil)
+				repo.EXPECT().GetVersion(mock.Anything).Return(SchemaVersion, nil)
+			},
+			buildExportDirectoryMock: nil,
+			buildNotificationsMock: func(notifications *mocks.Notifications) {
+				notifications.EXPECT().CheckListening(mock.Anything).Return(errFromNotifications)
+			},
+
+			expectedReady:  false,
+			expectedChecks: []string{CheckDatabase, CheckSchema, CheckNotifications},
+			expectedErrors: map[string]error{CheckNotifications: errFromNotifications},
+		},
+		{
+			name: "draining",
+
+			draining: true,
+
+			buildSchemaRepoMock:      nil,
+			buildExportDirectoryMock: nil,
+
+			expectedReady:  false,
+			expectedChecks: []string{CheckShutdown},
+			expectedErrors: map[string]error{CheckShutdown: ErrShuttingDown},
+		},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			schemaRepoMock := mocks.NewSchemaRepository(t)
+			if tc.buildSchemaRepoMock != nil {
+				tc.buildSchemaRepoMock(schemaRepoMock)
+			}
+
+			var exportDirectory ExportDirectory
+			if tc.buildExportDirectoryMock != nil {
+				exportDirectoryMock := mocks.NewExportDirectory(t)
+				tc.buildExportDirectoryMock(exportDirectoryMock)
+				exportDirectory = exportDirectoryMock
+			}
+
+			var notifications Notifications
+			if tc.buildNotificationsMock != nil {
+				notificationsMock := mocks.NewNotifications(t)
+				tc.buildNotificationsMock(notificationsMock)
+				notifications = notificationsMock
+			}
+
+			service := New(schemaRepoMock, exportDirectory, notifications)
+			if tc.draining {
+				service.Drain()
+			}
+
+			report := service.Ready(context.Background())
+
+			assert.Equal(t, tc.expectedReady, report.Ready())
+			checks := make([]string, 0, len(report.Checks))
+			for _, check := range report.Checks {
+				checks = append(checks, check.Name)
+				if expectedError := tc.expectedErrors[check.Name]; expectedError != nil {
+					assert.ErrorIs(t, check.Error, expectedError)
+				} else {
+					assert.NoError(t, check.Error)
+				}
+			}
+			assert.Equal(t, tc.expectedChecks, checks)
+		})
+	}
+}
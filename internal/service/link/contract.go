@@ -0,0 +1,15 @@
+//go:generate mockery --all --output ./mocks --case underscore --with-expecter
+package link
+
+import (
+	"net/url"
+	"time"
+)
+
+type URLSigner interface {
+	Sign(fileName string) url.Values
+}
+
+type Presigner interface {
+	PresignGet(name string, ttl time.Duration) string
+}
@@ -0,0 +1,79 @@
+// Code generated by mockery v2.33.0. DO NOT EDIT.
+
+package mocks
+
+import (
+	time "time"
+
+	mock "github.com/stretchr/testify/mock"
+)
+
+// Presigner is an autogenerated mock type for the Presigner type
+type Presigner struct {
+	mock.Mock
+}
+
+type Presigner_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *Presigner) EXPECT() *Presigner_Expecter {
+	return &Presigner_Expecter{mock: &_m.Mock}
+}
+
+// PresignGet provides a mock function with given fields: name, ttl
+func (_m *Presigner) PresignGet(name string, ttl time.Duration) string {
+	ret := _m.Called(name, ttl)
+
+	var r0 string
+	if rf, ok := ret.Get(0).(func(string, time.Duration) string); ok {
+		r0 = rf(name, ttl)
+	} else {
+		r0 = ret.Get(0).(string)
+	}
+
+	return r0
+}
+
+// Presigner_PresignGet_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'PresignGet'
+type Presigner_PresignGet_Call struct {
+	*mock.Call
+}
+
+// PresignGet is a helper method to define mock.On call
+//   - name string
+//   - ttl time.Duration
+func (_e *Presigner_Expecter) PresignGet(name interface{}, ttl interface{}) *Presigner_PresignGet_Call {
+	return &Presigner_PresignGet_Call{Call: _e.mock.On("PresignGet", name, ttl)}
+}
+
+func (_c *Presigner_PresignGet_Call) Run(run func(name string, ttl time.Duration)) *Presigner_PresignGet_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(string), args[1].(time.Duration))
+	})
+	return _c
+}
+
+func (_c *Presigner_PresignGet_Call) Return(_a0 string) *Presigner_PresignGet_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *Presigner_PresignGet_Call) RunAndReturn(run func(string, time.Duration) string) *Presigner_PresignGet_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// NewPresigner creates a new instance of Presigner. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewPresigner(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *Presigner {
+	mock := &Presigner{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
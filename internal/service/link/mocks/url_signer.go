@@ -0,0 +1,80 @@
+// Code generated by mockery v2.33.0. DO NOT EDIT.
+
+package mocks
+
+import (
+	url "net/url"
+
+	mock "github.com/stretchr/testify/mock"
+)
+
+// URLSigner is an autogenerated mock type for the URLSigner type
+type URLSigner struct {
+	mock.Mock
+}
+
+type URLSigner_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *URLSigner) EXPECT() *URLSigner_Expecter {
+	return &URLSigner_Expecter{mock: &_m.Mock}
+}
+
+// Sign provides a mock function with given fields: fileName
+func (_m *URLSigner) Sign(fileName string) url.Values {
+	ret := _m.Called(fileName)
+
+	var r0 url.Values
+	if rf, ok := ret.Get(0).(func(string) url.Values); ok {
+		r0 = rf(fileName)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(url.Values)
+		}
+	}
+
+	return r0
+}
+
+// URLSigner_Sign_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Sign'
+type URLSigner_Sign_Call struct {
+	*mock.Call
+}
+
+// Sign is a helper method to define mock.On call
+//   - fileName string
+func (_e *URLSigner_Expecter) Sign(fileName interface{}) *URLSigner_Sign_Call {
+	return &URLSigner_Sign_Call{Call: _e.mock.On("Sign", fileName)}
+}
+
+func (_c *URLSigner_Sign_Call) Run(run func(fileName string)) *URLSigner_Sign_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(string))
+	})
+	return _c
+}
+
+func (_c *URLSigner_Sign_Call) Return(_a0 url.Values) *URLSigner_Sign_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *URLSigner_Sign_Call) RunAndReturn(run func(string) url.Values) *URLSigner_Sign_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// NewURLSigner creates a new instance of URLSigner. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewURLSigner(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *URLSigner {
+	mock := &URLSigner{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
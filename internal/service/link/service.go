@@ -0,0 +1,35 @@
+package link
+
+import (
+	"time"
+)
+
+const schema = "http://"
+
+// Proxy generates links to download handler of this service, which streams file from blob storage
+type Proxy struct {
+	urlSigner       URLSigner
+	staticURIPrefix string
+}
+
+func NewProxy(urlSigner URLSigner, staticURIPrefix string) Proxy {
+	return Proxy{urlSigner: urlSigner, staticURIPrefix: staticURIPrefix}
+}
+
+func (p Proxy) GenerateURL(host string, fileName string) string {
+	return schema + host + p.staticURIPrefix + "/" + fileName + "?" + p.urlSigner.Sign(fileName).Encode()
+}
+
+// Presign generates links which allow downloading file directly from blob storage
+type Presign struct {
+	presigner Presigner
+	ttl       time.Duration
+}
+
+func NewPresign(presigner Presigner, ttl time.Duration) Presign {
+	return Presign{presigner: presigner, ttl: ttl}
+}
+
+func (p Presign) GenerateURL(_ string, fileName string) string {
+	return p.presigner.PresignGet(fileName, p.ttl)
+}
@@ -0,0 +1,37 @@
+package link
+
+import (
+	"net/url"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/pollykon/avito_test_task/internal/service/link/mocks"
+)
+
+func TestProxy_GenerateURL(t *testing.T) {
+	urlSignerMock := mocks.NewURLSigner(t)
+	urlSignerMock.EXPECT().Sign("log.csv").
+		Return(url.Values{"expires": []string{"1693526400"}, "signature": []string{"abcdef"}})
+
+	proxy := NewProxy(urlSignerMock, "/static")
+
+	assert.Equal(t,
+		"http://localhost:1011/static/log.csv?expires=1693526400&signature=abcdef",
+		proxy.GenerateURL("localhost:1011", "log.csv"),
+	)
+}
+
+func TestPresign_GenerateURL(t *testing.T) {
+	presignerMock := mocks.NewPresigner(t)
+	presignerMock.EXPECT().PresignGet("log.csv", time.Hour).
+		Return("http://minio:9000/logs/log.csv?X-Amz-Signature=abcdef")
+
+	presign := NewPresign(presignerMock, time.Hour)
+
+	assert.Equal(t,
+		"http://minio:9000/logs/log.csv?X-Amz-Signature=abcdef",
+		presign.GenerateURL("localhost:1011", "log.csv"),
+	)
+}
@@ -0,0 +1,61 @@
+package log
+
+import (
+	"archive/zip"
+	"bytes"
+	"compress/gzip"
+	"fmt"
+	"strings"
+)
+
+// compress packs csv content and returns it with name of file to store. Zip archive contains single csv file
+func compress(content []byte, fileID string, compression string) ([]byte, string, error) {
+	csvName := fileID + extensionCSV
+
+	var buffer bytes.Buffer
+	switch compression {
+	case CompressionNone:
+		return content, csvName, nil
+	case CompressionGzip:
+		writer := gzip.NewWriter(&buffer)
+		writer.Name = csvName
+		_, err := writer.Write(content)
+		if err != nil {
+			return nil, "", fmt.Errorf("error while writing gzip: %w", err)
+		}
+		err = writer.Close()
+		if err != nil {
+			return nil, "", fmt.Errorf("error while closing gzip: %w", err)
+		}
+		return buffer.Bytes(), csvName + extensionGzip, nil
+	case CompressionZip:
+		writer := zip.NewWriter(&buffer)
+		file, err := writer.Create(csvName)
+		if err != nil {
+			return nil, "", fmt.Errorf("error while creating zip entry: %w", err)
+		}
+		_, err = file.Write(content)
+		if err != nil {
+			return nil, "", fmt.Errorf("error while writing zip: %w", err)
+		}
+		err = writer.Close()
+		if err != nil {
+			return nil, "", fmt.Errorf("error while closing zip: %w", err)
+		}
+		return buffer.Bytes(), fileID + extensionZip, nil
+	default:
+		return nil, "", ErrUnknownCompression
+	}
+}
+
+// compressionByFileName returns compression of file saved by GenerateCSV
+func compressionByFileName(fileName string) string {
+	switch {
+	case strings.HasSuffix(fileName, extensionGzip):
+		return CompressionGzip
+	case strings.HasSuffix(fileName, extensionZip):
+		return CompressionZip
+	default:
+		return CompressionNone
+	}
+}
@@ -0,0 +1,19 @@
+package log
+
+import "errors"
+
+var ErrFileNotExist = errors.New("file doesn't exist")
+var ErrUnknownCompression = errors.New("unknown compression")
+
+// Compression of generated csv
+const (
+	CompressionNone = ""
+	CompressionGzip = "gzip"
+	CompressionZip  = "zip"
+)
+
+const (
+	extensionCSV  = ".csv"
+	extensionGzip = ".gz"
+	extensionZip  = ".zip"
+)
@@ -5,6 +5,7 @@ import (
 	"context"
 	"time"
 
+	"github.com/pollykon/avito_test_task/internal/repository/blob"
 	"github.com/pollykon/avito_test_task/internal/repository/log"
 )
 
@@ -12,6 +13,12 @@ type LogRepository interface {
 	Get(ctx context.Context, userID int64, from time.Time, to time.Time) ([]log.Log, error)
 }
 
-type CSVRepository interface {
-	Save(csv string) (string, error)
+type BlobStorage interface {
+	Save(ctx context.Context, name string, content []byte) error
+	Open(ctx context.Context, name string) (blob.Object, error)
+	Delete(ctx context.Context, name string) error
+}
+
+type ExportFileRepository interface {
+	Add(ctx context.Context, fileName string, size int64) error
 }
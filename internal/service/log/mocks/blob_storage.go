@@ -0,0 +1,178 @@
+// Code generated by mockery v2.33.0. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+
+	blob "github.com/pollykon/avito_test_task/internal/repository/blob"
+
+	mock "github.com/stretchr/testify/mock"
+)
+
+// BlobStorage is an autogenerated mock type for the BlobStorage type
+type BlobStorage struct {
+	mock.Mock
+}
+
+type BlobStorage_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *BlobStorage) EXPECT() *BlobStorage_Expecter {
+	return &BlobStorage_Expecter{mock: &_m.Mock}
+}
+
+// Delete provides a mock function with given fields: ctx, name
+func (_m *BlobStorage) Delete(ctx context.Context, name string) error {
+	ret := _m.Called(ctx, name)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) error); ok {
+		r0 = rf(ctx, name)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// BlobStorage_Delete_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Delete'
+type BlobStorage_Delete_Call struct {
+	*mock.Call
+}
+
+// Delete is a helper method to define mock.On call
+//   - ctx context.Context
+//   - name string
+func (_e *BlobStorage_Expecter) Delete(ctx interface{}, name interface{}) *BlobStorage_Delete_Call {
+	return &BlobStorage_Delete_Call{Call: _e.mock.On("Delete", ctx, name)}
+}
+
+func (_c *BlobStorage_Delete_Call) Run(run func(ctx context.Context, name string)) *BlobStorage_Delete_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string))
+	})
+	return _c
+}
+
+func (_c *BlobStorage_Delete_Call) Return(_a0 error) *BlobStorage_Delete_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *BlobStorage_Delete_Call) RunAndReturn(run func(context.Context, string) error) *BlobStorage_Delete_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Open provides a mock function with given fields: ctx, name
+func (_m *BlobStorage) Open(ctx context.Context, name string) (blob.Object, error) {
+	ret := _m.Called(ctx, name)
+
+	var r0 blob.Object
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) (blob.Object, error)); ok {
+		return rf(ctx, name)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) blob.Object); ok {
+		r0 = rf(ctx, name)
+	} else {
+		r0 = ret.Get(0).(blob.Object)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, name)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// BlobStorage_Open_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Open'
+type BlobStorage_Open_Call struct {
+	*mock.Call
+}
+
+// Open is a helper method to define mock.On call
+//   - ctx context.Context
+//   - name string
+func (_e *BlobStorage_Expecter) Open(ctx interface{}, name interface{}) *BlobStorage_Open_Call {
+	return &BlobStorage_Open_Call{Call: _e.mock.On("Open", ctx, name)}
+}
+
+func (_c *BlobStorage_Open_Call) Run(run func(ctx context.Context, name string)) *BlobStorage_Open_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string))
+	})
+	return _c
+}
+
+func (_c *BlobStorage_Open_Call) Return(_a0 blob.Object, _a1 error) *BlobStorage_Open_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *BlobStorage_Open_Call) RunAndReturn(run func(context.Context, string) (blob.Object, error)) *BlobStorage_Open_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Save provides a mock function with given fields: ctx, name, content
+func (_m *BlobStorage) Save(ctx context.Context, name string, content []byte) error {
+	ret := _m.Called(ctx, name, content)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, []byte) error); ok {
+		r0 = rf(ctx, name, content)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// BlobStorage_Save_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Save'
+type BlobStorage_Save_Call struct {
+	*mock.Call
+}
+
+// Save is a helper method to define mock.On call
+//   - ctx context.Context
+//   - name string
+//   - content []byte
+func (_e *BlobStorage_Expecter) Save(ctx interface{}, name interface{}, content interface{}) *BlobStorage_Save_Call {
+	return &BlobStorage_Save_Call{Call: _e.mock.On("Save", ctx, name, content)}
+}
+
+func (_c *BlobStorage_Save_Call) Run(run func(ctx context.Context, name string, content []byte)) *BlobStorage_Save_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string), args[2].([]byte))
+	})
+	return _c
+}
+
+func (_c *BlobStorage_Save_Call) Return(_a0 error) *BlobStorage_Save_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *BlobStorage_Save_Call) RunAndReturn(run func(context.Context, string, []byte) error) *BlobStorage_Save_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// NewBlobStorage creates a new instance of BlobStorage. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewBlobStorage(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *BlobStorage {
+	mock := &BlobStorage{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
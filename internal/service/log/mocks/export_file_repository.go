@@ -0,0 +1,80 @@
+// Code generated by mockery v2.33.0. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+
+	mock "github.com/stretchr/testify/mock"
+)
+
+// ExportFileRepository is an autogenerated mock type for the ExportFileRepository type
+type ExportFileRepository struct {
+	mock.Mock
+}
+
+type ExportFileRepository_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *ExportFileRepository) EXPECT() *ExportFileRepository_Expecter {
+	return &ExportFileRepository_Expecter{mock: &_m.Mock}
+}
+
+// Add provides a mock function with given fields: ctx, fileName, size
+func (_m *ExportFileRepository) Add(ctx context.Context, fileName string, size int64) error {
+	ret := _m.Called(ctx, fileName, size)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, int64) error); ok {
+		r0 = rf(ctx, fileName, size)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// ExportFileRepository_Add_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Add'
+type ExportFileRepository_Add_Call struct {
+	*mock.Call
+}
+
+// Add is a helper method to define mock.On call
+//   - ctx context.Context
+//   - fileName string
+//   - size int64
+func (_e *ExportFileRepository_Expecter) Add(ctx interface{}, fileName interface{}, size interface{}) *ExportFileRepository_Add_Call {
+	return &ExportFileRepository_Add_Call{Call: _e.mock.On("Add", ctx, fileName, size)}
+}
+
+func (_c *ExportFileRepository_Add_Call) Run(run func(ctx context.Context, fileName string, size int64)) *ExportFileRepository_Add_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string), args[2].(int64))
+	})
+	return _c
+}
+
+func (_c *ExportFileRepository_Add_Call) Return(_a0 error) *ExportFileRepository_Add_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *ExportFileRepository_Add_Call) RunAndReturn(run func(context.Context, string, int64) error) *ExportFileRepository_Add_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// NewExportFileRepository creates a new instance of ExportFileRepository. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewExportFileRepository(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *ExportFileRepository {
+	mock := &ExportFileRepository{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
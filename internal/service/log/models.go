@@ -1,6 +1,7 @@
 package log
 
 import (
+	"io"
 	"time"
 )
 
@@ -9,4 +10,23 @@ type GetCSVRequest struct {
 	From      time.Time
 	To        time.Time
 	Separator string
+	// Compression is one of CompressionNone, CompressionGzip, CompressionZip
+	Compression string
+}
+
+// CSVFile is content of generated csv. Content is io.ReadSeekCloser when blob storage supports seeking
+type CSVFile struct {
+	Content     io.ReadCloser
+	Size        int64
+	ModTime     time.Time
+	Compression string
+}
+
+// HistoryRecord is an operation with user's membership in segment
+type HistoryRecord struct {
+	ID         int64
+	SegmentID  string
+	Operation  string
+	Actor      string
+	InsertTime time.Time
 }
@@ -2,29 +2,46 @@ package log
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"strconv"
 	"strings"
 	"time"
+
+	"github.com/google/uuid"
+	"go.opentelemetry.io/otel/attribute"
+
+	"github.com/pollykon/avito_test_task/internal/repository/blob"
+	"github.com/pollykon/avito_test_task/internal/tracing"
 )
 
 type Service struct {
-	logRepo LogRepository
-	csvRepo CSVRepository
+	logRepo        LogRepository
+	blobStorage    BlobStorage
+	exportFileRepo ExportFileRepository
+	newFileID      func() string
 }
 
-func New(logRepo LogRepository, csvRepo CSVRepository) Service {
-	return Service{logRepo: logRepo, csvRepo: csvRepo}
+func New(logRepo LogRepository, blobStorage BlobStorage, exportFileRepo ExportFileRepository) Service {
+	return Service{
+		logRepo:        logRepo,
+		blobStorage:    blobStorage,
+		exportFileRepo: exportFileRepo,
+		newFileID:      func() string { return uuid.New().String() },
+	}
 }
 
-func (s Service) GenerateCSV(ctx context.Context, request GetCSVRequest) (string, error) {
+func (s Service) GenerateCSV(ctx context.Context, request GetCSVRequest) (fileName string, err error) {
+	ctx, span := tracing.Start(ctx, "log.Service.GenerateCSV", attribute.Int64("user.id", request.UserID))
+	defer func() { tracing.End(span, err) }()
+
 	logs, err := s.logRepo.Get(ctx, request.UserID, request.From, request.To)
 	if err != nil {
 		return "", fmt.Errorf("error from log service while getting logs: %w", err)
 	}
 
 	var csv []string
-	header := strings.Join([]string{"logId", "userId", "segmentId", "operation", "insertTime"}, request.Separator)
+	header := strings.Join([]string{"logId", "userId", "segmentId", "operation", "insertTime", "actor"}, request.Separator)
 	csv = append(csv, header)
 	for _, log := range logs {
 		row := strings.Join(
@@ -34,16 +51,80 @@ func (s Service) GenerateCSV(ctx context.Context, request GetCSVRequest) (string
 				log.SegmentID,
 				log.Operation,
 				log.InsertTime.Format(time.RFC3339),
+				log.Actor,
 			},
 			request.Separator,
 		)
 		csv = append(csv, row)
 	}
 
-	URI, err := s.csvRepo.Save(strings.Join(csv, "\n"))
+	content, fileName, err := compress([]byte(strings.Join(csv, "\n")), s.newFileID(), request.Compression)
+	if err != nil {
+		return "", fmt.Errorf("error from log service while compressing csv: %w", err)
+	}
+
+	err = s.blobStorage.Save(ctx, fileName, content)
 	if err != nil {
 		return "", fmt.Errorf("error from log service while saving csv: %w", err)
 	}
 
-	return URI, nil
+	// file metadata is used by cron which deletes old files
+	err = s.exportFileRepo.Add(ctx, fileName, int64(len(content)))
+	if err != nil {
+		// untracked file would never be deleted
+		_ = s.blobStorage.Delete(ctx, fileName)
+		return "", fmt.Errorf("error from log service while saving export file metadata: %w", err)
+	}
+
+	return fileName, nil
+}
+
+// GetHistory returns user's operations in [from, to) ordered by time
+func (s Service) GetHistory(
+	ctx context.Context,
+	userID int64,
+	from time.Time,
+	to time.Time,
+) (history []HistoryRecord, err error) {
+	ctx, span := tracing.Start(ctx, "log.Service.GetHistory", attribute.Int64("user.id", userID))
+	defer func() { tracing.End(span, err) }()
+
+	logs, err := s.logRepo.Get(ctx, userID, from, to)
+	if err != nil {
+		return nil, fmt.Errorf("error from log service while getting logs: %w", err)
+	}
+
+	history = make([]HistoryRecord, 0, len(logs))
+	for _, log := range logs {
+		history = append(history, HistoryRecord{
+			ID:         log.ID,
+			SegmentID:  log.SegmentID,
+			Operation:  log.Operation,
+			Actor:      log.Actor,
+			InsertTime: log.InsertTime,
+		})
+	}
+
+	return history, nil
+}
+
+// OpenCSV opens csv file generated by GenerateCSV. Caller must close file content
+func (s Service) OpenCSV(ctx context.Context, fileName string) (_ CSVFile, err error) {
+	ctx, span := tracing.Start(ctx, "log.Service.OpenCSV", attribute.String("file.name", fileName))
+	defer func() { tracing.End(span, err) }()
+
+	object, err := s.blobStorage.Open(ctx, fileName)
+	if err != nil {
+		if errors.Is(err, blob.ErrObjectNotExist) {
+			return CSVFile{}, ErrFileNotExist
+		}
+		return CSVFile{}, fmt.Errorf("error from log service while opening csv: %w", err)
+	}
+
+	return CSVFile{
+		Content:     object.Content,
+		Size:        object.Size,
+		ModTime:     object.ModTime,
+		Compression: compressionByFileName(fileName),
+	}, nil
 }
@@ -1,13 +1,19 @@
 package log
 
 import (
+	"archive/zip"
+	"bytes"
+	"compress/gzip"
 	"context"
 	"fmt"
+	"io"
 	"testing"
 	"time"
 
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
 
+	"github.com/pollykon/avito_test_task/internal/repository/blob"
 	logRepo "github.com/pollykon/avito_test_task/internal/repository/log"
 	"github.com/pollykon/avito_test_task/internal/service/log/mocks"
 )
@@ -25,7 +31,7 @@ func TestLogService_GenerateCSV_Success(t *testing.T) {
 		Separator: ",",
 	}
 
-	sentCSV := "logId,userId,segmentId,operation,insertTime\n1,12,AVITO,add,2023-08-01T00:00:00Z"
+	sentCSV := "logId,userId,segmentId,operation,insertTime,actor\n1,12,AVITO,add,2023-08-01T00:00:00Z,api_key:12"
 
 	expectedLogs := []logRepo.Log{
 		{
@@ -33,6 +39,7 @@ func TestLogService_GenerateCSV_Success(t *testing.T) {
 			UserID:     int64(12),
 			SegmentID:  "AVITO",
 			Operation:  logRepo.OperationTypeAdd,
+			Actor:      "api_key:12",
 			InsertTime: parsedFrom,
 		},
 	}
@@ -40,13 +47,17 @@ func TestLogService_GenerateCSV_Success(t *testing.T) {
 	expectedFileName := "log.csv"
 
 	logRepoMock := mocks.NewLogRepository(t)
-	logRepoMock.EXPECT().Get(context.Background(), sentRequest.UserID, sentRequest.From, sentRequest.To).
+	logRepoMock.EXPECT().Get(mock.Anything, sentRequest.UserID, sentRequest.From, sentRequest.To).
 		Return(expectedLogs, nil)
 
-	csvRepoMock := mocks.NewCSVRepository(t)
-	csvRepoMock.EXPECT().Save(sentCSV).Return(expectedFileName, nil)
+	blobStorageMock := mocks.NewBlobStorage(t)
+	blobStorageMock.EXPECT().Save(mock.Anything, expectedFileName, []byte(sentCSV)).Return(nil)
 
-	service := New(logRepoMock, csvRepoMock)
+	exportFileRepoMock := mocks.NewExportFileRepository(t)
+	exportFileRepoMock.EXPECT().Add(mock.Anything, expectedFileName, int64(len(sentCSV))).Return(nil)
+
+	service := New(logRepoMock, blobStorageMock, exportFileRepoMock)
+	service.newFileID = func() string { return "log" }
 
 	fileName, err := service.GenerateCSV(context.Background(), sentRequest)
 
@@ -54,6 +65,104 @@ func TestLogService_GenerateCSV_Success(t *testing.T) {
 	assert.Equal(t, expectedFileName, fileName)
 }
 
+func TestLogService_GenerateCSV_Compressed_Success(t *testing.T) {
+	parsedFrom, _ := time.Parse("2006-01", "2023-08")
+	parsedTo, _ := time.Parse("2006-01", "2023-09")
+
+	sentCSV := "logId,userId,segmentId,operation,insertTime,actor\n1,12,AVITO,add,2023-08-01T00:00:00Z,"
+
+	expectedLogs := []logRepo.Log{
+		{
+			ID:         1,
+			UserID:     int64(12),
+			SegmentID:  "AVITO",
+			Operation:  logRepo.OperationTypeAdd,
+			InsertTime: parsedFrom,
+		},
+	}
+
+	tt := []struct {
+		name string
+
+		compression string
+
+		expectedFileName string
+		decompress       func(content []byte) (string, error)
+	}{
+		{
+			name: "gzip",
+
+			compression: CompressionGzip,
+
+			expectedFileName: "log.csv.gz",
+			decompress: func(content []byte) (string, error) {
+				reader, err := gzip.NewReader(bytes.NewReader(content))
+				if err != nil {
+					return "", err
+				}
+				decompressed, err := io.ReadAll(reader)
+				return string(decompressed), err
+			},
+		},
+		{
+			name: "zip",
+
+			compression: CompressionZip,
+
+			expectedFileName: "log.zip",
+			decompress: func(content []byte) (string, error) {
+				reader, err := zip.NewReader(bytes.NewReader(content), int64(len(content)))
+				if err != nil {
+					return "", err
+				}
+				file, err := reader.Open("log.csv")
+				if err != nil {
+					return "", err
+				}
+				decompressed, err := io.ReadAll(file)
+				return string(decompressed), err
+			},
+		},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			sentRequest := GetCSVRequest{
+				UserID:      13,
+				From:        parsedFrom,
+				To:          parsedTo,
+				Separator:   ",",
+				Compression: tc.compression,
+			}
+
+			logRepoMock := mocks.NewLogRepository(t)
+			logRepoMock.EXPECT().Get(mock.Anything, sentRequest.UserID, sentRequest.From, sentRequest.To).
+				Return(expectedLogs, nil)
+
+			var savedContent []byte
+			blobStorageMock := mocks.NewBlobStorage(t)
+			blobStorageMock.EXPECT().Save(mock.Anything, tc.expectedFileName, mock.Anything).
+				Run(func(ctx context.Context, name string, content []byte) { savedContent = content }).
+				Return(nil)
+
+			exportFileRepoMock := mocks.NewExportFileRepository(t)
+			exportFileRepoMock.EXPECT().Add(mock.Anything, tc.expectedFileName, mock.Anything).Return(nil)
+
+			service := New(logRepoMock, blobStorageMock, exportFileRepoMock)
+			service.newFileID = func() string { return "log" }
+
+			fileName, err := service.GenerateCSV(context.Background(), sentRequest)
+
+			assert.NoError(t, err)
+			assert.Equal(t, tc.expectedFileName, fileName)
+
+			decompressed, err := tc.decompress(savedContent)
+			assert.NoError(t, err)
+			assert.Equal(t, sentCSV, decompressed)
+		})
+	}
+}
+
 func TestLogService_GenerateCSV_Error(t *testing.T) {
 	parsedFrom, _ := time.Parse("2006-01", "2023-08")
 	parsedTo, _ := time.Parse("2006-01", "2023-09")
@@ -63,10 +172,11 @@ func TestLogService_GenerateCSV_Error(t *testing.T) {
 		To:        parsedTo,
 		Separator: ",",
 	}
-	sentCSV := "logId,userId,segmentId,operation,insertTime\n1,12,AVITO,add,2023-08-01T00:00:00Z"
+	sentCSV := "logId,userId,segmentId,operation,insertTime,actor\n1,12,AVITO,add,2023-08-01T00:00:00Z,"
 
 	errFromLogRepo := fmt.Errorf("error from log repo")
-	errFromCSVRepo := fmt.Errorf("error from csv repo")
+	errFromBlobStorage := fmt.Errorf("error from blob storage")
+	errFromExportFileRepo := fmt.Errorf("error from export file repo")
 
 	expectedLogs := []logRepo.Log{
 		{
@@ -84,8 +194,9 @@ func TestLogService_GenerateCSV_Error(t *testing.T) {
 		sentRequest GetCSVRequest
 		sentCSV     string
 
-		buildLogRepoMock func(mock *mocks.LogRepository)
-		buildCSVRepoMock func(mock *mocks.CSVRepository)
+		buildLogRepoMock        func(mock *mocks.LogRepository)
+		buildBlobStorageMock    func(mock *mocks.BlobStorage)
+		buildExportFileRepoMock func(mock *mocks.ExportFileRepository)
 
 		expectedLogs     []logRepo.Log
 		expectedFileName string
@@ -98,33 +209,80 @@ func TestLogService_GenerateCSV_Error(t *testing.T) {
 			sentCSV:     sentCSV,
 
 			buildLogRepoMock: func(repo *mocks.LogRepository) {
-				repo.EXPECT().Get(context.Background(), sentRequest.UserID, sentRequest.From, sentRequest.To).
+				repo.EXPECT().Get(mock.Anything, sentRequest.UserID, sentRequest.From, sentRequest.To).
 					Return(nil, errFromLogRepo)
 			},
-			buildCSVRepoMock: nil,
+			buildBlobStorageMock: nil,
 
 			expectedLogs:     nil,
 			expectedFileName: "",
 			expectedError:    errFromLogRepo,
 		},
 		{
-			name: "unexpected_error_from_csv_repo",
+			name: "unknown_compression",
+
+			sentRequest: GetCSVRequest{
+				UserID:      sentRequest.UserID,
+				From:        sentRequest.From,
+				To:          sentRequest.To,
+				Separator:   sentRequest.Separator,
+				Compression: "rar",
+			},
+			sentCSV: sentCSV,
+
+			buildLogRepoMock: func(repo *mocks.LogRepository) {
+				repo.EXPECT().Get(mock.Anything, sentRequest.UserID, sentRequest.From, sentRequest.To).
+					Return(expectedLogs, nil)
+			},
+			buildBlobStorageMock: nil,
+
+			expectedLogs:     expectedLogs,
+			expectedFileName: "",
+			expectedError:    ErrUnknownCompression,
+		},
+		{
+			name: "unexpected_error_from_blob_storage",
 
 			sentRequest: sentRequest,
 			sentCSV:     sentCSV,
 
 			buildLogRepoMock: func(repo *mocks.LogRepository) {
-				repo.EXPECT().Get(context.Background(), sentRequest.UserID, sentRequest.From, sentRequest.To).
+				repo.EXPECT().Get(mock.Anything, sentRequest.UserID, sentRequest.From, sentRequest.To).
 					Return(expectedLogs, nil)
 			},
-			buildCSVRepoMock: func(repo *mocks.CSVRepository) {
-				repo.EXPECT().Save(sentCSV).
-					Return("", errFromCSVRepo)
+			buildBlobStorageMock: func(storage *mocks.BlobStorage) {
+				storage.EXPECT().Save(mock.Anything, "log.csv", []byte(sentCSV)).
+					Return(errFromBlobStorage)
 			},
 
 			expectedLogs:     expectedLogs,
 			expectedFileName: "",
-			expectedError:    errFromCSVRepo,
+			expectedError:    errFromBlobStorage,
+		},
+		{
+			name: "unexpected_error_from_export_file_repo",
+
+			sentRequest: sentRequest,
+			sentCSV:     sentCSV,
+
+			buildLogRepoMock: func(repo *mocks.LogRepository) {
+				repo.EXPECT().Get(mock.Anything, sentRequest.UserID, sentRequest.From, sentRequest.To).
+					Return(expectedLogs, nil)
+			},
+			buildBlobStorageMock: func(storage *mocks.BlobStorage) {
+				storage.EXPECT().Save(mock.Anything, "log.csv", []byte(sentCSV)).
+					Return(nil)
+				storage.EXPECT().Delete(mock.Anything, "log.csv").
+					Return(nil)
+			},
+			buildExportFileRepoMock: func(repo *mocks.ExportFileRepository) {
+				repo.EXPECT().Add(mock.Anything, "log.csv", int64(len(sentCSV))).
+					Return(errFromExportFileRepo)
+			},
+
+			expectedLogs:     expectedLogs,
+			expectedFileName: "",
+			expectedError:    errFromExportFileRepo,
 		},
 	}
 
@@ -135,12 +293,18 @@ func TestLogService_GenerateCSV_Error(t *testing.T) {
 				tc.buildLogRepoMock(logRepoMock)
 			}
 
-			csvRepoMock := mocks.NewCSVRepository(t)
-			if tc.buildCSVRepoMock != nil {
-				tc.buildCSVRepoMock(csvRepoMock)
+			blobStorageMock := mocks.NewBlobStorage(t)
+			if tc.buildBlobStorageMock != nil {
+				tc.buildBlobStorageMock(blobStorageMock)
+			}
+
+			exportFileRepoMock := mocks.NewExportFileRepository(t)
+			if tc.buildExportFileRepoMock != nil {
+				tc.buildExportFileRepoMock(exportFileRepoMock)
 			}
 
-			service := New(logRepoMock, csvRepoMock)
+			service := New(logRepoMock, blobStorageMock, exportFileRepoMock)
+			service.newFileID = func() string { return "log" }
 
 			fileName, err := service.GenerateCSV(context.Background(), tc.sentRequest)
 
@@ -149,3 +313,87 @@ func TestLogService_GenerateCSV_Error(t *testing.T) {
 		})
 	}
 }
+
+func TestLogService_OpenCSV_Success(t *testing.T) {
+	modTime := time.Date(2023, 8, 1, 0, 0, 0, 0, time.UTC)
+	expectedObject := blob.Object{Content: nil, Size: 10, ModTime: modTime}
+
+	blobStorageMock := mocks.NewBlobStorage(t)
+	blobStorageMock.EXPECT().Open(mock.Anything, "log.csv").Return(expectedObject, nil)
+
+	service := New(mocks.NewLogRepository(t), blobStorageMock, mocks.NewExportFileRepository(t))
+
+	file, err := service.OpenCSV(context.Background(), "log.csv")
+
+	assert.NoError(t, err)
+	assert.Equal(t, CSVFile{Content: nil, Size: 10, ModTime: modTime, Compression: CompressionNone}, file)
+}
+
+func TestLogService_OpenCSV_Error(t *testing.T) {
+	errFromBlobStorage := fmt.Errorf("error from blob storage")
+
+	tt := []struct {
+		name string
+
+		buildBlobStorageMock func(mock *mocks.BlobStorage)
+
+		expectedError error
+	}{
+		{
+			name: "file_not_exist",
+
+			buildBlobStorageMock: func(storage *mocks.BlobStorage) {
+				storage.EXPECT().Open(mock.Anything, "log.csv").Return(blob.Object{}, blob.ErrObjectNotExist)
+			},
+
+			expectedError: ErrFileNotExist,
+		},
+		{
+			name: "unexpected_error_from_blob_storage",
+
+			buildBlobStorageMock: func(storage *mocks.BlobStorage) {
+				storage.EXPECT().Open(mock.Anything, "log.csv").Return(blob.Object{}, errFromBlobStorage)
+			},
+
+			expectedError: errFromBlobStorage,
+		},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			blobStorageMock := mocks.NewBlobStorage(t)
+			tc.buildBlobStorageMock(blobStorageMock)
+
+			service := New(mocks.NewLogRepository(t), blobStorageMock, mocks.NewExportFileRepository(t))
+
+			file, err := service.OpenCSV(context.Background(), "log.csv")
+
+			assert.ErrorIs(t, err, tc.expectedError)
+			assert.Equal(t, CSVFile{}, file)
+		})
+	}
+}
+
+func TestLogService_GetHistory(t *testing.T) {
+	from := time.Date(2023, 8, 1, 0, 0, 0, 0, time.UTC)
+	to := time.Date(2023, 9, 1, 0, 0, 0, 0, time.UTC)
+	insertTime := time.Date(2023, 8, 10, 0, 0, 0, 0, time.UTC)
+	errFromRepo := fmt.Errorf("error from log repo")
+
+	logRepoMock := mocks.NewLogRepository(t)
+	logRepoMock.EXPECT().Get(mock.Anything, int64(10), from, to).Return([]logRepo.Log{
+		{ID: 1, UserID: 10, SegmentID: "AVITO", Operation: logRepo.OperationTypeAdd, Actor: "user:5", InsertTime: insertTime},
+	}, nil).Once()
+	logRepoMock.EXPECT().Get(mock.Anything, int64(10), from, to).Return(nil, errFromRepo).Once()
+
+	service := New(logRepoMock, mocks.NewBlobStorage(t), mocks.NewExportFileRepository(t))
+
+	history, err := service.GetHistory(context.Background(), 10, from, to)
+	assert.NoError(t, err)
+	assert.Equal(t, []HistoryRecord{
+		{ID: 1, SegmentID: "AVITO", Operation: logRepo.OperationTypeAdd, Actor: "user:5", InsertTime: insertTime},
+	}, history)
+
+	_, err = service.GetHistory(context.Background(), 10, from, to)
+	assert.ErrorIs(t, err, errFromRepo)
+}
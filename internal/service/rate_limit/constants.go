@@ -0,0 +1,5 @@
+package rate_limit
+
+import "errors"
+
+var ErrInvalidLimit = errors.New("invalid limit, expected rate/burst")
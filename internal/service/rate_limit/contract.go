@@ -0,0 +1,12 @@
+//go:generate mockery --all --output ./mocks --case underscore --with-expecter
+package rate_limit
+
+import (
+	"context"
+
+	rateLimitRepo "github.com/pollykon/avito_test_task/internal/repository/rate_limit"
+)
+
+type BucketRepository interface {
+	Take(ctx context.Context, key string, limit rateLimitRepo.Limit) (rateLimitRepo.Result, error)
+}
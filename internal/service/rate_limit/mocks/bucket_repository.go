@@ -0,0 +1,91 @@
+// Code generated by mockery v2.33.0. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+
+	rate_limit "github.com/pollykon/avito_test_task/internal/repository/rate_limit"
+	mock "github.com/stretchr/testify/mock"
+)
+
+// BucketRepository is an autogenerated mock type for the BucketRepository type
+type BucketRepository struct {
+	mock.Mock
+}
+
+type BucketRepository_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *BucketRepository) EXPECT() *BucketRepository_Expecter {
+	return &BucketRepository_Expecter{mock: &_m.Mock}
+}
+
+// Take provides a mock function with given fields: ctx, key, limit
+func (_m *BucketRepository) Take(ctx context.Context, key string, limit rate_limit.Limit) (rate_limit.Result, error) {
+	ret := _m.Called(ctx, key, limit)
+
+	var r0 rate_limit.Result
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, rate_limit.Limit) (rate_limit.Result, error)); ok {
+		return rf(ctx, key, limit)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, rate_limit.Limit) rate_limit.Result); ok {
+		r0 = rf(ctx, key, limit)
+	} else {
+		r0 = ret.Get(0).(rate_limit.Result)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, rate_limit.Limit) error); ok {
+		r1 = rf(ctx, key, limit)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// BucketRepository_Take_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Take'
+type BucketRepository_Take_Call struct {
+	*mock.Call
+}
+
+// Take is a helper method to define mock.On call
+//   - ctx context.Context
+//   - key string
+//   - limit rate_limit.Limit
+func (_e *BucketRepository_Expecter) Take(ctx interface{}, key interface{}, limit interface{}) *BucketRepository_Take_Call {
+	return &BucketRepository_Take_Call{Call: _e.mock.On("Take", ctx, key, limit)}
+}
+
+func (_c *BucketRepository_Take_Call) Run(run func(ctx context.Context, key string, limit rate_limit.Limit)) *BucketRepository_Take_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string), args[2].(rate_limit.Limit))
+	})
+	return _c
+}
+
+func (_c *BucketRepository_Take_Call) Return(_a0 rate_limit.Result, _a1 error) *BucketRepository_Take_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *BucketRepository_Take_Call) RunAndReturn(run func(context.Context, string, rate_limit.Limit) (rate_limit.Result, error)) *BucketRepository_Take_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// NewBucketRepository creates a new instance of BucketRepository. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewBucketRepository(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *BucketRepository {
+	mock := &BucketRepository{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
@@ -0,0 +1,45 @@
+package rate_limit
+
+import (
+	"time"
+
+	rateLimitRepo "github.com/pollykon/avito_test_task/internal/repository/rate_limit"
+)
+
+// Limits contains limits of endpoints, endpoints without own limit have Default one. Address limits all requests
+// of ip address before authentication
+type Limits struct {
+	Default   rateLimitRepo.Limit
+	Endpoints map[string]rateLimitRepo.Limit
+	Address   rateLimitRepo.Limit
+}
+
+func (l Limits) For(endpoint string) rateLimitRepo.Limit {
+	if limit, ok := l.Endpoints[endpoint]; ok {
+		return limit
+	}
+	return l.Default
+}
+
+// RefillTime returns time in which empty bucket of the slowest limit becomes full, bucket which wasn't used longer
+// is the same as absent one
+func (l Limits) RefillTime() time.Duration {
+	refillTime := func(limit rateLimitRepo.Limit) time.Duration {
+		if limit.Rate <= 0 {
+			return 0
+		}
+		return time.Duration(float64(limit.Burst) / limit.Rate * float64(time.Second))
+	}
+
+	result := max(refillTime(l.Default), refillTime(l.Address))
+	for _, limit := range l.Endpoints {
+		result = max(result, refillTime(limit))
+	}
+	return result
+}
+
+// Decision tells if request is allowed, RetryAfter is time until the next token for rejected requests
+type Decision struct {
+	Allowed    bool
+	RetryAfter time.Duration
+}
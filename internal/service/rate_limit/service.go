@@ -0,0 +1,72 @@
+package rate_limit
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	rateLimitRepository "github.com/pollykon/avito_test_task/internal/repository/rate_limit"
+)
+
+// Service limits requests of each client to each endpoint with token buckets
+type Service struct {
+	bucketRepo BucketRepository
+	limits     Limits
+}
+
+func New(bucketRepo BucketRepository, limits Limits) Service {
+	return Service{bucketRepo: bucketRepo, limits: limits}
+}
+
+// Allow takes token from bucket of client for endpoint. Endpoints with zero rate aren't limited
+func (s Service) Allow(ctx context.Context, client string, endpoint string) (Decision, error) {
+	return s.take(ctx, client+" "+endpoint, s.limits.For(endpoint))
+}
+
+// AllowAddress takes token from bucket of ip address shared by all endpoints. It is checked before authentication,
+// so requests with missing or wrong keys are limited too
+func (s Service) AllowAddress(ctx context.Context, address string) (Decision, error) {
+	return s.take(ctx, "ip:"+address, s.limits.Address)
+}
+
+func (s Service) take(ctx context.Context, key string, limit rateLimitRepository.Limit) (Decision, error) {
+	if limit.Rate <= 0 {
+		return Decision{Allowed: true}, nil
+	}
+
+	result, err := s.bucketRepo.Take(ctx, key, limit)
+	if err != nil {
+		return Decision{}, fmt.Errorf("error from rate limit service while taking token: %w", err)
+	}
+
+	if result.Allowed {
+		return Decision{Allowed: true}, nil
+	}
+
+	return Decision{
+		Allowed:    false,
+		RetryAfter: time.Duration((1 - result.Tokens) / limit.Rate * float64(time.Second)),
+	}, nil
+}
+
+// ParseLimit parses limit in "rate/burst" format, e.g. "10/20" is 10 requests per second with bursts up to 20
+func ParseLimit(value string) (rateLimitRepository.Limit, error) {
+	rateValue, burstValue, ok := strings.Cut(value, "/")
+	if !ok {
+		return rateLimitRepository.Limit{}, fmt.Errorf("%w: %s", ErrInvalidLimit, value)
+	}
+
+	rate, err := strconv.ParseFloat(rateValue, 64)
+	if err != nil || rate < 0 {
+		return rateLimitRepository.Limit{}, fmt.Errorf("%w: %s", ErrInvalidLimit, value)
+	}
+
+	burst, err := strconv.ParseInt(burstValue, 10, 64)
+	if err != nil || (rate > 0 && burst < 1) {
+		return rateLimitRepository.Limit{}, fmt.Errorf("%w: %s", ErrInvalidLimit, value)
+	}
+
+	return rateLimitRepository.Limit{Rate: rate, Burst: burst}, nil
+}
@@ -0,0 +1,146 @@
+package rate_limit
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	rateLimitRepository "github.com/pollykon/avito_test_task/internal/repository/rate_limit"
+	"github.com/pollykon/avito_test_task/internal/service/rate_limit/mocks"
+)
+
+func TestService_Allow(t *testing.T) {
+	errFromRepo := fmt.Errorf("error from repo")
+
+	limits := Limits{
+		Default: rateLimitRepository.Limit{Rate: 10, Burst: 20},
+		Endpoints: map[string]rateLimitRepository.Limit{
+			"get_user_active_segments_v1": {Rate: 2, Burst: 4},
+			"stream_user_segments_v1":     {Rate: 0, Burst: 0},
+		},
+	}
+
+	tt := []struct {
+		name string
+
+		endpoint string
+
+		buildBucketRepoMock func(repo *mocks.BucketRepository)
+
+		expectedDecision Decision
+		expectedError    error
+	}{
+		{
+			name: "allowed_by_default_limit",
+
+			endpoint: "add_segment_v1",
+
+			buildBucketRepoMock: func(repo *mocks.BucketRepository) {
+				repo.EXPECT().Take(context.Background(), "api_key:1 add_segment_v1", limits.Default).
+					Return(rateLimitRepository.Result{Allowed: true, Tokens: 19}, nil)
+			},
+
+			expectedDecision: Decision{Allowed: true},
+		},
+		{
+			name: "rejected_by_endpoint_limit",
+
+			endpoint: "get_user_active_segments_v1",
+
+			buildBucketRepoMock: func(repo *mocks.BucketRepository) {
+				repo.EXPECT().Take(context.Background(), "api_key:1 get_user_active_segments_v1", rateLimitRepository.Limit{Rate: 2, Burst: 4}).
+					Return(rateLimitRepository.Result{Allowed: false, Tokens: 0.5}, nil)
+			},
+
+			expectedDecision: Decision{Allowed: false, RetryAfter: 250 * time.Millisecond},
+		},
+		{
+			name: "unlimited_endpoint",
+
+			endpoint: "stream_user_segments_v1",
+
+			buildBucketRepoMock: nil,
+
+			expectedDecision: Decision{Allowed: true},
+		},
+		{
+			name: "repo_error",
+
+			endpoint: "add_segment_v1",
+
+			buildBucketRepoMock: func(repo *mocks.BucketRepository) {
+				repo.EXPECT().Take(context.Background(), "api_key:1 add_segment_v1", limits.Default).
+					Return(rateLimitRepository.Result{}, errFromRepo)
+			},
+
+			expectedError: errFromRepo,
+		},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			bucketRepoMock := mocks.NewBucketRepository(t)
+			if tc.buildBucketRepoMock != nil {
+				tc.buildBucketRepoMock(bucketRepoMock)
+			}
+
+			decision, err := New(bucketRepoMock, limits).Allow(context.Background(), "api_key:1", tc.endpoint)
+
+			assert.ErrorIs(t, err, tc.expectedError)
+			assert.Equal(t, tc.expectedDecision, decision)
+		})
+	}
+}
+
+func TestService_AllowAddress(t *testing.T) {
+	limits := Limits{Address: rateLimitRepository.Limit{Rate: 50, Burst: 100}}
+
+	bucketRepoMock := mocks.NewBucketRepository(t)
+	// bucket of address is shared by all endpoints
+	bucketRepoMock.EXPECT().Take(context.Background(), "ip:192.0.2.1", limits.Address).
+		Return(rateLimitRepository.Result{Allowed: false, Tokens: 0.5}, nil)
+
+	decision, err := New(bucketRepoMock, limits).AllowAddress(context.Background(), "192.0.2.1")
+
+	assert.NoError(t, err)
+	assert.Equal(t, Decision{Allowed: false, RetryAfter: 10 * time.Millisecond}, decision)
+
+	decision, err = New(mocks.NewBucketRepository(t), Limits{}).AllowAddress(context.Background(), "192.0.2.1")
+
+	assert.NoError(t, err)
+	assert.Equal(t, Decision{Allowed: true}, decision)
+}
+
+func TestParseLimit(t *testing.T) {
+	limit, err := ParseLimit("0.5/3")
+	assert.NoError(t, err)
+	assert.Equal(t, rateLimitRepository.Limit{Rate: 0.5, Burst: 3}, limit)
+
+	limit, err = ParseLimit("0/0")
+	assert.NoError(t, err)
+	assert.Equal(t, rateLimitRepository.Limit{}, limit)
+
+	for _, value := range []string{"10", "a/2", "-1/2", "10/0", "10/b"} {
+		_, err = ParseLimit(value)
+		assert.ErrorIs(t, err, ErrInvalidLimit, value)
+	}
+}
+
+func TestLimits_RefillTime(t *testing.T) {
+	limits := Limits{
+		Default: rateLimitRepository.Limit{Rate: 10, Burst: 20},
+		Endpoints: map[string]rateLimitRepository.Limit{
+			"slow":      {Rate: 0.5, Burst: 3},
+			"unlimited": {},
+		},
+	}
+	assert.Equal(t, 6*time.Second, limits.RefillTime())
+
+	limits.Address = rateLimitRepository.Limit{Rate: 1, Burst: 10}
+	assert.Equal(t, 10*time.Second, limits.RefillTime())
+
+	assert.Equal(t, time.Duration(0), Limits{}.RefillTime())
+}
@@ -0,0 +1,24 @@
+//go:generate mockery --all --output ./mocks --case underscore --with-expecter
+package relay
+
+import (
+	"context"
+	"time"
+
+	"github.com/pollykon/avito_test_task/internal/publisher"
+	outboxRepo "github.com/pollykon/avito_test_task/internal/repository/outbox"
+)
+
+type OutboxRepository interface {
+	ClaimPending(ctx context.Context, limit int64, leaseUntil time.Time) ([]outboxRepo.Event, error)
+	MarkSent(ctx context.Context, ids []int64) error
+	Release(ctx context.Context, ids []int64) error
+	MarkFailed(ctx context.Context, id int64, nextAttemptTime time.Time, lastError string) error
+	DeleteSent(ctx context.Context, retention time.Duration, limit int64) error
+	TryLock(ctx context.Context) (bool, error)
+	InTransaction(ctx context.Context, f func(ctx context.Context) error) error
+}
+
+type Publisher interface {
+	Publish(ctx context.Context, message publisher.Message) error
+}
@@ -0,0 +1,365 @@
+// Code generated by mockery v2.33.0. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+
+	outbox "github.com/pollykon/avito_test_task/internal/repository/outbox"
+	mock "github.com/stretchr/testify/mock"
+
+	time "time"
+)
+
+// OutboxRepository is an autogenerated mock type for the OutboxRepository type
+type OutboxRepository struct {
+	mock.Mock
+}
+
+type OutboxRepository_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *OutboxRepository) EXPECT() *OutboxRepository_Expecter {
+	return &OutboxRepository_Expecter{mock: &_m.Mock}
+}
+
+// ClaimPending provides a mock function with given fields: ctx, limit, leaseUntil
+func (_m *OutboxRepository) ClaimPending(ctx context.Context, limit int64, leaseUntil time.Time) ([]outbox.Event, error) {
+	ret := _m.Called(ctx, limit, leaseUntil)
+
+	var r0 []outbox.Event
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, int64, time.Time) ([]outbox.Event, error)); ok {
+		return rf(ctx, limit, leaseUntil)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, int64, time.Time) []outbox.Event); ok {
+		r0 = rf(ctx, limit, leaseUntil)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]outbox.Event)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, int64, time.Time) error); ok {
+		r1 = rf(ctx, limit, leaseUntil)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// OutboxRepository_ClaimPending_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ClaimPending'
+type OutboxRepository_ClaimPending_Call struct {
+	*mock.Call
+}
+
+// ClaimPending is a helper method to define mock.On call
+//   - ctx context.Context
+//   - limit int64
+//   - leaseUntil time.Time
+func (_e *OutboxRepository_Expecter) ClaimPending(ctx interface{}, limit interface{}, leaseUntil interface{}) *OutboxRepository_ClaimPending_Call {
+	return &OutboxRepository_ClaimPending_Call{Call: _e.mock.On("ClaimPending", ctx, limit, leaseUntil)}
+}
+
+func (_c *OutboxRepository_ClaimPending_Call) Run(run func(ctx context.Context, limit int64, leaseUntil time.Time)) *OutboxRepository_ClaimPending_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(int64), args[2].(time.Time))
+	})
+	return _c
+}
+
+func (_c *OutboxRepository_ClaimPending_Call) Return(_a0 []outbox.Event, _a1 error) *OutboxRepository_ClaimPending_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *OutboxRepository_ClaimPending_Call) RunAndReturn(run func(context.Context, int64, time.Time) ([]outbox.Event, error)) *OutboxRepository_ClaimPending_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// DeleteSent provides a mock function with given fields: ctx, retention, limit
+func (_m *OutboxRepository) DeleteSent(ctx context.Context, retention time.Duration, limit int64) error {
+	ret := _m.Called(ctx, retention, limit)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, time.Duration, int64) error); ok {
+		r0 = rf(ctx, retention, limit)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// OutboxRepository_DeleteSent_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'DeleteSent'
+type OutboxRepository_DeleteSent_Call struct {
+	*mock.Call
+}
+
+// DeleteSent is a helper method to define mock.On call
+//   - ctx context.Context
+//   - retention time.Duration
+//   - limit int64
+func (_e *OutboxRepository_Expecter) DeleteSent(ctx interface{}, retention interface{}, limit interface{}) *OutboxRepository_DeleteSent_Call {
+	return &OutboxRepository_DeleteSent_Call{Call: _e.mock.On("DeleteSent", ctx, retention, limit)}
+}
+
+func (_c *OutboxRepository_DeleteSent_Call) Run(run func(ctx context.Context, retention time.Duration, limit int64)) *OutboxRepository_DeleteSent_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(time.Duration), args[2].(int64))
+	})
+	return _c
+}
+
+func (_c *OutboxRepository_DeleteSent_Call) Return(_a0 error) *OutboxRepository_DeleteSent_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *OutboxRepository_DeleteSent_Call) RunAndReturn(run func(context.Context, time.Duration, int64) error) *OutboxRepository_DeleteSent_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// InTransaction provides a mock function with given fields: ctx, f
+func (_m *OutboxRepository) InTransaction(ctx context.Context, f func(context.Context) error) error {
+	ret := _m.Called(ctx, f)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, func(context.Context) error) error); ok {
+		r0 = rf(ctx, f)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// OutboxRepository_InTransaction_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'InTransaction'
+type OutboxRepository_InTransaction_Call struct {
+	*mock.Call
+}
+
+// InTransaction is a helper method to define mock.On call
+//   - ctx context.Context
+//   - f func(context.Context) error
+func (_e *OutboxRepository_Expecter) InTransaction(ctx interface{}, f interface{}) *OutboxRepository_InTransaction_Call {
+	return &OutboxRepository_InTransaction_Call{Call: _e.mock.On("InTransaction", ctx, f)}
+}
+
+func (_c *OutboxRepository_InTransaction_Call) Run(run func(ctx context.Context, f func(context.Context) error)) *OutboxRepository_InTransaction_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(func(context.Context) error))
+	})
+	return _c
+}
+
+func (_c *OutboxRepository_InTransaction_Call) Return(_a0 error) *OutboxRepository_InTransaction_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *OutboxRepository_InTransaction_Call) RunAndReturn(run func(context.Context, func(context.Context) error) error) *OutboxRepository_InTransaction_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// MarkFailed provides a mock function with given fields: ctx, id, nextAttemptTime, lastError
+func (_m *OutboxRepository) MarkFailed(ctx context.Context, id int64, nextAttemptTime time.Time, lastError string) error {
+	ret := _m.Called(ctx, id, nextAttemptTime, lastError)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, int64, time.Time, string) error); ok {
+		r0 = rf(ctx, id, nextAttemptTime, lastError)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// OutboxRepository_MarkFailed_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'MarkFailed'
+type OutboxRepository_MarkFailed_Call struct {
+	*mock.Call
+}
+
+// MarkFailed is a helper method to define mock.On call
+//   - ctx context.Context
+//   - id int64
+//   - nextAttemptTime time.Time
+//   - lastError string
+func (_e *OutboxRepository_Expecter) MarkFailed(ctx interface{}, id interface{}, nextAttemptTime interface{}, lastError interface{}) *OutboxRepository_MarkFailed_Call {
+	return &OutboxRepository_MarkFailed_Call{Call: _e.mock.On("MarkFailed", ctx, id, nextAttemptTime, lastError)}
+}
+
+func (_c *OutboxRepository_MarkFailed_Call) Run(run func(ctx context.Context, id int64, nextAttemptTime time.Time, lastError string)) *OutboxRepository_MarkFailed_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(int64), args[2].(time.Time), args[3].(string))
+	})
+	return _c
+}
+
+func (_c *OutboxRepository_MarkFailed_Call) Return(_a0 error) *OutboxRepository_MarkFailed_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *OutboxRepository_MarkFailed_Call) RunAndReturn(run func(context.Context, int64, time.Time, string) error) *OutboxRepository_MarkFailed_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// MarkSent provides a mock function with given fields: ctx, ids
+func (_m *OutboxRepository) MarkSent(ctx context.Context, ids []int64) error {
+	ret := _m.Called(ctx, ids)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, []int64) error); ok {
+		r0 = rf(ctx, ids)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// OutboxRepository_MarkSent_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'MarkSent'
+type OutboxRepository_MarkSent_Call struct {
+	*mock.Call
+}
+
+// MarkSent is a helper method to define mock.On call
+//   - ctx context.Context
+//   - ids []int64
+func (_e *OutboxRepository_Expecter) MarkSent(ctx interface{}, ids interface{}) *OutboxRepository_MarkSent_Call {
+	return &OutboxRepository_MarkSent_Call{Call: _e.mock.On("MarkSent", ctx, ids)}
+}
+
+func (_c *OutboxRepository_MarkSent_Call) Run(run func(ctx context.Context, ids []int64)) *OutboxRepository_MarkSent_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].([]int64))
+	})
+	return _c
+}
+
+func (_c *OutboxRepository_MarkSent_Call) Return(_a0 error) *OutboxRepository_MarkSent_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *OutboxRepository_MarkSent_Call) RunAndReturn(run func(context.Context, []int64) error) *OutboxRepository_MarkSent_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Release provides a mock function with given fields: ctx, ids
+func (_m *OutboxRepository) Release(ctx context.Context, ids []int64) error {
+	ret := _m.Called(ctx, ids)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, []int64) error); ok {
+		r0 = rf(ctx, ids)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// OutboxRepository_Release_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Release'
+type OutboxRepository_Release_Call struct {
+	*mock.Call
+}
+
+// Release is a helper method to define mock.On call
+//   - ctx context.Context
+//   - ids []int64
+func (_e *OutboxRepository_Expecter) Release(ctx interface{}, ids interface{}) *OutboxRepository_Release_Call {
+	return &OutboxRepository_Release_Call{Call: _e.mock.On("Release", ctx, ids)}
+}
+
+func (_c *OutboxRepository_Release_Call) Run(run func(ctx context.Context, ids []int64)) *OutboxRepository_Release_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].([]int64))
+	})
+	return _c
+}
+
+func (_c *OutboxRepository_Release_Call) Return(_a0 error) *OutboxRepository_Release_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *OutboxRepository_Release_Call) RunAndReturn(run func(context.Context, []int64) error) *OutboxRepository_Release_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// TryLock provides a mock function with given fields: ctx
+func (_m *OutboxRepository) TryLock(ctx context.Context) (bool, error) {
+	ret := _m.Called(ctx)
+
+	var r0 bool
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context) (bool, error)); ok {
+		return rf(ctx)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context) bool); ok {
+		r0 = rf(ctx)
+	} else {
+		r0 = ret.Get(0).(bool)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context) error); ok {
+		r1 = rf(ctx)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// OutboxRepository_TryLock_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'TryLock'
+type OutboxRepository_TryLock_Call struct {
+	*mock.Call
+}
+
+// TryLock is a helper method to define mock.On call
+//   - ctx context.Context
+func (_e *OutboxRepository_Expecter) TryLock(ctx interface{}) *OutboxRepository_TryLock_Call {
+	return &OutboxRepository_TryLock_Call{Call: _e.mock.On("TryLock", ctx)}
+}
+
+func (_c *OutboxRepository_TryLock_Call) Run(run func(ctx context.Context)) *OutboxRepository_TryLock_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context))
+	})
+	return _c
+}
+
+func (_c *OutboxRepository_TryLock_Call) Return(_a0 bool, _a1 error) *OutboxRepository_TryLock_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *OutboxRepository_TryLock_Call) RunAndReturn(run func(context.Context) (bool, error)) *OutboxRepository_TryLock_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// NewOutboxRepository creates a new instance of OutboxRepository. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewOutboxRepository(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *OutboxRepository {
+	mock := &OutboxRepository{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
@@ -0,0 +1,80 @@
+// Code generated by mockery v2.33.0. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+
+	publisher "github.com/pollykon/avito_test_task/internal/publisher"
+	mock "github.com/stretchr/testify/mock"
+)
+
+// Publisher is an autogenerated mock type for the Publisher type
+type Publisher struct {
+	mock.Mock
+}
+
+type Publisher_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *Publisher) EXPECT() *Publisher_Expecter {
+	return &Publisher_Expecter{mock: &_m.Mock}
+}
+
+// Publish provides a mock function with given fields: ctx, message
+func (_m *Publisher) Publish(ctx context.Context, message publisher.Message) error {
+	ret := _m.Called(ctx, message)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, publisher.Message) error); ok {
+		r0 = rf(ctx, message)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// Publisher_Publish_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Publish'
+type Publisher_Publish_Call struct {
+	*mock.Call
+}
+
+// Publish is a helper method to define mock.On call
+//   - ctx context.Context
+//   - message publisher.Message
+func (_e *Publisher_Expecter) Publish(ctx interface{}, message interface{}) *Publisher_Publish_Call {
+	return &Publisher_Publish_Call{Call: _e.mock.On("Publish", ctx, message)}
+}
+
+func (_c *Publisher_Publish_Call) Run(run func(ctx context.Context, message publisher.Message)) *Publisher_Publish_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(publisher.Message))
+	})
+	return _c
+}
+
+func (_c *Publisher_Publish_Call) Return(_a0 error) *Publisher_Publish_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *Publisher_Publish_Call) RunAndReturn(run func(context.Context, publisher.Message) error) *Publisher_Publish_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// NewPublisher creates a new instance of Publisher. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewPublisher(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *Publisher {
+	mock := &Publisher{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
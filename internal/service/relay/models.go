@@ -0,0 +1,16 @@
+package relay
+
+import outboxRepo "github.com/pollykon/avito_test_task/internal/repository/outbox"
+
+// orderingKey identifies events which are sent in order: events of one user or events about segments
+type orderingKey struct {
+	userID   int64
+	segments bool
+}
+
+func newOrderingKey(event outboxRepo.Event) orderingKey {
+	if event.UserID == nil {
+		return orderingKey{segments: true}
+	}
+	return orderingKey{userID: *event.UserID}
+}
@@ -0,0 +1,126 @@
+package relay
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/pollykon/avito_test_task/internal/publisher"
+	outboxRepo "github.com/pollykon/avito_test_task/internal/repository/outbox"
+)
+
+// Relay delivers events from outbox to publisher at-least-once
+type Relay struct {
+	outboxRepo OutboxRepository
+	publisher  Publisher
+	timeout    time.Duration
+	minBackoff time.Duration
+	maxBackoff time.Duration
+	now        func() time.Time
+}
+
+func New(
+	outboxRepo OutboxRepository,
+	publisher Publisher,
+	timeout time.Duration,
+	minBackoff time.Duration,
+	maxBackoff time.Duration,
+) *Relay {
+	return &Relay{
+		outboxRepo: outboxRepo,
+		publisher:  publisher,
+		timeout:    timeout,
+		minBackoff: minBackoff,
+		maxBackoff: maxBackoff,
+		now:        time.Now,
+	}
+}
+
+// Relay sends batch of pending events and returns number of sent ones. Failed event is retried with exponential
+// backoff, and the rest events of its user wait for it to keep order. Events are claimed under relay lock for time
+// of sending the whole batch and published outside of transaction, result of every event is saved right after
+// it is published. If another relay is claiming events, nothing is sent
+func (r *Relay) Relay(ctx context.Context, batchSize int64) (int64, error) {
+	var events []outboxRepo.Event
+	err := r.outboxRepo.InTransaction(ctx, func(ctx context.Context) error {
+		locked, err := r.outboxRepo.TryLock(ctx)
+		if err != nil {
+			return err
+		}
+
+		if !locked {
+			return nil
+		}
+
+		events, err = r.outboxRepo.ClaimPending(ctx, batchSize, r.now().Add(r.timeout*time.Duration(batchSize)))
+		return err
+	})
+	if err != nil {
+		return 0, fmt.Errorf("error from relay service while claiming events: %w", err)
+	}
+
+	if len(events) == 0 {
+		return 0, nil
+	}
+
+	var sent int64
+	failedKeys := make(map[orderingKey]struct{})
+	var skippedIDs []int64
+	for _, event := range events {
+		if _, failed := failedKeys[newOrderingKey(event)]; failed {
+			skippedIDs = append(skippedIDs, event.ID)
+			continue
+		}
+
+		errPublish := r.publisher.Publish(ctx, publisher.Message{
+			ID:         event.ID,
+			Type:       event.Type,
+			UserID:     event.UserID,
+			Payload:    event.Payload,
+			OccurredAt: event.InsertTime,
+		})
+		if errPublish != nil {
+			failedKeys[newOrderingKey(event)] = struct{}{}
+
+			err = r.outboxRepo.MarkFailed(ctx, event.ID, r.now().Add(r.backoff(event.Attempts)), errPublish.Error())
+			if err != nil {
+				// not saved events are sent again after lease expires
+				return sent, fmt.Errorf("error from relay service while saving failure of event %d: %w", event.ID, err)
+			}
+			continue
+		}
+
+		err = r.outboxRepo.MarkSent(ctx, []int64{event.ID})
+		if err != nil {
+			return sent, fmt.Errorf("error from relay service while marking event %d as sent: %w", event.ID, err)
+		}
+		sent++
+	}
+
+	// events after failed ones wait for them instead of lease
+	err = r.outboxRepo.Release(ctx, skippedIDs)
+	if err != nil {
+		return sent, fmt.Errorf("error from relay service while releasing events: %w", err)
+	}
+
+	return sent, nil
+}
+
+// DeleteSent removes events which were sent more than retention ago
+func (r *Relay) DeleteSent(ctx context.Context, retention time.Duration, batchSize int64) error {
+	return r.outboxRepo.DeleteSent(ctx, retention, batchSize)
+}
+
+// backoff returns delay before next attempt: minBackoff doubled after every failed attempt, but not more than maxBackoff
+func (r *Relay) backoff(attempts int64) time.Duration {
+	delay := r.minBackoff
+	for i := int64(0); i < attempts && delay < r.maxBackoff; i++ {
+		delay *= 2
+	}
+
+	if delay > r.maxBackoff {
+		return r.maxBackoff
+	}
+
+	return delay
+}
@@ -0,0 +1,200 @@
+package relay
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+
+	"github.com/pollykon/avito_test_task/internal/publisher"
+	outboxRepository "github.com/pollykon/avito_test_task/internal/repository/outbox"
+	"github.com/pollykon/avito_test_task/internal/service/relay/mocks"
+)
+
+func TestRelay_Relay_Success(t *testing.T) {
+	batchSize := int64(100)
+	now := time.Date(2023, 8, 1, 0, 0, 0, 0, time.UTC)
+
+	firstUserID, secondUserID := int64(10), int64(11)
+	events := []outboxRepository.Event{
+		{ID: 1, UserID: &firstUserID, Type: outboxRepository.EventTypeUserAddedToSegment, Payload: []byte(`{}`), Attempts: 2},
+		{ID: 2, UserID: &secondUserID, Type: outboxRepository.EventTypeUserAddedToSegment, Payload: []byte(`{}`)},
+		{ID: 3, UserID: &firstUserID, Type: outboxRepository.EventTypeUserDeletedFromSegment, Payload: []byte(`{}`)},
+		// events about segments have no user, they don't wait for events of users
+		{ID: 4, Type: outboxRepository.EventTypeSegmentCreated, Payload: []byte(`{}`)},
+	}
+	errFromPublisher := fmt.Errorf("error from publisher")
+
+	outboxRepoMock := mocks.NewOutboxRepository(t)
+	outboxRepoMock.EXPECT().InTransaction(context.Background(), mock.Anything).
+		RunAndReturn(func(ctx context.Context, f func(context.Context) error) error {
+			return f(ctx)
+		})
+	outboxRepoMock.EXPECT().TryLock(context.Background()).Return(true, nil)
+	// events are claimed for time of sending the whole batch
+	outboxRepoMock.EXPECT().ClaimPending(context.Background(), batchSize, now.Add(100*time.Second)).Return(events, nil)
+	// first event of user 10 failed after 2 attempts, so it is delayed for 1s * 2^2
+	outboxRepoMock.EXPECT().MarkFailed(context.Background(), int64(1), now.Add(4*time.Second), errFromPublisher.Error()).
+		Return(nil)
+	outboxRepoMock.EXPECT().MarkSent(context.Background(), []int64{2}).Return(nil)
+	outboxRepoMock.EXPECT().MarkSent(context.Background(), []int64{4}).Return(nil)
+	// the next event of user 10 waits for the failed one
+	outboxRepoMock.EXPECT().Release(context.Background(), []int64{3}).Return(nil)
+
+	publisherMock := mocks.NewPublisher(t)
+	publisherMock.EXPECT().Publish(context.Background(), publisher.Message{
+		ID: 1, Type: outboxRepository.EventTypeUserAddedToSegment, UserID: &firstUserID, Payload: []byte(`{}`),
+	}).Return(errFromPublisher)
+	publisherMock.EXPECT().Publish(context.Background(), publisher.Message{
+		ID: 2, Type: outboxRepository.EventTypeUserAddedToSegment, UserID: &secondUserID, Payload: []byte(`{}`),
+	}).Return(nil)
+	publisherMock.EXPECT().Publish(context.Background(), publisher.Message{
+		ID: 4, Type: outboxRepository.EventTypeSegmentCreated, Payload: []byte(`{}`),
+	}).Return(nil)
+
+	relay := New(outboxRepoMock, publisherMock, time.Second, time.Second, time.Minute)
+	relay.now = func() time.Time { return now }
+
+	sent, err := relay.Relay(context.Background(), batchSize)
+
+	assert.NoError(t, err)
+	assert.Equal(t, int64(2), sent)
+}
+
+func TestRelay_Relay_NotLocked(t *testing.T) {
+	outboxRepoMock := mocks.NewOutboxRepository(t)
+	outboxRepoMock.EXPECT().InTransaction(context.Background(), mock.Anything).
+		RunAndReturn(func(ctx context.Context, f func(context.Context) error) error {
+			return f(ctx)
+		})
+	outboxRepoMock.EXPECT().TryLock(context.Background()).Return(false, nil)
+
+	relay := New(outboxRepoMock, mocks.NewPublisher(t), time.Second, time.Second, time.Minute)
+
+	sent, err := relay.Relay(context.Background(), 100)
+
+	assert.NoError(t, err)
+	assert.Equal(t, int64(0), sent)
+}
+
+func TestRelay_Relay_Error(t *testing.T) {
+	batchSize := int64(100)
+	userID := int64(10)
+	events := []outboxRepository.Event{{ID: 1, UserID: &userID, Payload: []byte(`{}`)}}
+
+	errFromOutboxRepo := fmt.Errorf("error from outbox repo")
+	errFromPublisher := fmt.Errorf("error from publisher")
+
+	tt := []struct {
+		name string
+
+		buildOutboxRepoMock func(mock *mocks.OutboxRepository)
+		buildPublisherMock  func(mock *mocks.Publisher)
+
+		expectedError error
+	}{
+		{
+			name: "unexpected_error_from_try_lock",
+
+			buildOutboxRepoMock: func(repo *mocks.OutboxRepository) {
+				repo.EXPECT().TryLock(context.Background()).Return(false, errFromOutboxRepo)
+			},
+			buildPublisherMock: nil,
+
+			expectedError: errFromOutboxRepo,
+		},
+		{
+			name: "unexpected_error_from_claim_pending",
+
+			buildOutboxRepoMock: func(repo *mocks.OutboxRepository) {
+				repo.EXPECT().TryLock(context.Background()).Return(true, nil)
+				repo.EXPECT().ClaimPending(context.Background(), batchSize, mock.Anything).Return(nil, errFromOutboxRepo)
+			},
+			buildPublisherMock: nil,
+
+			expectedError: errFromOutboxRepo,
+		},
+		{
+			name: "unexpected_error_from_mark_failed",
+
+			buildOutboxRepoMock: func(repo *mocks.OutboxRepository) {
+				repo.EXPECT().TryLock(context.Background()).Return(true, nil)
+				repo.EXPECT().ClaimPending(context.Background(), batchSize, mock.Anything).Return(events, nil)
+				repo.EXPECT().MarkFailed(context.Background(), int64(1), mock.Anything, errFromPublisher.Error()).
+					Return(errFromOutboxRepo)
+			},
+			buildPublisherMock: func(publisher *mocks.Publisher) {
+				publisher.EXPECT().Publish(context.Background(), mock.Anything).Return(errFromPublisher)
+			},
+
+			expectedError: errFromOutboxRepo,
+		},
+		{
+			name: "unexpected_error_from_mark_sent",
+
+			buildOutboxRepoMock: func(repo *mocks.OutboxRepository) {
+				repo.EXPECT().TryLock(context.Background()).Return(true, nil)
+				repo.EXPECT().ClaimPending(context.Background(), batchSize, mock.Anything).Return(events, nil)
+				repo.EXPECT().MarkSent(context.Background(), []int64{1}).Return(errFromOutboxRepo)
+			},
+			buildPublisherMock: func(publisher *mocks.Publisher) {
+				publisher.EXPECT().Publish(context.Background(), mock.Anything).Return(nil)
+			},
+
+			expectedError: errFromOutboxRepo,
+		},
+		{
+			name: "unexpected_error_from_release",
+
+			buildOutboxRepoMock: func(repo *mocks.OutboxRepository) {
+				repo.EXPECT().TryLock(context.Background()).Return(true, nil)
+				repo.EXPECT().ClaimPending(context.Background(), batchSize, mock.Anything).Return(events, nil)
+				repo.EXPECT().MarkFailed(context.Background(), int64(1), mock.Anything, errFromPublisher.Error()).
+					Return(nil)
+				repo.EXPECT().Release(context.Background(), []int64(nil)).Return(errFromOutboxRepo)
+			},
+			buildPublisherMock: func(publisher *mocks.Publisher) {
+				publisher.EXPECT().Publish(context.Background(), mock.Anything).Return(errFromPublisher)
+			},
+
+			expectedError: errFromOutboxRepo,
+		},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			outboxRepoMock := mocks.NewOutboxRepository(t)
+			outboxRepoMock.EXPECT().InTransaction(context.Background(), mock.Anything).
+				RunAndReturn(func(ctx context.Context, f func(context.Context) error) error {
+					return f(ctx)
+				})
+			if tc.buildOutboxRepoMock != nil {
+				tc.buildOutboxRepoMock(outboxRepoMock)
+			}
+
+			publisherMock := mocks.NewPublisher(t)
+			if tc.buildPublisherMock != nil {
+				tc.buildPublisherMock(publisherMock)
+			}
+
+			relay := New(outboxRepoMock, publisherMock, time.Second, time.Second, time.Minute)
+
+			sent, err := relay.Relay(context.Background(), batchSize)
+
+			assert.ErrorIs(t, err, tc.expectedError)
+			assert.Equal(t, int64(0), sent)
+		})
+	}
+}
+
+func TestRelay_backoff(t *testing.T) {
+	relay := New(mocks.NewOutboxRepository(t), mocks.NewPublisher(t), time.Second, time.Second, time.Minute)
+
+	assert.Equal(t, time.Second, relay.backoff(0))
+	assert.Equal(t, 8*time.Second, relay.backoff(3))
+	assert.Equal(t, time.Minute, relay.backoff(10))
+	assert.Equal(t, time.Minute, relay.backoff(1000))
+}
@@ -5,12 +5,14 @@ import (
 	"context"
 	"time"
 
+	outboxRepo "github.com/pollykon/avito_test_task/internal/repository/outbox"
 	segmentRepo "github.com/pollykon/avito_test_task/internal/repository/segment"
 )
 
 type SegmentRepository interface {
 	AddSegment(ctx context.Context, slug string, percent *int64) error
 	DeleteSegment(ctx context.Context, slug string) error
+	GetSegments(ctx context.Context) ([]segmentRepo.Segment, error)
 	AddUserToSegment(ctx context.Context, userID int64, slugs []string, ttl *time.Duration) error
 	DeleteUserFromSegment(ctx context.Context, userID int64, slugs []string) error
 	GetUserActiveSegments(ctx context.Context, userID int64, userHash int64) (segmentRepo.UserSegments, error)
@@ -18,7 +20,11 @@ type SegmentRepository interface {
 }
 
 type LogRepository interface {
-	Add(ctx context.Context, userID int64, segment []string, operation string) error
+	Add(ctx context.Context, userID int64, segment []string, operation string, actor string) error
+}
+
+type OutboxRepository interface {
+	Add(ctx context.Context, events []outboxRepo.Event) error
 }
 
 type Transaction interface {
@@ -21,13 +21,13 @@ func (_m *LogRepository) EXPECT() *LogRepository_Expecter {
 	return &LogRepository_Expecter{mock: &_m.Mock}
 }
 
-// Add provides a mock function with given fields: ctx, userID, _a2, operation
-func (_m *LogRepository) Add(ctx context.Context, userID int64, _a2 []string, operation string) error {
-	ret := _m.Called(ctx, userID, _a2, operation)
+// Add provides a mock function with given fields: ctx, userID, _a2, operation, actor
+func (_m *LogRepository) Add(ctx context.Context, userID int64, _a2 []string, operation string, actor string) error {
+	ret := _m.Called(ctx, userID, _a2, operation, actor)
 
 	var r0 error
-	if rf, ok := ret.Get(0).(func(context.Context, int64, []string, string) error); ok {
-		r0 = rf(ctx, userID, _a2, operation)
+	if rf, ok := ret.Get(0).(func(context.Context, int64, []string, string, string) error); ok {
+		r0 = rf(ctx, userID, _a2, operation, actor)
 	} else {
 		r0 = ret.Error(0)
 	}
@@ -45,13 +45,14 @@ type LogRepository_Add_Call struct {
 //   - userID int64
 //   - _a2 []string
 //   - operation string
-func (_e *LogRepository_Expecter) Add(ctx interface{}, userID interface{}, _a2 interface{}, operation interface{}) *LogRepository_Add_Call {
-	return &LogRepository_Add_Call{Call: _e.mock.On("Add", ctx, userID, _a2, operation)}
+//   - actor string
+func (_e *LogRepository_Expecter) Add(ctx interface{}, userID interface{}, _a2 interface{}, operation interface{}, actor interface{}) *LogRepository_Add_Call {
+	return &LogRepository_Add_Call{Call: _e.mock.On("Add", ctx, userID, _a2, operation, actor)}
 }
 
-func (_c *LogRepository_Add_Call) Run(run func(ctx context.Context, userID int64, _a2 []string, operation string)) *LogRepository_Add_Call {
+func (_c *LogRepository_Add_Call) Run(run func(ctx context.Context, userID int64, _a2 []string, operation string, actor string)) *LogRepository_Add_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		run(args[0].(context.Context), args[1].(int64), args[2].([]string), args[3].(string))
+		run(args[0].(context.Context), args[1].(int64), args[2].([]string), args[3].(string), args[4].(string))
 	})
 	return _c
 }
@@ -61,7 +62,7 @@ func (_c *LogRepository_Add_Call) Return(_a0 error) *LogRepository_Add_Call {
 	return _c
 }
 
-func (_c *LogRepository_Add_Call) RunAndReturn(run func(context.Context, int64, []string, string) error) *LogRepository_Add_Call {
+func (_c *LogRepository_Add_Call) RunAndReturn(run func(context.Context, int64, []string, string, string) error) *LogRepository_Add_Call {
 	_c.Call.Return(run)
 	return _c
 }
@@ -200,6 +200,60 @@ func (_c *SegmentRepository_DeleteUserFromSegment_Call) RunAndReturn(run func(co
 	return _c
 }
 
+// GetSegments provides a mock function with given fields: ctx
+func (_m *SegmentRepository) GetSegments(ctx context.Context) ([]repositorysegment.Segment, error) {
+	ret := _m.Called(ctx)
+
+	var r0 []repositorysegment.Segment
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context) ([]repositorysegment.Segment, error)); ok {
+		return rf(ctx)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context) []repositorysegment.Segment); ok {
+		r0 = rf(ctx)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]repositorysegment.Segment)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context) error); ok {
+		r1 = rf(ctx)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// SegmentRepository_GetSegments_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetSegments'
+type SegmentRepository_GetSegments_Call struct {
+	*mock.Call
+}
+
+// GetSegments is a helper method to define mock.On call
+//   - ctx context.Context
+func (_e *SegmentRepository_Expecter) GetSegments(ctx interface{}) *SegmentRepository_GetSegments_Call {
+	return &SegmentRepository_GetSegments_Call{Call: _e.mock.On("GetSegments", ctx)}
+}
+
+func (_c *SegmentRepository_GetSegments_Call) Run(run func(ctx context.Context)) *SegmentRepository_GetSegments_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context))
+	})
+	return _c
+}
+
+func (_c *SegmentRepository_GetSegments_Call) Return(_a0 []repositorysegment.Segment, _a1 error) *SegmentRepository_GetSegments_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *SegmentRepository_GetSegments_Call) RunAndReturn(run func(context.Context) ([]repositorysegment.Segment, error)) *SegmentRepository_GetSegments_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // GetUserActiveSegments provides a mock function with given fields: ctx, userID, userHash
 func (_m *SegmentRepository) GetUserActiveSegments(ctx context.Context, userID int64, userHash int64) (repositorysegment.UserSegments, error) {
 	ret := _m.Called(ctx, userID, userHash)
@@ -0,0 +1,6 @@
+package segment
+
+type Segment struct {
+	Slug    string
+	Percent *int64
+}
@@ -8,58 +8,148 @@ import (
 	"strconv"
 	"time"
 
+	"go.opentelemetry.io/otel/attribute"
+
+	"github.com/pollykon/avito_test_task/internal/actor"
+	"github.com/pollykon/avito_test_task/internal/metrics"
 	logRepository "github.com/pollykon/avito_test_task/internal/repository/log"
+	outboxRepository "github.com/pollykon/avito_test_task/internal/repository/outbox"
 	segmentRepository "github.com/pollykon/avito_test_task/internal/repository/segment"
+	"github.com/pollykon/avito_test_task/internal/tracing"
 )
 
 type Service struct {
 	logRepo     LogRepository
 	segmentRepo SegmentRepository
+	outboxRepo  OutboxRepository
 }
 
-func New(logRepo LogRepository, segmentRepo SegmentRepository) Service {
-	return Service{logRepo: logRepo, segmentRepo: segmentRepo}
+func New(logRepo LogRepository, segmentRepo SegmentRepository, outboxRepo OutboxRepository) Service {
+	return Service{logRepo: logRepo, segmentRepo: segmentRepo, outboxRepo: outboxRepo}
 }
 
-func (s Service) AddSegment(ctx context.Context, slug string, percent *int64) error {
-	err := s.segmentRepo.AddSegment(ctx, slug, percent)
-	if err != nil {
-		if errors.Is(err, segmentRepository.ErrSegmentAlreadyExists) {
-			return ErrSegmentAlreadyExists
+func (s Service) AddSegment(ctx context.Context, slug string, percent *int64) (err error) {
+	ctx, span := tracing.Start(ctx, "segment.Service.AddSegment", attribute.String("segment.slug", slug))
+	defer func() { tracing.End(span, err) }()
+
+	err = s.segmentRepo.InTransaction(ctx, func(ctx context.Context) error {
+		err := s.segmentRepo.AddSegment(ctx, slug, percent)
+		if err != nil {
+			if errors.Is(err, segmentRepository.ErrSegmentAlreadyExists) {
+				return ErrSegmentAlreadyExists
+			}
+			return fmt.Errorf("error from segment service while inserting into segment: %w", err)
+		}
+
+		event := outboxRepository.NewSegmentEvent(outboxRepository.EventTypeSegmentCreated, slug, percent)
+		err = s.outboxRepo.Add(ctx, []outboxRepository.Event{event})
+		if err != nil {
+			return fmt.Errorf("error from segment service while adding events: %w", err)
 		}
-		return fmt.Errorf("error from segment service while inserting into segment: %w", err)
+
+		return nil
+	})
+	if err != nil {
+		return fmt.Errorf("error from segment service in transaction: %w", err)
 	}
 
 	return nil
 }
 
-func (s Service) DeleteSegment(ctx context.Context, slug string) error {
-	err := s.segmentRepo.DeleteSegment(ctx, slug)
-	if err != nil {
-		if errors.Is(err, segmentRepository.ErrSegmentNotExist) {
-			return ErrSegmentNotExist
+func (s Service) DeleteSegment(ctx context.Context, slug string) (err error) {
+	ctx, span := tracing.Start(ctx, "segment.Service.DeleteSegment", attribute.String("segment.slug", slug))
+	defer func() { tracing.End(span, err) }()
+
+	err = s.segmentRepo.InTransaction(ctx, func(ctx context.Context) error {
+		err := s.segmentRepo.DeleteSegment(ctx, slug)
+		if err != nil {
+			if errors.Is(err, segmentRepository.ErrSegmentNotExist) {
+				return ErrSegmentNotExist
+			}
+			return fmt.Errorf("error from segment service while deleting from segment: %w", err)
 		}
-		return fmt.Errorf("error from segment service while deleting from segment: %w", err)
+
+		event := outboxRepository.NewSegmentEvent(outboxRepository.EventTypeSegmentDeleted, slug, nil)
+		err = s.outboxRepo.Add(ctx, []outboxRepository.Event{event})
+		if err != nil {
+			return fmt.Errorf("error from segment service while adding events: %w", err)
+		}
+
+		return nil
+	})
+	if err != nil {
+		return fmt.Errorf("error from segment service in transaction: %w", err)
 	}
 
 	return nil
 }
 
-func (s Service) AddUserToSegment(ctx context.Context, userID int64, slugs []string, ttl *time.Duration) error {
+func (s Service) GetSegments(ctx context.Context) (result []Segment, err error) {
+	ctx, span := tracing.Start(ctx, "segment.Service.GetSegments")
+	defer func() { tracing.End(span, err) }()
+
+	segments, err := s.segmentRepo.GetSegments(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("error from segment service while getting segments: %w", err)
+	}
+
+	result = make([]Segment, 0, len(segments))
+	for _, segment := range segments {
+		result = append(result, Segment{Slug: segment.ID, Percent: segment.Percent})
+	}
+
+	return result, nil
+}
+
+func (s Service) AddUserToSegment(
+	ctx context.Context,
+	userID int64,
+	slugs []string,
+	ttl *time.Duration,
+) (err error) {
+	ctx, span := tracing.Start(
+		ctx,
+		"segment.Service.AddUserToSegment",
+		attribute.Int64("user.id", userID),
+		attribute.StringSlice("segment.slugs", slugs),
+	)
+	defer func() { tracing.End(span, err) }()
+
+	return s.addUserToSegment(ctx, userID, slugs, ttl, outboxRepository.ReasonRequest)
+}
+
+func (s Service) addUserToSegment(
+	ctx context.Context,
+	userID int64,
+	slugs []string,
+	ttl *time.Duration,
+	reason string,
+) error {
 	err := s.segmentRepo.InTransaction(ctx, func(ctx context.Context) error {
 		err := s.segmentRepo.AddUserToSegment(ctx, userID, slugs, ttl)
 		if err != nil {
 			if errors.Is(err, segmentRepository.ErrUserAlreadyInSegment) {
 				return ErrUserAlreadyInSegment
 			}
+			if errors.Is(err, segmentRepository.ErrSegmentNotExist) {
+				return ErrSegmentNotExist
+			}
 			return fmt.Errorf("error from segment service while adding user to segment: %w", err)
 		}
 
-		err = s.logRepo.Add(ctx, userID, slugs, logRepository.OperationTypeAdd)
+		err = s.logRepo.Add(ctx, userID, slugs, logRepository.OperationTypeAdd, actorID(ctx))
 		if err != nil {
 			return fmt.Errorf("error from segment service while adding log: %w", err)
 		}
 
+		events := outboxRepository.NewMembershipEvents(
+			outboxRepository.EventTypeUserAddedToSegment, userID, slugs, reason,
+		)
+		err = s.outboxRepo.Add(ctx, events)
+		if err != nil {
+			return fmt.Errorf("error from segment service while adding events: %w", err)
+		}
+
 		return nil
 	})
 	if err != nil {
@@ -69,18 +159,34 @@ func (s Service) AddUserToSegment(ctx context.Context, userID int64, slugs []str
 	return nil
 }
 
-func (s Service) DeleteUserFromSegment(ctx context.Context, userID int64, slugs []string) error {
-	err := s.segmentRepo.InTransaction(ctx, func(ctx context.Context) error {
+func (s Service) DeleteUserFromSegment(ctx context.Context, userID int64, slugs []string) (err error) {
+	ctx, span := tracing.Start(
+		ctx,
+		"segment.Service.DeleteUserFromSegment",
+		attribute.Int64("user.id", userID),
+		attribute.StringSlice("segment.slugs", slugs),
+	)
+	defer func() { tracing.End(span, err) }()
+
+	err = s.segmentRepo.InTransaction(ctx, func(ctx context.Context) error {
 		err := s.segmentRepo.DeleteUserFromSegment(ctx, userID, slugs)
 		if err != nil {
 			return fmt.Errorf("error from segment service while deleting user from segment: %w", err)
 		}
 
-		err = s.logRepo.Add(ctx, userID, slugs, logRepository.OperationTypeDelete)
+		err = s.logRepo.Add(ctx, userID, slugs, logRepository.OperationTypeDelete, actorID(ctx))
 		if err != nil {
 			return fmt.Errorf("error from segment service while adding log: %w", err)
 		}
 
+		events := outboxRepository.NewMembershipEvents(
+			outboxRepository.EventTypeUserDeletedFromSegment, userID, slugs, outboxRepository.ReasonRequest,
+		)
+		err = s.outboxRepo.Add(ctx, events)
+		if err != nil {
+			return fmt.Errorf("error from segment service while adding events: %w", err)
+		}
+
 		return nil
 	})
 	if err != nil {
@@ -90,26 +196,42 @@ func (s Service) DeleteUserFromSegment(ctx context.Context, userID int64, slugs
 	return nil
 }
 
-func (s Service) GetUserActiveSegments(ctx context.Context, userID int64) ([]string, error) {
-	var activeSegments []string
-	err := s.segmentRepo.InTransaction(ctx, func(ctx context.Context) error {
-		hashProcessor := fnv.New32a()
-		_, _ = hashProcessor.Write([]byte(strconv.FormatInt(userID, 10)))
-		userHash := int64(hashProcessor.Sum32())
+// GetUserActiveSegments returns active segments of user and adds user to percent segments which user falls into.
+// Segments are evaluated without transaction, so they may be read from replica. Only if user must be added to new
+// percent segments, they are evaluated again and added in transaction on primary
+func (s Service) GetUserActiveSegments(ctx context.Context, userID int64) (activeSegments []string, err error) {
+	ctx, span := tracing.Start(ctx, "segment.Service.GetUserActiveSegments", attribute.Int64("user.id", userID))
+	defer func() { tracing.End(span, err) }()
 
+	hashProcessor := fnv.New32a()
+	_, _ = hashProcessor.Write([]byte(strconv.FormatInt(userID, 10)))
+	userHash := int64(hashProcessor.Sum32())
+
+	segments, err := s.segmentRepo.GetUserActiveSegments(ctx, userID, userHash)
+	if err != nil {
+		return nil, fmt.Errorf("error from segment service while getting user's segments: %w", err)
+	}
+
+	if len(segments.NewSegments) == 0 {
+		return segments.ActiveSegments, nil
+	}
+
+	// transaction may be retried, so only segments read by its last run are used after it
+	var materialized segmentRepository.UserSegments
+	err = s.segmentRepo.InTransaction(ctx, func(ctx context.Context) error {
 		segments, err := s.segmentRepo.GetUserActiveSegments(ctx, userID, userHash)
 		if err != nil {
 			return fmt.Errorf("error from segment service while getting user's segments: %w", err)
 		}
 
 		if len(segments.NewSegments) != 0 {
-			err = s.AddUserToSegment(ctx, userID, segments.NewSegments, nil)
+			err = s.addUserToSegment(ctx, userID, segments.NewSegments, nil, outboxRepository.ReasonPercent)
 			if err != nil {
 				return fmt.Errorf("error from segment service while adding percent segments: %w", err)
 			}
 		}
 
-		activeSegments = append(segments.ActiveSegments, segments.NewSegments...)
+		materialized = segments
 
 		return nil
 	})
@@ -117,5 +239,13 @@ func (s Service) GetUserActiveSegments(ctx context.Context, userID int64) ([]str
 		return nil, fmt.Errorf("error from segment service in transaction: %w", err)
 	}
 
-	return activeSegments, nil
+	metrics.PercentMembershipsMaterialized.Add(float64(len(materialized.NewSegments)))
+
+	return append(materialized.ActiveSegments, materialized.NewSegments...), nil
+}
+
+// actorID returns id of actor who made request, empty for operations made by service itself
+func actorID(ctx context.Context) string {
+	a, _ := actor.FromContext(ctx)
+	return a.ID
 }
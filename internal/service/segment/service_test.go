@@ -11,7 +11,9 @@ import (
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
 
+	"github.com/pollykon/avito_test_task/internal/actor"
 	logRepository "github.com/pollykon/avito_test_task/internal/repository/log"
+	outboxRepository "github.com/pollykon/avito_test_task/internal/repository/outbox"
 	segmentRepository "github.com/pollykon/avito_test_task/internal/repository/segment"
 	"github.com/pollykon/avito_test_task/internal/service/segment/mocks"
 )
@@ -29,24 +31,34 @@ func TestService_GetUserActiveSegments_Success(t *testing.T) {
 	segmentRepoMock := mocks.NewSegmentRepository(t)
 	logRepoMock := mocks.NewLogRepository(t)
 
-	segmentRepoMock.EXPECT().InTransaction(context.Background(), mock.Anything).
+	segmentRepoMock.EXPECT().InTransaction(mock.Anything, mock.Anything).
 		Run(func(ctx context.Context, f func(context.Context) error) {
 			assert.NoError(t, f(ctx))
 		}).Return(nil)
 
+	// segments are evaluated again in transaction before user is added to new ones
 	segmentRepoMock.EXPECT().
-		GetUserActiveSegments(context.Background(), sentUserID, userHash).
-		Return(expectedSegments, nil)
+		GetUserActiveSegments(mock.Anything, sentUserID, userHash).
+		Return(expectedSegments, nil).Times(2)
 
 	segmentRepoMock.EXPECT().
-		AddUserToSegment(context.Background(), sentUserID, expectedSegments.NewSegments, (*time.Duration)(nil)).
+		AddUserToSegment(mock.Anything, sentUserID, expectedSegments.NewSegments, (*time.Duration)(nil)).
 		Return(nil)
 
 	logRepoMock.EXPECT().
-		Add(context.Background(), sentUserID, expectedSegments.NewSegments, logRepository.OperationTypeAdd).
+		Add(mock.Anything, sentUserID, expectedSegments.NewSegments, logRepository.OperationTypeAdd, "").
 		Return(nil)
 
-	service := New(logRepoMock, segmentRepoMock)
+	outboxRepoMock := mocks.NewOutboxRepository(t)
+	outboxRepoMock.EXPECT().
+		Add(mock.Anything, []outboxRepository.Event{{
+			UserID:  &sentUserID,
+			Type:    outboxRepository.EventTypeUserAddedToSegment,
+			Payload: []byte(`{"userId":10,"segmentId":"AVITO_VOICE_MESSAGES","reason":"percent"}`),
+		}}).
+		Return(nil)
+
+	service := New(logRepoMock, segmentRepoMock, outboxRepoMock)
 
 	currentSegments, err := service.GetUserActiveSegments(context.Background(), sentUserID)
 
@@ -56,6 +68,22 @@ func TestService_GetUserActiveSegments_Success(t *testing.T) {
 	assert.Equal(t, expectedActiveSegments, currentSegments)
 }
 
+func TestService_GetUserActiveSegments_WithoutNewSegments(t *testing.T) {
+	sentUserID := int64(10)
+
+	// there is nothing to add, so segments aren't evaluated again in transaction
+	segmentRepoMock := mocks.NewSegmentRepository(t)
+	segmentRepoMock.EXPECT().GetUserActiveSegments(mock.Anything, sentUserID, mock.Anything).
+		Return(segmentRepository.UserSegments{ActiveSegments: []string{"AVITO_DISCOUNT_50"}}, nil)
+
+	service := New(mocks.NewLogRepository(t), segmentRepoMock, mocks.NewOutboxRepository(t))
+
+	currentSegments, err := service.GetUserActiveSegments(context.Background(), sentUserID)
+
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"AVITO_DISCOUNT_50"}, currentSegments)
+}
+
 func TestService_GetUserActiveSegments_Error(t *testing.T) {
 	sentUserID := int64(10)
 	hashProcessor := fnv.New32a()
@@ -86,13 +114,16 @@ func TestService_GetUserActiveSegments_Error(t *testing.T) {
 			sentUserHash: sentUserHash,
 
 			buildSegmentRepoMock: func(repo *mocks.SegmentRepository) {
-				repo.EXPECT().InTransaction(context.Background(), mock.Anything).
+				repo.EXPECT().InTransaction(mock.Anything, mock.Anything).
 					Run(func(ctx context.Context, f func(context.Context) error) {
 						assert.NoError(t, f(ctx))
 					}).Return(expectedErrorFromRepo)
 
-				repo.EXPECT().GetUserActiveSegments(context.Background(), sentUserID, sentUserHash).
-					Return(segmentRepository.UserSegments{}, nil)
+				repo.EXPECT().GetUserActiveSegments(mock.Anything, sentUserID, sentUserHash).
+					Return(expectedSegments, nil).Once()
+				// user was added to new segments by concurrent request
+				repo.EXPECT().GetUserActiveSegments(mock.Anything, sentUserID, sentUserHash).
+					Return(segmentRepository.UserSegments{}, nil).Once()
 			},
 
 			expectedSegments: segmentRepository.UserSegments{},
@@ -105,12 +136,7 @@ func TestService_GetUserActiveSegments_Error(t *testing.T) {
 			sentUserHash: sentUserHash,
 
 			buildSegmentRepoMock: func(repo *mocks.SegmentRepository) {
-				repo.EXPECT().InTransaction(context.Background(), mock.Anything).
-					Run(func(ctx context.Context, f func(context.Context) error) {
-						assert.ErrorIs(t, f(ctx), expectedErrorFromRepo)
-					}).Return(expectedErrorFromRepo)
-
-				repo.EXPECT().GetUserActiveSegments(context.Background(), sentUserID, sentUserHash).
+				repo.EXPECT().GetUserActiveSegments(mock.Anything, sentUserID, sentUserHash).
 					Return(segmentRepository.UserSegments{}, expectedErrorFromRepo)
 			},
 
@@ -124,16 +150,16 @@ func TestService_GetUserActiveSegments_Error(t *testing.T) {
 			sentUserHash: sentUserHash,
 
 			buildSegmentRepoMock: func(repo *mocks.SegmentRepository) {
-				repo.EXPECT().InTransaction(context.Background(), mock.Anything).
+				repo.EXPECT().InTransaction(mock.Anything, mock.Anything).
 					Run(func(ctx context.Context, f func(context.Context) error) {
 						assert.ErrorIs(t, f(ctx), expectedErrorFromRepo)
 					}).Return(expectedErrorFromRepo)
 
-				repo.EXPECT().GetUserActiveSegments(context.Background(), sentUserID, sentUserHash).
-					Return(expectedSegments, nil)
+				repo.EXPECT().GetUserActiveSegments(mock.Anything, sentUserID, sentUserHash).
+					Return(expectedSegments, nil).Times(2)
 
 				repo.EXPECT().AddUserToSegment(
-					context.Background(),
+					mock.Anything,
 					sentUserID,
 					expectedSegments.NewSegments,
 					(*time.Duration)(nil)).
@@ -142,7 +168,7 @@ func TestService_GetUserActiveSegments_Error(t *testing.T) {
 
 			buildLogRepoMock: func(repo *mocks.LogRepository) {
 				repo.EXPECT().
-					Add(context.Background(), sentUserID, expectedSegments.NewSegments, logRepository.OperationTypeAdd).
+					Add(mock.Anything, sentUserID, expectedSegments.NewSegments, logRepository.OperationTypeAdd, "").
 					Return(expectedErrorFromRepo)
 			},
 
@@ -158,7 +184,7 @@ func TestService_GetUserActiveSegments_Error(t *testing.T) {
 				tc.buildSegmentRepoMock(segmentRepoMock)
 			}
 
-			service := New(mocks.NewLogRepository(t), segmentRepoMock)
+			service := New(mocks.NewLogRepository(t), segmentRepoMock, mocks.NewOutboxRepository(t))
 
 			currentSegments, err := service.GetUserActiveSegments(context.Background(), tc.sentUserID)
 
@@ -175,9 +201,21 @@ func TestService_AddSegment_Success(t *testing.T) {
 	sentPercent := int64(2)
 
 	segmentRepoMock := mocks.NewSegmentRepository(t)
-	segmentRepoMock.EXPECT().AddSegment(context.Background(), sentSlug, &sentPercent).Return(nil)
+	segmentRepoMock.EXPECT().InTransaction(mock.Anything, mock.Anything).
+		Run(func(ctx context.Context, f func(context.Context) error) {
+			assert.NoError(t, f(ctx))
+		}).Return(nil)
+	segmentRepoMock.EXPECT().AddSegment(mock.Anything, sentSlug, &sentPercent).Return(nil)
+
+	outboxRepoMock := mocks.NewOutboxRepository(t)
+	outboxRepoMock.EXPECT().
+		Add(mock.Anything, []outboxRepository.Event{{
+			Type:    outboxRepository.EventTypeSegmentCreated,
+			Payload: []byte(`{"segmentId":"AVITO","percent":2}`),
+		}}).
+		Return(nil)
 
-	service := New(mocks.NewLogRepository(t), segmentRepoMock)
+	service := New(mocks.NewLogRepository(t), segmentRepoMock, outboxRepoMock)
 
 	err := service.AddSegment(context.Background(), sentSlug, &sentPercent)
 
@@ -195,6 +233,7 @@ func TestService_AddSegment_Error(t *testing.T) {
 		sentPercent *int64
 
 		buildMockSegmentRepo func(mock *mocks.SegmentRepository)
+		buildMockOutboxRepo  func(mock *mocks.OutboxRepository)
 
 		expectedError error
 	}{
@@ -205,7 +244,7 @@ func TestService_AddSegment_Error(t *testing.T) {
 			sentPercent: &sentPercent,
 
 			buildMockSegmentRepo: func(repo *mocks.SegmentRepository) {
-				repo.EXPECT().AddSegment(context.Background(), "AVITO", &sentPercent).Return(expectedErrorFromRepo)
+				repo.EXPECT().AddSegment(mock.Anything, "AVITO", &sentPercent).Return(expectedErrorFromRepo)
 			},
 
 			expectedError: expectedErrorFromRepo,
@@ -216,23 +255,47 @@ func TestService_AddSegment_Error(t *testing.T) {
 			sentPercent: &sentPercent,
 
 			buildMockSegmentRepo: func(repo *mocks.SegmentRepository) {
-				repo.EXPECT().AddSegment(context.Background(), "AVITO", &sentPercent).
+				repo.EXPECT().AddSegment(mock.Anything, "AVITO", &sentPercent).
 					Return(segmentRepository.ErrSegmentAlreadyExists)
 			},
 
 			expectedError: ErrSegmentAlreadyExists,
+		}, {
+			name: "unexpected_error_from_outbox_repo",
+
+			sentSlug:    "AVITO",
+			sentPercent: &sentPercent,
+
+			buildMockSegmentRepo: func(repo *mocks.SegmentRepository) {
+				repo.EXPECT().AddSegment(mock.Anything, "AVITO", &sentPercent).Return(nil)
+			},
+			buildMockOutboxRepo: func(repo *mocks.OutboxRepository) {
+				repo.EXPECT().Add(mock.Anything, mock.Anything).Return(expectedErrorFromRepo)
+			},
+
+			expectedError: expectedErrorFromRepo,
 		},
 	}
 
 	for _, tc := range tt {
 		t.Run(tc.name, func(t *testing.T) {
 			segmentRepoMock := mocks.NewSegmentRepository(t)
+			segmentRepoMock.EXPECT().InTransaction(mock.Anything, mock.Anything).
+				RunAndReturn(func(ctx context.Context, f func(context.Context) error) error {
+					return f(ctx)
+				})
 
 			if tc.buildMockSegmentRepo != nil {
 				tc.buildMockSegmentRepo(segmentRepoMock)
 			}
 
-			service := New(mocks.NewLogRepository(t), segmentRepoMock)
+			outboxRepoMock := mocks.NewOutboxRepository(t)
+
+			if tc.buildMockOutboxRepo != nil {
+				tc.buildMockOutboxRepo(outboxRepoMock)
+			}
+
+			service := New(mocks.NewLogRepository(t), segmentRepoMock, outboxRepoMock)
 
 			err := service.AddSegment(context.Background(), tc.sentSlug, tc.sentPercent)
 
@@ -245,8 +308,21 @@ func TestService_DeleteSegment_Success(t *testing.T) {
 	sentSlug := "AVITO"
 
 	segmentRepoMock := mocks.NewSegmentRepository(t)
-	segmentRepoMock.EXPECT().DeleteSegment(context.Background(), sentSlug).Return(nil)
-	service := New(mocks.NewLogRepository(t), segmentRepoMock)
+	segmentRepoMock.EXPECT().InTransaction(mock.Anything, mock.Anything).
+		Run(func(ctx context.Context, f func(context.Context) error) {
+			assert.NoError(t, f(ctx))
+		}).Return(nil)
+	segmentRepoMock.EXPECT().DeleteSegment(mock.Anything, sentSlug).Return(nil)
+
+	outboxRepoMock := mocks.NewOutboxRepository(t)
+	outboxRepoMock.EXPECT().
+		Add(mock.Anything, []outboxRepository.Event{{
+			Type:    outboxRepository.EventTypeSegmentDeleted,
+			Payload: []byte(`{"segmentId":"AVITO"}`),
+		}}).
+		Return(nil)
+
+	service := New(mocks.NewLogRepository(t), segmentRepoMock, outboxRepoMock)
 
 	err := service.DeleteSegment(context.Background(), sentSlug)
 
@@ -261,7 +337,8 @@ func TestService_DeleteSegment_Error(t *testing.T) {
 
 		sentSlug string
 
-		buildRepositoryMock func(mock *mocks.SegmentRepository)
+		buildRepositoryMock       func(mock *mocks.SegmentRepository)
+		buildOutboxRepositoryMock func(mock *mocks.OutboxRepository)
 
 		expectedError error
 	}{
@@ -271,7 +348,7 @@ func TestService_DeleteSegment_Error(t *testing.T) {
 			sentSlug: "AVITO",
 
 			buildRepositoryMock: func(repo *mocks.SegmentRepository) {
-				repo.EXPECT().DeleteSegment(context.Background(), "AVITO").
+				repo.EXPECT().DeleteSegment(mock.Anything, "AVITO").
 					Return(expectedErrorFromRepo)
 			},
 
@@ -283,23 +360,47 @@ func TestService_DeleteSegment_Error(t *testing.T) {
 			sentSlug: "AVITO",
 
 			buildRepositoryMock: func(repo *mocks.SegmentRepository) {
-				repo.EXPECT().DeleteSegment(context.Background(), "AVITO").
+				repo.EXPECT().DeleteSegment(mock.Anything, "AVITO").
 					Return(segmentRepository.ErrSegmentNotExist)
 			},
 
 			expectedError: ErrSegmentNotExist,
 		},
+		{
+			name: "unexpected_error_from_outbox_repo",
+
+			sentSlug: "AVITO",
+
+			buildRepositoryMock: func(repo *mocks.SegmentRepository) {
+				repo.EXPECT().DeleteSegment(mock.Anything, "AVITO").Return(nil)
+			},
+			buildOutboxRepositoryMock: func(repo *mocks.OutboxRepository) {
+				repo.EXPECT().Add(mock.Anything, mock.Anything).Return(expectedErrorFromRepo)
+			},
+
+			expectedError: expectedErrorFromRepo,
+		},
 	}
 
 	for _, tc := range tt {
 		t.Run(tc.name, func(t *testing.T) {
 			segmentRepoMock := mocks.NewSegmentRepository(t)
+			segmentRepoMock.EXPECT().InTransaction(mock.Anything, mock.Anything).
+				RunAndReturn(func(ctx context.Context, f func(context.Context) error) error {
+					return f(ctx)
+				})
 
-			if segmentRepoMock != nil {
+			if tc.buildRepositoryMock != nil {
 				tc.buildRepositoryMock(segmentRepoMock)
 			}
 
-			service := New(mocks.NewLogRepository(t), segmentRepoMock)
+			outboxRepoMock := mocks.NewOutboxRepository(t)
+
+			if tc.buildOutboxRepositoryMock != nil {
+				tc.buildOutboxRepositoryMock(outboxRepoMock)
+			}
+
+			service := New(mocks.NewLogRepository(t), segmentRepoMock, outboxRepoMock)
 
 			err := service.DeleteSegment(context.Background(), tc.sentSlug)
 
@@ -314,20 +415,29 @@ func TestService_AddUserToSegment_Success(t *testing.T) {
 	sentTTLToDuration := time.Duration(2) * time.Hour
 
 	segmentRepoMock := mocks.NewSegmentRepository(t)
-	segmentRepoMock.EXPECT().InTransaction(context.Background(), mock.Anything).
+	segmentRepoMock.EXPECT().InTransaction(mock.Anything, mock.Anything).
 		Run(func(ctx context.Context, f func(context.Context) error) {
 			assert.NoError(t, f(ctx))
 		}).Return(nil)
 
 	segmentRepoMock.EXPECT().
-		AddUserToSegment(context.Background(), sentUserID, sentSlugs, &sentTTLToDuration).
+		AddUserToSegment(mock.Anything, sentUserID, sentSlugs, &sentTTLToDuration).
 		Return(nil)
 
 	logRepoMock := mocks.NewLogRepository(t)
 	logRepoMock.EXPECT().
-		Add(context.Background(), sentUserID, sentSlugs, logRepository.OperationTypeAdd).Return(nil)
+		Add(mock.Anything, sentUserID, sentSlugs, logRepository.OperationTypeAdd, "").Return(nil)
+
+	outboxRepoMock := mocks.NewOutboxRepository(t)
+	outboxRepoMock.EXPECT().
+		Add(mock.Anything, []outboxRepository.Event{{
+			UserID:  &sentUserID,
+			Type:    outboxRepository.EventTypeUserAddedToSegment,
+			Payload: []byte(`{"userId":10,"segmentId":"AVITO","reason":"request"}`),
+		}}).
+		Return(nil)
 
-	service := New(logRepoMock, segmentRepoMock)
+	service := New(logRepoMock, segmentRepoMock, outboxRepoMock)
 
 	err := service.AddUserToSegment(context.Background(), int64(sentUserID), sentSlugs, &sentTTLToDuration)
 
@@ -348,6 +458,7 @@ func TestService_AddUserToSegment_Error(t *testing.T) {
 
 		buildSegmentRepoMock func(mock *mocks.SegmentRepository)
 		buildLogRepoMock     func(mock *mocks.LogRepository)
+		buildOutboxRepoMock  func(mock *mocks.OutboxRepository)
 
 		expectedErrorFromRepo error
 	}{
@@ -359,18 +470,21 @@ func TestService_AddUserToSegment_Error(t *testing.T) {
 			sentTTL:    &positiveTTL,
 
 			buildSegmentRepoMock: func(repo *mocks.SegmentRepository) {
-				repo.EXPECT().InTransaction(context.Background(), mock.Anything).
+				repo.EXPECT().InTransaction(mock.Anything, mock.Anything).
 					Run(func(ctx context.Context, f func(context.Context) error) {
 						assert.NoError(t, f(ctx))
 					}).Return(expectedErrorFromRepo)
 
-				repo.EXPECT().AddUserToSegment(context.Background(), int64(2), []string{"AVITO"}, &positiveTTLDuration).
+				repo.EXPECT().AddUserToSegment(mock.Anything, int64(2), []string{"AVITO"}, &positiveTTLDuration).
 					Return(nil)
 			},
 			buildLogRepoMock: func(repo *mocks.LogRepository) {
-				repo.EXPECT().Add(context.Background(), int64(2), []string{"AVITO"}, logRepository.OperationTypeAdd).
+				repo.EXPECT().Add(mock.Anything, int64(2), []string{"AVITO"}, logRepository.OperationTypeAdd, "").
 					Return(nil)
 			},
+			buildOutboxRepoMock: func(repo *mocks.OutboxRepository) {
+				repo.EXPECT().Add(mock.Anything, mock.Anything).Return(nil)
+			},
 
 			expectedErrorFromRepo: expectedErrorFromRepo,
 		},
@@ -382,18 +496,38 @@ func TestService_AddUserToSegment_Error(t *testing.T) {
 			sentTTL:    &positiveTTL,
 
 			buildSegmentRepoMock: func(repo *mocks.SegmentRepository) {
-				repo.EXPECT().InTransaction(context.Background(), mock.Anything).
+				repo.EXPECT().InTransaction(mock.Anything, mock.Anything).
 					Run(func(ctx context.Context, f func(context.Context) error) {
 						assert.ErrorIs(t, f(ctx), expectedErrorFromRepo)
 					}).Return(expectedErrorFromRepo)
 
-				repo.EXPECT().AddUserToSegment(context.Background(), int64(2), []string{"AVITO"}, &positiveTTLDuration).
+				repo.EXPECT().AddUserToSegment(mock.Anything, int64(2), []string{"AVITO"}, &positiveTTLDuration).
 					Return(expectedErrorFromRepo)
 			},
 			buildLogRepoMock: nil,
 
 			expectedErrorFromRepo: expectedErrorFromRepo,
 		},
+		{
+			name: "segment_not_exist",
+
+			sentUserID: int64(2),
+			sentSlugs:  []string{"AVITO"},
+			sentTTL:    &positiveTTL,
+
+			buildSegmentRepoMock: func(repo *mocks.SegmentRepository) {
+				repo.EXPECT().InTransaction(mock.Anything, mock.Anything).
+					RunAndReturn(func(ctx context.Context, f func(context.Context) error) error {
+						return f(ctx)
+					})
+
+				repo.EXPECT().AddUserToSegment(mock.Anything, int64(2), []string{"AVITO"}, &positiveTTLDuration).
+					Return(segmentRepository.ErrSegmentNotExist)
+			},
+			buildLogRepoMock: nil,
+
+			expectedErrorFromRepo: ErrSegmentNotExist,
+		},
 		{
 			name: "unexpected_error_from_log_repo",
 
@@ -402,19 +536,45 @@ func TestService_AddUserToSegment_Error(t *testing.T) {
 			sentTTL:    &positiveTTL,
 
 			buildSegmentRepoMock: func(repo *mocks.SegmentRepository) {
-				repo.EXPECT().InTransaction(context.Background(), mock.Anything).
+				repo.EXPECT().InTransaction(mock.Anything, mock.Anything).
 					Run(func(ctx context.Context, f func(context.Context) error) {
 						assert.ErrorIs(t, f(ctx), expectedErrorFromRepo)
 					}).Return(expectedErrorFromRepo)
 
-				repo.EXPECT().AddUserToSegment(context.Background(), int64(2), []string{"AVITO"}, &positiveTTLDuration).
+				repo.EXPECT().AddUserToSegment(mock.Anything, int64(2), []string{"AVITO"}, &positiveTTLDuration).
 					Return(nil)
 			},
 			buildLogRepoMock: func(repo *mocks.LogRepository) {
-				repo.EXPECT().Add(context.Background(), int64(2), []string{"AVITO"}, logRepository.OperationTypeAdd).
+				repo.EXPECT().Add(mock.Anything, int64(2), []string{"AVITO"}, logRepository.OperationTypeAdd, "").
 					Return(expectedErrorFromRepo)
 			},
 
+			expectedErrorFromRepo: expectedErrorFromRepo,
+		},
+		{
+			name: "unexpected_error_from_outbox_repo",
+
+			sentUserID: int64(2),
+			sentSlugs:  []string{"AVITO"},
+			sentTTL:    &positiveTTL,
+
+			buildSegmentRepoMock: func(repo *mocks.SegmentRepository) {
+				repo.EXPECT().InTransaction(mock.Anything, mock.Anything).
+					Run(func(ctx context.Context, f func(context.Context) error) {
+						assert.ErrorIs(t, f(ctx), expectedErrorFromRepo)
+					}).Return(expectedErrorFromRepo)
+
+				repo.EXPECT().AddUserToSegment(mock.Anything, int64(2), []string{"AVITO"}, &positiveTTLDuration).
+					Return(nil)
+			},
+			buildLogRepoMock: func(repo *mocks.LogRepository) {
+				repo.EXPECT().Add(mock.Anything, int64(2), []string{"AVITO"}, logRepository.OperationTypeAdd, "").
+					Return(nil)
+			},
+			buildOutboxRepoMock: func(repo *mocks.OutboxRepository) {
+				repo.EXPECT().Add(mock.Anything, mock.Anything).Return(expectedErrorFromRepo)
+			},
+
 			expectedErrorFromRepo: expectedErrorFromRepo,
 		},
 	}
@@ -435,7 +595,13 @@ func TestService_AddUserToSegment_Error(t *testing.T) {
 				tc.buildLogRepoMock(logRepoMock)
 			}
 
-			service := New(logRepoMock, segmentRepoMock)
+			outboxRepoMock := mocks.NewOutboxRepository(t)
+
+			if tc.buildOutboxRepoMock != nil {
+				tc.buildOutboxRepoMock(outboxRepoMock)
+			}
+
+			service := New(logRepoMock, segmentRepoMock, outboxRepoMock)
 
 			err := service.AddUserToSegment(context.Background(), tc.sentUserID, tc.sentSlugs, &positiveTTLDuration)
 
@@ -449,26 +615,57 @@ func TestService_DeleteUserFromSegments_Success(t *testing.T) {
 	sentSlugs := []string{"AVITO"}
 
 	segmentRepoMock := mocks.NewSegmentRepository(t)
-	segmentRepoMock.EXPECT().InTransaction(context.Background(), mock.Anything).
+	segmentRepoMock.EXPECT().InTransaction(mock.Anything, mock.Anything).
 		Run(func(ctx context.Context, f func(context.Context) error) {
 			assert.NoError(t, f(ctx))
 		}).Return(nil)
 
 	segmentRepoMock.EXPECT().
-		DeleteUserFromSegment(context.Background(), sentUserID, sentSlugs).
+		DeleteUserFromSegment(mock.Anything, sentUserID, sentSlugs).
 		Return(nil)
 
 	logRepoMock := mocks.NewLogRepository(t)
-	logRepoMock.EXPECT().Add(context.Background(), sentUserID, sentSlugs, logRepository.OperationTypeDelete).
+	logRepoMock.EXPECT().Add(mock.Anything, sentUserID, sentSlugs, logRepository.OperationTypeDelete, "").
 		Return(nil)
 
-	service := New(logRepoMock, segmentRepoMock)
+	outboxRepoMock := mocks.NewOutboxRepository(t)
+	outboxRepoMock.EXPECT().
+		Add(mock.Anything, []outboxRepository.Event{{
+			UserID:  &sentUserID,
+			Type:    outboxRepository.EventTypeUserDeletedFromSegment,
+			Payload: []byte(`{"userId":10,"segmentId":"AVITO","reason":"request"}`),
+		}}).
+		Return(nil)
+
+	service := New(logRepoMock, segmentRepoMock, outboxRepoMock)
 
 	err := service.DeleteUserFromSegment(context.Background(), sentUserID, sentSlugs)
 
 	assert.NoError(t, err)
 }
 
+func TestService_DeleteUserFromSegments_LogsActor(t *testing.T) {
+	ctx := actor.WithActor(context.Background(), actor.Actor{ID: "api_key:12"})
+
+	segmentRepoMock := mocks.NewSegmentRepository(t)
+	segmentRepoMock.EXPECT().InTransaction(mock.Anything, mock.Anything).
+		Run(func(ctx context.Context, f func(context.Context) error) {
+			assert.NoError(t, f(ctx))
+		}).Return(nil)
+	segmentRepoMock.EXPECT().DeleteUserFromSegment(mock.Anything, int64(10), []string{"AVITO"}).Return(nil)
+
+	logRepoMock := mocks.NewLogRepository(t)
+	logRepoMock.EXPECT().Add(mock.Anything, int64(10), []string{"AVITO"}, logRepository.OperationTypeDelete, "api_key:12").
+		Return(nil)
+
+	outboxRepoMock := mocks.NewOutboxRepository(t)
+	outboxRepoMock.EXPECT().Add(mock.Anything, mock.Anything).Return(nil)
+
+	err := New(logRepoMock, segmentRepoMock, outboxRepoMock).DeleteUserFromSegment(ctx, 10, []string{"AVITO"})
+
+	assert.NoError(t, err)
+}
+
 func TestService_DeleteUserFromSegments_Error(t *testing.T) {
 	expectedErrorFromRepo := fmt.Errorf("error from log repository")
 
@@ -480,6 +677,7 @@ func TestService_DeleteUserFromSegments_Error(t *testing.T) {
 
 		buildSegmentRepoMock func(mock *mocks.SegmentRepository)
 		buildLogRepoMock     func(mock *mocks.LogRepository)
+		buildOutboxRepoMock  func(mock *mocks.OutboxRepository)
 
 		expectedErrorFromRepo error
 	}{
@@ -490,18 +688,21 @@ func TestService_DeleteUserFromSegments_Error(t *testing.T) {
 			sentSlugs:  []string{"AVITO"},
 
 			buildSegmentRepoMock: func(repo *mocks.SegmentRepository) {
-				repo.EXPECT().InTransaction(context.Background(), mock.Anything).
+				repo.EXPECT().InTransaction(mock.Anything, mock.Anything).
 					Run(func(ctx context.Context, f func(context.Context) error) {
 						assert.NoError(t, f(ctx))
 					}).Return(expectedErrorFromRepo)
 
-				repo.EXPECT().DeleteUserFromSegment(context.Background(), int64(2), []string{"AVITO"}).
+				repo.EXPECT().DeleteUserFromSegment(mock.Anything, int64(2), []string{"AVITO"}).
 					Return(nil)
 			},
 			buildLogRepoMock: func(repo *mocks.LogRepository) {
-				repo.EXPECT().Add(context.Background(), int64(2), []string{"AVITO"}, logRepository.OperationTypeDelete).
+				repo.EXPECT().Add(mock.Anything, int64(2), []string{"AVITO"}, logRepository.OperationTypeDelete, "").
 					Return(nil)
 			},
+			buildOutboxRepoMock: func(repo *mocks.OutboxRepository) {
+				repo.EXPECT().Add(mock.Anything, mock.Anything).Return(nil)
+			},
 
 			expectedErrorFromRepo: expectedErrorFromRepo,
 		},
@@ -512,12 +713,12 @@ func TestService_DeleteUserFromSegments_Error(t *testing.T) {
 			sentSlugs:  []string{"AVITO"},
 
 			buildSegmentRepoMock: func(repo *mocks.SegmentRepository) {
-				repo.EXPECT().InTransaction(context.Background(), mock.Anything).
+				repo.EXPECT().InTransaction(mock.Anything, mock.Anything).
 					Run(func(ctx context.Context, f func(context.Context) error) {
 						assert.ErrorIs(t, f(ctx), expectedErrorFromRepo)
 					}).Return(expectedErrorFromRepo)
 
-				repo.EXPECT().DeleteUserFromSegment(context.Background(), int64(2), []string{"AVITO"}).
+				repo.EXPECT().DeleteUserFromSegment(mock.Anything, int64(2), []string{"AVITO"}).
 					Return(expectedErrorFromRepo)
 			},
 			buildLogRepoMock: nil,
@@ -531,19 +732,44 @@ func TestService_DeleteUserFromSegments_Error(t *testing.T) {
 			sentSlugs:  []string{"AVITO"},
 
 			buildSegmentRepoMock: func(repo *mocks.SegmentRepository) {
-				repo.EXPECT().InTransaction(context.Background(), mock.Anything).
+				repo.EXPECT().InTransaction(mock.Anything, mock.Anything).
 					Run(func(ctx context.Context, f func(context.Context) error) {
 						assert.ErrorIs(t, f(ctx), expectedErrorFromRepo)
 					}).Return(expectedErrorFromRepo)
 
-				repo.EXPECT().DeleteUserFromSegment(context.Background(), int64(2), []string{"AVITO"}).
+				repo.EXPECT().DeleteUserFromSegment(mock.Anything, int64(2), []string{"AVITO"}).
 					Return(nil)
 			},
 			buildLogRepoMock: func(repo *mocks.LogRepository) {
-				repo.EXPECT().Add(context.Background(), int64(2), []string{"AVITO"}, logRepository.OperationTypeDelete).
+				repo.EXPECT().Add(mock.Anything, int64(2), []string{"AVITO"}, logRepository.OperationTypeDelete, "").
 					Return(expectedErrorFromRepo)
 			},
 
+			expectedErrorFromRepo: expectedErrorFromRepo,
+		},
+		{
+			name: "unexpected_error_from_outbox_repo",
+
+			sentUserID: int64(2),
+			sentSlugs:  []string{"AVITO"},
+
+			buildSegmentRepoMock: func(repo *mocks.SegmentRepository) {
+				repo.EXPECT().InTransaction(mock.Anything, mock.Anything).
+					Run(func(ctx context.Context, f func(context.Context) error) {
+						assert.ErrorIs(t, f(ctx), expectedErrorFromRepo)
+					}).Return(expectedErrorFromRepo)
+
+				repo.EXPECT().DeleteUserFromSegment(mock.Anything, int64(2), []string{"AVITO"}).
+					Return(nil)
+			},
+			buildLogRepoMock: func(repo *mocks.LogRepository) {
+				repo.EXPECT().Add(mock.Anything, int64(2), []string{"AVITO"}, logRepository.OperationTypeDelete, "").
+					Return(nil)
+			},
+			buildOutboxRepoMock: func(repo *mocks.OutboxRepository) {
+				repo.EXPECT().Add(mock.Anything, mock.Anything).Return(expectedErrorFromRepo)
+			},
+
 			expectedErrorFromRepo: expectedErrorFromRepo,
 		},
 	}
@@ -562,7 +788,13 @@ func TestService_DeleteUserFromSegments_Error(t *testing.T) {
 				tc.buildLogRepoMock(logRepoMock)
 			}
 
-			service := New(logRepoMock, segmentRepoMock)
+			outboxRepoMock := mocks.NewOutboxRepository(t)
+
+			if tc.buildOutboxRepoMock != nil {
+				tc.buildOutboxRepoMock(outboxRepoMock)
+			}
+
+			service := New(logRepoMock, segmentRepoMock, outboxRepoMock)
 
 			err := service.DeleteUserFromSegment(context.Background(), tc.sentUserID, tc.sentSlugs)
 
@@ -570,3 +802,22 @@ func TestService_DeleteUserFromSegments_Error(t *testing.T) {
 		})
 	}
 }
+
+func TestService_GetSegments(t *testing.T) {
+	percent := int64(10)
+	errFromRepo := fmt.Errorf("error from segment repository")
+
+	segmentRepoMock := mocks.NewSegmentRepository(t)
+	segmentRepoMock.EXPECT().GetSegments(mock.Anything).
+		Return([]segmentRepository.Segment{{ID: "AVITO"}, {ID: "VOICE", Percent: &percent}}, nil).Once()
+	segmentRepoMock.EXPECT().GetSegments(mock.Anything).Return(nil, errFromRepo).Once()
+
+	service := New(mocks.NewLogRepository(t), segmentRepoMock, mocks.NewOutboxRepository(t))
+
+	segments, err := service.GetSegments(context.Background())
+	assert.NoError(t, err)
+	assert.Equal(t, []Segment{{Slug: "AVITO"}, {Slug: "VOICE", Percent: &percent}}, segments)
+
+	_, err = service.GetSegments(context.Background())
+	assert.ErrorIs(t, err, errFromRepo)
+}
@@ -0,0 +1,12 @@
+//go:generate mockery --all --output ./mocks --case underscore --with-expecter
+package stream
+
+import (
+	"context"
+
+	outboxRepo "github.com/pollykon/avito_test_task/internal/repository/outbox"
+)
+
+type OutboxRepository interface {
+	GetUsersEvents(ctx context.Context, userIDs []int64, afterID int64, limit int64) ([]outboxRepo.Event, error)
+}
@@ -0,0 +1,94 @@
+// Code generated by mockery v2.33.0. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+
+	outbox "github.com/pollykon/avito_test_task/internal/repository/outbox"
+	mock "github.com/stretchr/testify/mock"
+)
+
+// OutboxRepository is an autogenerated mock type for the OutboxRepository type
+type OutboxRepository struct {
+	mock.Mock
+}
+
+type OutboxRepository_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *OutboxRepository) EXPECT() *OutboxRepository_Expecter {
+	return &OutboxRepository_Expecter{mock: &_m.Mock}
+}
+
+// GetUsersEvents provides a mock function with given fields: ctx, userIDs, afterID, limit
+func (_m *OutboxRepository) GetUsersEvents(ctx context.Context, userIDs []int64, afterID int64, limit int64) ([]outbox.Event, error) {
+	ret := _m.Called(ctx, userIDs, afterID, limit)
+
+	var r0 []outbox.Event
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, []int64, int64, int64) ([]outbox.Event, error)); ok {
+		return rf(ctx, userIDs, afterID, limit)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, []int64, int64, int64) []outbox.Event); ok {
+		r0 = rf(ctx, userIDs, afterID, limit)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]outbox.Event)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, []int64, int64, int64) error); ok {
+		r1 = rf(ctx, userIDs, afterID, limit)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// OutboxRepository_GetUsersEvents_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetUsersEvents'
+type OutboxRepository_GetUsersEvents_Call struct {
+	*mock.Call
+}
+
+// GetUsersEvents is a helper method to define mock.On call
+//   - ctx context.Context
+//   - userIDs []int64
+//   - afterID int64
+//   - limit int64
+func (_e *OutboxRepository_Expecter) GetUsersEvents(ctx interface{}, userIDs interface{}, afterID interface{}, limit interface{}) *OutboxRepository_GetUsersEvents_Call {
+	return &OutboxRepository_GetUsersEvents_Call{Call: _e.mock.On("GetUsersEvents", ctx, userIDs, afterID, limit)}
+}
+
+func (_c *OutboxRepository_GetUsersEvents_Call) Run(run func(ctx context.Context, userIDs []int64, afterID int64, limit int64)) *OutboxRepository_GetUsersEvents_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].([]int64), args[2].(int64), args[3].(int64))
+	})
+	return _c
+}
+
+func (_c *OutboxRepository_GetUsersEvents_Call) Return(_a0 []outbox.Event, _a1 error) *OutboxRepository_GetUsersEvents_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *OutboxRepository_GetUsersEvents_Call) RunAndReturn(run func(context.Context, []int64, int64, int64) ([]outbox.Event, error)) *OutboxRepository_GetUsersEvents_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// NewOutboxRepository creates a new instance of OutboxRepository. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewOutboxRepository(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *OutboxRepository {
+	mock := &OutboxRepository{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
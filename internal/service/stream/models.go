@@ -0,0 +1,18 @@
+package stream
+
+import "github.com/pollykon/avito_test_task/internal/publisher"
+
+// Subscription receives events of users. Backlog contains events after last event id requested on subscribe,
+// Reset is true instead if too many events were missed and client must reload segments of users. Events receives
+// new events and may repeat some of the backlog. Events is closed when subscriber can't keep up or notifications
+// could be lost, then client should resubscribe from last received event
+type Subscription struct {
+	Backlog []publisher.Message
+	Reset   bool
+	Events  <-chan publisher.Message
+}
+
+type subscriber struct {
+	userIDs map[int64]struct{}
+	events  chan publisher.Message
+}
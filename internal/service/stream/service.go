@@ -0,0 +1,191 @@
+package stream
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sync"
+	"sync/atomic"
+
+	"github.com/lib/pq"
+
+	"github.com/pollykon/avito_test_task/internal/publisher"
+)
+
+// backlogPageSize is a number of events read from outbox at once while collecting backlog
+const backlogPageSize = 1000
+
+// ErrNotListening is returned by CheckListening until notifications are distributed
+var ErrNotListening = errors.New("outbox notifications aren't listened")
+
+// Service fans out outbox notifications to subscribers of users
+type Service struct {
+	outboxRepo OutboxRepository
+	bufferSize int
+	maxBacklog int
+	running    atomic.Bool
+
+	mu          sync.Mutex
+	subscribers map[*subscriber]struct{}
+}
+
+func New(outboxRepo OutboxRepository, bufferSize int, maxBacklog int) *Service {
+	return &Service{
+		outboxRepo:  outboxRepo,
+		bufferSize:  bufferSize,
+		maxBacklog:  maxBacklog,
+		subscribers: make(map[*subscriber]struct{}),
+	}
+}
+
+// Run distributes notifications until ctx is done or notifications are closed
+func (s *Service) Run(ctx context.Context, notifications <-chan *pq.Notification) {
+	s.running.Store(true)
+	defer s.running.Store(false)
+	defer s.closeAll()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case notification, ok := <-notifications:
+			if !ok {
+				return
+			}
+
+			// nil notification means that connection was reestablished and some notifications could be lost
+			if notification == nil {
+				s.closeAll()
+				continue
+			}
+
+			var message publisher.Message
+			err := json.Unmarshal([]byte(notification.Extra), &message)
+			if err != nil {
+				continue
+			}
+
+			s.publish(message)
+		}
+	}
+}
+
+// CheckListening returns ErrNotListening if notifications aren't distributed, subscribers wouldn't receive new events
+func (s *Service) CheckListening(_ context.Context) error {
+	if !s.running.Load() {
+		return ErrNotListening
+	}
+	return nil
+}
+
+// Subscribe starts receiving events of users. If lastEventID isn't 0, events after it are returned as backlog,
+// or subscription is reset if there are more than maxBacklog of them. Unsubscribe must be called when subscription
+// isn't needed anymore
+func (s *Service) Subscribe(ctx context.Context, userIDs []int64, lastEventID int64) (Subscription, func(), error) {
+	sub := &subscriber{
+		userIDs: make(map[int64]struct{}, len(userIDs)),
+		events:  make(chan publisher.Message, s.bufferSize),
+	}
+	for _, userID := range userIDs {
+		sub.userIDs[userID] = struct{}{}
+	}
+
+	// subscriber is registered before reading backlog, so events committed in between aren't missed
+	s.mu.Lock()
+	s.subscribers[sub] = struct{}{}
+	s.mu.Unlock()
+
+	unsubscribe := func() { s.remove(sub) }
+
+	var (
+		backlog []publisher.Message
+		reset   bool
+	)
+	if lastEventID > 0 {
+		var err error
+		backlog, reset, err = s.getBacklog(ctx, userIDs, lastEventID)
+		if err != nil {
+			unsubscribe()
+			return Subscription{}, nil, fmt.Errorf("error from stream service while getting backlog: %w", err)
+		}
+	}
+
+	return Subscription{Backlog: backlog, Reset: reset, Events: sub.events}, unsubscribe, nil
+}
+
+// getBacklog reads events of users after lastEventID page by page. Reading stops and reset is true as soon as
+// there are more than maxBacklog events, so old or forged id doesn't load whole outbox into memory
+func (s *Service) getBacklog(ctx context.Context, userIDs []int64, lastEventID int64) ([]publisher.Message, bool, error) {
+	var backlog []publisher.Message
+	for afterID := lastEventID; ; {
+		// one event more than maxBacklog is read to know that backlog is too large
+		limit := min(backlogPageSize, int64(s.maxBacklog-len(backlog)+1))
+		events, err := s.outboxRepo.GetUsersEvents(ctx, userIDs, afterID, limit)
+		if err != nil {
+			return nil, false, err
+		}
+
+		if len(backlog)+len(events) > s.maxBacklog {
+			return nil, true, nil
+		}
+
+		for _, event := range events {
+			backlog = append(backlog, publisher.Message{
+				ID:         event.ID,
+				Type:       event.Type,
+				UserID:     event.UserID,
+				Payload:    event.Payload,
+				OccurredAt: event.InsertTime,
+			})
+			afterID = event.ID
+		}
+
+		if int64(len(events)) < limit {
+			return backlog, false, nil
+		}
+	}
+}
+
+func (s *Service) publish(message publisher.Message) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	for sub := range s.subscribers {
+		// events about segments have no user and aren't streamed
+		if message.UserID == nil {
+			continue
+		}
+		if _, ok := sub.userIDs[*message.UserID]; !ok {
+			continue
+		}
+
+		select {
+		case sub.events <- message:
+		default:
+			// slow subscriber is dropped instead of blocking others, it resumes from last received event
+			delete(s.subscribers, sub)
+			close(sub.events)
+		}
+	}
+}
+
+func (s *Service) remove(sub *subscriber) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if _, ok := s.subscribers[sub]; ok {
+		delete(s.subscribers, sub)
+		close(sub.events)
+	}
+}
+
+func (s *Service) closeAll() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	for sub := range s.subscribers {
+		delete(s.subscribers, sub)
+		close(sub.events)
+	}
+}
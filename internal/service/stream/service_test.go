@@ -0,0 +1,195 @@
+package stream
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/lib/pq"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/pollykon/avito_test_task/internal/publisher"
+	outboxRepository "github.com/pollykon/avito_test_task/internal/repository/outbox"
+	"github.com/pollykon/avito_test_task/internal/service/stream/mocks"
+)
+
+func receive(t *testing.T, events <-chan publisher.Message) (publisher.Message, bool) {
+	t.Helper()
+
+	select {
+	case message, ok := <-events:
+		return message, ok
+	case <-time.After(time.Second):
+		t.Fatal("no event received")
+		return publisher.Message{}, false
+	}
+}
+
+func TestService_Subscribe_Backlog(t *testing.T) {
+	firstUserID, secondUserID := int64(10), int64(11)
+	userIDs := []int64{firstUserID, secondUserID}
+	insertTime := time.Date(2023, 8, 1, 0, 0, 0, 0, time.UTC)
+
+	outboxRepoMock := mocks.NewOutboxRepository(t)
+	outboxRepoMock.EXPECT().GetUsersEvents(context.Background(), userIDs, int64(5), int64(backlogPageSize)).
+		Return([]outboxRepository.Event{
+			{ID: 6, UserID: &firstUserID, Type: outboxRepository.EventTypeUserAddedToSegment, Payload: []byte(`{}`), InsertTime: insertTime},
+			{ID: 8, UserID: &secondUserID, Type: outboxRepository.EventTypeUserDeletedFromSegment, Payload: []byte(`{}`), InsertTime: insertTime},
+		}, nil)
+
+	service := New(outboxRepoMock, 10, 10000)
+
+	subscription, unsubscribe, err := service.Subscribe(context.Background(), userIDs, 5)
+	require.NoError(t, err)
+	defer unsubscribe()
+
+	assert.Equal(t, []publisher.Message{
+		{ID: 6, UserID: &firstUserID, Type: outboxRepository.EventTypeUserAddedToSegment, Payload: []byte(`{}`), OccurredAt: insertTime},
+		{ID: 8, UserID: &secondUserID, Type: outboxRepository.EventTypeUserDeletedFromSegment, Payload: []byte(`{}`), OccurredAt: insertTime},
+	}, subscription.Backlog)
+}
+
+func TestService_Subscribe_BacklogLimit(t *testing.T) {
+	userID := int64(10)
+	insertTime := time.Date(2023, 8, 1, 0, 0, 0, 0, time.UTC)
+	event := outboxRepository.Event{ID: 6, UserID: &userID, Type: outboxRepository.EventTypeUserAddedToSegment, Payload: []byte(`{}`), InsertTime: insertTime}
+
+	tt := []struct {
+		name string
+
+		events []outboxRepository.Event
+
+		expectedBacklog []publisher.Message
+		expectedReset   bool
+	}{
+		{
+			name: "within_limit",
+
+			events: []outboxRepository.Event{event},
+
+			expectedBacklog: []publisher.Message{
+				{ID: 6, UserID: &userID, Type: outboxRepository.EventTypeUserAddedToSegment, Payload: []byte(`{}`), OccurredAt: insertTime},
+			},
+			expectedReset: false,
+		},
+		{
+			name: "exceeds_limit",
+
+			events: []outboxRepository.Event{event, {ID: 7, UserID: &userID}},
+
+			expectedBacklog: nil,
+			expectedReset:   true,
+		},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			outboxRepoMock := mocks.NewOutboxRepository(t)
+			// one event more than limit is requested
+			outboxRepoMock.EXPECT().GetUsersEvents(context.Background(), []int64{10}, int64(5), int64(2)).
+				Return(tc.events, nil)
+
+			service := New(outboxRepoMock, 10, 1)
+
+			subscription, unsubscribe, err := service.Subscribe(context.Background(), []int64{10}, 5)
+			require.NoError(t, err)
+			defer unsubscribe()
+
+			assert.Equal(t, tc.expectedBacklog, subscription.Backlog)
+			assert.Equal(t, tc.expectedReset, subscription.Reset)
+		})
+	}
+}
+
+func TestService_Subscribe_Error(t *testing.T) {
+	errFromOutboxRepo := fmt.Errorf("error from outbox repo")
+
+	outboxRepoMock := mocks.NewOutboxRepository(t)
+	outboxRepoMock.EXPECT().GetUsersEvents(context.Background(), []int64{10}, int64(5), int64(backlogPageSize)).
+		Return(nil, errFromOutboxRepo)
+
+	service := New(outboxRepoMock, 10, 10000)
+
+	_, _, err := service.Subscribe(context.Background(), []int64{10}, 5)
+
+	assert.ErrorIs(t, err, errFromOutboxRepo)
+	assert.Empty(t, service.subscribers)
+}
+
+func TestService_Run(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	service := New(mocks.NewOutboxRepository(t), 1, 10000)
+	notifications := make(chan *pq.Notification)
+	go service.Run(ctx, notifications)
+
+	subscription, unsubscribe, err := service.Subscribe(ctx, []int64{10}, 0)
+	require.NoError(t, err)
+	defer unsubscribe()
+
+	assert.Nil(t, subscription.Backlog)
+
+	notifications <- &pq.Notification{Extra: `{"id":1,"type":"user_segment.added","userId":11,"payload":{}}`}
+	// events about segments have no user and aren't streamed
+	notifications <- &pq.Notification{Extra: `{"id":2,"type":"segment.created","userId":null,"payload":{}}`}
+	notifications <- &pq.Notification{Extra: `{"id":3,"type":"user_segment.added","userId":10,"payload":{}}`}
+
+	message, ok := receive(t, subscription.Events)
+	require.True(t, ok)
+	assert.Equal(t, int64(3), message.ID)
+
+	// subscriber which doesn't read events is dropped when its buffer is full
+	notifications <- &pq.Notification{Extra: `{"id":4,"type":"user_segment.added","userId":10,"payload":{}}`}
+	notifications <- &pq.Notification{Extra: `{"id":5,"type":"user_segment.added","userId":10,"payload":{}}`}
+
+	message, ok = receive(t, subscription.Events)
+	require.True(t, ok)
+	assert.Equal(t, int64(4), message.ID)
+
+	_, ok = receive(t, subscription.Events)
+	assert.False(t, ok)
+}
+
+func TestService_Run_Reconnect(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	service := New(mocks.NewOutboxRepository(t), 10, 10000)
+	notifications := make(chan *pq.Notification)
+	go service.Run(ctx, notifications)
+
+	subscription, unsubscribe, err := service.Subscribe(ctx, []int64{10}, 0)
+	require.NoError(t, err)
+	defer unsubscribe()
+
+	// notifications could be lost while listener was reconnecting, so subscribers must resume
+	notifications <- nil
+
+	_, ok := receive(t, subscription.Events)
+	assert.False(t, ok)
+}
+
+func TestService_CheckListening(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+
+	service := New(mocks.NewOutboxRepository(t), 10, 10000)
+	assert.ErrorIs(t, service.CheckListening(ctx), ErrNotListening)
+
+	notifications := make(chan *pq.Notification)
+	stopped := make(chan struct{})
+	go func() {
+		service.Run(ctx, notifications)
+		close(stopped)
+	}()
+
+	// notification is received only when Run is started
+	notifications <- nil
+	assert.NoError(t, service.CheckListening(ctx))
+
+	cancel()
+	<-stopped
+	assert.ErrorIs(t, service.CheckListening(context.Background()), ErrNotListening)
+}
@@ -0,0 +1,7 @@
+package webhook
+
+import "errors"
+
+var ErrSubscriptionNotExist = errors.New("subscription doesn't exist")
+var ErrInvalidURL = errors.New("invalid url")
+var ErrUnknownEventType = errors.New("unknown event type")
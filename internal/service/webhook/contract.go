@@ -0,0 +1,33 @@
+//go:generate mockery --all --output ./mocks --case underscore --with-expecter
+package webhook
+
+import (
+	"context"
+	"time"
+
+	"github.com/pollykon/avito_test_task/internal/publisher"
+	outboxRepo "github.com/pollykon/avito_test_task/internal/repository/outbox"
+	webhookRepo "github.com/pollykon/avito_test_task/internal/repository/webhook"
+)
+
+type WebhookRepository interface {
+	AddSubscription(ctx context.Context, subscription webhookRepo.Subscription) (int64, error)
+	DeleteSubscription(ctx context.Context, id int64) error
+	GetSubscriptions(ctx context.Context) ([]webhookRepo.Subscription, error)
+	GetDeliveries(ctx context.Context, subscriptionID int64, limit int64, offset int64) ([]webhookRepo.Delivery, error)
+	AddDeliveries(ctx context.Context, deliveries []webhookRepo.Delivery) error
+	ClaimPendingDeliveries(ctx context.Context, limit int64, leaseUntil time.Time) ([]webhookRepo.PendingDelivery, error)
+	MarkDelivered(ctx context.Context, id int64, statusCode int64) error
+	MarkFailed(ctx context.Context, id int64, nextAttemptTime time.Time, statusCode int64, lastError string) error
+	MarkDead(ctx context.Context, id int64, statusCode int64, lastError string) error
+	InTransaction(ctx context.Context, f func(ctx context.Context) error) error
+}
+
+type OutboxRepository interface {
+	GetNotDispatched(ctx context.Context, limit int64) ([]outboxRepo.Event, error)
+	MarkDispatched(ctx context.Context, ids []int64) error
+}
+
+type Sender interface {
+	Send(ctx context.Context, url string, secret string, message publisher.Message) (int64, error)
+}
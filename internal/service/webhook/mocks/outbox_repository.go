@@ -0,0 +1,135 @@
+// Code generated by mockery v2.33.0. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+
+	outbox "github.com/pollykon/avito_test_task/internal/repository/outbox"
+	mock "github.com/stretchr/testify/mock"
+)
+
+// OutboxRepository is an autogenerated mock type for the OutboxRepository type
+type OutboxRepository struct {
+	mock.Mock
+}
+
+type OutboxRepository_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *OutboxRepository) EXPECT() *OutboxRepository_Expecter {
+	return &OutboxRepository_Expecter{mock: &_m.Mock}
+}
+
+// GetNotDispatched provides a mock function with given fields: ctx, limit
+func (_m *OutboxRepository) GetNotDispatched(ctx context.Context, limit int64) ([]outbox.Event, error) {
+	ret := _m.Called(ctx, limit)
+
+	var r0 []outbox.Event
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, int64) ([]outbox.Event, error)); ok {
+		return rf(ctx, limit)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, int64) []outbox.Event); ok {
+		r0 = rf(ctx, limit)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]outbox.Event)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, int64) error); ok {
+		r1 = rf(ctx, limit)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// OutboxRepository_GetNotDispatched_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetNotDispatched'
+type OutboxRepository_GetNotDispatched_Call struct {
+	*mock.Call
+}
+
+// GetNotDispatched is a helper method to define mock.On call
+//   - ctx context.Context
+//   - limit int64
+func (_e *OutboxRepository_Expecter) GetNotDispatched(ctx interface{}, limit interface{}) *OutboxRepository_GetNotDispatched_Call {
+	return &OutboxRepository_GetNotDispatched_Call{Call: _e.mock.On("GetNotDispatched", ctx, limit)}
+}
+
+func (_c *OutboxRepository_GetNotDispatched_Call) Run(run func(ctx context.Context, limit int64)) *OutboxRepository_GetNotDispatched_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(int64))
+	})
+	return _c
+}
+
+func (_c *OutboxRepository_GetNotDispatched_Call) Return(_a0 []outbox.Event, _a1 error) *OutboxRepository_GetNotDispatched_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *OutboxRepository_GetNotDispatched_Call) RunAndReturn(run func(context.Context, int64) ([]outbox.Event, error)) *OutboxRepository_GetNotDispatched_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// MarkDispatched provides a mock function with given fields: ctx, ids
+func (_m *OutboxRepository) MarkDispatched(ctx context.Context, ids []int64) error {
+	ret := _m.Called(ctx, ids)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, []int64) error); ok {
+		r0 = rf(ctx, ids)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// OutboxRepository_MarkDispatched_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'MarkDispatched'
+type OutboxRepository_MarkDispatched_Call struct {
+	*mock.Call
+}
+
+// MarkDispatched is a helper method to define mock.On call
+//   - ctx context.Context
+//   - ids []int64
+func (_e *OutboxRepository_Expecter) MarkDispatched(ctx interface{}, ids interface{}) *OutboxRepository_MarkDispatched_Call {
+	return &OutboxRepository_MarkDispatched_Call{Call: _e.mock.On("MarkDispatched", ctx, ids)}
+}
+
+func (_c *OutboxRepository_MarkDispatched_Call) Run(run func(ctx context.Context, ids []int64)) *OutboxRepository_MarkDispatched_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].([]int64))
+	})
+	return _c
+}
+
+func (_c *OutboxRepository_MarkDispatched_Call) Return(_a0 error) *OutboxRepository_MarkDispatched_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *OutboxRepository_MarkDispatched_Call) RunAndReturn(run func(context.Context, []int64) error) *OutboxRepository_MarkDispatched_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// NewOutboxRepository creates a new instance of OutboxRepository. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewOutboxRepository(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *OutboxRepository {
+	mock := &OutboxRepository{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
@@ -0,0 +1,92 @@
+// Code generated by mockery v2.33.0. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+
+	publisher "github.com/pollykon/avito_test_task/internal/publisher"
+	mock "github.com/stretchr/testify/mock"
+)
+
+// Sender is an autogenerated mock type for the Sender type
+type Sender struct {
+	mock.Mock
+}
+
+type Sender_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *Sender) EXPECT() *Sender_Expecter {
+	return &Sender_Expecter{mock: &_m.Mock}
+}
+
+// Send provides a mock function with given fields: ctx, url, secret, message
+func (_m *Sender) Send(ctx context.Context, url string, secret string, message publisher.Message) (int64, error) {
+	ret := _m.Called(ctx, url, secret, message)
+
+	var r0 int64
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, publisher.Message) (int64, error)); ok {
+		return rf(ctx, url, secret, message)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, publisher.Message) int64); ok {
+		r0 = rf(ctx, url, secret, message)
+	} else {
+		r0 = ret.Get(0).(int64)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, string, publisher.Message) error); ok {
+		r1 = rf(ctx, url, secret, message)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Sender_Send_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Send'
+type Sender_Send_Call struct {
+	*mock.Call
+}
+
+// Send is a helper method to define mock.On call
+//   - ctx context.Context
+//   - url string
+//   - secret string
+//   - message publisher.Message
+func (_e *Sender_Expecter) Send(ctx interface{}, url interface{}, secret interface{}, message interface{}) *Sender_Send_Call {
+	return &Sender_Send_Call{Call: _e.mock.On("Send", ctx, url, secret, message)}
+}
+
+func (_c *Sender_Send_Call) Run(run func(ctx context.Context, url string, secret string, message publisher.Message)) *Sender_Send_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string), args[2].(string), args[3].(publisher.Message))
+	})
+	return _c
+}
+
+func (_c *Sender_Send_Call) Return(_a0 int64, _a1 error) *Sender_Send_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *Sender_Send_Call) RunAndReturn(run func(context.Context, string, string, publisher.Message) (int64, error)) *Sender_Send_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// NewSender creates a new instance of Sender. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewSender(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *Sender {
+	mock := &Sender{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
@@ -0,0 +1,524 @@
+// Code generated by mockery v2.33.0. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+
+	mock "github.com/stretchr/testify/mock"
+
+	time "time"
+
+	webhook "github.com/pollykon/avito_test_task/internal/repository/webhook"
+)
+
+// WebhookRepository is an autogenerated mock type for the WebhookRepository type
+type WebhookRepository struct {
+	mock.Mock
+}
+
+type WebhookRepository_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *WebhookRepository) EXPECT() *WebhookRepository_Expecter {
+	return &WebhookRepository_Expecter{mock: &_m.Mock}
+}
+
+// AddDeliveries provides a mock function with given fields: ctx, deliveries
+func (_m *WebhookRepository) AddDeliveries(ctx context.Context, deliveries []webhook.Delivery) error {
+	ret := _m.Called(ctx, deliveries)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, []webhook.Delivery) error); ok {
+		r0 = rf(ctx, deliveries)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// WebhookRepository_AddDeliveries_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'AddDeliveries'
+type WebhookRepository_AddDeliveries_Call struct {
+	*mock.Call
+}
+
+// AddDeliveries is a helper method to define mock.On call
+//   - ctx context.Context
+//   - deliveries []webhook.Delivery
+func (_e *WebhookRepository_Expecter) AddDeliveries(ctx interface{}, deliveries interface{}) *WebhookRepository_AddDeliveries_Call {
+	return &WebhookRepository_AddDeliveries_Call{Call: _e.mock.On("AddDeliveries", ctx, deliveries)}
+}
+
+func (_c *WebhookRepository_AddDeliveries_Call) Run(run func(ctx context.Context, deliveries []webhook.Delivery)) *WebhookRepository_AddDeliveries_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].([]webhook.Delivery))
+	})
+	return _c
+}
+
+func (_c *WebhookRepository_AddDeliveries_Call) Return(_a0 error) *WebhookRepository_AddDeliveries_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *WebhookRepository_AddDeliveries_Call) RunAndReturn(run func(context.Context, []webhook.Delivery) error) *WebhookRepository_AddDeliveries_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// AddSubscription provides a mock function with given fields: ctx, subscription
+func (_m *WebhookRepository) AddSubscription(ctx context.Context, subscription webhook.Subscription) (int64, error) {
+	ret := _m.Called(ctx, subscription)
+
+	var r0 int64
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, webhook.Subscription) (int64, error)); ok {
+		return rf(ctx, subscription)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, webhook.Subscription) int64); ok {
+		r0 = rf(ctx, subscription)
+	} else {
+		r0 = ret.Get(0).(int64)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, webhook.Subscription) error); ok {
+		r1 = rf(ctx, subscription)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// WebhookRepository_AddSubscription_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'AddSubscription'
+type WebhookRepository_AddSubscription_Call struct {
+	*mock.Call
+}
+
+// AddSubscription is a helper method to define mock.On call
+//   - ctx context.Context
+//   - subscription webhook.Subscription
+func (_e *WebhookRepository_Expecter) AddSubscription(ctx interface{}, subscription interface{}) *WebhookRepository_AddSubscription_Call {
+	return &WebhookRepository_AddSubscription_Call{Call: _e.mock.On("AddSubscription", ctx, subscription)}
+}
+
+func (_c *WebhookRepository_AddSubscription_Call) Run(run func(ctx context.Context, subscription webhook.Subscription)) *WebhookRepository_AddSubscription_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(webhook.Subscription))
+	})
+	return _c
+}
+
+func (_c *WebhookRepository_AddSubscription_Call) Return(_a0 int64, _a1 error) *WebhookRepository_AddSubscription_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *WebhookRepository_AddSubscription_Call) RunAndReturn(run func(context.Context, webhook.Subscription) (int64, error)) *WebhookRepository_AddSubscription_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// ClaimPendingDeliveries provides a mock function with given fields: ctx, limit, leaseUntil
+func (_m *WebhookRepository) ClaimPendingDeliveries(ctx context.Context, limit int64, leaseUntil time.Time) ([]webhook.PendingDelivery, error) {
+	ret := _m.Called(ctx, limit, leaseUntil)
+
+	var r0 []webhook.PendingDelivery
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, int64, time.Time) ([]webhook.PendingDelivery, error)); ok {
+		return rf(ctx, limit, leaseUntil)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, int64, time.Time) []webhook.PendingDelivery); ok {
+		r0 = rf(ctx, limit, leaseUntil)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]webhook.PendingDelivery)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, int64, time.Time) error); ok {
+		r1 = rf(ctx, limit, leaseUntil)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// WebhookRepository_ClaimPendingDeliveries_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ClaimPendingDeliveries'
+type WebhookRepository_ClaimPendingDeliveries_Call struct {
+	*mock.Call
+}
+
+// ClaimPendingDeliveries is a helper method to define mock.On call
+//   - ctx context.Context
+//   - limit int64
+//   - leaseUntil time.Time
+func (_e *WebhookRepository_Expecter) ClaimPendingDeliveries(ctx interface{}, limit interface{}, leaseUntil interface{}) *WebhookRepository_ClaimPendingDeliveries_Call {
+	return &WebhookRepository_ClaimPendingDeliveries_Call{Call: _e.mock.On("ClaimPendingDeliveries", ctx, limit, leaseUntil)}
+}
+
+func (_c *WebhookRepository_ClaimPendingDeliveries_Call) Run(run func(ctx context.Context, limit int64, leaseUntil time.Time)) *WebhookRepository_ClaimPendingDeliveries_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(int64), args[2].(time.Time))
+	})
+	return _c
+}
+
+func (_c *WebhookRepository_ClaimPendingDeliveries_Call) Return(_a0 []webhook.PendingDelivery, _a1 error) *WebhookRepository_ClaimPendingDeliveries_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *WebhookRepository_ClaimPendingDeliveries_Call) RunAndReturn(run func(context.Context, int64, time.Time) ([]webhook.PendingDelivery, error)) *WebhookRepository_ClaimPendingDeliveries_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// DeleteSubscription provides a mock function with given fields: ctx, id
+func (_m *WebhookRepository) DeleteSubscription(ctx context.Context, id int64) error {
+	ret := _m.Called(ctx, id)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, int64) error); ok {
+		r0 = rf(ctx, id)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// WebhookRepository_DeleteSubscription_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'DeleteSubscription'
+type WebhookRepository_DeleteSubscription_Call struct {
+	*mock.Call
+}
+
+// DeleteSubscription is a helper method to define mock.On call
+//   - ctx context.Context
+//   - id int64
+func (_e *WebhookRepository_Expecter) DeleteSubscription(ctx interface{}, id interface{}) *WebhookRepository_DeleteSubscription_Call {
+	return &WebhookRepository_DeleteSubscription_Call{Call: _e.mock.On("DeleteSubscription", ctx, id)}
+}
+
+func (_c *WebhookRepository_DeleteSubscription_Call) Run(run func(ctx context.Context, id int64)) *WebhookRepository_DeleteSubscription_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(int64))
+	})
+	return _c
+}
+
+func (_c *WebhookRepository_DeleteSubscription_Call) Return(_a0 error) *WebhookRepository_DeleteSubscription_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *WebhookRepository_DeleteSubscription_Call) RunAndReturn(run func(context.Context, int64) error) *WebhookRepository_DeleteSubscription_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetDeliveries provides a mock function with given fields: ctx, subscriptionID, limit, offset
+func (_m *WebhookRepository) GetDeliveries(ctx context.Context, subscriptionID int64, limit int64, offset int64) ([]webhook.Delivery, error) {
+	ret := _m.Called(ctx, subscriptionID, limit, offset)
+
+	var r0 []webhook.Delivery
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, int64, int64, int64) ([]webhook.Delivery, error)); ok {
+		return rf(ctx, subscriptionID, limit, offset)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, int64, int64, int64) []webhook.Delivery); ok {
+		r0 = rf(ctx, subscriptionID, limit, offset)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]webhook.Delivery)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, int64, int64, int64) error); ok {
+		r1 = rf(ctx, subscriptionID, limit, offset)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// WebhookRepository_GetDeliveries_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetDeliveries'
+type WebhookRepository_GetDeliveries_Call struct {
+	*mock.Call
+}
+
+// GetDeliveries is a helper method to define mock.On call
+//   - ctx context.Context
+//   - subscriptionID int64
+//   - limit int64
+//   - offset int64
+func (_e *WebhookRepository_Expecter) GetDeliveries(ctx interface{}, subscriptionID interface{}, limit interface{}, offset interface{}) *WebhookRepository_GetDeliveries_Call {
+	return &WebhookRepository_GetDeliveries_Call{Call: _e.mock.On("GetDeliveries", ctx, subscriptionID, limit, offset)}
+}
+
+func (_c *WebhookRepository_GetDeliveries_Call) Run(run func(ctx context.Context, subscriptionID int64, limit int64, offset int64)) *WebhookRepository_GetDeliveries_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(int64), args[2].(int64), args[3].(int64))
+	})
+	return _c
+}
+
+func (_c *WebhookRepository_GetDeliveries_Call) Return(_a0 []webhook.Delivery, _a1 error) *WebhookRepository_GetDeliveries_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *WebhookRepository_GetDeliveries_Call) RunAndReturn(run func(context.Context, int64, int64, int64) ([]webhook.Delivery, error)) *WebhookRepository_GetDeliveries_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetSubscriptions provides a mock function with given fields: ctx
+func (_m *WebhookRepository) GetSubscriptions(ctx context.Context) ([]webhook.Subscription, error) {
+	ret := _m.Called(ctx)
+
+	var r0 []webhook.Subscription
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context) ([]webhook.Subscription, error)); ok {
+		return rf(ctx)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context) []webhook.Subscription); ok {
+		r0 = rf(ctx)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]webhook.Subscription)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context) error); ok {
+		r1 = rf(ctx)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// WebhookRepository_GetSubscriptions_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetSubscriptions'
+type WebhookRepository_GetSubscriptions_Call struct {
+	*mock.Call
+}
+
+// GetSubscriptions is a helper method to define mock.On call
+//   - ctx context.Context
+func (_e *WebhookRepository_Expecter) GetSubscriptions(ctx interface{}) *WebhookRepository_GetSubscriptions_Call {
+	return &WebhookRepository_GetSubscriptions_Call{Call: _e.mock.On("GetSubscriptions", ctx)}
+}
+
+func (_c *WebhookRepository_GetSubscriptions_Call) Run(run func(ctx context.Context)) *WebhookRepository_GetSubscriptions_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context))
+	})
+	return _c
+}
+
+func (_c *WebhookRepository_GetSubscriptions_Call) Return(_a0 []webhook.Subscription, _a1 error) *WebhookRepository_GetSubscriptions_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *WebhookRepository_GetSubscriptions_Call) RunAndReturn(run func(context.Context) ([]webhook.Subscription, error)) *WebhookRepository_GetSubscriptions_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// InTransaction provides a mock function with given fields: ctx, f
+func (_m *WebhookRepository) InTransaction(ctx context.Context, f func(context.Context) error) error {
+	ret := _m.Called(ctx, f)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, func(context.Context) error) error); ok {
+		r0 = rf(ctx, f)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// WebhookRepository_InTransaction_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'InTransaction'
+type WebhookRepository_InTransaction_Call struct {
+	*mock.Call
+}
+
+// InTransaction is a helper method to define mock.On call
+//   - ctx context.Context
+//   - f func(context.Context) error
+func (_e *WebhookRepository_Expecter) InTransaction(ctx interface{}, f interface{}) *WebhookRepository_InTransaction_Call {
+	return &WebhookRepository_InTransaction_Call{Call: _e.mock.On("InTransaction", ctx, f)}
+}
+
+func (_c *WebhookRepository_InTransaction_Call) Run(run func(ctx context.Context, f func(context.Context) error)) *WebhookRepository_InTransaction_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(func(context.Context) error))
+	})
+	return _c
+}
+
+func (_c *WebhookRepository_InTransaction_Call) Return(_a0 error) *WebhookRepository_InTransaction_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *WebhookRepository_InTransaction_Call) RunAndReturn(run func(context.Context, func(context.Context) error) error) *WebhookRepository_InTransaction_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// MarkDead provides a mock function with given fields: ctx, id, statusCode, lastError
+func (_m *WebhookRepository) MarkDead(ctx context.Context, id int64, statusCode int64, lastError string) error {
+	ret := _m.Called(ctx, id, statusCode, lastError)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, int64, int64, string) error); ok {
+		r0 = rf(ctx, id, statusCode, lastError)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// WebhookRepository_MarkDead_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'MarkDead'
+type WebhookRepository_MarkDead_Call struct {
+	*mock.Call
+}
+
+// MarkDead is a helper method to define mock.On call
+//   - ctx context.Context
+//   - id int64
+//   - statusCode int64
+//   - lastError string
+func (_e *WebhookRepository_Expecter) MarkDead(ctx interface{}, id interface{}, statusCode interface{}, lastError interface{}) *WebhookRepository_MarkDead_Call {
+	return &WebhookRepository_MarkDead_Call{Call: _e.mock.On("MarkDead", ctx, id, statusCode, lastError)}
+}
+
+func (_c *WebhookRepository_MarkDead_Call) Run(run func(ctx context.Context, id int64, statusCode int64, lastError string)) *WebhookRepository_MarkDead_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(int64), args[2].(int64), args[3].(string))
+	})
+	return _c
+}
+
+func (_c *WebhookRepository_MarkDead_Call) Return(_a0 error) *WebhookRepository_MarkDead_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *WebhookRepository_MarkDead_Call) RunAndReturn(run func(context.Context, int64, int64, string) error) *WebhookRepository_MarkDead_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// MarkDelivered provides a mock function with given fields: ctx, id, statusCode
+func (_m *WebhookRepository) MarkDelivered(ctx context.Context, id int64, statusCode int64) error {
+	ret := _m.Called(ctx, id, statusCode)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, int64, int64) error); ok {
+		r0 = rf(ctx, id, statusCode)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// WebhookRepository_MarkDelivered_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'MarkDelivered'
+type WebhookRepository_MarkDelivered_Call struct {
+	*mock.Call
+}
+
+// MarkDelivered is a helper method to define mock.On call
+//   - ctx context.Context
+//   - id int64
+//   - statusCode int64
+func (_e *WebhookRepository_Expecter) MarkDelivered(ctx interface{}, id interface{}, statusCode interface{}) *WebhookRepository_MarkDelivered_Call {
+	return &WebhookRepository_MarkDelivered_Call{Call: _e.mock.On("MarkDelivered", ctx, id, statusCode)}
+}
+
+func (_c *WebhookRepository_MarkDelivered_Call) Run(run func(ctx context.Context, id int64, statusCode int64)) *WebhookRepository_MarkDelivered_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(int64), args[2].(int64))
+	})
+	return _c
+}
+
+func (_c *WebhookRepository_MarkDelivered_Call) Return(_a0 error) *WebhookRepository_MarkDelivered_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *WebhookRepository_MarkDelivered_Call) RunAndReturn(run func(context.Context, int64, int64) error) *WebhookRepository_MarkDelivered_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// MarkFailed provides a mock function with given fields: ctx, id, nextAttemptTime, statusCode, lastError
+func (_m *WebhookRepository) MarkFailed(ctx context.Context, id int64, nextAttemptTime time.Time, statusCode int64, lastError string) error {
+	ret := _m.Called(ctx, id, nextAttemptTime, statusCode, lastError)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, int64, time.Time, int64, string) error); ok {
+		r0 = rf(ctx, id, nextAttemptTime, statusCode, lastError)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// WebhookRepository_MarkFailed_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'MarkFailed'
+type WebhookRepository_MarkFailed_Call struct {
+	*mock.Call
+}
+
+// MarkFailed is a helper method to define mock.On call
+//   - ctx context.Context
+//   - id int64
+//   - nextAttemptTime time.Time
+//   - statusCode int64
+//   - lastError string
+func (_e *WebhookRepository_Expecter) MarkFailed(ctx interface{}, id interface{}, nextAttemptTime interface{}, statusCode interface{}, lastError interface{}) *WebhookRepository_MarkFailed_Call {
+	return &WebhookRepository_MarkFailed_Call{Call: _e.mock.On("MarkFailed", ctx, id, nextAttemptTime, statusCode, lastError)}
+}
+
+func (_c *WebhookRepository_MarkFailed_Call) Run(run func(ctx context.Context, id int64, nextAttemptTime time.Time, statusCode int64, lastError string)) *WebhookRepository_MarkFailed_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(int64), args[2].(time.Time), args[3].(int64), args[4].(string))
+	})
+	return _c
+}
+
+func (_c *WebhookRepository_MarkFailed_Call) Return(_a0 error) *WebhookRepository_MarkFailed_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *WebhookRepository_MarkFailed_Call) RunAndReturn(run func(context.Context, int64, time.Time, int64, string) error) *WebhookRepository_MarkFailed_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// NewWebhookRepository creates a new instance of WebhookRepository. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewWebhookRepository(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *WebhookRepository {
+	mock := &WebhookRepository{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
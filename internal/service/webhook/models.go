@@ -0,0 +1,37 @@
+package webhook
+
+import (
+	"encoding/json"
+	"time"
+)
+
+// Subscription receives events of EventTypes about SegmentIDs, empty filter matches any event type or segment
+type Subscription struct {
+	ID         int64
+	URL        string
+	Secret     string
+	EventTypes []string
+	SegmentIDs []string
+	InsertTime time.Time
+}
+
+// Delivery is an attempt to send event to subscription. Status is pending until event is delivered
+// or given up after too many failed attempts
+type Delivery struct {
+	ID             int64
+	EventID        int64
+	EventType      string
+	Payload        json.RawMessage
+	EventTime      time.Time
+	Status         string
+	Attempts       int64
+	LastStatusCode *int64
+	LastError      *string
+	InsertTime     time.Time
+	DeliveredTime  *time.Time
+}
+
+// eventPayload contains fields of event payload which subscriptions are filtered by
+type eventPayload struct {
+	SegmentID string `json:"segmentId"`
+}
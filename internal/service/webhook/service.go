@@ -0,0 +1,114 @@
+package webhook
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/url"
+	"slices"
+
+	outboxRepository "github.com/pollykon/avito_test_task/internal/repository/outbox"
+	webhookRepository "github.com/pollykon/avito_test_task/internal/repository/webhook"
+)
+
+// Service manages webhook subscriptions
+type Service struct {
+	webhookRepo WebhookRepository
+	// allowHTTP allows urls without tls, e.g. of receivers in local network during development
+	allowHTTP bool
+}
+
+func New(webhookRepo WebhookRepository, allowHTTP bool) Service {
+	return Service{webhookRepo: webhookRepo, allowHTTP: allowHTTP}
+}
+
+// AddSubscription validates subscription and returns its id
+func (s Service) AddSubscription(ctx context.Context, subscription Subscription) (int64, error) {
+	parsedURL, err := url.Parse(subscription.URL)
+	if err != nil || !s.schemeAllowed(parsedURL.Scheme) || parsedURL.Host == "" {
+		return 0, ErrInvalidURL
+	}
+
+	for _, eventType := range subscription.EventTypes {
+		if !slices.Contains(outboxRepository.EventTypes, eventType) {
+			return 0, fmt.Errorf("%w: %s", ErrUnknownEventType, eventType)
+		}
+	}
+
+	id, err := s.webhookRepo.AddSubscription(ctx, webhookRepository.Subscription{
+		URL:        subscription.URL,
+		Secret:     subscription.Secret,
+		EventTypes: subscription.EventTypes,
+		SegmentIDs: subscription.SegmentIDs,
+	})
+	if err != nil {
+		return 0, fmt.Errorf("error from webhook service while adding subscription: %w", err)
+	}
+
+	return id, nil
+}
+
+// schemeAllowed reports whether webhooks may be sent by url with scheme, only https is allowed by default
+func (s Service) schemeAllowed(scheme string) bool {
+	return scheme == "https" || s.allowHTTP && scheme == "http"
+}
+
+func (s Service) DeleteSubscription(ctx context.Context, id int64) error {
+	err := s.webhookRepo.DeleteSubscription(ctx, id)
+	if err != nil {
+		if errors.Is(err, webhookRepository.ErrSubscriptionNotExist) {
+			return ErrSubscriptionNotExist
+		}
+		return fmt.Errorf("error from webhook service while deleting subscription: %w", err)
+	}
+
+	return nil
+}
+
+func (s Service) GetSubscriptions(ctx context.Context) ([]Subscription, error) {
+	subscriptions, err := s.webhookRepo.GetSubscriptions(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("error from webhook service while getting subscriptions: %w", err)
+	}
+
+	result := make([]Subscription, 0, len(subscriptions))
+	for _, subscription := range subscriptions {
+		result = append(result, Subscription{
+			ID:         subscription.ID,
+			URL:        subscription.URL,
+			Secret:     subscription.Secret,
+			EventTypes: subscription.EventTypes,
+			SegmentIDs: subscription.SegmentIDs,
+			InsertTime: subscription.InsertTime,
+		})
+	}
+
+	return result, nil
+}
+
+// GetDeliveries returns delivery history of subscription, the newest deliveries go first
+func (s Service) GetDeliveries(ctx context.Context, subscriptionID int64, limit int64, offset int64) ([]Delivery, error) {
+	deliveries, err := s.webhookRepo.GetDeliveries(ctx, subscriptionID, limit, offset)
+	if err != nil {
+		return nil, fmt.Errorf("error from webhook service while getting deliveries: %w", err)
+	}
+
+	result := make([]Delivery, 0, len(deliveries))
+	for _, delivery := range deliveries {
+		result = append(result, Delivery{
+			ID:             delivery.ID,
+			EventID:        delivery.EventID,
+			EventType:      delivery.EventType,
+			Payload:        delivery.Payload,
+			EventTime:      delivery.EventTime,
+			Status:         delivery.Status,
+			Attempts:       delivery.Attempts,
+			LastStatusCode: delivery.LastStatusCode,
+			LastError:      delivery.LastError,
+			InsertTime:     delivery.InsertTime,
+			DeliveredTime:  delivery.DeliveredTime,
+		})
+	}
+
+	return result, nil
+}
@@ -0,0 +1,174 @@
+package webhook
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	webhookRepository "github.com/pollykon/avito_test_task/internal/repository/webhook"
+	"github.com/pollykon/avito_test_task/internal/service/webhook/mocks"
+)
+
+func TestService_AddSubscription_Success(t *testing.T) {
+	subscription := Subscription{
+		URL:        "https://example.com/hook",
+		Secret:     "secret",
+		EventTypes: []string{"user_segment.added"},
+		SegmentIDs: []string{"AVITO"},
+	}
+
+	webhookRepoMock := mocks.NewWebhookRepository(t)
+	webhookRepoMock.EXPECT().AddSubscription(context.Background(), webhookRepository.Subscription{
+		URL:        subscription.URL,
+		Secret:     subscription.Secret,
+		EventTypes: subscription.EventTypes,
+		SegmentIDs: subscription.SegmentIDs,
+	}).Return(int64(7), nil)
+
+	id, err := New(webhookRepoMock, false).AddSubscription(context.Background(), subscription)
+
+	assert.NoError(t, err)
+	assert.Equal(t, int64(7), id)
+}
+
+func TestService_AddSubscription_AllowHTTP(t *testing.T) {
+	subscription := Subscription{URL: "http://receiver:8080/hook"}
+
+	webhookRepoMock := mocks.NewWebhookRepository(t)
+	webhookRepoMock.EXPECT().AddSubscription(context.Background(), webhookRepository.Subscription{
+		URL: subscription.URL,
+	}).Return(int64(7), nil)
+
+	id, err := New(webhookRepoMock, true).AddSubscription(context.Background(), subscription)
+
+	assert.NoError(t, err)
+	assert.Equal(t, int64(7), id)
+}
+
+func TestService_AddSubscription_Error(t *testing.T) {
+	errFromRepo := fmt.Errorf("error from repo")
+
+	tt := []struct {
+		name string
+
+		subscription Subscription
+
+		buildWebhookRepoMock func(repo *mocks.WebhookRepository)
+
+		expectedError error
+	}{
+		{
+			name: "invalid_url",
+
+			subscription: Subscription{URL: "example.com/hook"},
+
+			buildWebhookRepoMock: nil,
+
+			expectedError: ErrInvalidURL,
+		},
+		{
+			name: "http_not_allowed",
+
+			subscription: Subscription{URL: "http://example.com/hook"},
+
+			buildWebhookRepoMock: nil,
+
+			expectedError: ErrInvalidURL,
+		},
+		{
+			name: "unsupported_scheme",
+
+			subscription: Subscription{URL: "ftp://example.com/hook"},
+
+			buildWebhookRepoMock: nil,
+
+			expectedError: ErrInvalidURL,
+		},
+		{
+			name: "unknown_event_type",
+
+			subscription: Subscription{URL: "https://example.com/hook", EventTypes: []string{"user.created"}},
+
+			buildWebhookRepoMock: nil,
+
+			expectedError: ErrUnknownEventType,
+		},
+		{
+			name: "repo_error",
+
+			subscription: Subscription{URL: "https://example.com/hook"},
+
+			buildWebhookRepoMock: func(repo *mocks.WebhookRepository) {
+				repo.EXPECT().AddSubscription(context.Background(), webhookRepository.Subscription{
+					URL: "https://example.com/hook",
+				}).Return(0, errFromRepo)
+			},
+
+			expectedError: errFromRepo,
+		},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			webhookRepoMock := mocks.NewWebhookRepository(t)
+			if tc.buildWebhookRepoMock != nil {
+				tc.buildWebhookRepoMock(webhookRepoMock)
+			}
+
+			_, err := New(webhookRepoMock, false).AddSubscription(context.Background(), tc.subscription)
+
+			assert.ErrorIs(t, err, tc.expectedError)
+		})
+	}
+}
+
+func TestService_DeleteSubscription(t *testing.T) {
+	errFromRepo := fmt.Errorf("error from repo")
+
+	tt := []struct {
+		name string
+
+		errFromRepo error
+
+		expectedError error
+	}{
+		{
+			name: "success",
+
+			errFromRepo: nil,
+
+			expectedError: nil,
+		},
+		{
+			name: "not_exist",
+
+			errFromRepo: webhookRepository.ErrSubscriptionNotExist,
+
+			expectedError: ErrSubscriptionNotExist,
+		},
+		{
+			name: "repo_error",
+
+			errFromRepo: errFromRepo,
+
+			expectedError: errFromRepo,
+		},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			webhookRepoMock := mocks.NewWebhookRepository(t)
+			webhookRepoMock.EXPECT().DeleteSubscription(context.Background(), int64(7)).Return(tc.errFromRepo)
+
+			err := New(webhookRepoMock, false).DeleteSubscription(context.Background(), 7)
+
+			if tc.expectedError == nil {
+				assert.NoError(t, err)
+				return
+			}
+			assert.ErrorIs(t, err, tc.expectedError)
+		})
+	}
+}
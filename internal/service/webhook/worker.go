@@ -0,0 +1,170 @@
+package webhook
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"slices"
+	"time"
+
+	"github.com/pollykon/avito_test_task/internal/publisher"
+	webhookRepository "github.com/pollykon/avito_test_task/internal/repository/webhook"
+)
+
+// Worker fans out outbox events to matching subscriptions and delivers them at-least-once
+type Worker struct {
+	webhookRepo WebhookRepository
+	outboxRepo  OutboxRepository
+	sender      Sender
+	timeout     time.Duration
+	maxAttempts int64
+	minBackoff  time.Duration
+	maxBackoff  time.Duration
+	now         func() time.Time
+}
+
+func NewWorker(
+	webhookRepo WebhookRepository,
+	outboxRepo OutboxRepository,
+	sender Sender,
+	timeout time.Duration,
+	maxAttempts int64,
+	minBackoff time.Duration,
+	maxBackoff time.Duration,
+) *Worker {
+	return &Worker{
+		webhookRepo: webhookRepo,
+		outboxRepo:  outboxRepo,
+		sender:      sender,
+		timeout:     timeout,
+		maxAttempts: maxAttempts,
+		minBackoff:  minBackoff,
+		maxBackoff:  maxBackoff,
+		now:         time.Now,
+	}
+}
+
+// Dispatch creates deliveries for batch of new outbox events and returns number of dispatched events.
+// Subscriptions added later don't receive earlier events
+func (w *Worker) Dispatch(ctx context.Context, batchSize int64) (int64, error) {
+	var dispatched int64
+	err := w.webhookRepo.InTransaction(ctx, func(ctx context.Context) error {
+		events, err := w.outboxRepo.GetNotDispatched(ctx, batchSize)
+		if err != nil {
+			return err
+		}
+
+		if len(events) == 0 {
+			return nil
+		}
+
+		subscriptions, err := w.webhookRepo.GetSubscriptions(ctx)
+		if err != nil {
+			return err
+		}
+
+		var deliveries []webhookRepository.Delivery
+		eventIDs := make([]int64, 0, len(events))
+		for _, event := range events {
+			eventIDs = append(eventIDs, event.ID)
+
+			var payload eventPayload
+			err = json.Unmarshal(event.Payload, &payload)
+			if err != nil {
+				return fmt.Errorf("error while unmarshalling payload of event %d: %w", event.ID, err)
+			}
+
+			for _, subscription := range subscriptions {
+				if !matches(subscription.EventTypes, event.Type) || !matches(subscription.SegmentIDs, payload.SegmentID) {
+					continue
+				}
+
+				deliveries = append(deliveries, webhookRepository.Delivery{
+					SubscriptionID: subscription.ID,
+					EventID:        event.ID,
+					UserID:         event.UserID,
+					EventType:      event.Type,
+					Payload:        event.Payload,
+					EventTime:      event.InsertTime,
+				})
+			}
+		}
+
+		err = w.webhookRepo.AddDeliveries(ctx, deliveries)
+		if err != nil {
+			return err
+		}
+
+		err = w.outboxRepo.MarkDispatched(ctx, eventIDs)
+		if err != nil {
+			return err
+		}
+
+		dispatched = int64(len(eventIDs))
+		return nil
+	})
+	if err != nil {
+		return 0, fmt.Errorf("error from webhook worker while dispatching events: %w", err)
+	}
+
+	return dispatched, nil
+}
+
+// Deliver sends batch of pending deliveries and returns number of delivered ones. Deliveries are claimed for time
+// of sending the whole batch and sent outside of transaction, result of every delivery is saved right after it is
+// sent. Failed delivery is retried with exponential backoff and marked as dead after maxAttempts
+func (w *Worker) Deliver(ctx context.Context, batchSize int64) (int64, error) {
+	leaseUntil := w.now().Add(w.timeout * time.Duration(batchSize))
+	deliveries, err := w.webhookRepo.ClaimPendingDeliveries(ctx, batchSize, leaseUntil)
+	if err != nil {
+		return 0, fmt.Errorf("error from webhook worker while claiming deliveries: %w", err)
+	}
+
+	var delivered int64
+	for _, delivery := range deliveries {
+		statusCode, errSend := w.sender.Send(ctx, delivery.URL, delivery.Secret, publisher.Message{
+			ID:         delivery.EventID,
+			Type:       delivery.EventType,
+			UserID:     delivery.UserID,
+			Payload:    delivery.Payload,
+			OccurredAt: delivery.EventTime,
+		})
+		switch {
+		case errSend == nil:
+			err = w.webhookRepo.MarkDelivered(ctx, delivery.ID, statusCode)
+			if err == nil {
+				delivered++
+			}
+		case delivery.Attempts+1 >= w.maxAttempts:
+			err = w.webhookRepo.MarkDead(ctx, delivery.ID, statusCode, errSend.Error())
+		default:
+			nextAttemptTime := w.now().Add(w.backoff(delivery.Attempts))
+			err = w.webhookRepo.MarkFailed(ctx, delivery.ID, nextAttemptTime, statusCode, errSend.Error())
+		}
+		if err != nil {
+			// not saved deliveries are sent again after lease expires
+			return delivered, fmt.Errorf("error from webhook worker while saving result of delivery %d: %w", delivery.ID, err)
+		}
+	}
+
+	return delivered, nil
+}
+
+// backoff returns delay before next attempt: minBackoff doubled after every failed attempt, but not more than maxBackoff
+func (w *Worker) backoff(attempts int64) time.Duration {
+	delay := w.minBackoff
+	for i := int64(0); i < attempts && delay < w.maxBackoff; i++ {
+		delay *= 2
+	}
+
+	if delay > w.maxBackoff {
+		return w.maxBackoff
+	}
+
+	return delay
+}
+
+// matches checks if value passes filter, empty filter passes everything
+func matches(filter []string, value string) bool {
+	return len(filter) == 0 || slices.Contains(filter, value)
+}
@@ -0,0 +1,196 @@
+package webhook
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+
+	"github.com/pollykon/avito_test_task/internal/publisher"
+	outboxRepository "github.com/pollykon/avito_test_task/internal/repository/outbox"
+	webhookRepository "github.com/pollykon/avito_test_task/internal/repository/webhook"
+	"github.com/pollykon/avito_test_task/internal/service/webhook/mocks"
+)
+
+func inTransaction(ctx context.Context, f func(context.Context) error) error {
+	return f(ctx)
+}
+
+func TestWorker_Dispatch_Success(t *testing.T) {
+	batchSize := int64(100)
+	eventTime := time.Date(2023, 8, 1, 0, 0, 0, 0, time.UTC)
+	userID := int64(10)
+
+	events := []outboxRepository.Event{
+		{
+			ID:         1,
+			UserID:     &userID,
+			Type:       outboxRepository.EventTypeUserAddedToSegment,
+			Payload:    []byte(`{"userId":10,"segmentId":"AVITO","reason":"request"}`),
+			InsertTime: eventTime,
+		},
+		{
+			ID:         2,
+			Type:       outboxRepository.EventTypeSegmentCreated,
+			Payload:    []byte(`{"segmentId":"VOICE"}`),
+			InsertTime: eventTime,
+		},
+	}
+	subscriptions := []webhookRepository.Subscription{
+		{ID: 1},
+		{ID: 2, EventTypes: []string{outboxRepository.EventTypeSegmentCreated}},
+		{ID: 3, SegmentIDs: []string{"AVITO"}},
+		{ID: 4, EventTypes: []string{outboxRepository.EventTypeSegmentDeleted}},
+	}
+
+	webhookRepoMock := mocks.NewWebhookRepository(t)
+	webhookRepoMock.EXPECT().InTransaction(context.Background(), mock.Anything).RunAndReturn(inTransaction)
+	webhookRepoMock.EXPECT().GetSubscriptions(context.Background()).Return(subscriptions, nil)
+	webhookRepoMock.EXPECT().AddDeliveries(context.Background(), []webhookRepository.Delivery{
+		{SubscriptionID: 1, EventID: 1, UserID: &userID, EventType: events[0].Type, Payload: events[0].Payload, EventTime: eventTime},
+		{SubscriptionID: 3, EventID: 1, UserID: &userID, EventType: events[0].Type, Payload: events[0].Payload, EventTime: eventTime},
+		{SubscriptionID: 1, EventID: 2, EventType: events[1].Type, Payload: events[1].Payload, EventTime: eventTime},
+		{SubscriptionID: 2, EventID: 2, EventType: events[1].Type, Payload: events[1].Payload, EventTime: eventTime},
+	}).Return(nil)
+
+	outboxRepoMock := mocks.NewOutboxRepository(t)
+	outboxRepoMock.EXPECT().GetNotDispatched(context.Background(), batchSize).Return(events, nil)
+	outboxRepoMock.EXPECT().MarkDispatched(context.Background(), []int64{1, 2}).Return(nil)
+
+	worker := NewWorker(webhookRepoMock, outboxRepoMock, mocks.NewSender(t), time.Second, 10, time.Second, time.Minute)
+
+	dispatched, err := worker.Dispatch(context.Background(), batchSize)
+
+	assert.NoError(t, err)
+	assert.Equal(t, int64(2), dispatched)
+}
+
+func TestWorker_Dispatch_NoEvents(t *testing.T) {
+	webhookRepoMock := mocks.NewWebhookRepository(t)
+	webhookRepoMock.EXPECT().InTransaction(context.Background(), mock.Anything).RunAndReturn(inTransaction)
+
+	outboxRepoMock := mocks.NewOutboxRepository(t)
+	outboxRepoMock.EXPECT().GetNotDispatched(context.Background(), int64(100)).Return(nil, nil)
+
+	worker := NewWorker(webhookRepoMock, outboxRepoMock, mocks.NewSender(t), time.Second, 10, time.Second, time.Minute)
+
+	dispatched, err := worker.Dispatch(context.Background(), 100)
+
+	assert.NoError(t, err)
+	assert.Equal(t, int64(0), dispatched)
+}
+
+func TestWorker_Dispatch_Error(t *testing.T) {
+	errFromOutboxRepo := fmt.Errorf("error from outbox repo")
+
+	webhookRepoMock := mocks.NewWebhookRepository(t)
+	webhookRepoMock.EXPECT().InTransaction(context.Background(), mock.Anything).RunAndReturn(inTransaction)
+
+	outboxRepoMock := mocks.NewOutboxRepository(t)
+	outboxRepoMock.EXPECT().GetNotDispatched(context.Background(), int64(100)).Return(nil, errFromOutboxRepo)
+
+	worker := NewWorker(webhookRepoMock, outboxRepoMock, mocks.NewSender(t), time.Second, 10, time.Second, time.Minute)
+
+	_, err := worker.Dispatch(context.Background(), 100)
+
+	assert.ErrorIs(t, err, errFromOutboxRepo)
+}
+
+func TestWorker_Deliver_Success(t *testing.T) {
+	batchSize := int64(100)
+	now := time.Date(2023, 8, 1, 0, 0, 0, 0, time.UTC)
+	userID := int64(10)
+
+	newDelivery := func(id int64, attempts int64) webhookRepository.PendingDelivery {
+		return webhookRepository.PendingDelivery{
+			Delivery: webhookRepository.Delivery{
+				ID:        id,
+				EventID:   id * 10,
+				UserID:    &userID,
+				EventType: outboxRepository.EventTypeUserAddedToSegment,
+				Payload:   []byte(`{}`),
+				Attempts:  attempts,
+			},
+			URL:    "https://example.com/hook",
+			Secret: "secret",
+		}
+	}
+	newMessage := func(id int64) publisher.Message {
+		return publisher.Message{
+			ID:      id * 10,
+			Type:    outboxRepository.EventTypeUserAddedToSegment,
+			UserID:  &userID,
+			Payload: []byte(`{}`),
+		}
+	}
+	errFromSender := fmt.Errorf("error from sender")
+
+	webhookRepoMock := mocks.NewWebhookRepository(t)
+	// deliveries are claimed for time of sending the whole batch
+	webhookRepoMock.EXPECT().ClaimPendingDeliveries(context.Background(), batchSize, now.Add(100*time.Second)).
+		Return([]webhookRepository.PendingDelivery{newDelivery(1, 0), newDelivery(2, 2), newDelivery(3, 9)}, nil)
+	webhookRepoMock.EXPECT().MarkDelivered(context.Background(), int64(1), int64(200)).Return(nil)
+	// second delivery failed after 2 attempts, so it is delayed for 1s * 2^2
+	webhookRepoMock.EXPECT().MarkFailed(context.Background(), int64(2), now.Add(4*time.Second), int64(503), errFromSender.Error()).
+		Return(nil)
+	// third delivery failed the last allowed attempt
+	webhookRepoMock.EXPECT().MarkDead(context.Background(), int64(3), int64(0), errFromSender.Error()).Return(nil)
+
+	senderMock := mocks.NewSender(t)
+	senderMock.EXPECT().Send(context.Background(), "https://example.com/hook", "secret", newMessage(1)).Return(200, nil)
+	senderMock.EXPECT().Send(context.Background(), "https://example.com/hook", "secret", newMessage(2)).
+		Return(503, errFromSender)
+	senderMock.EXPECT().Send(context.Background(), "https://example.com/hook", "secret", newMessage(3)).
+		Return(0, errFromSender)
+
+	worker := NewWorker(webhookRepoMock, mocks.NewOutboxRepository(t), senderMock, time.Second, 10, time.Second, time.Minute)
+	worker.now = func() time.Time { return now }
+
+	delivered, err := worker.Deliver(context.Background(), batchSize)
+
+	assert.NoError(t, err)
+	assert.Equal(t, int64(1), delivered)
+}
+
+func TestWorker_Deliver_Error(t *testing.T) {
+	errFromWebhookRepo := fmt.Errorf("error from webhook repo")
+
+	webhookRepoMock := mocks.NewWebhookRepository(t)
+	webhookRepoMock.EXPECT().ClaimPendingDeliveries(context.Background(), int64(100), mock.Anything).
+		Return(nil, errFromWebhookRepo)
+
+	worker := NewWorker(
+		webhookRepoMock, mocks.NewOutboxRepository(t), mocks.NewSender(t), time.Second, 10, time.Second, time.Minute,
+	)
+
+	_, err := worker.Deliver(context.Background(), 100)
+
+	assert.ErrorIs(t, err, errFromWebhookRepo)
+}
+
+func TestWorker_Deliver_SaveResultError(t *testing.T) {
+	errFromWebhookRepo := fmt.Errorf("error from webhook repo")
+	delivery := func(id int64) webhookRepository.PendingDelivery {
+		return webhookRepository.PendingDelivery{Delivery: webhookRepository.Delivery{ID: id}, URL: "https://example.com/hook"}
+	}
+
+	webhookRepoMock := mocks.NewWebhookRepository(t)
+	webhookRepoMock.EXPECT().ClaimPendingDeliveries(context.Background(), int64(100), mock.Anything).
+		Return([]webhookRepository.PendingDelivery{delivery(1), delivery(2), delivery(3)}, nil)
+	webhookRepoMock.EXPECT().MarkDelivered(context.Background(), int64(1), int64(200)).Return(nil)
+	webhookRepoMock.EXPECT().MarkDelivered(context.Background(), int64(2), int64(200)).Return(errFromWebhookRepo)
+
+	// third delivery isn't sent, it is sent again after lease expires
+	senderMock := mocks.NewSender(t)
+	senderMock.EXPECT().Send(context.Background(), "https://example.com/hook", "", mock.Anything).Return(200, nil).Times(2)
+
+	worker := NewWorker(webhookRepoMock, mocks.NewOutboxRepository(t), senderMock, time.Second, 10, time.Second, time.Minute)
+
+	delivered, err := worker.Deliver(context.Background(), 100)
+
+	assert.ErrorIs(t, err, errFromWebhookRepo)
+	assert.Equal(t, int64(1), delivered)
+}
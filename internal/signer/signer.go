@@ -0,0 +1,72 @@
+package signer
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"net/url"
+	"strconv"
+	"time"
+)
+
+var ErrInvalidSignature = errors.New("invalid signature")
+var ErrExpired = errors.New("signature expired")
+
+// Query parameters of signed url
+const (
+	ParamExpires   = "expires"
+	ParamSignature = "signature"
+)
+
+// Signer produces and checks HMAC signatures which grant time-limited access to files
+type Signer struct {
+	secret []byte
+	ttl    time.Duration
+	now    func() time.Time
+}
+
+func New(secret string, ttl time.Duration) Signer {
+	return Signer{secret: []byte(secret), ttl: ttl, now: time.Now}
+}
+
+// Sign returns query parameters which grant access to fileName until ttl expires
+func (s Signer) Sign(fileName string) url.Values {
+	expires := strconv.FormatInt(s.now().Add(s.ttl).Unix(), 10)
+
+	return url.Values{
+		ParamExpires:   []string{expires},
+		ParamSignature: []string{s.signature(fileName, expires)},
+	}
+}
+
+// Verify checks that query parameters were produced by Sign for fileName and link is still actual
+func (s Signer) Verify(fileName string, query url.Values) error {
+	expires := query.Get(ParamExpires)
+	signature, err := hex.DecodeString(query.Get(ParamSignature))
+	if err != nil || expires == "" {
+		return ErrInvalidSignature
+	}
+
+	expected, _ := hex.DecodeString(s.signature(fileName, expires))
+	if !hmac.Equal(signature, expected) {
+		return ErrInvalidSignature
+	}
+
+	expiresUnix, err := strconv.ParseInt(expires, 10, 64)
+	if err != nil {
+		return ErrInvalidSignature
+	}
+
+	if !s.now().Before(time.Unix(expiresUnix, 0)) {
+		return ErrExpired
+	}
+
+	return nil
+}
+
+func (s Signer) signature(fileName string, expires string) string {
+	mac := hmac.New(sha256.New, s.secret)
+	_, _ = mac.Write([]byte(fileName + "\n" + expires))
+	return hex.EncodeToString(mac.Sum(nil))
+}
@@ -0,0 +1,99 @@
+package signer
+
+import (
+	"net/url"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSigner_Verify_Success(t *testing.T) {
+	s := New("secret", time.Hour)
+
+	query := s.Sign("log.csv")
+
+	assert.NoError(t, s.Verify("log.csv", query))
+}
+
+func TestSigner_Verify_Error(t *testing.T) {
+	signedAt := time.Date(2023, 8, 1, 0, 0, 0, 0, time.UTC)
+
+	s := New("secret", time.Hour)
+	s.now = func() time.Time { return signedAt }
+	query := s.Sign("log.csv")
+
+	tt := []struct {
+		name string
+
+		secret   string
+		now      time.Time
+		fileName string
+		query    url.Values
+
+		expectedError error
+	}{
+		{
+			name: "another_file",
+
+			secret:   "secret",
+			now:      signedAt,
+			fileName: "another.csv",
+			query:    query,
+
+			expectedError: ErrInvalidSignature,
+		},
+		{
+			name: "another_secret",
+
+			secret:   "another_secret",
+			now:      signedAt,
+			fileName: "log.csv",
+			query:    query,
+
+			expectedError: ErrInvalidSignature,
+		},
+		{
+			name: "changed_expires",
+
+			secret:   "secret",
+			now:      signedAt,
+			fileName: "log.csv",
+			query: url.Values{
+				ParamExpires:   []string{"4102444800"},
+				ParamSignature: query[ParamSignature],
+			},
+
+			expectedError: ErrInvalidSignature,
+		},
+		{
+			name: "missing_signature",
+
+			secret:   "secret",
+			now:      signedAt,
+			fileName: "log.csv",
+			query:    url.Values{},
+
+			expectedError: ErrInvalidSignature,
+		},
+		{
+			name: "expired",
+
+			secret:   "secret",
+			now:      signedAt.Add(time.Hour),
+			fileName: "log.csv",
+			query:    query,
+
+			expectedError: ErrExpired,
+		},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			verifier := New(tc.secret, time.Hour)
+			verifier.now = func() time.Time { return tc.now }
+
+			assert.ErrorIs(t, verifier.Verify(tc.fileName, tc.query), tc.expectedError)
+		})
+	}
+}
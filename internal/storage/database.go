@@ -3,63 +3,300 @@ package storage
 import (
 	"context"
 	"database/sql"
+	"errors"
+	"fmt"
+	"regexp"
+	"runtime"
+	"strings"
+	"time"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
+
+	"github.com/pollykon/avito_test_task/internal/metrics"
+	"github.com/pollykon/avito_test_task/internal/tracing"
 )
 
 type Database struct {
-	db *sql.DB
+	db       *sql.DB
+	replica  *replica
+	txConfig TransactionConfig
 }
 
 func New(db *sql.DB) Database {
 	return Database{
-		db: db,
+		db:       db,
+		txConfig: DefaultTransactionConfig,
+	}
+}
+
+// NewWithReplica creates database which runs read-only queries of QueryReadContext on replica
+// while it is available and doesn't lag behind primary more than configured
+func NewWithReplica(db *sql.DB, replicaDB *sql.DB, config ReplicaConfig) Database {
+	return Database{
+		db:       db,
+		replica:  newReplica(replicaDB, config),
+		txConfig: DefaultTransactionConfig,
 	}
 }
 
+// WithTransactionConfig returns database which runs transactions with config
+func (db Database) WithTransactionConfig(config TransactionConfig) Database {
+	db.txConfig = config
+	return db
+}
+
+// dbSystem identifies database in spans
+const dbSystem = "postgresql"
+
 type ctxKey string
 
 const txKey = ctxKey("transaction")
 
 type TransactionWrapper func(ctx context.Context, f func(ctx context.Context) error) error
 
-func (db *Database) ExecContext(ctx context.Context, query string, args ...interface{}) (sql.Result, error) {
+func (db *Database) ExecContext(ctx context.Context, query string, args ...interface{}) (_ sql.Result, err error) {
+	ctx, done := startQuery(ctx, "storage.Database.ExecContext", query)
+	defer func() { done(err) }()
+
 	if tx := extractTx(ctx); tx != nil {
-		_, err := tx.ExecContext(ctx, query, args...)
-		return nil, err
+		return tx.ExecContext(ctx, query, args...)
 	}
 
 	affectedRows, err := db.db.ExecContext(ctx, query, args...)
 	return affectedRows, err
 }
 
-func (db *Database) QueryContext(ctx context.Context, query string, args ...interface{}) (*sql.Rows, error) {
+func (db *Database) QueryContext(ctx context.Context, query string, args ...interface{}) (_ *sql.Rows, err error) {
+	ctx, done := startQuery(ctx, "storage.Database.QueryContext", query)
+	defer func() { done(err) }()
+
+	if tx := extractTx(ctx); tx != nil {
+		return tx.QueryContext(ctx, query, args...)
+	}
+
+	return db.db.QueryContext(ctx, query, args...)
+}
+
+// QueryReadContext runs read-only query on replica, if there is no replica or it can't be used now,
+// query runs on primary. Queries in transaction run in it, so they see changes made by transaction
+func (db *Database) QueryReadContext(ctx context.Context, query string, args ...interface{}) (_ *sql.Rows, err error) {
+	ctx, done := startQuery(ctx, "storage.Database.QueryReadContext", query)
+	defer func() { done(err) }()
+
 	if tx := extractTx(ctx); tx != nil {
 		return tx.QueryContext(ctx, query, args...)
 	}
 
+	if db.replica != nil {
+		reason := db.replica.unusableReason(ctx)
+		if reason == "" {
+			rows, err := db.replica.db.QueryContext(ctx, query, args...)
+			if err == nil || ctx.Err() != nil || !isConnectionError(err) {
+				trace.SpanFromContext(ctx).SetAttributes(attribute.Bool("db.replica", true))
+				return rows, err
+			}
+
+			db.replica.markUnavailable()
+			reason = reasonUnavailable
+		}
+
+		metrics.DBReplicaFallbacks.WithLabelValues(reason).Inc()
+	}
+
 	return db.db.QueryContext(ctx, query, args...)
 }
 
-func (db *Database) WithTransaction(ctx context.Context, f func(ctx context.Context) error) error {
-	tx, err := db.db.BeginTx(ctx, nil)
+// PingContext checks that database is reachable
+func (db *Database) PingContext(ctx context.Context) error {
+	return db.db.PingContext(ctx)
+}
+
+// WithTransaction runs f in transaction. If there is already transaction in context, f runs in savepoint of it:
+// when f fails, only its changes are rolled back and caller of the outer transaction decides whether to commit
+// changes made before. Transaction started with WithRetries is run again with backoff when it fails because of
+// serialization failure or deadlock
+func (db *Database) WithTransaction(
+	ctx context.Context,
+	f func(ctx context.Context) error,
+	opts ...TransactionOption,
+) (err error) {
+	if tx := extractTransaction(ctx); tx != nil {
+		return db.withSavepoint(ctx, tx, f)
+	}
+
+	ctx, span := tracing.Start(ctx, "storage.Database.WithTransaction", attribute.String("db.system", dbSystem))
+	defer func() { tracing.End(span, err) }()
+
+	options := &transactionOptions{tx: sql.TxOptions{Isolation: db.txConfig.Isolation}}
+	for _, opt := range opts {
+		opt(options)
+	}
+
+	var retries int
+	var lastCode string
+	defer func() {
+		if retries == 0 {
+			return
+		}
+
+		span.SetAttributes(attribute.Int("db.transaction.retries", retries))
+		if db.txConfig.Logger != nil {
+			db.txConfig.Logger.WarnContext(
+				ctx, "transaction was retried",
+				"retries", retries, "code", lastCode, "succeeded", err == nil,
+			)
+		}
+	}()
+
+	for {
+		err = db.runTransaction(ctx, &options.tx, f)
+
+		code, retryable := retryableCode(err)
+		if !options.retries || !retryable || retries >= db.txConfig.MaxRetries {
+			return err
+		}
+
+		timer := time.NewTimer(retryBackoff(db.txConfig, retries))
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return err
+		case <-timer.C:
+		}
+
+		retries++
+		lastCode = code
+		metrics.DBTransactionRetries.WithLabelValues(code).Inc()
+	}
+}
+
+func (db *Database) runTransaction(
+	ctx context.Context,
+	options *sql.TxOptions,
+	f func(ctx context.Context) error,
+) error {
+	tx, err := db.db.BeginTx(ctx, options)
 	if err != nil {
 		return err
 	}
 
 	defer func() { _ = tx.Rollback() }()
 
-	err = f(context.WithValue(ctx, txKey, tx))
+	err = f(context.WithValue(ctx, txKey, &transaction{tx: tx}))
+	if err != nil {
+		metrics.DBTransactionRollbacks.Inc()
+		return err
+	}
+
+	err = tx.Commit()
 	if err != nil {
+		metrics.DBTransactionRollbacks.Inc()
 		return err
 	}
 
-	return tx.Commit()
+	return nil
+}
+
+// withSavepoint runs f in savepoint of transaction, savepoints are named by depth of nesting
+func (db *Database) withSavepoint(ctx context.Context, parent *transaction, f func(ctx context.Context) error) (err error) {
+	tx := &transaction{tx: parent.tx, depth: parent.depth + 1}
+	savepoint := fmt.Sprintf("sp_%d", tx.depth)
+
+	ctx, span := tracing.Start(
+		ctx,
+		"storage.Database.WithTransaction",
+		attribute.String("db.system", dbSystem),
+		attribute.String("db.savepoint", savepoint),
+	)
+	defer func() { tracing.End(span, err) }()
+
+	_, err = tx.tx.ExecContext(ctx, "savepoint "+savepoint)
+	if err != nil {
+		return err
+	}
+
+	err = f(context.WithValue(ctx, txKey, tx))
+	if err != nil {
+		// changes of f are undone, savepoint is released to reuse its name by next nested call
+		_, rollbackErr := tx.tx.ExecContext(ctx, "rollback to savepoint "+savepoint)
+		if rollbackErr == nil {
+			_, rollbackErr = tx.tx.ExecContext(ctx, "release savepoint "+savepoint)
+		}
+		return errors.Join(err, rollbackErr)
+	}
+
+	_, err = tx.tx.ExecContext(ctx, "release savepoint "+savepoint)
+	return err
+}
+
+// transaction is transaction in context with depth of nested calls of WithTransaction
+type transaction struct {
+	tx    *sql.Tx
+	depth int
+}
+
+// extractTransaction returns transaction from context if there is one
+func extractTransaction(ctx context.Context) *transaction {
+	if tx, ok := ctx.Value(txKey).(*transaction); ok {
+		return tx
+	}
+	return nil
 }
 
 // extractTx checks if there is transaction in context. If transaction in context, it returns transaction struct to
 // perform operation in transactions
 func extractTx(ctx context.Context) *sql.Tx {
-	if tx, ok := ctx.Value(txKey).(*sql.Tx); ok {
-		return tx
+	if tx := extractTransaction(ctx); tx != nil {
+		return tx.tx
 	}
 	return nil
 }
+
+// startQuery starts span of query and returns function which ends it with error of query
+// and records query duration by repository method which runs it
+func startQuery(ctx context.Context, name string, query string) (context.Context, func(err error)) {
+	start := time.Now()
+	method := callerMethod()
+
+	ctx, span := tracing.Start(
+		ctx,
+		name,
+		attribute.String("db.system", dbSystem),
+		attribute.String("db.statement", query),
+		attribute.String("code.function", method),
+	)
+
+	return ctx, func(err error) {
+		metrics.DBQueryDuration.WithLabelValues(method).Observe(time.Since(start).Seconds())
+		tracing.End(span, err)
+	}
+}
+
+// closureSuffix matches suffix of anonymous functions, e.g. ".func1.2"
+var closureSuffix = regexp.MustCompile(`(\.func\d+)+(\.\d+)*$`)
+
+// callerMethod returns name of the first function outside of storage in call stack,
+// e.g. "repository/segment.Repository.AddSegment" for queries in transaction function of AddSegment
+func callerMethod() string {
+	pcs := make([]uintptr, 16)
+	frames := runtime.CallersFrames(pcs[:runtime.Callers(2, pcs)])
+	for {
+		frame, more := frames.Next()
+		if !strings.Contains(frame.Function, "/internal/storage.") {
+			return methodName(frame.Function)
+		}
+		if !more {
+			return "unknown"
+		}
+	}
+}
+
+// methodName shortens full function name to path from internal package, receiver type and method
+func methodName(function string) string {
+	if i := strings.Index(function, "/internal/"); i >= 0 {
+		function = function[i+len("/internal/"):]
+	}
+	function = closureSuffix.ReplaceAllString(function, "")
+	return strings.NewReplacer("(*", "", ")", "").Replace(function)
+}
@@ -0,0 +1,45 @@
+package storage
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMethodName(t *testing.T) {
+	tt := []struct {
+		name string
+
+		function string
+
+		expectedMethod string
+	}{
+		{
+			name: "pointer_receiver",
+
+			function: "github.com/pollykon/avito_test_task/internal/repository/segment.(*Repository).AddSegment",
+
+			expectedMethod: "repository/segment.Repository.AddSegment",
+		},
+		{
+			name: "value_receiver",
+
+			function: "github.com/pollykon/avito_test_task/internal/repository/log.Repository.Add",
+
+			expectedMethod: "repository/log.Repository.Add",
+		},
+		{
+			name: "closure",
+
+			function: "github.com/pollykon/avito_test_task/internal/repository/segment.Repository.AddSegment.func1.2",
+
+			expectedMethod: "repository/segment.Repository.AddSegment",
+		},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			assert.Equal(t, tc.expectedMethod, methodName(tc.function))
+		})
+	}
+}
@@ -0,0 +1,152 @@
+package storage
+
+import (
+	"context"
+	"database/sql"
+	"database/sql/driver"
+	"errors"
+	"io"
+	"sync"
+)
+
+// fakeDB is a database/sql driver which records statements instead of running them, it replaces postgres in tests
+type fakeDB struct {
+	mu         sync.Mutex
+	statements []string
+
+	// queryErr is returned by queries except check of replica lag
+	queryErr error
+	// lagSeconds is returned by check of replica lag, lagErr fails it
+	lagSeconds float64
+	lagErr     error
+	// rowsAffected is result of statements
+	rowsAffected int64
+	// execErrs are returned by statements one by one, statements after them succeed
+	execErrs []error
+	// isolations are isolation levels of started transactions
+	isolations []driver.IsolationLevel
+}
+
+func newFakeDB() (*fakeDB, *sql.DB) {
+	fake := &fakeDB{}
+	return fake, sql.OpenDB(fake)
+}
+
+func (f *fakeDB) record(statement string) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.statements = append(f.statements, statement)
+}
+
+func (f *fakeDB) recorded() []string {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return append([]string(nil), f.statements...)
+}
+
+func (f *fakeDB) Connect(context.Context) (driver.Conn, error) {
+	return &fakeConn{db: f}, nil
+}
+
+func (f *fakeDB) Driver() driver.Driver {
+	return fakeDriver{}
+}
+
+type fakeDriver struct{}
+
+func (fakeDriver) Open(string) (driver.Conn, error) {
+	return nil, errors.New("fake driver is opened by connector")
+}
+
+type fakeConn struct {
+	db *fakeDB
+}
+
+func (c *fakeConn) Prepare(string) (driver.Stmt, error) {
+	return nil, errors.New("prepared statements aren't supported by fake driver")
+}
+
+func (c *fakeConn) Close() error {
+	return nil
+}
+
+func (c *fakeConn) Begin() (driver.Tx, error) {
+	return c.BeginTx(context.Background(), driver.TxOptions{})
+}
+
+func (c *fakeConn) BeginTx(_ context.Context, options driver.TxOptions) (driver.Tx, error) {
+	c.db.record("begin")
+
+	c.db.mu.Lock()
+	c.db.isolations = append(c.db.isolations, options.Isolation)
+	c.db.mu.Unlock()
+
+	return fakeTx{db: c.db}, nil
+}
+
+func (c *fakeConn) ExecContext(_ context.Context, query string, _ []driver.NamedValue) (driver.Result, error) {
+	c.db.record(query)
+
+	c.db.mu.Lock()
+	defer c.db.mu.Unlock()
+	if len(c.db.execErrs) != 0 {
+		err := c.db.execErrs[0]
+		c.db.execErrs = c.db.execErrs[1:]
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return driver.RowsAffected(c.db.rowsAffected), nil
+}
+
+func (c *fakeConn) QueryContext(_ context.Context, query string, _ []driver.NamedValue) (driver.Rows, error) {
+	if query == replicaLagQuery {
+		if c.db.lagErr != nil {
+			return nil, c.db.lagErr
+		}
+		return &fakeRows{values: [][]driver.Value{{c.db.lagSeconds}}}, nil
+	}
+
+	c.db.record(query)
+	if c.db.queryErr != nil {
+		return nil, c.db.queryErr
+	}
+	return &fakeRows{}, nil
+}
+
+type fakeTx struct {
+	db *fakeDB
+}
+
+func (t fakeTx) Commit() error {
+	t.db.record("commit")
+	return nil
+}
+
+func (t fakeTx) Rollback() error {
+	t.db.record("rollback")
+	return nil
+}
+
+// fakeRows returns rows of one column
+type fakeRows struct {
+	values [][]driver.Value
+}
+
+func (r *fakeRows) Columns() []string {
+	return []string{"value"}
+}
+
+func (r *fakeRows) Close() error {
+	return nil
+}
+
+func (r *fakeRows) Next(dest []driver.Value) error {
+	if len(r.values) == 0 {
+		return io.EOF
+	}
+	copy(dest, r.values[0])
+	r.values = r.values[1:]
+	return nil
+}
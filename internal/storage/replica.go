@@ -0,0 +1,121 @@
+package storage
+
+import (
+	"context"
+	"database/sql"
+	"database/sql/driver"
+	"errors"
+	"net"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/lib/pq"
+)
+
+// reasons why replica isn't used, they label fallbacks to primary in metrics
+const (
+	reasonUnavailable = "unavailable"
+	reasonLag         = "lag"
+)
+
+const errCodeQueryCanceled = "57014"
+
+// replicaCheckTimeout limits check of replica lag, query waits for it
+const replicaCheckTimeout = time.Second
+
+// replicaLagQuery returns lag of replica in seconds. Replica which replayed all received WAL isn't lagging even if
+// its last replayed transaction is old, e.g. when there are no writes to primary
+const replicaLagQuery = `select case
+			when not pg_is_in_recovery() or pg_last_wal_receive_lsn() = pg_last_wal_replay_lsn() then 0
+			else coalesce(extract(epoch from now() - pg_last_xact_replay_timestamp()), 0)
+		  end`
+
+// ReplicaConfig sets when replica is used for read-only queries
+type ReplicaConfig struct {
+	// MaxLag is lag of replica after which queries go to primary
+	MaxLag time.Duration
+	// CheckInterval is how often lag and availability of replica are checked
+	CheckInterval time.Duration
+}
+
+type replica struct {
+	db     *sql.DB
+	config ReplicaConfig
+
+	state    atomic.Pointer[replicaState]
+	checking sync.Mutex
+}
+
+// replicaState is result of the last check of replica, empty reason means that replica can be used
+type replicaState struct {
+	checkedAt time.Time
+	reason    string
+}
+
+func newReplica(db *sql.DB, config ReplicaConfig) *replica {
+	r := &replica{db: db, config: config}
+	r.state.Store(&replicaState{})
+	return r
+}
+
+// unusableReason returns why replica can't be used now. Replica is checked at most once per check interval
+// by one of queries, other queries use result of the previous check meanwhile
+func (r *replica) unusableReason(ctx context.Context) string {
+	state := r.state.Load()
+	if time.Since(state.checkedAt) < r.config.CheckInterval || !r.checking.TryLock() {
+		return state.reason
+	}
+	defer r.checking.Unlock()
+
+	reason := r.check(ctx)
+	r.state.Store(&replicaState{checkedAt: time.Now(), reason: reason})
+
+	return reason
+}
+
+func (r *replica) check(ctx context.Context) string {
+	// check isn't canceled with query, otherwise canceled query would mark replica unavailable
+	ctx, cancel := context.WithTimeout(context.WithoutCancel(ctx), replicaCheckTimeout)
+	defer cancel()
+
+	var lagSeconds float64
+	err := r.db.QueryRowContext(ctx, replicaLagQuery).Scan(&lagSeconds)
+	if err != nil {
+		return reasonUnavailable
+	}
+
+	if time.Duration(lagSeconds*float64(time.Second)) > r.config.MaxLag {
+		return reasonLag
+	}
+
+	return ""
+}
+
+// markUnavailable makes queries go to primary until the next check
+func (r *replica) markUnavailable() {
+	r.state.Store(&replicaState{checkedAt: time.Now(), reason: reasonUnavailable})
+}
+
+// isConnectionError checks if query failed because database is unreachable or can't serve queries,
+// such queries can be repeated on primary
+func isConnectionError(err error) bool {
+	if errors.Is(err, driver.ErrBadConn) {
+		return true
+	}
+
+	var netErr net.Error
+	if errors.As(err, &netErr) {
+		return true
+	}
+
+	// class 08 is connection exception, class 57 is operator intervention, e.g. database is starting up,
+	// except canceled queries which would fail on primary too
+	var pqErr *pq.Error
+	if errors.As(err, &pqErr) {
+		class := pqErr.Code.Class()
+		return class == "08" || class == "57" && pqErr.Code != errCodeQueryCanceled
+	}
+
+	return false
+}
@@ -0,0 +1,160 @@
+package storage
+
+import (
+	"context"
+	"errors"
+	"net"
+	"testing"
+	"time"
+
+	"github.com/lib/pq"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/require"
+
+	"github.com/pollykon/avito_test_task/internal/metrics"
+)
+
+const readQuery = "select id from segment"
+
+func TestDatabase_QueryReadContext(t *testing.T) {
+	tt := []struct {
+		name string
+
+		lagSeconds float64
+		lagErr     error
+		replicaErr error
+
+		expectedErr      error
+		expectedReplica  []string
+		expectedPrimary  []string
+		expectedFallback string
+	}{
+		{
+			name: "replica",
+
+			expectedReplica: []string{readQuery},
+		},
+		{
+			name: "replica_lags",
+
+			lagSeconds: 10,
+
+			expectedPrimary:  []string{readQuery},
+			expectedFallback: reasonLag,
+		},
+		{
+			name: "replica_check_failed",
+
+			lagErr: errors.New("connection refused"),
+
+			expectedPrimary:  []string{readQuery},
+			expectedFallback: reasonUnavailable,
+		},
+		{
+			name: "replica_connection_lost",
+
+			replicaErr: &net.OpError{Op: "read", Err: errors.New("connection reset by peer")},
+
+			expectedReplica:  []string{readQuery},
+			expectedPrimary:  []string{readQuery},
+			expectedFallback: reasonUnavailable,
+		},
+		{
+			name: "replica_starting_up",
+
+			replicaErr: &pq.Error{Code: "57P03"},
+
+			expectedReplica:  []string{readQuery},
+			expectedPrimary:  []string{readQuery},
+			expectedFallback: reasonUnavailable,
+		},
+		{
+			name: "query_error",
+
+			replicaErr: &pq.Error{Code: "42601"},
+
+			expectedErr:     &pq.Error{Code: "42601"},
+			expectedReplica: []string{readQuery},
+		},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			primary, primaryDB := newFakeDB()
+			replica, replicaDB := newFakeDB()
+			replica.lagSeconds = tc.lagSeconds
+			replica.lagErr = tc.lagErr
+			replica.queryErr = tc.replicaErr
+
+			db := NewWithReplica(primaryDB, replicaDB, ReplicaConfig{MaxLag: 5 * time.Second, CheckInterval: time.Hour})
+
+			var fallbacksBefore float64
+			if tc.expectedFallback != "" {
+				fallbacksBefore = testutil.ToFloat64(metrics.DBReplicaFallbacks.WithLabelValues(tc.expectedFallback))
+			}
+
+			rows, err := db.QueryReadContext(context.Background(), readQuery)
+			if rows != nil {
+				_ = rows.Close()
+			}
+			require.Equal(t, tc.expectedErr, err)
+
+			require.Equal(t, tc.expectedReplica, replica.recorded())
+			require.Equal(t, tc.expectedPrimary, primary.recorded())
+
+			if tc.expectedFallback != "" {
+				fallbacks := testutil.ToFloat64(metrics.DBReplicaFallbacks.WithLabelValues(tc.expectedFallback))
+				require.Equal(t, fallbacksBefore+1, fallbacks)
+			}
+		})
+	}
+}
+
+func TestDatabase_QueryReadContext_UnavailableReplicaIsSkipped(t *testing.T) {
+	primary, primaryDB := newFakeDB()
+	replica, replicaDB := newFakeDB()
+	replica.queryErr = &net.OpError{Op: "read", Err: errors.New("connection reset by peer")}
+
+	db := NewWithReplica(primaryDB, replicaDB, ReplicaConfig{MaxLag: 5 * time.Second, CheckInterval: time.Hour})
+
+	for i := 0; i < 3; i++ {
+		rows, err := db.QueryReadContext(context.Background(), readQuery)
+		require.NoError(t, err)
+		_ = rows.Close()
+	}
+
+	// replica is used again only after the next check
+	require.Equal(t, []string{readQuery}, replica.recorded())
+	require.Equal(t, []string{readQuery, readQuery, readQuery}, primary.recorded())
+}
+
+func TestDatabase_QueryReadContext_Transaction(t *testing.T) {
+	primary, primaryDB := newFakeDB()
+	replica, replicaDB := newFakeDB()
+
+	db := NewWithReplica(primaryDB, replicaDB, ReplicaConfig{MaxLag: 5 * time.Second, CheckInterval: time.Hour})
+
+	err := db.WithTransaction(context.Background(), func(ctx context.Context) error {
+		rows, err := db.QueryReadContext(ctx, readQuery)
+		if err != nil {
+			return err
+		}
+		return rows.Close()
+	})
+	require.NoError(t, err)
+
+	require.Empty(t, replica.recorded())
+	require.Equal(t, []string{"begin", readQuery, "commit"}, primary.recorded())
+}
+
+func TestDatabase_QueryReadContext_WithoutReplica(t *testing.T) {
+	primary, primaryDB := newFakeDB()
+
+	db := New(primaryDB)
+
+	rows, err := db.QueryReadContext(context.Background(), readQuery)
+	require.NoError(t, err)
+	_ = rows.Close()
+
+	require.Equal(t, []string{readQuery}, primary.recorded())
+}
@@ -0,0 +1,94 @@
+package storage
+
+import (
+	"database/sql"
+	"errors"
+	"log/slog"
+	"math/rand"
+	"time"
+
+	"github.com/lib/pq"
+)
+
+// codes of errors after which transaction can succeed if it is run again
+const (
+	errCodeSerializationFailure = "40001"
+	errCodeDeadlockDetected     = "40P01"
+)
+
+// TransactionConfig sets isolation level of transactions and retries of transactions which failed because of
+// serialization failure or deadlock
+type TransactionConfig struct {
+	Isolation sql.IsolationLevel
+	// MaxRetries is number of attempts after the first one, 0 disables retries
+	MaxRetries      int
+	RetryMinBackoff time.Duration
+	RetryMaxBackoff time.Duration
+	// Logger logs transactions which were retried, nil disables logs
+	Logger *slog.Logger
+}
+
+// DefaultTransactionConfig is used by databases created without config of transactions
+var DefaultTransactionConfig = TransactionConfig{
+	Isolation:       sql.LevelReadCommitted,
+	MaxRetries:      3,
+	RetryMinBackoff: 10 * time.Millisecond,
+	RetryMaxBackoff: 500 * time.Millisecond,
+}
+
+// TransactionOption changes transaction started by WithTransaction, nested calls run in transaction of outer call
+// and ignore options
+type TransactionOption func(options *transactionOptions)
+
+type transactionOptions struct {
+	tx      sql.TxOptions
+	retries bool
+}
+
+// WithIsolation runs transaction with isolation level instead of configured one
+func WithIsolation(level sql.IsolationLevel) TransactionOption {
+	return func(options *transactionOptions) {
+		options.tx.Isolation = level
+	}
+}
+
+// WithRetries runs transaction again when it fails because of serialization failure or deadlock. Function of
+// transaction is called again too, so it must not have side effects outside of database
+func WithRetries() TransactionOption {
+	return func(options *transactionOptions) {
+		options.retries = true
+	}
+}
+
+// retryableCode returns code of error if transaction failed with it can be retried
+func retryableCode(err error) (string, bool) {
+	var pqErr *pq.Error
+	if !errors.As(err, &pqErr) {
+		return "", false
+	}
+
+	switch pqErr.Code {
+	case errCodeSerializationFailure, errCodeDeadlockDetected:
+		return string(pqErr.Code), true
+	default:
+		return "", false
+	}
+}
+
+// retryBackoff returns delay before retry: minBackoff doubled after every failed attempt, but not more than
+// maxBackoff. Random half of delay is dropped, so conflicting transactions don't retry at the same time
+func retryBackoff(config TransactionConfig, attempts int) time.Duration {
+	delay := config.RetryMinBackoff
+	for i := 0; i < attempts && delay < config.RetryMaxBackoff; i++ {
+		delay *= 2
+	}
+
+	if delay > config.RetryMaxBackoff {
+		delay = config.RetryMaxBackoff
+	}
+	if delay <= 0 {
+		return 0
+	}
+
+	return delay/2 + time.Duration(rand.Int63n(int64(delay/2)+1))
+}
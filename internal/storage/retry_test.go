@@ -0,0 +1,192 @@
+package storage
+
+import (
+	"bytes"
+	"context"
+	"database/sql"
+	"database/sql/driver"
+	"log/slog"
+	"testing"
+	"time"
+
+	"github.com/lib/pq"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/require"
+
+	"github.com/pollykon/avito_test_task/internal/metrics"
+)
+
+var (
+	errSerializationFailure = &pq.Error{Code: errCodeSerializationFailure}
+	errDeadlockDetected     = &pq.Error{Code: errCodeDeadlockDetected}
+)
+
+func TestDatabase_WithTransaction_Retries(t *testing.T) {
+	tt := []struct {
+		name string
+
+		execErrs []error
+		options  []TransactionOption
+
+		expectedErr        error
+		expectedStatements []string
+		expectedRetries    map[string]float64
+		expectedLog        string
+	}{
+		{
+			name: "retried_after_serialization_failure",
+
+			execErrs: []error{errSerializationFailure},
+			options:  []TransactionOption{WithRetries()},
+
+			expectedStatements: []string{"begin", "insert a", "rollback", "begin", "insert a", "commit"},
+			expectedRetries:    map[string]float64{errCodeSerializationFailure: 1},
+			expectedLog:        "retries=1 code=40001 succeeded=true",
+		},
+		{
+			name: "retries_exhausted",
+
+			execErrs: []error{errDeadlockDetected, errSerializationFailure, errDeadlockDetected},
+			options:  []TransactionOption{WithRetries()},
+
+			expectedErr: errDeadlockDetected,
+			expectedStatements: []string{
+				"begin", "insert a", "rollback",
+				"begin", "insert a", "rollback",
+				"begin", "insert a", "rollback",
+			},
+			expectedRetries: map[string]float64{errCodeSerializationFailure: 1, errCodeDeadlockDetected: 1},
+			expectedLog:     "retries=2 code=40001 succeeded=false",
+		},
+		{
+			name: "not_retryable_error",
+
+			execErrs: []error{&pq.Error{Code: "23505"}},
+			options:  []TransactionOption{WithRetries()},
+
+			expectedErr:        &pq.Error{Code: "23505"},
+			expectedStatements: []string{"begin", "insert a", "rollback"},
+		},
+		{
+			name: "retries_not_enabled",
+
+			execErrs: []error{errSerializationFailure},
+
+			expectedErr:        errSerializationFailure,
+			expectedStatements: []string{"begin", "insert a", "rollback"},
+		},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			fake, sqlDB := newFakeDB()
+			fake.execErrs = tc.execErrs
+
+			logs := &bytes.Buffer{}
+			db := New(sqlDB).WithTransactionConfig(TransactionConfig{
+				MaxRetries:      2,
+				RetryMinBackoff: time.Millisecond,
+				RetryMaxBackoff: time.Millisecond,
+				Logger:          slog.New(slog.NewTextHandler(logs, nil)),
+			})
+
+			retriesBefore := make(map[string]float64)
+			for code := range tc.expectedRetries {
+				retriesBefore[code] = testutil.ToFloat64(metrics.DBTransactionRetries.WithLabelValues(code))
+			}
+
+			err := db.WithTransaction(context.Background(), func(ctx context.Context) error {
+				_, err := db.ExecContext(ctx, "insert a")
+				return err
+			}, tc.options...)
+			require.Equal(t, tc.expectedErr, err)
+			require.Equal(t, tc.expectedStatements, fake.recorded())
+
+			for code, retries := range tc.expectedRetries {
+				actual := testutil.ToFloat64(metrics.DBTransactionRetries.WithLabelValues(code))
+				require.Equal(t, retriesBefore[code]+retries, actual)
+			}
+
+			if tc.expectedLog == "" {
+				require.Empty(t, logs.String())
+			} else {
+				require.Contains(t, logs.String(), tc.expectedLog)
+			}
+		})
+	}
+}
+
+func TestDatabase_WithTransaction_NestedErrorIsRetried(t *testing.T) {
+	fake, sqlDB := newFakeDB()
+	fake.execErrs = []error{nil, errSerializationFailure}
+
+	db := New(sqlDB).WithTransactionConfig(TransactionConfig{MaxRetries: 1})
+
+	err := db.WithTransaction(context.Background(), func(ctx context.Context) error {
+		return db.WithTransaction(ctx, exec(&db, "insert a"))
+	}, WithRetries())
+	require.NoError(t, err)
+
+	require.Equal(t, []string{
+		"begin",
+		"savepoint sp_1",
+		"insert a",
+		"rollback to savepoint sp_1",
+		"release savepoint sp_1",
+		"rollback",
+		"begin",
+		"savepoint sp_1",
+		"insert a",
+		"release savepoint sp_1",
+		"commit",
+	}, fake.recorded())
+}
+
+func TestDatabase_WithTransaction_CanceledDuringBackoff(t *testing.T) {
+	fake, sqlDB := newFakeDB()
+
+	db := New(sqlDB).WithTransactionConfig(TransactionConfig{
+		MaxRetries:      3,
+		RetryMinBackoff: time.Hour,
+		RetryMaxBackoff: time.Hour,
+	})
+
+	ctx, cancel := context.WithCancel(context.Background())
+	time.AfterFunc(10*time.Millisecond, cancel)
+
+	err := db.WithTransaction(ctx, func(ctx context.Context) error {
+		return errSerializationFailure
+	}, WithRetries())
+	require.Equal(t, errSerializationFailure, err)
+
+	require.Equal(t, []string{"begin", "rollback"}, fake.recorded())
+}
+
+func TestDatabase_WithTransaction_Isolation(t *testing.T) {
+	fake, sqlDB := newFakeDB()
+	db := New(sqlDB)
+
+	require.NoError(t, db.WithTransaction(context.Background(), exec(&db, "insert a")))
+	require.NoError(t, db.WithTransaction(
+		context.Background(), exec(&db, "insert b"), WithIsolation(sql.LevelSerializable),
+	))
+
+	require.Equal(t, []driver.IsolationLevel{
+		driver.IsolationLevel(sql.LevelReadCommitted),
+		driver.IsolationLevel(sql.LevelSerializable),
+	}, fake.isolations)
+}
+
+func TestRetryBackoff(t *testing.T) {
+	config := TransactionConfig{RetryMinBackoff: 10 * time.Millisecond, RetryMaxBackoff: 50 * time.Millisecond}
+
+	for i := 0; i < 100; i++ {
+		first := retryBackoff(config, 0)
+		require.GreaterOrEqual(t, first, 5*time.Millisecond)
+		require.LessOrEqual(t, first, 10*time.Millisecond)
+
+		capped := retryBackoff(config, 10)
+		require.GreaterOrEqual(t, capped, 25*time.Millisecond)
+		require.LessOrEqual(t, capped, 50*time.Millisecond)
+	}
+}
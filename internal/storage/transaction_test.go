@@ -0,0 +1,173 @@
+package storage
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+var errTransaction = errors.New("transaction error")
+
+func exec(db *Database, query string) func(ctx context.Context) error {
+	return func(ctx context.Context) error {
+		_, err := db.ExecContext(ctx, query)
+		return err
+	}
+}
+
+func TestDatabase_WithTransaction(t *testing.T) {
+	tt := []struct {
+		name string
+
+		f func(db *Database) func(ctx context.Context) error
+
+		expectedErr        error
+		expectedStatements []string
+	}{
+		{
+			name: "commit",
+
+			f: func(db *Database) func(ctx context.Context) error {
+				return exec(db, "insert a")
+			},
+
+			expectedStatements: []string{"begin", "insert a", "commit"},
+		},
+		{
+			name: "rollback",
+
+			f: func(db *Database) func(ctx context.Context) error {
+				return func(ctx context.Context) error {
+					_ = exec(db, "insert a")(ctx)
+					return errTransaction
+				}
+			},
+
+			expectedErr:        errTransaction,
+			expectedStatements: []string{"begin", "insert a", "rollback"},
+		},
+		{
+			name: "nested_commit",
+
+			f: func(db *Database) func(ctx context.Context) error {
+				return func(ctx context.Context) error {
+					return db.WithTransaction(ctx, exec(db, "insert a"))
+				}
+			},
+
+			expectedStatements: []string{"begin", "savepoint sp_1", "insert a", "release savepoint sp_1", "commit"},
+		},
+		{
+			name: "nested_rollback_is_handled",
+
+			f: func(db *Database) func(ctx context.Context) error {
+				return func(ctx context.Context) error {
+					err := db.WithTransaction(ctx, func(ctx context.Context) error {
+						_ = exec(db, "insert a")(ctx)
+						return errTransaction
+					})
+					if !errors.Is(err, errTransaction) {
+						return err
+					}
+					return exec(db, "insert b")(ctx)
+				}
+			},
+
+			expectedStatements: []string{
+				"begin",
+				"savepoint sp_1",
+				"insert a",
+				"rollback to savepoint sp_1",
+				"release savepoint sp_1",
+				"insert b",
+				"commit",
+			},
+		},
+		{
+			name: "nested_rollback_is_returned",
+
+			f: func(db *Database) func(ctx context.Context) error {
+				return func(ctx context.Context) error {
+					_ = exec(db, "insert a")(ctx)
+					return db.WithTransaction(ctx, func(ctx context.Context) error {
+						_ = exec(db, "insert b")(ctx)
+						return errTransaction
+					})
+				}
+			},
+
+			expectedErr: errTransaction,
+			expectedStatements: []string{
+				"begin",
+				"insert a",
+				"savepoint sp_1",
+				"insert b",
+				"rollback to savepoint sp_1",
+				"release savepoint sp_1",
+				"rollback",
+			},
+		},
+		{
+			name: "deeply_nested",
+
+			f: func(db *Database) func(ctx context.Context) error {
+				return func(ctx context.Context) error {
+					err := db.WithTransaction(ctx, func(ctx context.Context) error {
+						return db.WithTransaction(ctx, exec(db, "insert a"))
+					})
+					if err != nil {
+						return err
+					}
+					return db.WithTransaction(ctx, exec(db, "insert b"))
+				}
+			},
+
+			expectedStatements: []string{
+				"begin",
+				"savepoint sp_1",
+				"savepoint sp_2",
+				"insert a",
+				"release savepoint sp_2",
+				"release savepoint sp_1",
+				"savepoint sp_1",
+				"insert b",
+				"release savepoint sp_1",
+				"commit",
+			},
+		},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			fake, sqlDB := newFakeDB()
+			db := New(sqlDB)
+
+			err := db.WithTransaction(context.Background(), tc.f(&db))
+			require.ErrorIs(t, err, tc.expectedErr)
+
+			require.Equal(t, tc.expectedStatements, fake.recorded())
+		})
+	}
+}
+
+func TestDatabase_ExecContext_ResultInTransaction(t *testing.T) {
+	fake, sqlDB := newFakeDB()
+	fake.rowsAffected = 3
+	db := New(sqlDB)
+
+	var rowsAffected int64
+	err := db.WithTransaction(context.Background(), func(ctx context.Context) error {
+		return db.WithTransaction(ctx, func(ctx context.Context) error {
+			res, err := db.ExecContext(ctx, "update segment set deleted = true")
+			if err != nil {
+				return err
+			}
+			rowsAffected, err = res.RowsAffected()
+			return err
+		})
+	})
+	require.NoError(t, err)
+	require.Equal(t, int64(3), rowsAffected)
+}
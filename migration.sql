@@ -8,13 +8,33 @@ create table segment(
     percent bigint check ( 0 < percent and percent <= 100)
 );
 
+-- log is partitioned by month, upcoming partitions are created by crons ahead of time. There is no default partition:
+-- its rows would block creation of partitions for their months and would never be deleted by retention
 create table log(
-    id bigserial primary key,
+    id bigserial,
     user_id bigint,
     segment_id text,
     operation text,
-    insert_time timestamp with time zone default now() not null
-);
+    -- actor is an id of authenticated client which made operation, null for operations made by service itself
+    actor text,
+    insert_time timestamp with time zone default now() not null,
+    primary key (id, insert_time)
+) partition by range (insert_time);
+
+do $$
+declare
+    month timestamp;
+begin
+    for i in 0..3 loop
+        month := date_trunc('month', now() at time zone 'utc') + make_interval(months => i);
+        execute format(
+            'create table if not exists %I partition of log for values from (%L) to (%L)',
+            'log_' || to_char(month, 'YYYY_MM'),
+            month at time zone 'utc',
+            (month + interval '1 month') at time zone 'utc'
+        );
+    end loop;
+end $$;
 
 create table user_segment(
      id bigserial primary key,
@@ -26,3 +46,90 @@ create table user_segment(
 );
 
 create index log_user_id_insert_time_ix on log(user_id, insert_time desc);
+create index log_operation_insert_time_ix on log(operation, insert_time);
+
+create table export_file(
+    file_name text primary key,
+    size bigint not null,
+    insert_time timestamp with time zone default now() not null
+);
+
+create index export_file_insert_time_ix on export_file(insert_time);
+
+-- outbox keeps membership change events until relay delivers them. Events are ordered by user_id,
+-- it is null for events about segments, which are ordered among themselves
+create table outbox(
+    id bigserial primary key,
+    user_id bigint,
+    event_type text not null,
+    payload jsonb not null,
+    insert_time timestamp with time zone default now() not null,
+    attempts bigint not null default 0,
+    next_attempt_time timestamp with time zone default now() not null,
+    last_error text,
+    sent_time timestamp with time zone,
+    webhook_dispatch_time timestamp with time zone
+);
+
+create index outbox_not_sent_ix on outbox(user_id, id) where sent_time is null;
+-- events of users are read by id to resume their streams
+create index outbox_user_id_ix on outbox(user_id, id);
+create index outbox_not_dispatched_ix on outbox(id) where webhook_dispatch_time is null;
+create index outbox_sent_time_ix on outbox(sent_time) where sent_time is not null;
+
+create table webhook_subscription(
+    id bigserial primary key,
+    url text not null,
+    secret text not null,
+    -- empty filters match any event type or segment
+    event_types text[] not null default '{}',
+    segment_ids text[] not null default '{}',
+    insert_time timestamp with time zone default now() not null
+);
+
+create table webhook_delivery(
+    id bigserial primary key,
+    subscription_id bigint not null references webhook_subscription(id) on delete cascade,
+    event_id bigint not null,
+    user_id bigint,
+    event_type text not null,
+    payload jsonb not null,
+    event_time timestamp with time zone not null,
+    status text not null default 'pending',
+    attempts bigint not null default 0,
+    next_attempt_time timestamp with time zone default now() not null,
+    last_status_code bigint,
+    last_error text,
+    insert_time timestamp with time zone default now() not null,
+    delivered_time timestamp with time zone
+);
+
+create index webhook_delivery_pending_ix on webhook_delivery(next_attempt_time) where status = 'pending';
+create index webhook_delivery_subscription_id_ix on webhook_delivery(subscription_id, id desc);
+
+-- api_key stores only sha256 hashes of keys, keys are shown once when created
+create table api_key(
+    id bigserial primary key,
+    name text not null,
+    key_hash text not null unique,
+    prefix text not null,
+    scopes text[] not null,
+    insert_time timestamp with time zone default now() not null,
+    revoke_time timestamp with time zone
+);
+
+-- rate_limit_bucket contains token buckets of clients shared by replicas, allowed is a result of the last request
+create table rate_limit_bucket(
+    key text primary key,
+    tokens double precision not null,
+    allowed boolean not null,
+    update_time timestamp with time zone not null
+);
+
+-- schema_version is a version of this schema, it is increased with each change of schema.
+-- Service isn't ready until database is migrated to version it expects
+create table schema_version(
+    version bigint not null
+);
+
+insert into schema_version(version) values (3);
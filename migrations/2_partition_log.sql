@@ -0,0 +1,73 @@
+-- Upgrades database created by migration.sql of version 1 or earlier to version 2: table log is moved into monthly
+-- partitions and default partition of log is removed. Rows of old table and of default partition are copied into
+-- monthly partitions, so history isn't lost. Script is run once in a single transaction while crons are stopped.
+begin;
+
+create table if not exists schema_version(
+    version bigint not null
+);
+
+do $$
+declare
+    month timestamp;
+    last_month timestamp := date_trunc('month', now() at time zone 'utc') + interval '3 months';
+begin
+    if not exists (select 1 from pg_partitioned_table where partrelid = 'log'::regclass) then
+        -- log of the first schema isn't partitioned, names of its sequence, key and indexes are taken by new table
+        alter table log rename to log_unpartitioned;
+        alter table log_unpartitioned rename constraint log_pkey to log_unpartitioned_pkey;
+        alter sequence log_id_seq rename to log_unpartitioned_id_seq;
+        alter table log_unpartitioned add column if not exists actor text;
+        drop index if exists log_user_id_insert_time_ix;
+        drop index if exists log_operation_insert_time_ix;
+
+        create table log(
+            id bigserial,
+            user_id bigint,
+            segment_id text,
+            operation text,
+            actor text,
+            insert_time timestamp with time zone default now() not null,
+            primary key (id, insert_time)
+        ) partition by range (insert_time);
+
+        create index log_user_id_insert_time_ix on log(user_id, insert_time desc);
+        create index log_operation_insert_time_ix on log(operation, insert_time);
+    elsif to_regclass('log_default') is not null then
+        -- rows of default partition block creation of partitions for their months, so they are moved out
+        alter table log detach partition log_default;
+        alter table log_default rename to log_unpartitioned;
+    end if;
+
+    if to_regclass('log_unpartitioned') is null then
+        return;
+    end if;
+
+    select
+        least(date_trunc('month', min(insert_time) at time zone 'utc'), last_month),
+        greatest(date_trunc('month', max(insert_time) at time zone 'utc'), last_month)
+    into month, last_month
+    from log_unpartitioned;
+
+    while month <= last_month loop
+        execute format(
+            'create table if not exists %I partition of log for values from (%L) to (%L)',
+            'log_' || to_char(month, 'YYYY_MM'),
+            month at time zone 'utc',
+            (month + interval '1 month') at time zone 'utc'
+        );
+        month := month + interval '1 month';
+    end loop;
+
+    insert into log(id, user_id, segment_id, operation, actor, insert_time)
+    select id, user_id, segment_id, operation, actor, insert_time from log_unpartitioned;
+
+    perform setval(pg_get_serial_sequence('log', 'id'), coalesce(max(id), 0) + 1, false) from log;
+
+    drop table log_unpartitioned;
+end $$;
+
+delete from schema_version;
+insert into schema_version(version) values (2);
+
+commit;
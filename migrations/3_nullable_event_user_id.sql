@@ -0,0 +1,14 @@
+-- Upgrades database of version 2 to version 3: events about segments have null user_id instead of 0,
+-- so they aren't mixed with events of user with id 0. Script is run once while outbox_relay is stopped.
+begin;
+
+alter table outbox alter column user_id drop not null;
+alter table webhook_delivery alter column user_id drop not null;
+
+update outbox set user_id = null where event_type in ('segment.created', 'segment.deleted');
+update webhook_delivery set user_id = null where event_type in ('segment.created', 'segment.deleted');
+
+delete from schema_version;
+insert into schema_version(version) values (3);
+
+commit;